@@ -16,6 +16,14 @@ const (
 	ACK_MOST_TIMES         = 5
 	WRITE_LOG_FLAG         = uint64(1)
 	DELETE_LOG_FLAG        = uint64(2)
+	COPY_LOG_FLAG          = uint64(3)
+)
+
+// Every SLOT_SIZE bytes of a chunk are versioned separately, the version of the slot at
+// offset is stored at VERSION_START_LOCATION + 8 * (offset / SLOT_SIZE).
+const (
+	SLOT_SIZE      = FILE_SIZE >> 5
+	SLOTS_PER_FILE = FILE_SIZE / SLOT_SIZE
 )
 
 var ElectionServer = []string{
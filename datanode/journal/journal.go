@@ -26,3 +26,11 @@ func ConstructDeleteEntry(request *fsrpc.DeleteChunkRequest) []byte {
 	entry = append(entry, utils.Uint64ToBytes(request.Id)...)
 	return entry
 }
+
+func ConstructCopyEntry(request *fsrpc.CopyChunkRequest) []byte {
+	var entry []byte
+	entry = append(entry, utils.Uint64ToBytes(config.COPY_LOG_FLAG)...)
+	entry = append(entry, utils.Uint64ToBytes(request.Id)...)
+	entry = append(entry, utils.Uint64ToBytes(request.NewId)...)
+	return entry
+}
@@ -14,12 +14,19 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"sync"
 )
 
+// number of locks which chunks are hashed to, see Server.chunkLock
+const chunkLocks = 64
+
 type Server struct {
 	fsrpc.UnimplementedDataNodeServer
 	dataPath string
 	writer   *common_journal.Writer
+	// Writes to different slots of a chunk may arrive concurrently, they are serialized
+	// by the lock of the chunk, so that they won't overwrite each other.
+	locks [chunkLocks]sync.Mutex
 }
 
 func NewServer(path string, writer *common_journal.Writer) *Server {
@@ -60,6 +67,124 @@ func (s *Server) DeleteChunk(ctx context.Context, request *fsrpc.DeleteChunkRequ
 	return reply, nil
 }
 
+func (s *Server) CopyChunk(ctx context.Context, request *fsrpc.CopyChunkRequest) (*fsrpc.CopyChunkReply, error) {
+	reply := new(fsrpc.CopyChunkReply)
+	var err error
+
+	// the chunk to copy has never been written
+	if _, err = os.Stat(s.getFilename(request.Id)); err != nil {
+		reply.Status = fsrpc.Status_NotFound
+		return reply, nil
+	}
+
+	entry := journal.ConstructCopyEntry(request)
+	for i := 0; i < config.ACK_MOST_TIMES; i++ {
+		err = s.writer.CommitEntry(ctx, entry)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil { // write to kafka fail
+		reply.Status = fsrpc.Status_Unavailable
+		fmt.Println(err)
+		return reply, nil
+	}
+
+	err = s.copyFile(request.Id, request.NewId)
+	if err != nil {
+		reply.Status = fsrpc.Status_Unavailable
+		return reply, nil
+	}
+	reply.Status = fsrpc.Status_OK
+	return reply, nil
+}
+
+/*
+copyFile
+Copy the file of chunk id to the file of chunk newID, including the version stored in it.
+Locks of both chunks are held, so a write in flight to either of them is not mixed into
+the copy. The copy is written to a temporary file first and renamed, so a failed copy
+leaves no truncated chunk behind.
+*/
+func (s *Server) copyFile(id uint64, newID uint64) error {
+	// Locks are taken in ascending order of their indexes to avoid deadlocks, since
+	// chunks are hashed to locks.
+	first, second := id%chunkLocks, newID%chunkLocks
+	if first > second {
+		first, second = second, first
+	}
+	s.locks[first].Lock()
+	defer s.locks[first].Unlock()
+	if second != first {
+		s.locks[second].Lock()
+		defer s.locks[second].Unlock()
+	}
+
+	data, err := os.ReadFile(s.getFilename(id))
+	if err != nil {
+		return err
+	}
+	tmp := s.getFilename(newID) + ".tmp"
+	err = os.WriteFile(tmp, data, 0755)
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, s.getFilename(newID))
+}
+
+/*
+CopySlot
+Copy size bytes at offset of chunk id to new_offset of chunk new_id, which is used by
+MasterNode to pack slots into fewer chunks. The source slot must be at version exactly,
+otherwise a write to it may be missed. The data is written to the new slot like
+WriteChunk with new_version, so the copy is journaled as an ordinary write.
+*/
+func (s *Server) CopySlot(ctx context.Context, request *fsrpc.CopySlotRequest) (*fsrpc.CopySlotReply, error) {
+	reply := new(fsrpc.CopySlotReply)
+
+	file, err := os.Open(s.getFilename(request.Id))
+	if err != nil {
+		reply.Status = fsrpc.Status_NotFound
+		return reply, nil
+	}
+	if utils.GetVersion(file, request.Offset) != request.Version {
+		file.Close()
+		reply.Status = fsrpc.Status_WrongVersion
+		return reply, nil
+	}
+	data := make([]byte, request.Size)
+	_, err = file.ReadAt(data, int64(request.Offset))
+	file.Close()
+	if err != nil {
+		reply.Status = fsrpc.Status_NotFound
+		return reply, nil
+	}
+
+	writeReply, err := s.WriteChunk(ctx, &fsrpc.WriteChunkRequest{
+		Id:         request.NewId,
+		Offset:     request.NewOffset,
+		Size:       request.Size,
+		TargetSize: request.Size,
+		Version:    request.NewVersion,
+		Padding:    " ",
+		Data:       data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reply.Status = writeReply.Status
+	return reply, nil
+}
+
+/*
+chunkLock
+Returns the lock of chunk id, which should be held while writing it.
+*/
+func (s *Server) chunkLock(id uint64) *sync.Mutex {
+	return &s.locks[id%chunkLocks]
+}
+
 func (s *Server) ReadChunk(ctx context.Context, request *fsrpc.ReadChunkRequest) (*fsrpc.ReadChunkReply, error) {
 	reply := new(fsrpc.ReadChunkReply)
 
@@ -72,21 +197,23 @@ func (s *Server) ReadChunk(ctx context.Context, request *fsrpc.ReadChunkRequest)
 		return reply, nil
 	}
 
-	// check version
-	curVersion := utils.GetVersion(file)
+	// check version of the slot at offset
+	curVersion := utils.GetVersion(file, request.Offset)
 	if curVersion >= request.Version {
 		// the version is correct
 		data := make([]byte, request.Size)
 		_, err = file.ReadAt(data, int64(request.Offset))
-		file.Close()
 
 		// can not read data at this pos
 		if err != nil {
+			file.Close()
 			reply.Status = fsrpc.Status_NotFound
 			return reply, nil
 		}
 		// read the correct data
 		reply.Data = data
+		reply.Versions = utils.GetVersions(file, request.Offset, request.Size)
+		file.Close()
 		reply.Status = fsrpc.Status_OK
 	} else {
 		file.Close()
@@ -118,12 +245,15 @@ func (s *Server) WriteChunk(ctx context.Context, request *fsrpc.WriteChunkReques
 		return reply, nil
 	}
 
+	lock := s.chunkLock(request.Id)
+	lock.Lock()
+	defer lock.Unlock()
 	file, err := os.OpenFile(s.getFilename(request.Id), os.O_RDWR, 0755)
 
 	// first time
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			// MasterNode assigns version 1 to those chunks which don't exist before.
+			// MasterNode assigns version 1 to slots of those chunks which don't exist before.
 			if request.Version != 1 {
 				reply.Status = fsrpc.Status_WrongVersion
 				return reply, nil
@@ -144,7 +274,7 @@ func (s *Server) WriteChunk(ctx context.Context, request *fsrpc.WriteChunkReques
 			}
 
 			// update the version
-			utils.SyncAndUpdateVersion(file, request.Version)
+			utils.SyncAndUpdateVersion(file, request.Offset, request.Version)
 			reply.Status = fsrpc.Status_OK
 			return reply, nil
 		}
@@ -152,7 +282,8 @@ func (s *Server) WriteChunk(ctx context.Context, request *fsrpc.WriteChunkReques
 		return reply, nil
 	}
 
-	curVersion := utils.GetVersion(file)
+	// Slots are versioned separately, so writes to different slots don't wait for each other.
+	curVersion := utils.GetVersion(file, request.Offset)
 	// print("current version: ", curVersion, ", request version: ", request.Version)
 	if curVersion+1 == request.Version {
 		// can update
@@ -165,7 +296,7 @@ func (s *Server) WriteChunk(ctx context.Context, request *fsrpc.WriteChunkReques
 		}
 
 		// update the version
-		utils.SyncAndUpdateVersion(file, request.Version)
+		utils.SyncAndUpdateVersion(file, request.Offset, request.Version)
 
 		reply.Status = fsrpc.Status_OK
 		return reply, nil
@@ -187,6 +318,9 @@ func (s *Server) HandleWriteMsg(msg []byte) error {
 	offset := utils.BytesToUint64(msg[16:24])
 	size := utils.BytesToUint64(msg[24:32])
 
+	lock := s.chunkLock(chunkid)
+	lock.Lock()
+	defer lock.Unlock()
 	// try to open the file
 	file, err := os.OpenFile(s.getFilename(chunkid), os.O_RDWR, 0755)
 
@@ -208,7 +342,7 @@ func (s *Server) HandleWriteMsg(msg []byte) error {
 		}
 
 		// the version is newest
-		utils.SyncAndUpdateVersion(file, version)
+		utils.SyncAndUpdateVersion(file, offset, version)
 		return nil
 	}
 
@@ -223,7 +357,7 @@ func (s *Server) HandleWriteMsg(msg []byte) error {
 
 	// if they have different checksum or different version
 	if utils.BytesToUint32(msg[32:36]) != dataCks ||
-		version != utils.GetVersion(file) {
+		version != utils.GetVersion(file, offset) {
 		// overwrite
 		for {
 			_, err = file.WriteAt(msg[36:], int64(offset))
@@ -232,7 +366,7 @@ func (s *Server) HandleWriteMsg(msg []byte) error {
 			}
 		}
 		// update the version
-		utils.SyncAndUpdateVersion(file, version)
+		utils.SyncAndUpdateVersion(file, offset, version)
 	}
 	return nil
 }
@@ -247,6 +381,13 @@ func (s *Server) HandleDeleteMsg(msg []byte) error {
 	return nil
 }
 
+func (s *Server) HandleCopyMsg(msg []byte) error {
+	chunkid := utils.BytesToUint64(msg[0:8])
+	newChunkid := utils.BytesToUint64(msg[8:16])
+	// A copy is journaled only if the source chunk exists, so it must exist here too.
+	return s.copyFile(chunkid, newChunkid)
+}
+
 func (s *Server) HandleMsg(msg []byte) error {
 	// TODO: secondary handle message from kafka
 	logType := utils.BytesToUint64(msg[0:8])
@@ -256,6 +397,8 @@ func (s *Server) HandleMsg(msg []byte) error {
 		return s.HandleWriteMsg(msg[8:])
 	case config.DELETE_LOG_FLAG:
 		return s.HandleDeleteMsg(msg[8:])
+	case config.COPY_LOG_FLAG:
+		return s.HandleCopyMsg(msg[8:])
 	default:
 		return nil
 	}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/fourstring/sheetfs/common_journal"
+	"github.com/fourstring/sheetfs/config"
 	. "github.com/fourstring/sheetfs/datanode/config"
 	fsrpc "github.com/fourstring/sheetfs/protocol"
 	"log"
+	"os"
 	"testing"
 )
 
@@ -81,6 +83,46 @@ func TestDatanode(t *testing.T) {
 		t.Error("wrong")
 	}
 
+	// other slots are versioned separately
+	slotOffset := uint64(config.SLOT_SIZE * 8)
+	req = fsrpc.WriteChunkRequest{Id: 1, Offset: slotOffset, Data: data, Size: uint64(size), Padding: " ", Version: 1}
+	res, _ = s.WriteChunk(context.Background(), &req)
+	if res.Status != fsrpc.Status_OK {
+		t.Error("wrong")
+	}
+
+	readReq = fsrpc.ReadChunkRequest{Id: 1, Size: config.FILE_SIZE, Version: 3}
+	readRes, _ = s.ReadChunk(context.Background(), &readReq)
+	if readRes.Status != fsrpc.Status_OK || len(readRes.Versions) != config.SLOTS_PER_FILE ||
+		readRes.Versions[0] != 3 || readRes.Versions[8] != 1 {
+		t.Error("wrong")
+	}
+	if string(readRes.Data[slotOffset:slotOffset+16]) != "second test data" {
+		t.Error("wrong")
+	}
+
+	// copy a slot to another chunk
+	copyReq := fsrpc.CopySlotRequest{Id: 1, Offset: slotOffset, Size: config.SLOT_SIZE, Version: 1,
+		NewId: 2, NewOffset: config.SLOT_SIZE, NewVersion: 1}
+	copyRes, _ := s.CopySlot(context.Background(), &copyReq)
+	if copyRes.Status != fsrpc.Status_OK {
+		t.Error("wrong")
+	}
+	readReq = fsrpc.ReadChunkRequest{Id: 2, Offset: config.SLOT_SIZE, Size: 16, Version: 1}
+	readRes, _ = s.ReadChunk(context.Background(), &readReq)
+	if readRes.Status != fsrpc.Status_OK || string(readRes.Data) != "second test data" {
+		t.Error("wrong")
+	}
+
+	// the slot to copy is at another version
+	copyReq.NewVersion = 2
+	copyReq.Offset = 0
+	copyRes, _ = s.CopySlot(context.Background(), &copyReq)
+	if copyRes.Status != fsrpc.Status_WrongVersion {
+		t.Error("wrong")
+	}
+	_, _ = s.DeleteChunk(context.Background(), &fsrpc.DeleteChunkRequest{Id: 2})
+
 	deleteReq := fsrpc.DeleteChunkRequest{Id: 1}
 	deleteRes, _ := s.DeleteChunk(context.Background(), &deleteReq)
 	if deleteRes.Status != fsrpc.Status_OK {
@@ -104,3 +146,35 @@ func TestDatanodeParallel(t *testing.T) {
 
 	// TODO
 }
+
+func TestCopyFile(t *testing.T) {
+	s := NewServer(t.TempDir(), nil)
+	data := []byte("this is the test data")
+	err := os.WriteFile(s.getFilename(1), data, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// chunks hashed to the same lock
+	for _, newID := range []uint64{2, 1 + chunkLocks} {
+		err = s.copyFile(1, newID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		copied, err := os.ReadFile(s.getFilename(newID))
+		if err != nil || string(copied) != string(data) {
+			t.Error("wrong")
+		}
+		if _, err = os.Stat(s.getFilename(newID) + ".tmp"); err == nil {
+			t.Error("wrong")
+		}
+	}
+
+	// the chunk to copy doesn't exist
+	if s.copyFile(3, 4) == nil {
+		t.Error("wrong")
+	}
+	if _, err = os.Stat(s.getFilename(4)); err == nil {
+		t.Error("wrong")
+	}
+}
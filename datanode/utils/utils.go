@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/binary"
 	"github.com/fourstring/sheetfs/config"
 	"github.com/fourstring/sheetfs/datanode/buffmgr"
@@ -75,16 +76,77 @@ func GetPaddedFile(data []byte, size uint64, targetSize uint64, padding string,
 	return paddedData
 }
 
-func SyncAndUpdateVersion(file *os.File, version uint64) {
+// Size of a chunk file holding versions of all of its slots.
+const versionedFileSize = config.VERSION_START_LOCATION + config.SLOTS_PER_FILE*8
+
+/*
+versionLocation
+Returns the location where the version of the slot containing offset is stored.
+*/
+func versionLocation(offset uint64) int64 {
+	return config.VERSION_START_LOCATION + int64(offset/config.SLOT_SIZE)*8
+}
+
+/*
+isWholeVersioned
+Returns true if file was written before slots were versioned separately. Such a file
+holds a single version at VERSION_START_LOCATION, which is the version of all slots.
+*/
+func isWholeVersioned(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Size() < versionedFileSize
+}
+
+func SyncAndUpdateVersion(file *os.File, offset uint64, version uint64) {
 	//file.Sync()
+	if isWholeVersioned(file) {
+		// expand the single version to all slots before one of them is updated
+		old := Uint64ToBytes(GetVersion(file, 0))
+		file.WriteAt(bytes.Repeat(old, config.SLOTS_PER_FILE), config.VERSION_START_LOCATION)
+	}
 	data := Uint64ToBytes(version)
-	file.WriteAt(data, config.VERSION_START_LOCATION)
+	file.WriteAt(data, versionLocation(offset))
 	//file.Sync()
 	file.Close()
 }
 
-func GetVersion(file *os.File) uint64 {
+func GetVersion(file *os.File, offset uint64) uint64 {
+	if isWholeVersioned(file) {
+		offset = 0
+	}
 	buf := make([]byte, 8)
-	file.ReadAt(buf, config.VERSION_START_LOCATION)
+	file.ReadAt(buf, versionLocation(offset))
 	return BytesToUint64(buf)
 }
+
+/*
+GetVersions
+Returns versions of all slots overlapping [offset, offset+size), in order. Slots which
+have never been written are of version 0.
+*/
+func GetVersions(file *os.File, offset uint64, size uint64) []uint64 {
+	if size == 0 {
+		return nil
+	}
+	first, last := offset/config.SLOT_SIZE, (offset+size-1)/config.SLOT_SIZE
+	if last >= config.SLOTS_PER_FILE {
+		last = config.SLOTS_PER_FILE - 1
+	}
+	if first > last {
+		return nil
+	}
+	versions := make([]uint64, last-first+1)
+	if isWholeVersioned(file) {
+		version := GetVersion(file, 0)
+		for i := range versions {
+			versions[i] = version
+		}
+		return versions
+	}
+	buf := make([]byte, (last-first+1)*8)
+	file.ReadAt(buf, versionLocation(offset))
+	for i := range versions {
+		versions[i] = BytesToUint64(buf[i*8 : i*8+8])
+	}
+	return versions
+}
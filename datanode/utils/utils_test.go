@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"github.com/fourstring/sheetfs/config"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestWholeVersionedFile(t *testing.T) {
+	// a chunk written before slots were versioned separately, with a single version
+	name := path.Join(t.TempDir(), "chunk_1")
+	data := make([]byte, config.VERSION_START_LOCATION)
+	err := os.WriteFile(name, append(data, Uint64ToBytes(5)...), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.OpenFile(name, os.O_RDWR, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if GetVersion(file, 0) != 5 || GetVersion(file, config.SLOT_SIZE*8) != 5 {
+		t.Error("wrong")
+	}
+	versions := GetVersions(file, config.SLOT_SIZE, config.SLOT_SIZE*2)
+	if len(versions) != 2 || versions[0] != 5 || versions[1] != 5 {
+		t.Error("wrong")
+	}
+
+	// the first write expands the version to all slots
+	SyncAndUpdateVersion(file, config.SLOT_SIZE*8, 6)
+	file, err = os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if GetVersion(file, config.SLOT_SIZE*8) != 6 {
+		t.Error("wrong")
+	}
+	versions = GetVersions(file, 0, config.FILE_SIZE)
+	if len(versions) != config.SLOTS_PER_FILE {
+		t.Fatal("wrong")
+	}
+	for i, v := range versions {
+		if i != 8 && v != 5 {
+			t.Error("wrong")
+		}
+	}
+}
+
+func TestNewFile(t *testing.T) {
+	name := path.Join(t.TempDir(), "chunk_1")
+	file, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = file.WriteAt(make([]byte, config.FILE_SIZE), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SyncAndUpdateVersion(file, 0, 1)
+
+	file, err = os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	versions := GetVersions(file, 0, config.SLOT_SIZE*2)
+	if len(versions) != 2 || versions[0] != 1 || versions[1] != 0 {
+		t.Error("wrong")
+	}
+}
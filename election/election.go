@@ -149,3 +149,29 @@ func (e *Elector) AckLeader(info string) error {
 	_, err := e.conn.Set(e.electionAck, []byte(info), -1)
 	return err
 }
+
+/*
+WatchLeadership
+Watch the proposal Znode of this node. The proposal is Ephemeral, so it will be deleted
+by Zookeeper once the session of this node expires, and then this node is no longer the
+primary, though it may not realize that. A primary node can use the returned channel to
+stop tasks which should only be run by the primary, e.g. through
+common_journal.NewZKEventCancelContext.
+
+This method should only be called after CreateProposal.
+
+@return
+	<-chan zk.Event: a channel which emits an event when the proposal is changed or
+	deleted, or the session is broken.
+	error: not nil if failed to watch the proposal.
+*/
+func (e *Elector) WatchLeadership() (<-chan zk.Event, error) {
+	exists, _, c, err := e.conn.ExistsW(fmt.Sprintf("%s/%s", e.electionZnode, e.proposal))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, zk.ErrNoNode
+	}
+	return c, nil
+}
@@ -0,0 +1,212 @@
+package fsclient
+
+import (
+	"context"
+	"github.com/fourstring/sheetfs/config"
+	fsrpc "github.com/fourstring/sheetfs/protocol"
+	"io/fs"
+	"sort"
+	"sync"
+)
+
+/*
+segment
+A slot to be written in a chunk at version. data shorter than size is filled with padding.
+*/
+type segment struct {
+	offset  uint64
+	size    uint64
+	version uint64
+	data    []byte
+}
+
+/*
+chunkWrite
+All segments of a chunk written by a batch, see File.WriteCells.
+*/
+type chunkWrite struct {
+	chunk    *fsrpc.Chunk
+	segments []segment
+}
+
+/*
+writeSegments
+Write all segments of w in the order of their offsets. Slots are versioned separately,
+so every segment is written by its own request at its version.
+*/
+func (f *File) writeSegments(ctx context.Context, w *chunkWrite, padding string) error {
+	sort.Slice(w.segments, func(i, j int) bool {
+		return w.segments[i].offset < w.segments[j].offset
+	})
+	for _, seg := range w.segments {
+		err := f.writeChunk(ctx, w.chunk, &fsrpc.WriteChunkRequest{
+			Id:         w.chunk.Id,
+			Offset:     seg.offset,
+			Size:       uint64(len(seg.data)),
+			TargetSize: seg.size,
+			Version:    seg.version,
+			Padding:    padding,
+			Data:       seg.data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+WriteCells
+Write a batch of cells with a single request to MasterNode. Data of cells are grouped
+by chunks, and chunks are written in parallel. Like WriteAt, old slots
+of cells moved to bigger slots are cleared with padding, and data of large cells are
+written to overflow chunks.
+
+Like WriteAt, this function will spin until the previous version of every chunk is
+written to DataNode or cancelled by ctx, so ctx is generally necessary.
+
+If MasterNode fails in the middle of the batch, cells handled before the failure are still
+written, then the error of MasterNode is returned.
+
+@para
+	ctx: context.Context used to cancel operation
+	cells: data to write, keyed by row and column of cells
+	padding: padding character used to pad a cell to its maximum size, for LuckySheet file,
+	a " " should be passed in.
+@return
+	error(error)
+		fs.ErrNotExist: f has been closed before
+		fs.ErrInvalid: some data is too large for a cell
+		*UnexpectedStatusError: MasterNode or DataNode returns a unexpected status
+		some other errors returned by rpc
+		*CancelledError: If operations(spin or rpc call) are cancelled by ctx. And only if there is no
+		other errors happened and ctx cancelled, a CancelledError will be returned.
+*/
+func (f *File) WriteCells(ctx context.Context, cells map[CellPos][]byte, padding string) error {
+	if len(cells) == 0 {
+		return nil
+	}
+	positions := make([]CellPos, 0, len(cells))
+	for pos := range cells {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Row != positions[j].Row {
+			return positions[i].Row < positions[j].Row
+		}
+		return positions[i].Col < positions[j].Col
+	})
+	masterReq := fsrpc.WriteCellsRequest{Fd: f.fd, Cells: make([]*fsrpc.CellWrite, len(positions))}
+	for i, pos := range positions {
+		masterReq.Cells[i] = &fsrpc.CellWrite{Tab: f.tab, Row: pos.Row, Column: pos.Col, Size: uint64(len(cells[pos]))}
+	}
+	_r, err := f.client.ensureMasterRPCWithRetry("WriteCells", ctx, &masterReq)
+
+	// RPC fail may arise by broken master client
+	if err != nil {
+		return err
+	}
+
+	masterReply := _r.(*fsrpc.WriteCellsReply)
+	var statusErr error
+	switch masterReply.Status {
+	case fsrpc.Status_OK:
+	case fsrpc.Status_NotFound:
+		statusErr = fs.ErrNotExist
+	case fsrpc.Status_Invalid:
+		statusErr = fs.ErrInvalid
+	default:
+		statusErr = NewUnexpectedStatusError(masterReply.Status)
+	}
+	// MasterNode may fail in the middle of a batch, after versions of cells before the
+	// failure have been increased. Those cells are still written, otherwise later writes
+	// to them would spin forever.
+	if statusErr != nil && len(masterReply.Cells) == 0 {
+		return statusErr
+	}
+
+	var chunks []*fsrpc.Chunk
+	for _, cell := range masterReply.Cells {
+		chunks = append(append(chunks, cell.Chunk), cell.Overflow...)
+	}
+	for _, cell := range masterReply.Vacated {
+		chunks = append(chunks, cell.Chunk)
+	}
+	err = f.client.checkNewDataNode(chunks)
+	if err != nil {
+		return err
+	}
+	// if padding is empty
+	if len(padding) == 0 {
+		padding = " "
+	}
+
+	// group slots to write by chunks
+	writes := map[uint64]*chunkWrite{}
+	var order []*chunkWrite
+	add := func(chunk *fsrpc.Chunk, seg segment) {
+		w, ok := writes[chunk.Id]
+		if !ok {
+			w = &chunkWrite{chunk: chunk}
+			writes[chunk.Id] = w
+			order = append(order, w)
+		}
+		w.segments = append(w.segments, seg)
+	}
+	for i, cell := range masterReply.Cells {
+		data := cells[positions[i]]
+		var rest []byte
+		if uint64(len(data)) > cell.Size {
+			data, rest = data[:cell.Size], data[cell.Size:]
+		}
+		add(cell.Chunk, segment{offset: cell.Offset, size: cell.Size, version: cell.Version, data: data})
+		for _, c := range cell.Overflow {
+			data = truncate(rest, config.FILE_SIZE)
+			rest = rest[len(data):]
+			add(c, segment{offset: 0, size: config.FILE_SIZE, version: c.Version, data: data})
+		}
+	}
+	for _, cell := range masterReply.Vacated {
+		// the old slot may have been taken by another cell in the batch, whose version
+		// is increased only once by MasterNode
+		taken := false
+		if w, ok := writes[cell.Chunk.Id]; ok {
+			for _, seg := range w.segments {
+				if seg.offset == cell.Offset {
+					taken = true
+				}
+			}
+		}
+		if !taken {
+			add(cell.Chunk, segment{offset: cell.Offset, size: cell.Size, version: cell.Version})
+		}
+	}
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var workerErr error
+	var workerMu sync.Mutex
+	for _, w := range order {
+		wg.Add(1)
+		w := w
+		go func() {
+			defer wg.Done()
+			err := f.writeSegments(ctx, w, padding)
+			if err != nil {
+				workerMu.Lock()
+				// Errors of other workers are caused by cancel, keep the first one.
+				if workerErr == nil {
+					workerErr = err
+				}
+				cancel()
+				workerMu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if workerErr != nil {
+		return workerErr
+	}
+	return statusErr
+}
@@ -11,7 +11,6 @@ import (
 	"google.golang.org/grpc/status"
 	"io/fs"
 	"reflect"
-	"strings"
 	"sync"
 	"time"
 )
@@ -40,6 +39,10 @@ type Client struct {
 	zk          *zk.Conn
 	master      *masterNode
 	datanodeMap map[string]*dataNode
+	// Every client opens a session with MasterNode, files are opened in the session.
+	session uint64
+	// Closed to stop renewing the lease of session.
+	stop chan struct{}
 }
 
 func NewClient(config *ClientConfig) (*Client, error) {
@@ -53,6 +56,7 @@ func NewClient(config *ClientConfig) (*Client, error) {
 		zk:          conn,
 		master:      &masterNode{},
 		datanodeMap: map[string]*dataNode{},
+		stop:        make(chan struct{}),
 	}
 
 	var rpcc fsrpc.MasterNodeClient
@@ -70,6 +74,12 @@ func NewClient(config *ClientConfig) (*Client, error) {
 
 	c.master.c = rpcc
 
+	lease, err := c.openSession(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	go c.keepAlive(lease)
+
 	return c, nil
 }
 
@@ -214,21 +224,21 @@ func (c *Client) ensureDataNodeRPCWithRetry(datanodeGroup, name string, params .
 /*
 Create
 @para
-	name(string):  the name of the file
+	name(string):  the name of the file, which is a path like "dir/subdir/name"
 @return
 	f(*File): fd
 	error(error): nil is no error
 				fs.ErrExist: already exist
+				fs.ErrNotExist: the directory containing the file doesn't exist
 				fs.ErrInvalid: wrong para
 */
 func (c *Client) Create(ctx context.Context, name string) (f *File, err error) {
-	// check filename
-	if name == "" || strings.Contains(name, "/") ||
-		strings.Contains(name, "\\") {
+	// check path of the file
+	if !isValidPath(name) {
 		return nil, fs.ErrInvalid
 	}
 	// create the file
-	req := fsrpc.CreateSheetRequest{Filename: name}
+	req := fsrpc.CreateSheetRequest{Filename: name, Session: c.currentSession()}
 	// reply, err := c.master.CreateSheet(ctx, &req)
 	_reply, err := c.ensureMasterRPCWithRetry("CreateSheet", ctx, &req)
 
@@ -238,11 +248,26 @@ func (c *Client) Create(ctx context.Context, name string) (f *File, err error) {
 
 	reply := _reply.(*fsrpc.CreateSheetReply)
 
+	if reply.Status == fsrpc.Status_Expired {
+		// retry once in a new session
+		if err = c.renewSession(ctx, req.Session); err != nil {
+			return nil, err
+		}
+		req.Session = c.currentSession()
+		_reply, err = c.ensureMasterRPCWithRetry("CreateSheet", ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+		reply = _reply.(*fsrpc.CreateSheetReply)
+	}
+
 	switch reply.Status {
 	case fsrpc.Status_OK:
 		return newFile(reply.Fd, name, c), nil
 	case fsrpc.Status_Exist:
 		return nil, fs.ErrExist
+	case fsrpc.Status_NotFound:
+		return nil, fs.ErrNotExist
 	default:
 		return nil, NewUnexpectedStatusError(reply.Status)
 	}
@@ -250,12 +275,12 @@ func (c *Client) Create(ctx context.Context, name string) (f *File, err error) {
 
 /*
 Delete
+Delete a file permanently, including all of its data stored in DataNodes.
 @para
 	name(string) : the name of the file
 @return
 	error(error): nil is no error
-				fs.ErrExist: already exist
-				fs.ErrInvalid: wrong para
+				fs.ErrNotExist: no such file
 */
 func (c *Client) Delete(ctx context.Context, name string) (err error) {
 	// DeleteSheet
@@ -279,6 +304,123 @@ func (c *Client) Delete(ctx context.Context, name string) (err error) {
 	}
 }
 
+/*
+Rename
+Rename a file, or move it to another directory. Files opened before renaming are
+still valid.
+@para
+	name(string): the current name of the file
+	newName(string): the new name of the file
+@return
+	error(error): nil is no error
+				fs.ErrNotExist: no such file, or the directory of newName doesn't exist
+				fs.ErrExist: newName already exist
+				fs.ErrInvalid: wrong para
+*/
+func (c *Client) Rename(ctx context.Context, name string, newName string) (err error) {
+	// check path of the file
+	if !isValidPath(newName) {
+		return fs.ErrInvalid
+	}
+	req := fsrpc.RenameSheetRequest{Filename: name, NewFilename: newName}
+	_reply, err := c.ensureMasterRPCWithRetry("RenameSheet", ctx, &req)
+
+	if err != nil {
+		return err
+	}
+
+	reply := _reply.(*fsrpc.RenameSheetReply)
+
+	switch reply.Status {
+	case fsrpc.Status_OK:
+		return nil
+	case fsrpc.Status_NotFound:
+		return fs.ErrNotExist
+	case fsrpc.Status_Exist:
+		return fs.ErrExist
+	default:
+		return NewUnexpectedStatusError(reply.Status)
+	}
+}
+
+/*
+Copy
+Copy a file to a new file. The copy is done by MasterNode, and data are shared
+copy-on-write, so it's much cheaper than reading and writing every cell.
+@para
+	name(string): the name of the file to copy
+	newName(string): the name of the copy
+@return
+	error(error): nil is no error
+				fs.ErrNotExist: no such file, or the directory of newName doesn't exist
+				fs.ErrExist: newName already exist
+				fs.ErrInvalid: wrong para
+*/
+func (c *Client) Copy(ctx context.Context, name string, newName string) (err error) {
+	// check path of the file
+	if !isValidPath(newName) {
+		return fs.ErrInvalid
+	}
+	req := fsrpc.CopySheetRequest{Filename: name, NewFilename: newName}
+	_reply, err := c.ensureMasterRPCWithRetry("CopySheet", ctx, &req)
+
+	if err != nil {
+		return err
+	}
+
+	reply := _reply.(*fsrpc.CopySheetReply)
+
+	switch reply.Status {
+	case fsrpc.Status_OK:
+		return nil
+	case fsrpc.Status_NotFound:
+		return fs.ErrNotExist
+	case fsrpc.Status_Exist:
+		return fs.ErrExist
+	default:
+		return NewUnexpectedStatusError(reply.Status)
+	}
+}
+
+/*
+Stat
+Get metadata and statistics of a file, including recycled ones.
+@para
+	name(string): the name of the file
+@return
+	stat(*fsrpc.SheetStat): metadata of the file, times are in unix nanoseconds
+	error(error): nil is no error
+				fs.ErrNotExist: no such file
+				fs.ErrInvalid: wrong para
+*/
+func (c *Client) Stat(ctx context.Context, name string) (stat *fsrpc.SheetStat, err error) {
+	// check path of the file
+	if !isValidPath(name) {
+		return nil, fs.ErrInvalid
+	}
+	req := fsrpc.StatSheetRequest{Target: &fsrpc.StatSheetRequest_Filename{Filename: name}}
+	return c.statSheet(ctx, &req)
+}
+
+func (c *Client) statSheet(ctx context.Context, req *fsrpc.StatSheetRequest) (*fsrpc.SheetStat, error) {
+	_reply, err := c.ensureMasterRPCWithRetry("StatSheet", ctx, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reply := _reply.(*fsrpc.StatSheetReply)
+
+	switch reply.Status {
+	case fsrpc.Status_OK:
+		return reply.Stat, nil
+	case fsrpc.Status_NotFound:
+		return nil, fs.ErrNotExist
+	default:
+		return nil, NewUnexpectedStatusError(reply.Status)
+	}
+}
+
 /*
 Open
 @para
@@ -289,13 +431,12 @@ Open
 	error(error)
 */
 func (c *Client) Open(ctx context.Context, name string) (f *File, err error) {
-	// check filename
-	if name == "" || strings.Contains(name, "/") ||
-		strings.Contains(name, "\\") {
+	// check path of the file
+	if !isValidPath(name) {
 		return nil, fs.ErrInvalid
 	}
 	// open the required file
-	req := fsrpc.OpenSheetRequest{Filename: name}
+	req := fsrpc.OpenSheetRequest{Filename: name, Session: c.currentSession()}
 	// reply, err := c.master.OpenSheet(ctx, &req)
 	_reply, err := c.ensureMasterRPCWithRetry("OpenSheet", ctx, &req)
 
@@ -305,6 +446,19 @@ func (c *Client) Open(ctx context.Context, name string) (f *File, err error) {
 
 	reply := _reply.(*fsrpc.OpenSheetReply)
 
+	if reply.Status == fsrpc.Status_Expired {
+		// retry once in a new session
+		if err = c.renewSession(ctx, req.Session); err != nil {
+			return nil, err
+		}
+		req.Session = c.currentSession()
+		_reply, err = c.ensureMasterRPCWithRetry("OpenSheet", ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+		reply = _reply.(*fsrpc.OpenSheetReply)
+	}
+
 	switch reply.Status {
 	case fsrpc.Status_OK: // open correctly
 		return newFile(reply.Fd, name, c), err
@@ -0,0 +1,110 @@
+package fsclient
+
+import (
+	"context"
+	fsrpc "github.com/fourstring/sheetfs/protocol"
+	"io/fs"
+)
+
+/*
+MkDir
+Create a directory. Directories are not created recursively.
+@para
+	path(string): path of the directory, like "dir/subdir"
+@return
+	error(error): nil is no error
+				fs.ErrExist: there has been a file or directory at path
+				fs.ErrNotExist: the directory containing path doesn't exist
+				fs.ErrInvalid: wrong para
+*/
+func (c *Client) MkDir(ctx context.Context, path string) error {
+	if !isValidPath(path) {
+		return fs.ErrInvalid
+	}
+	req := fsrpc.MkDirRequest{Path: path}
+	_reply, err := c.ensureMasterRPCWithRetry("MkDir", ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	reply := _reply.(*fsrpc.MkDirReply)
+	switch reply.Status {
+	case fsrpc.Status_OK:
+		return nil
+	case fsrpc.Status_Exist:
+		return fs.ErrExist
+	case fsrpc.Status_NotFound:
+		return fs.ErrNotExist
+	default:
+		return NewUnexpectedStatusError(reply.Status)
+	}
+}
+
+/*
+RmDir
+Remove a directory. If recursive is true, all files and subdirectories in it are
+deleted permanently, otherwise the directory must be empty.
+@para
+	path(string): path of the directory
+	recursive(bool): whether to remove files and subdirectories in the directory
+@return
+	error(error): nil is no error
+				fs.ErrNotExist: no such directory
+				ErrNotEmpty: the directory is not empty and recursive is false
+				fs.ErrInvalid: wrong para
+*/
+func (c *Client) RmDir(ctx context.Context, path string, recursive bool) error {
+	if !isValidPath(path) {
+		return fs.ErrInvalid
+	}
+	req := fsrpc.RmDirRequest{Path: path, Recursive: recursive}
+	_reply, err := c.ensureMasterRPCWithRetry("RmDir", ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	reply := _reply.(*fsrpc.RmDirReply)
+	switch reply.Status {
+	case fsrpc.Status_OK:
+		return nil
+	case fsrpc.Status_NotFound:
+		return fs.ErrNotExist
+	case fsrpc.Status_NotEmpty:
+		return ErrNotEmpty
+	default:
+		return NewUnexpectedStatusError(reply.Status)
+	}
+}
+
+/*
+ListDir
+List subdirectories and files directly in a directory.
+@para
+	path(string): path of the directory, "" for the root directory
+@return
+	dirs([]string): full paths of subdirectories
+	sheets([]*fsrpc.Sheet): files in the directory
+	error(error): nil is no error
+				fs.ErrNotExist: no such directory
+				fs.ErrInvalid: wrong para
+*/
+func (c *Client) ListDir(ctx context.Context, path string) ([]string, []*fsrpc.Sheet, error) {
+	if path != "" && !isValidPath(path) {
+		return nil, nil, fs.ErrInvalid
+	}
+	req := fsrpc.ListDirRequest{Path: path}
+	_reply, err := c.ensureMasterRPCWithRetry("ListDir", ctx, &req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reply := _reply.(*fsrpc.ListDirReply)
+	switch reply.Status {
+	case fsrpc.Status_OK:
+		return reply.Dirs, reply.Sheets, nil
+	case fsrpc.Status_NotFound:
+		return nil, nil, fs.ErrNotExist
+	default:
+		return nil, nil, NewUnexpectedStatusError(reply.Status)
+	}
+}
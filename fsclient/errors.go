@@ -1,10 +1,17 @@
 package fsclient
 
 import (
+	"errors"
 	"fmt"
 	fsrpc "github.com/fourstring/sheetfs/protocol"
 )
 
+// ErrNotEmpty is returned when removing a directory which is not empty non-recursively.
+var ErrNotEmpty = errors.New("directory not empty")
+
+// ErrIteratorDone is returned by SheetIterator.Next when there are no more sheets.
+var ErrIteratorDone = errors.New("no more items in iterator")
+
 type CancelledError struct {
 }
 
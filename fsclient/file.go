@@ -6,6 +6,7 @@ import (
 	"github.com/fourstring/sheetfs/config"
 	fsrpc "github.com/fourstring/sheetfs/protocol"
 	"io/fs"
+	"sort"
 	"sync"
 )
 
@@ -13,16 +14,79 @@ type File struct {
 	fd       uint64
 	filename string
 	client   *Client
+	// The tab whose cells are accessed through f, see WithTab.
+	tab uint32
 }
 
 func newFile(fd uint64, filename string, client *Client) *File {
 	return &File{fd: fd, filename: filename, client: client}
 }
 
+/*
+WithTab
+Returns a File accessing cells in tab through the fd of f. A File returned by Open or
+Create accesses tab 0, the first tab of a new sheet. All of them share the same fd, so
+closing any of them closes the others.
+*/
+func (f *File) WithTab(tab uint32) *File {
+	return &File{fd: f.fd, filename: f.filename, client: f.client, tab: tab}
+}
+
+/*
+Close
+Release the fd of f. After Close, f should not be used any more.
+@return
+	error(error): nil if no error
+		fs.ErrClosed: f has been closed before
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (f *File) Close(ctx context.Context) error {
+	req := fsrpc.CloseSheetRequest{Fd: f.fd}
+	_r, err := f.client.ensureMasterRPCWithRetry("CloseSheet", ctx, &req)
+
+	if err != nil {
+		return err
+	}
+
+	reply := _r.(*fsrpc.CloseSheetReply)
+
+	switch reply.Status {
+	case fsrpc.Status_OK:
+		return nil
+	case fsrpc.Status_NotFound:
+		return fs.ErrClosed
+	default:
+		return NewUnexpectedStatusError(reply.Status)
+	}
+}
+
+/*
+Stat
+Get metadata and statistics of f, see Client.Stat.
+@return
+	stat(*fsrpc.SheetStat): metadata of the file
+	error(error): nil if no error
+		fs.ErrClosed: f has been closed before
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (f *File) Stat(ctx context.Context) (*fsrpc.SheetStat, error) {
+	req := fsrpc.StatSheetRequest{Target: &fsrpc.StatSheetRequest_Fd{Fd: f.fd}}
+	stat, err := f.client.statSheet(ctx, &req)
+	if err == fs.ErrNotExist {
+		return nil, fs.ErrClosed
+	}
+	return stat, err
+}
+
 /*
 Read
-Request Master to get a list of all Chunks of a file. For each chunk, start a worker
-goroutine to fetch them, and reassemble them into a complete file.
+Read the tab of f as a sheet, see WithTab. Request Master to get a list of all Chunks
+holding cells of the tab. For each chunk, start a worker goroutine to fetch them, and
+reassemble them into a complete sheet.
+Overflow chunks of large cells are fetched by the worker of the chunk holding the cell,
+and spliced right after the slot of the cell.
 
 Sometimes(e.g. due to network delay), client will receive a newer Version from master
 which is not actually written to DataNode by another client. If so, this function will
@@ -33,39 +97,94 @@ spin until it success or cancelled by ctx, so ctx is generally necessary.
 @return
 	n(int64): the read size, -1 if error
 	error(error)
+		fs.ErrInvalid: the tab of f doesn't exist
 		*UnexpectedStatusError: MasterNode or DataNode returns a unexpected status
 		some other errors returned by rpc
 		*CancelledError: If operations(spin or rpc call) are cancelled by ctx. And only if there is no
 		other errors happened and ctx cancelled, a CancelledError will be returned.
 */
 func (f *File) Read(ctx context.Context) (b []byte, n int64, err error) {
-	// read whole sheet
-	masterReq := fsrpc.ReadSheetRequest{Fd: f.fd}
+	masterReply, data, metaData, err := f.readSheet(ctx, &fsrpc.ReadSheetRequest{Fd: f.fd, Tab: f.tab})
+	if masterReply == nil {
+		return []byte{}, -1, err
+	}
+
+	// convert to JSON
+	res := connect(data[f.tab], metaData[f.tab])
+
+	return res, int64(len(res)), err
+}
+
+/*
+ReadWorkbook
+Read all tabs of the sheet like Read, with a single request to MasterNode. Sheets of
+tabs are reassembled into a JSON array in the order of tabs.
+@return
+	b([]byte): the read data, see Read.
+	n(int64): the read size, -1 if error
+	error(error): see Read.
+*/
+func (f *File) ReadWorkbook(ctx context.Context) (b []byte, n int64, err error) {
+	masterReply, data, metaData, err := f.readSheet(ctx, &fsrpc.ReadSheetRequest{Fd: f.fd, AllTabs: true})
+	if masterReply == nil {
+		return []byte{}, -1, err
+	}
+
+	res := []byte("[")
+	for i, tab := range masterReply.Tabs {
+		if i > 0 {
+			res = append(res, ',')
+		}
+		res = append(res, connect(data[tab.Id], metaData[tab.Id])...)
+	}
+	res = append(res, ']')
+
+	return res, int64(len(res)), err
+}
+
+/*
+readSheet
+Request Master to get Chunks by masterReq, and read them in parallel, see Read.
+@return
+	masterReply(*fsrpc.ReadSheetReply): nil if the request to MasterNode fails.
+	data(map[uint32][]byte): data of cells grouped by tabs.
+	metaData(map[uint32][]byte): data of metadata cells of tabs.
+	error(error): see Read, data is partially read if masterReply is not nil.
+*/
+func (f *File) readSheet(ctx context.Context, masterReq *fsrpc.ReadSheetRequest) (*fsrpc.ReadSheetReply, map[uint32][]byte, map[uint32][]byte, error) {
 	// masterReply, err := f.client.master.ReadSheet(ctx, &masterReq)
-	_r, err := f.client.ensureMasterRPCWithRetry("ReadSheet", ctx, &masterReq)
+	_r, err := f.client.ensureMasterRPCWithRetry("ReadSheet", ctx, masterReq)
 
 	// RPC fail may arise by broken master client
 	if err != nil {
-		return []byte{}, -1, err
+		return nil, nil, nil, err
 	}
 
 	masterReply := _r.(*fsrpc.ReadSheetReply)
 
-	err = f.client.checkNewDataNode(masterReply.Chunks)
+	chunks := masterReply.Chunks
+	for _, chunk := range masterReply.Chunks {
+		for _, e := range chunk.Extents {
+			chunks = append(chunks, e.Chunks...)
+		}
+	}
+	err = f.client.checkNewDataNode(chunks)
 	if err != nil {
-		return nil, -1, err
+		return nil, nil, nil, err
 	}
 	switch masterReply.Status {
 	case fsrpc.Status_OK:
 	case fsrpc.Status_NotFound:
-		return []byte{}, -1, fs.ErrNotExist
+		return nil, nil, nil, fs.ErrNotExist
+	case fsrpc.Status_Invalid:
+		return nil, nil, nil, fs.ErrInvalid
 	default:
-		return []byte{}, -1, NewUnexpectedStatusError(masterReply.Status)
+		return nil, nil, nil, NewUnexpectedStatusError(masterReply.Status)
 	}
 	// read every chunk of the file
 	var wg sync.WaitGroup
-	data := make([]byte, 0)
-	metaData := make([]byte, 0)
+	data := map[uint32][]byte{}
+	metaData := map[uint32][]byte{}
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	var workerErr error
@@ -90,11 +209,11 @@ func (f *File) Read(ctx context.Context) (b []byte, n int64, err error) {
 					return
 				default:
 				}
+				// cells are versioned separately, check them below
 				dataReply, err := f.client.concurrentReadChunk(ctx, chunk, &fsrpc.ReadChunkRequest{
-					Id:      chunk.Id,
-					Offset:  0,
-					Size:    config.FILE_SIZE,
-					Version: chunk.Version,
+					Id:     chunk.Id,
+					Offset: 0,
+					Size:   config.FILE_SIZE,
 				})
 				if err != nil {
 					workerMu.Lock()
@@ -106,7 +225,9 @@ func (f *File) Read(ctx context.Context) (b []byte, n int64, err error) {
 				}
 				switch dataReply.Status {
 				case fsrpc.Status_OK:
-					// Do nothing
+					if !slotsWritten(dataReply.Versions, 0, chunk.Extents) {
+						continue
+					}
 				case fsrpc.Status_WrongVersion:
 					continue
 				case fsrpc.Status_NotFound:
@@ -124,11 +245,28 @@ func (f *File) Read(ctx context.Context) (b []byte, n int64, err error) {
 					return
 				}
 
-				if chunk.HoldsMeta {
-					metaData = dataReply.Data
-				} else {
+				// a chunk may hold cells of different tabs
+				extents := map[uint32][]*fsrpc.CellExtent{}
+				for _, e := range chunk.Extents {
+					extents[e.Tab] = append(extents[e.Tab], e)
+				}
+				for tab, tabExtents := range extents {
+					// strip padding and reassemble large cells stored in overflow chunks
+					chunkData, err := f.extractCells(ctx, dataReply.Data, tabExtents)
 					workerMu.Lock()
-					data = append(data, dataReply.Data...)
+					if err != nil {
+						if workerErr == nil {
+							workerErr = err
+						}
+						cancel()
+						workerMu.Unlock()
+						return
+					}
+					if chunk.HoldsMeta {
+						metaData[tab] = chunkData
+					} else {
+						data[tab] = append(data[tab], chunkData...)
+					}
 					workerMu.Unlock()
 				}
 				return
@@ -138,10 +276,202 @@ func (f *File) Read(ctx context.Context) (b []byte, n int64, err error) {
 	// wait for all tasks finish
 	wg.Wait()
 
-	// convert to JSON
-	res := connect(data, metaData)
+	return masterReply, data, metaData, workerErr
+}
+
+/*
+readChunk
+Read a range of a chunk at given version from DataNode. It will spin until the
+version is written to DataNode or cancelled by ctx.
+@return
+	data([]byte): the read data
+	error(error)
+		fs.ErrNotExist: the chunk is not found
+		*UnexpectedStatusError: DataNode returns a unexpected status
+		some other errors returned by rpc
+		*CancelledError: If operations(spin or rpc call) are cancelled by ctx.
+*/
+func (f *File) readChunk(ctx context.Context, chunk *fsrpc.Chunk, req *fsrpc.ReadChunkRequest) ([]byte, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, &CancelledError{}
+		default:
+		}
+		dataReply, err := f.client.concurrentReadChunk(ctx, chunk, req)
+		// already do re-ask datanode server
+
+		if err != nil {
+			return nil, err
+		}
+		switch dataReply.Status {
+		case fsrpc.Status_OK:
+			return dataReply.Data, nil
+		case fsrpc.Status_NotFound:
+			// not found
+			return nil, fs.ErrNotExist
+		case fsrpc.Status_WrongVersion:
+			// spin until cancelled
+			continue
+		default:
+			return nil, NewUnexpectedStatusError(dataReply.Status)
+		}
+	}
+}
+
+/*
+slotsWritten
+Returns true if the slot of every extent has been written at its version, given versions
+of slots read from offset returned by DataNode.
+*/
+func slotsWritten(versions []uint64, offset uint64, extents []*fsrpc.CellExtent) bool {
+	for _, e := range extents {
+		i := e.Offset/config.SLOT_SIZE - offset/config.SLOT_SIZE
+		if i >= uint64(len(versions)) || versions[i] < e.Version {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+readSlots
+Read a range of a chunk covering slots of extents. Slots are versioned separately, so it
+will spin until every slot of extents is written to DataNode at its version or cancelled
+by ctx, see readChunk.
+*/
+func (f *File) readSlots(ctx context.Context, chunk *fsrpc.Chunk, offset uint64, size uint64, extents []*fsrpc.CellExtent) ([]byte, error) {
+	req := &fsrpc.ReadChunkRequest{Id: chunk.Id, Offset: offset, Size: size}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, &CancelledError{}
+		default:
+		}
+		dataReply, err := f.client.concurrentReadChunk(ctx, chunk, req)
+		if err != nil {
+			return nil, err
+		}
+		switch dataReply.Status {
+		case fsrpc.Status_OK:
+			if slotsWritten(dataReply.Versions, offset, extents) {
+				return dataReply.Data, nil
+			}
+		case fsrpc.Status_NotFound:
+			return nil, fs.ErrNotExist
+		case fsrpc.Status_WrongVersion:
+		default:
+			return nil, NewUnexpectedStatusError(dataReply.Status)
+		}
+		// spin until cancelled
+	}
+}
+
+/*
+writeChunk
+Write data to a chunk at given version. It will spin until the previous version is
+written to DataNode or cancelled by ctx.
+@return
+	error(error)
+		fs.ErrNotExist: the chunk is not found
+		*UnexpectedStatusError: DataNode returns a unexpected status
+		some other errors returned by rpc
+		*CancelledError: If operations(spin or rpc call) are cancelled by ctx.
+*/
+func (f *File) writeChunk(ctx context.Context, chunk *fsrpc.Chunk, req *fsrpc.WriteChunkRequest) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return &CancelledError{}
+		default:
+		}
+		dataReply, err := f.client.concurrentWriteChunk(ctx, chunk, req)
+		// already do re-ask datanode server
 
-	return res, int64(len(res)), workerErr
+		if err != nil {
+			return err
+		}
+		switch dataReply.Status {
+		case fsrpc.Status_OK:
+			return nil
+		case fsrpc.Status_NotFound:
+			// not found
+			return fs.ErrNotExist
+		case fsrpc.Status_WrongVersion:
+			// spin until cancelled or success
+			continue
+		default:
+			return NewUnexpectedStatusError(dataReply.Status)
+		}
+	}
+}
+
+/*
+readOverflow
+Read whole overflow chunks of a large cell in order, see fsrpc.Cell.
+*/
+func (f *File) readOverflow(ctx context.Context, chunks []*fsrpc.Chunk) ([]byte, error) {
+	var data []byte
+	for _, c := range chunks {
+		d, err := f.readChunk(ctx, c, &fsrpc.ReadChunkRequest{
+			Id:      c.Id,
+			Offset:  0,
+			Size:    config.FILE_SIZE,
+			Version: c.Version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, d...)
+	}
+	return data, nil
+}
+
+/*
+extractCells
+Extract data of cells from the data of a whole chunk, in the order of their offsets.
+Padding is stripped, and data of large cells in overflow chunks is appended right after
+their slots.
+*/
+func (f *File) extractCells(ctx context.Context, data []byte, extents []*fsrpc.CellExtent) ([]byte, error) {
+	sort.Slice(extents, func(i, j int) bool {
+		return extents[i].Offset < extents[j].Offset
+	})
+	res := make([]byte, 0, len(data))
+	for _, e := range extents {
+		d, err := f.extractCell(ctx, data, e)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, d...)
+	}
+	return res, nil
+}
+
+/*
+extractCell
+Extract data of a single cell from data of its chunk read from offset 0, see extractCells.
+*/
+func (f *File) extractCell(ctx context.Context, data []byte, e *fsrpc.CellExtent) ([]byte, error) {
+	if e.Offset >= uint64(len(data)) {
+		return nil, nil
+	}
+	end := e.Offset + e.Length
+	if e.Length > e.Size {
+		end = e.Offset + e.Size
+	}
+	if end > uint64(len(data)) {
+		end = uint64(len(data))
+	}
+	res := data[e.Offset:end:end]
+	if e.Length > e.Size {
+		d, err := f.readOverflow(ctx, e.Chunks)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, truncate(d, e.Length-e.Size)...)
+	}
+	return res, nil
 }
 
 /*
@@ -150,13 +480,15 @@ Sometimes(e.g. due to network delay), client will receive a newer Version from m
 which is not actually written to DataNode by another client. If so, this function will
 spin until it success or cancelled by ctx, so ctx is generally necessary.
 
+Only data written to the cell is returned, without padding. Data of a large cell stored
+in overflow chunks is read and reassembled transparently.
+
 @para
 	ctx: context.Context used to cancel operation
 	b: buffer for reading cell
 	row, col
 @return
-	fd(uint64): the fd of the open file
-	status(Status)
+	n(int64): length of data written to the cell
 	error(error)
 		*UnexpectedStatusError: MasterNode or DataNode returns a unexpected status
 		some other errors returned by rpc
@@ -167,6 +499,7 @@ func (f *File) ReadAt(ctx context.Context, b []byte, row uint32, col uint32) (n
 	// read cell to get metadata
 	masterReq := fsrpc.ReadCellRequest{
 		Fd:     f.fd,
+		Tab:    f.tab,
 		Row:    row,
 		Column: col,
 	}
@@ -185,47 +518,33 @@ func (f *File) ReadAt(ctx context.Context, b []byte, row uint32, col uint32) (n
 		return -1, NewUnexpectedStatusError(masterReply.Status)
 	}
 
-	err = f.client.checkNewDataNode([]*fsrpc.Chunk{masterReply.Cell.Chunk})
+	cell := masterReply.Cell
+	err = f.client.checkNewDataNode(append([]*fsrpc.Chunk{cell.Chunk}, cell.Overflow...))
 	if err != nil {
 		return -1, err
 	}
 
 	// use metadata to read chunk
 	dataReq := fsrpc.ReadChunkRequest{
-		Id:      masterReply.Cell.Chunk.Id,
-		Offset:  masterReply.Cell.Offset,
-		Size:    masterReply.Cell.Size,
-		Version: masterReply.Cell.Chunk.Version,
+		Id:      cell.Chunk.Id,
+		Offset:  cell.Offset,
+		Size:    cell.Size,
+		Version: cell.Version,
 	}
-	for {
-		select {
-		case <-ctx.Done():
-			return -1, &CancelledError{}
-		default:
-		}
-		dataReply, err := f.client.concurrentReadChunk(ctx, masterReply.Cell.Chunk, &dataReq)
-		// already do re-ask datanode server
-
+	data, err := f.readChunk(ctx, cell.Chunk, &dataReq)
+	if err != nil {
+		return -1, err
+	}
+	data = truncate(data, cell.Length)
+	if cell.Length > cell.Size {
+		overflow, err := f.readOverflow(ctx, cell.Overflow)
 		if err != nil {
 			return -1, err
 		}
-		switch dataReply.Status {
-		case fsrpc.Status_OK:
-			// open correctly
-			// DynamicCopy(&b, dataReply.Data)
-			copy(b, dataReply.Data)
-			// b = append(b, dataReply.Data[len(b):]...)
-			return int64(masterReply.Cell.Size), nil
-		case fsrpc.Status_NotFound:
-			// not found
-			return -1, fs.ErrNotExist
-		case fsrpc.Status_WrongVersion:
-			// spin until cancelled
-			continue
-		default:
-			return -1, NewUnexpectedStatusError(dataReply.Status)
-		}
+		data = append(data[:len(data):len(data)], truncate(overflow, cell.Length-cell.Size)...)
 	}
+	copy(b, data)
+	return int64(len(data)), nil
 }
 
 /*
@@ -234,17 +553,21 @@ Sometimes(e.g. due to network delay), client will receive a newer Version from m
 which is not actually written to DataNode by another client. If so, this function will
 spin until it success or cancelled by ctx, so ctx is generally necessary.
 
+If b doesn't fit in the slot of the cell, MasterNode moves the cell to a bigger slot,
+whose old slot is cleared with padding here. If b doesn't fit in the biggest slot either,
+MasterNode allocates overflow chunks for the cell, and the rest of b is written to them.
+All overflow chunks are always written, the unused parts of them are filled with padding.
+
 @para
-	@para
 	ctx: context.Context used to cancel operation
-	b: buffer for reading cell
+	b: data to write to the cell
 	padding: padding character used to pad a cell to its maximum size, for LuckySheet file,
 	a " " should be passed in.
 	row, col
 @return
-	fd(uint64): the fd of the open file
-	status(Status)
+	n(int64): bytes written
 	error(error)
+		fs.ErrInvalid: b is too large for a cell
 		*UnexpectedStatusError: MasterNode or DataNode returns a unexpected status
 		some other errors returned by rpc
 		*CancelledError: If operations(spin or rpc call) are cancelled by ctx. And only if there is no
@@ -254,8 +577,10 @@ func (f *File) WriteAt(ctx context.Context, b []byte, row uint32, col uint32, pa
 	// read cell to get metadata
 	masterReq := fsrpc.WriteCellRequest{
 		Fd:     f.fd,
+		Tab:    f.tab,
 		Row:    row,
 		Column: col,
+		Size:   uint64(len(b)),
 	}
 	//masterReply, err := f.client.master.WriteCell(ctx, &masterReq)
 	_r, err := f.client.ensureMasterRPCWithRetry("WriteCell", ctx, &masterReq)
@@ -266,20 +591,20 @@ func (f *File) WriteAt(ctx context.Context, b []byte, row uint32, col uint32, pa
 	}
 
 	masterReply := _r.(*fsrpc.WriteCellReply)
-	// get the correct version
-	var version uint64
 	switch masterReply.Status {
 	case fsrpc.Status_OK:
 		// open correctly
-		version = masterReply.Cell.Chunk.Version
 	case fsrpc.Status_NotFound:
 		// not found, should let the client know
 		return -1, fs.ErrNotExist
+	case fsrpc.Status_Invalid:
+		return -1, fs.ErrInvalid
 	default:
 		return -1, NewUnexpectedStatusError(masterReply.Status)
 	}
 
-	err = f.client.checkNewDataNode([]*fsrpc.Chunk{masterReply.Cell.Chunk})
+	cell := masterReply.Cell
+	err = f.client.checkNewDataNode(append([]*fsrpc.Chunk{cell.Chunk}, cell.Overflow...))
 	if err != nil {
 		return -1, err
 	}
@@ -288,45 +613,127 @@ func (f *File) WriteAt(ctx context.Context, b []byte, row uint32, col uint32, pa
 		padding = " "
 	}
 
-	targetSize := uint64(config.BLOCK_SIZE)
-	if row == MetaCellRow && col == MetaCellCol {
-		targetSize = uint64(config.FILE_SIZE)
-	}
+	// slots of cells have different sizes
+	targetSize := cell.Size
 	// use metadata to read chunk
+	data := b
+	if uint64(len(data)) > targetSize {
+		data = data[:targetSize]
+	}
 	dataReq := fsrpc.WriteChunkRequest{
-		Id:         masterReply.Cell.Chunk.Id,
-		Offset:     masterReply.Cell.Offset,
-		Size:       uint64(len(b)),
+		Id:         cell.Chunk.Id,
+		Offset:     cell.Offset,
+		Size:       uint64(len(data)),
 		TargetSize: targetSize,
-		Version:    version,
+		Version:    cell.Version,
 		Padding:    padding,
-		Data:       b,
+		Data:       data,
 	}
-	for {
-		select {
-		case <-ctx.Done():
-			return -1, &CancelledError{}
-		default:
+	err = f.writeChunk(ctx, cell.Chunk, &dataReq)
+	if err != nil {
+		return -1, err
+	}
+	rest := b[len(data):]
+	for _, c := range cell.Overflow {
+		data = rest
+		if len(data) > config.FILE_SIZE {
+			data = data[:config.FILE_SIZE]
 		}
-		dataReply, err := f.client.concurrentWriteChunk(ctx, masterReply.Cell.Chunk, &dataReq)
-		// already do re-ask datanode server
-
+		rest = rest[len(data):]
+		err = f.writeChunk(ctx, c, &fsrpc.WriteChunkRequest{
+			Id:         c.Id,
+			Offset:     0,
+			Size:       uint64(len(data)),
+			TargetSize: config.FILE_SIZE,
+			Version:    c.Version,
+			Padding:    padding,
+			Data:       data,
+		})
 		if err != nil {
 			return -1, err
 		}
-		// fmt.Print(dataReply.String())
-		switch dataReply.Status {
-		case fsrpc.Status_OK:
-			// open correctly
-			return int64(len(b)), nil
-		case fsrpc.Status_NotFound:
-			// not found
-			return -1, fs.ErrNotExist
-		case fsrpc.Status_WrongVersion:
-			// spin until cancelled or success
-			continue
-		default:
-			return -1, NewUnexpectedStatusError(dataReply.Status)
+	}
+	if masterReply.Vacated != nil {
+		// the cell has been moved to a bigger slot, clear the old one
+		err = f.clearSlot(ctx, masterReply.Vacated, padding)
+		if err != nil {
+			return -1, err
 		}
 	}
+	return int64(len(b)), nil
+}
+
+/*
+DeleteAt
+Delete the cell located at (row, col). MasterNode frees the slot of the cell for
+reuse, and the slot is overwritten with padding, so the deleted cell won't come
+back from Read.
+
+Like WriteAt, this function will spin until the padding is written or cancelled by
+ctx, so ctx is generally necessary.
+
+@para
+	ctx: context.Context used to cancel operation
+	row, col
+	padding: padding character used to overwrite the cell, for LuckySheet file,
+	a " " should be passed in.
+@return
+	error(error)
+		fs.ErrNotExist: f has been closed, or there is no such cell
+		*UnexpectedStatusError: MasterNode or DataNode returns a unexpected status
+		some other errors returned by rpc
+		*CancelledError: If operations(spin or rpc call) are cancelled by ctx.
+*/
+func (f *File) DeleteAt(ctx context.Context, row uint32, col uint32, padding string) error {
+	masterReq := fsrpc.DeleteCellRequest{
+		Fd:     f.fd,
+		Tab:    f.tab,
+		Row:    row,
+		Column: col,
+	}
+	_r, err := f.client.ensureMasterRPCWithRetry("DeleteCell", ctx, &masterReq)
+
+	// RPC fail may arise by broken master client
+	if err != nil {
+		return err
+	}
+
+	masterReply := _r.(*fsrpc.DeleteCellReply)
+	switch masterReply.Status {
+	case fsrpc.Status_OK:
+	case fsrpc.Status_NotFound, fsrpc.Status_Invalid:
+		return fs.ErrNotExist
+	default:
+		return NewUnexpectedStatusError(masterReply.Status)
+	}
+	if masterReply.Cell == nil {
+		// The whole chunk has been freed, nothing to overwrite.
+		return nil
+	}
+
+	return f.clearSlot(ctx, masterReply.Cell, padding)
+}
+
+/*
+clearSlot
+Overwrite a freed slot with padding at its version, so that the data of the slot won't
+be returned by Read.
+*/
+func (f *File) clearSlot(ctx context.Context, cell *fsrpc.Cell, padding string) error {
+	err := f.client.checkNewDataNode([]*fsrpc.Chunk{cell.Chunk})
+	if err != nil {
+		return err
+	}
+	if len(padding) == 0 {
+		padding = " "
+	}
+	dataReq := fsrpc.WriteChunkRequest{
+		Id:         cell.Chunk.Id,
+		Offset:     cell.Offset,
+		Size:       0,
+		TargetSize: cell.Size,
+		Version:    cell.Version,
+		Padding:    padding,
+	}
+	return f.writeChunk(ctx, cell.Chunk, &dataReq)
 }
@@ -0,0 +1,423 @@
+package fsclient
+
+import (
+	"bytes"
+	"context"
+	"github.com/fourstring/sheetfs/config"
+	fsrpc "github.com/fourstring/sheetfs/protocol"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"io/fs"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Group name of the only DataNode of files created by newTestFile.
+const testDataNode = "test datanode"
+
+/*
+fakeMaster
+A MasterNodeClient recording requests and replying with reply, so requests built by a
+File can be checked without starting any node. Only RPCs used by tests are implemented.
+*/
+type fakeMaster struct {
+	fsrpc.MasterNodeClient
+	mu       sync.Mutex
+	requests []interface{}
+	reply    interface{}
+}
+
+func (m *fakeMaster) call(in interface{}) interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, in)
+	return m.reply
+}
+
+func (m *fakeMaster) ReadSheet(ctx context.Context, in *fsrpc.ReadSheetRequest, opts ...grpc.CallOption) (*fsrpc.ReadSheetReply, error) {
+	return m.call(in).(*fsrpc.ReadSheetReply), nil
+}
+
+func (m *fakeMaster) ReadCells(ctx context.Context, in *fsrpc.ReadCellsRequest, opts ...grpc.CallOption) (*fsrpc.ReadCellsReply, error) {
+	return m.call(in).(*fsrpc.ReadCellsReply), nil
+}
+
+func (m *fakeMaster) WriteCells(ctx context.Context, in *fsrpc.WriteCellsRequest, opts ...grpc.CallOption) (*fsrpc.WriteCellsReply, error) {
+	return m.call(in).(*fsrpc.WriteCellsReply), nil
+}
+
+type fakeChunk struct {
+	data     []byte
+	versions []uint64
+}
+
+/*
+fakeDataNode
+A DataNodeClient keeping chunks in memory. Data of chunks are only changed by put, writes
+are recorded by chunks to be checked by tests.
+*/
+type fakeDataNode struct {
+	fsrpc.DataNodeClient
+	mu     sync.Mutex
+	chunks map[uint64]*fakeChunk
+	reads  []*fsrpc.ReadChunkRequest
+	writes map[uint64][]*fsrpc.WriteChunkRequest
+}
+
+/*
+put
+Store data in chunk id from offset, and set the version of the slot at offset.
+*/
+func (d *fakeDataNode) put(id uint64, offset uint64, data []byte, version uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c, ok := d.chunks[id]
+	if !ok {
+		c = &fakeChunk{data: make([]byte, config.FILE_SIZE), versions: make([]uint64, config.SLOTS_PER_FILE)}
+		d.chunks[id] = c
+	}
+	copy(c.data[offset:], data)
+	c.versions[offset/config.SLOT_SIZE] = version
+}
+
+// A cell stored by putCells.
+type testCell struct {
+	tab  uint32
+	pos  CellPos
+	data string
+}
+
+/*
+putCells
+Store cells in consecutive slots of chunk id from offset 0, padded and at version 1.
+@return
+	*fsrpc.Chunk: the chunk with extents of cells in order, as replied by MasterNode.
+*/
+func (d *fakeDataNode) putCells(id uint64, cells ...testCell) *fsrpc.Chunk {
+	chunk := &fsrpc.Chunk{Id: id, Datanode: testDataNode, Version: uint64(len(cells))}
+	for i, cell := range cells {
+		offset := uint64(i) * config.SLOT_SIZE
+		d.put(id, offset, []byte(cell.data+"   "), 1)
+		chunk.Extents = append(chunk.Extents, &fsrpc.CellExtent{
+			Tab:     cell.tab,
+			Row:     cell.pos.Row,
+			Column:  cell.pos.Col,
+			Offset:  offset,
+			Size:    config.SLOT_SIZE,
+			Length:  uint64(len(cell.data)),
+			Version: 1,
+		})
+	}
+	return chunk
+}
+
+func (d *fakeDataNode) ReadChunk(ctx context.Context, in *fsrpc.ReadChunkRequest, opts ...grpc.CallOption) (*fsrpc.ReadChunkReply, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reads = append(d.reads, in)
+	c, ok := d.chunks[in.Id]
+	if !ok {
+		return &fsrpc.ReadChunkReply{Status: fsrpc.Status_NotFound}, nil
+	}
+	first, last := in.Offset/config.SLOT_SIZE, (in.Offset+in.Size-1)/config.SLOT_SIZE
+	return &fsrpc.ReadChunkReply{
+		Status:   fsrpc.Status_OK,
+		Data:     c.data[in.Offset : in.Offset+in.Size],
+		Versions: c.versions[first : last+1],
+	}, nil
+}
+
+func (d *fakeDataNode) WriteChunk(ctx context.Context, in *fsrpc.WriteChunkRequest, opts ...grpc.CallOption) (*fsrpc.WriteChunkReply, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.writes[in.Id] = append(d.writes[in.Id], in)
+	return &fsrpc.WriteChunkReply{Status: fsrpc.Status_OK}, nil
+}
+
+/*
+newTestFile
+Returns a File of fd 1 whose client talks to a fakeMaster and a fakeDataNode directly,
+without ZooKeeper or sessions. Chunks replied by the fakeMaster should be on testDataNode.
+*/
+func newTestFile() (*File, *fakeMaster, *fakeDataNode) {
+	master := &fakeMaster{}
+	datanode := &fakeDataNode{
+		chunks: map[uint64]*fakeChunk{},
+		writes: map[uint64][]*fsrpc.WriteChunkRequest{},
+	}
+	c := &Client{
+		cfg:         &ClientConfig{MaxRetry: maxRetry},
+		master:      &masterNode{c: master},
+		datanodeMap: map[string]*dataNode{testDataNode: {c: datanode}},
+		stop:        make(chan struct{}),
+	}
+	return newFile(1, "test file", c), master, datanode
+}
+
+func TestSlotsWritten(t *testing.T) {
+	Convey("Check versions of slots read from an offset", t, func() {
+		extents := []*fsrpc.CellExtent{
+			{Offset: 2 * config.SLOT_SIZE, Size: config.SLOT_SIZE, Version: 3},
+			{Offset: 4 * config.SLOT_SIZE, Size: config.SLOT_SIZE, Version: 1},
+		}
+		offset := uint64(2 * config.SLOT_SIZE)
+		So(slotsWritten([]uint64{3, 0, 1}, offset, extents), ShouldBeTrue)
+		So(slotsWritten([]uint64{4, 0, 2}, offset, extents), ShouldBeTrue)
+		So(slotsWritten([]uint64{2, 0, 1}, offset, extents), ShouldBeFalse)
+		So(slotsWritten([]uint64{3, 0, 0}, offset, extents), ShouldBeFalse)
+		// the slot of the second extent is not read
+		So(slotsWritten([]uint64{3, 0}, offset, extents), ShouldBeFalse)
+		So(slotsWritten(nil, offset, nil), ShouldBeTrue)
+	})
+}
+
+func TestFile_extractCells(t *testing.T) {
+	Convey("Build test file", t, func() {
+		f, _, datanode := newTestFile()
+		chunk := datanode.putCells(1, testCell{data: "ab"}, testCell{data: "cde"})
+		data := datanode.chunks[1].data
+
+		Convey("Strip padding in the order of offsets", func() {
+			res, err := f.extractCells(ctx, data, []*fsrpc.CellExtent{chunk.Extents[1], chunk.Extents[0]})
+			So(err, ShouldBeNil)
+			So(string(res), ShouldEqual, "abcde")
+		})
+
+		Convey("Skip slots beyond data", func() {
+			res, err := f.extractCells(ctx, data[:config.SLOT_SIZE], chunk.Extents)
+			So(err, ShouldBeNil)
+			So(string(res), ShouldEqual, "ab")
+		})
+
+		Convey("Append data of overflow chunks", func() {
+			datanode.put(2, 0, []byte("xyz "), 1)
+			datanode.put(3, 0, []byte("uvw "), 1)
+			large := chunk.Extents[1]
+			large.Length = config.SLOT_SIZE + config.FILE_SIZE + 2
+			large.Chunks = []*fsrpc.Chunk{
+				{Id: 2, Datanode: testDataNode, Version: 1},
+				{Id: 3, Datanode: testDataNode, Version: 1},
+			}
+			res, err := f.extractCells(ctx, data, chunk.Extents)
+			So(err, ShouldBeNil)
+			So(len(res), ShouldEqual, 2+config.SLOT_SIZE+config.FILE_SIZE+2)
+			So(string(res[:5]), ShouldEqual, "abcde")
+			So(string(res[2+config.SLOT_SIZE:][:3]), ShouldEqual, "xyz")
+			So(string(res[2+config.SLOT_SIZE+config.FILE_SIZE:]), ShouldEqual, "uv")
+		})
+	})
+}
+
+func TestFile_ReadRange(t *testing.T) {
+	Convey("Build test file", t, func() {
+		f, master, datanode := newTestFile()
+		chunk := datanode.putCells(1,
+			testCell{pos: CellPos{Row: 5, Col: 5}, data: "out of range"},
+			testCell{pos: CellPos{Row: 0, Col: 0}, data: "a"},
+			testCell{pos: CellPos{Row: 0, Col: 1}, data: "bc"},
+		)
+		// only extents of cells in the range are replied
+		chunk.Extents = chunk.Extents[1:]
+		master.reply = &fsrpc.ReadCellsReply{
+			Status: fsrpc.Status_OK,
+			Chunks: []*fsrpc.Chunk{chunk, datanode.putCells(2, testCell{pos: CellPos{Row: 1, Col: 0}, data: "d"})},
+		}
+
+		Convey("Read every chunk once", func() {
+			cells, err := f.ReadRange(ctx, 0, 2, 0, 2)
+			So(err, ShouldBeNil)
+			So(master.requests, ShouldResemble, []interface{}{&fsrpc.ReadCellsRequest{
+				Fd: 1, RowStart: 0, RowEnd: 2, ColumnStart: 0, ColumnEnd: 2,
+			}})
+			So(cells, ShouldResemble, map[CellPos][]byte{
+				{Row: 0, Col: 0}: []byte("a"),
+				{Row: 0, Col: 1}: []byte("bc"),
+				{Row: 1, Col: 0}: []byte("d"),
+			})
+			So(len(datanode.reads), ShouldEqual, 2)
+			for _, req := range datanode.reads {
+				if req.Id == 1 {
+					// the span covering slots in the range only
+					So(req.Offset, ShouldEqual, config.SLOT_SIZE)
+					So(req.Size, ShouldEqual, 2*config.SLOT_SIZE)
+				} else {
+					So(req.Offset, ShouldEqual, 0)
+					So(req.Size, ShouldEqual, config.SLOT_SIZE)
+				}
+			}
+		})
+
+		Convey("Spin until a slot is written", func() {
+			datanode.put(2, 0, nil, 0)
+			ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			defer cancel()
+			cells, err := f.ReadRange(ctx, 0, 2, 0, 2)
+			So(cells, ShouldBeNil)
+			So(err, ShouldHaveSameTypeAs, &CancelledError{})
+		})
+
+		Convey("Read a closed file", func() {
+			master.reply = &fsrpc.ReadCellsReply{Status: fsrpc.Status_NotFound}
+			cells, err := f.ReadRange(ctx, 0, 2, 0, 2)
+			So(cells, ShouldBeNil)
+			So(err, ShouldEqual, fs.ErrClosed)
+			So(datanode.reads, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestFile_WriteCells(t *testing.T) {
+	Convey("Build test file", t, func() {
+		f, master, datanode := newTestFile()
+		large := bytes.Repeat([]byte("x"), config.SLOT_SIZE+5)
+		cells := map[CellPos][]byte{
+			{Row: 1, Col: 0}: []byte("c"),
+			{Row: 0, Col: 1}: []byte("bb"),
+			{Row: 0, Col: 0}: large,
+		}
+		chunk1 := &fsrpc.Chunk{Id: 1, Datanode: testDataNode, Version: 3}
+		chunk2 := &fsrpc.Chunk{Id: 2, Datanode: testDataNode, Version: 1}
+		chunk3 := &fsrpc.Chunk{Id: 3, Datanode: testDataNode, Version: 2}
+		// cells are replied in the order of the request
+		replied := []*fsrpc.Cell{
+			{Chunk: chunk1, Offset: 0, Size: config.SLOT_SIZE, Version: 2, Overflow: []*fsrpc.Chunk{chunk2}},
+			{Chunk: chunk1, Offset: 2 * config.SLOT_SIZE, Size: config.SLOT_SIZE, Version: 3},
+			{Chunk: chunk3, Offset: 0, Size: config.SLOT_SIZE, Version: 1},
+		}
+		master.reply = &fsrpc.WriteCellsReply{
+			Status: fsrpc.Status_OK,
+			Cells:  replied,
+			Vacated: []*fsrpc.Cell{
+				// taken by the second cell of the batch
+				{Chunk: chunk1, Offset: 2 * config.SLOT_SIZE, Size: config.SLOT_SIZE, Version: 3},
+				{Chunk: chunk3, Offset: config.SLOT_SIZE, Size: config.SLOT_SIZE, Version: 2},
+			},
+		}
+
+		Convey("Request sizes of cells sorted by positions", func() {
+			err := f.WriteCells(ctx, cells, "")
+			So(err, ShouldBeNil)
+			So(master.requests, ShouldResemble, []interface{}{&fsrpc.WriteCellsRequest{
+				Fd: 1,
+				Cells: []*fsrpc.CellWrite{
+					{Row: 0, Column: 0, Size: config.SLOT_SIZE + 5},
+					{Row: 0, Column: 1, Size: 2},
+					{Row: 1, Column: 0, Size: 1},
+				},
+			}})
+		})
+
+		Convey("Write slots grouped by chunks", func() {
+			err := f.WriteCells(ctx, cells, "")
+			So(err, ShouldBeNil)
+			So(datanode.writes[1], ShouldResemble, []*fsrpc.WriteChunkRequest{
+				{Id: 1, Offset: 0, Size: config.SLOT_SIZE, TargetSize: config.SLOT_SIZE, Version: 2, Padding: " ", Data: large[:config.SLOT_SIZE]},
+				{Id: 1, Offset: 2 * config.SLOT_SIZE, Size: 2, TargetSize: config.SLOT_SIZE, Version: 3, Padding: " ", Data: []byte("bb")},
+			})
+			So(datanode.writes[2], ShouldResemble, []*fsrpc.WriteChunkRequest{
+				{Id: 2, Offset: 0, Size: 5, TargetSize: config.FILE_SIZE, Version: 1, Padding: " ", Data: large[config.SLOT_SIZE:]},
+			})
+			// the vacated slot is cleared with padding
+			So(datanode.writes[3], ShouldResemble, []*fsrpc.WriteChunkRequest{
+				{Id: 3, Offset: 0, Size: 1, TargetSize: config.SLOT_SIZE, Version: 1, Padding: " ", Data: []byte("c")},
+				{Id: 3, Offset: config.SLOT_SIZE, Size: 0, TargetSize: config.SLOT_SIZE, Version: 2, Padding: " "},
+			})
+		})
+
+		Convey("Write cells handled before a failure", func() {
+			master.reply = &fsrpc.WriteCellsReply{Status: fsrpc.Status_Invalid, Cells: replied[:1]}
+			err := f.WriteCells(ctx, cells, "*")
+			So(err, ShouldEqual, fs.ErrInvalid)
+			So(len(datanode.writes[1]), ShouldEqual, 1)
+			So(datanode.writes[1][0].Padding, ShouldEqual, "*")
+			So(len(datanode.writes[2]), ShouldEqual, 1)
+			So(datanode.writes[3], ShouldBeEmpty)
+		})
+
+		Convey("Write to a closed file", func() {
+			master.reply = &fsrpc.WriteCellsReply{Status: fsrpc.Status_NotFound}
+			err := f.WriteCells(ctx, cells, "")
+			So(err, ShouldEqual, fs.ErrNotExist)
+			So(datanode.writes, ShouldBeEmpty)
+		})
+
+		Convey("Write nothing", func() {
+			err := f.WriteCells(ctx, map[CellPos][]byte{}, "")
+			So(err, ShouldBeNil)
+			So(master.requests, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestFile_WithTab(t *testing.T) {
+	Convey("Build test file", t, func() {
+		f, master, _ := newTestFile()
+		tf := f.WithTab(2)
+		So(tf.fd, ShouldEqual, f.fd)
+		So(f.tab, ShouldEqual, 0)
+
+		Convey("Read a range of the tab", func() {
+			master.reply = &fsrpc.ReadCellsReply{Status: fsrpc.Status_OK}
+			_, err := tf.ReadRange(ctx, 0, 1, 0, 1)
+			So(err, ShouldBeNil)
+			So(master.requests, ShouldResemble, []interface{}{&fsrpc.ReadCellsRequest{
+				Fd: 1, Tab: 2, RowStart: 0, RowEnd: 1, ColumnStart: 0, ColumnEnd: 1,
+			}})
+		})
+
+		Convey("Write cells of the tab", func() {
+			master.reply = &fsrpc.WriteCellsReply{Status: fsrpc.Status_OK}
+			err := tf.WriteCells(ctx, map[CellPos][]byte{{Row: 0, Col: 0}: []byte("a"), {Row: 0, Col: 1}: []byte("b")}, "")
+			So(err, ShouldBeNil)
+			So(master.requests, ShouldResemble, []interface{}{&fsrpc.WriteCellsRequest{
+				Fd: 1,
+				Cells: []*fsrpc.CellWrite{
+					{Tab: 2, Row: 0, Column: 0, Size: 1},
+					{Tab: 2, Row: 0, Column: 1, Size: 1},
+				},
+			}})
+		})
+
+		Convey("Read the tab as a sheet", func() {
+			master.reply = &fsrpc.ReadSheetReply{Status: fsrpc.Status_OK}
+			b, _, err := tf.Read(ctx)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, "{\"celldata\": []}")
+			So(master.requests, ShouldResemble, []interface{}{&fsrpc.ReadSheetRequest{Fd: 1, Tab: 2}})
+		})
+
+		Convey("Read a tab which doesn't exist", func() {
+			master.reply = &fsrpc.ReadSheetReply{Status: fsrpc.Status_Invalid}
+			_, n, err := tf.Read(ctx)
+			So(n, ShouldEqual, -1)
+			So(err, ShouldEqual, fs.ErrInvalid)
+		})
+	})
+}
+
+func TestFile_ReadWorkbook(t *testing.T) {
+	Convey("Build test file", t, func() {
+		f, master, datanode := newTestFile()
+		meta := datanode.putCells(2, testCell{tab: 1, data: "\"m\": 1"})
+		meta.HoldsMeta = true
+		master.reply = &fsrpc.ReadSheetReply{
+			Status: fsrpc.Status_OK,
+			Chunks: []*fsrpc.Chunk{
+				// a chunk may hold cells of different tabs
+				datanode.putCells(1, testCell{tab: 0, data: "a0,"}, testCell{tab: 1, data: "b1,"}, testCell{tab: 1, data: "b2,"}),
+				meta,
+			},
+			// tab 1 has been moved before tab 0
+			Tabs: []*fsrpc.Tab{{Id: 1, Name: "second", Position: 0}, {Id: 0, Name: "first", Position: 1}},
+		}
+
+		Convey("Read all tabs in order", func() {
+			b, n, err := f.WithTab(1).ReadWorkbook(ctx)
+			So(err, ShouldBeNil)
+			So(master.requests, ShouldResemble, []interface{}{&fsrpc.ReadSheetRequest{Fd: 1, AllTabs: true}})
+			So(string(b), ShouldEqual, "[{\"celldata\": [b1,b2],\"m\": 1},{\"celldata\": [a0]}]")
+			So(n, ShouldEqual, len(b))
+		})
+	})
+}
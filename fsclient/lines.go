@@ -0,0 +1,96 @@
+package fsclient
+
+import (
+	"context"
+	fsrpc "github.com/fourstring/sheetfs/protocol"
+	"io/fs"
+)
+
+/*
+statusReply
+Replies of RPCs which return nothing but a status.
+*/
+type statusReply interface {
+	GetStatus() fsrpc.Status
+}
+
+/*
+editLines
+Call one of RPCs inserting or deleting lines of f. Those RPCs only remap cells on
+MasterNode, so no DataNode is contacted.
+*/
+func (f *File) editLines(ctx context.Context, name string, req interface{}) error {
+	_r, err := f.client.ensureMasterRPCWithRetry(name, ctx, req)
+	if err != nil {
+		return err
+	}
+
+	reply := _r.(statusReply)
+	switch reply.GetStatus() {
+	case fsrpc.Status_OK:
+		return nil
+	case fsrpc.Status_NotFound:
+		return fs.ErrClosed
+	case fsrpc.Status_Invalid:
+		return fs.ErrInvalid
+	default:
+		return NewUnexpectedStatusError(reply.GetStatus())
+	}
+}
+
+/*
+InsertRows
+Insert count empty rows before row. Cells from row on are moved down by count rows,
+without rewriting their data.
+@para
+	row(uint32): the first row to move down
+	count(uint32): number of rows to insert
+@return
+	error(error): nil if no error
+		fs.ErrClosed: f has been closed before
+		fs.ErrInvalid: count is 0, or some cell would be moved out of the sheet
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (f *File) InsertRows(ctx context.Context, row uint32, count uint32) error {
+	req := fsrpc.InsertRowsRequest{Fd: f.fd, Tab: f.tab, Row: row, Count: count}
+	return f.editLines(ctx, "InsertRows", &req)
+}
+
+/*
+DeleteRows
+Delete cells in count rows from row, and move cells below them up by count rows,
+without rewriting their data.
+@para
+	row(uint32): the first row to delete
+	count(uint32): number of rows to delete
+@return
+	error(error): nil if no error
+		fs.ErrClosed: f has been closed before
+		fs.ErrInvalid: count is 0, or the rows exceed the sheet
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (f *File) DeleteRows(ctx context.Context, row uint32, count uint32) error {
+	req := fsrpc.DeleteRowsRequest{Fd: f.fd, Tab: f.tab, Row: row, Count: count}
+	return f.editLines(ctx, "DeleteRows", &req)
+}
+
+/*
+InsertColumns
+Insert count empty columns before col, see InsertRows.
+*/
+func (f *File) InsertColumns(ctx context.Context, col uint32, count uint32) error {
+	req := fsrpc.InsertColumnsRequest{Fd: f.fd, Tab: f.tab, Column: col, Count: count}
+	return f.editLines(ctx, "InsertColumns", &req)
+}
+
+/*
+DeleteColumns
+Delete cells in count columns from col, and move cells on the right of them left
+by count columns, see DeleteRows.
+*/
+func (f *File) DeleteColumns(ctx context.Context, col uint32, count uint32) error {
+	req := fsrpc.DeleteColumnsRequest{Fd: f.fd, Tab: f.tab, Column: col, Count: count}
+	return f.editLines(ctx, "DeleteColumns", &req)
+}
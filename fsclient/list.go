@@ -0,0 +1,110 @@
+package fsclient
+
+import (
+	"context"
+	fsrpc "github.com/fourstring/sheetfs/protocol"
+	"io/fs"
+)
+
+/*
+ListOptions
+Options of Client.ListSheets, the zero value lists all sheets ordered by name.
+*/
+type ListOptions struct {
+	// Path of the directory, "" for the root directory. Sheets in subdirectories are
+	// listed too.
+	Dir string
+	// Only sheets whose name starts with Prefix are listed.
+	Prefix   string
+	Recycled fsrpc.RecycledFilter
+	Order    fsrpc.SheetOrder
+	// Number of sheets fetched from MasterNode in a request, 0 for the default size.
+	PageSize uint32
+}
+
+/*
+SheetIterator
+Iterates over sheets listed by Client.ListSheets, fetching them from MasterNode page
+by page lazily. It's not goroutine-safe.
+*/
+type SheetIterator struct {
+	client *Client
+	req    fsrpc.ListSheetsRequest
+	page   []*fsrpc.Sheet
+	// true if the last page has been fetched
+	last bool
+}
+
+/*
+ListSheets
+List sheets in a directory and all of its subdirectories. No request is sent until
+the first call to Next.
+@para
+	opts(ListOptions): filters and order of sheets
+@return
+	*SheetIterator: iterator over listed sheets
+*/
+func (c *Client) ListSheets(opts ListOptions) *SheetIterator {
+	return &SheetIterator{
+		client: c,
+		req: fsrpc.ListSheetsRequest{
+			Dir:      opts.Dir,
+			Prefix:   opts.Prefix,
+			Recycled: opts.Recycled,
+			Order:    opts.Order,
+			PageSize: opts.PageSize,
+		},
+	}
+}
+
+/*
+Next
+Returns the next sheet, fetching the next page from MasterNode if necessary.
+@return
+	sheet(*fsrpc.Sheet): the next sheet
+	error(error): nil if no error
+		ErrIteratorDone: there are no more sheets
+		fs.ErrNotExist: no such directory
+		fs.ErrInvalid: wrong para
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (it *SheetIterator) Next(ctx context.Context) (*fsrpc.Sheet, error) {
+	for len(it.page) == 0 {
+		if it.last {
+			return nil, ErrIteratorDone
+		}
+		err := it.fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sheet := it.page[0]
+	it.page = it.page[1:]
+	return sheet, nil
+}
+
+func (it *SheetIterator) fetch(ctx context.Context) error {
+	if it.req.Dir != "" && !isValidPath(it.req.Dir) {
+		return fs.ErrInvalid
+	}
+	_reply, err := it.client.ensureMasterRPCWithRetry("ListSheets", ctx, &it.req)
+	if err != nil {
+		return err
+	}
+
+	reply := _reply.(*fsrpc.ListSheetsReply)
+	switch reply.Status {
+	case fsrpc.Status_OK:
+	case fsrpc.Status_NotFound:
+		return fs.ErrNotExist
+	case fsrpc.Status_Invalid:
+		return fs.ErrInvalid
+	default:
+		return NewUnexpectedStatusError(reply.Status)
+	}
+	it.page = reply.Sheets
+	it.req.PageToken = reply.NextPageToken
+	it.last = reply.NextPageToken == ""
+	return nil
+}
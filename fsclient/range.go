@@ -0,0 +1,146 @@
+package fsclient
+
+import (
+	"context"
+	fsrpc "github.com/fourstring/sheetfs/protocol"
+	"io/fs"
+	"sync"
+)
+
+/*
+CellPos
+Row and column of a cell, see File.ReadRange.
+*/
+type CellPos struct {
+	Row uint32
+	Col uint32
+}
+
+/*
+readRangeChunk
+Read the span of chunk covering all extents of it at once, and extract data of those
+cells from the span.
+*/
+func (f *File) readRangeChunk(ctx context.Context, chunk *fsrpc.Chunk) (map[CellPos][]byte, error) {
+	lo, hi := chunk.Extents[0].Offset, uint64(0)
+	for _, e := range chunk.Extents {
+		if e.Offset < lo {
+			lo = e.Offset
+		}
+		if e.Offset+e.Size > hi {
+			hi = e.Offset + e.Size
+		}
+	}
+	data, err := f.readSlots(ctx, chunk, lo, hi-lo, chunk.Extents)
+	if err != nil {
+		return nil, err
+	}
+	cells := make(map[CellPos][]byte, len(chunk.Extents))
+	for _, e := range chunk.Extents {
+		d, err := f.extractCell(ctx, data, &fsrpc.CellExtent{
+			Offset: e.Offset - lo,
+			Size:   e.Size,
+			Chunks: e.Chunks,
+			Length: e.Length,
+		})
+		if err != nil {
+			return nil, err
+		}
+		cells[CellPos{Row: e.Row, Col: e.Column}] = d
+	}
+	return cells, nil
+}
+
+/*
+ReadRange
+Read all cells in rows [rowStart, rowEnd) and columns [colStart, colEnd) with a single
+request to MasterNode. Every chunk holding some of those cells is read only once, and
+chunks are read in parallel. Like ReadAt, only data written to cells is returned.
+
+Like Read, this function will spin if some chunk has not been written to DataNode
+at the version returned by MasterNode, so ctx is generally necessary.
+
+@para
+	ctx: context.Context used to cancel operation
+	rowStart, rowEnd, colStart, colEnd: bounds of the range
+@return
+	cells(map[CellPos][]byte): data of cells in the range, cells which don't exist are
+	absent.
+	error(error)
+		fs.ErrClosed: f has been closed before
+		*UnexpectedStatusError: MasterNode or DataNode returns a unexpected status
+		some other errors returned by rpc
+		*CancelledError: If operations(spin or rpc call) are cancelled by ctx. And only if there is no
+		other errors happened and ctx cancelled, a CancelledError will be returned.
+*/
+func (f *File) ReadRange(ctx context.Context, rowStart, rowEnd, colStart, colEnd uint32) (map[CellPos][]byte, error) {
+	masterReq := fsrpc.ReadCellsRequest{
+		Fd:          f.fd,
+		Tab:         f.tab,
+		RowStart:    rowStart,
+		RowEnd:      rowEnd,
+		ColumnStart: colStart,
+		ColumnEnd:   colEnd,
+	}
+	_r, err := f.client.ensureMasterRPCWithRetry("ReadCells", ctx, &masterReq)
+
+	// RPC fail may arise by broken master client
+	if err != nil {
+		return nil, err
+	}
+
+	masterReply := _r.(*fsrpc.ReadCellsReply)
+	switch masterReply.Status {
+	case fsrpc.Status_OK:
+	case fsrpc.Status_NotFound:
+		return nil, fs.ErrClosed
+	default:
+		return nil, NewUnexpectedStatusError(masterReply.Status)
+	}
+
+	chunks := masterReply.Chunks
+	for _, chunk := range masterReply.Chunks {
+		for _, e := range chunk.Extents {
+			chunks = append(chunks, e.Chunks...)
+		}
+	}
+	err = f.client.checkNewDataNode(chunks)
+	if err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cells := map[CellPos][]byte{}
+	var workerErr error
+	var workerMu sync.Mutex
+
+	for _, chunk := range masterReply.Chunks {
+		wg.Add(1)
+		chunk := chunk
+		go func() {
+			defer wg.Done()
+			chunkCells, err := f.readRangeChunk(ctx, chunk)
+			workerMu.Lock()
+			defer workerMu.Unlock()
+			if err != nil {
+				// Errors of other workers are caused by cancel, keep the first one.
+				if workerErr == nil {
+					workerErr = err
+				}
+				cancel()
+				return
+			}
+			for pos, d := range chunkCells {
+				cells[pos] = d
+			}
+		}()
+	}
+	wg.Wait()
+
+	if workerErr != nil {
+		return nil, workerErr
+	}
+	return cells, nil
+}
@@ -0,0 +1,109 @@
+package fsclient
+
+import (
+	"context"
+	fsrpc "github.com/fourstring/sheetfs/protocol"
+	"time"
+)
+
+/*
+openSession
+Open a new session with MasterNode and make it the current session of c.
+
+@return
+	time.Duration: duration of the lease of new session
+	error(error): nil if no error
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (c *Client) openSession(ctx context.Context) (time.Duration, error) {
+	_reply, err := c.ensureMasterRPCWithRetry("OpenSession", ctx, &fsrpc.Empty{})
+	if err != nil {
+		return 0, err
+	}
+
+	reply := _reply.(*fsrpc.OpenSessionReply)
+	if reply.Status != fsrpc.Status_OK {
+		return 0, NewUnexpectedStatusError(reply.Status)
+	}
+
+	c.mu.Lock()
+	c.session = reply.Session
+	c.mu.Unlock()
+	return time.Duration(reply.Lease) * time.Millisecond, nil
+}
+
+func (c *Client) currentSession() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.session
+}
+
+/*
+renewSession
+Open a new session if the current session is still the expired one. Sessions may be
+expired when the client can't reach MasterNode for a long time, all fds opened in the
+expired session have been released by MasterNode, so they're lost anyway, but the
+client can continue to open files in the new session.
+
+@para
+	expired: ID of the expired session
+*/
+func (c *Client) renewSession(ctx context.Context, expired uint64) error {
+	if c.currentSession() != expired {
+		// Some other goroutine has opened a new session.
+		return nil
+	}
+	_, err := c.openSession(ctx)
+	return err
+}
+
+/*
+keepAlive
+Renew the lease of current session periodically, until c.stop is closed. A third of
+the lease is used as the period, so a lease can survive one or two lost renewals.
+*/
+func (c *Client) keepAlive(lease time.Duration) {
+	ticker := time.NewTicker(lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), lease/3)
+			session := c.currentSession()
+			_reply, err := c.ensureMasterRPCWithRetry("KeepAlive", ctx, &fsrpc.KeepAliveRequest{Session: session})
+			if err == nil && _reply.(*fsrpc.KeepAliveReply).Status == fsrpc.Status_Expired {
+				_ = c.renewSession(ctx, session)
+			}
+			cancel()
+		}
+	}
+}
+
+/*
+Close
+Stop renewing the lease and close the session of c. All files opened by c are closed
+by MasterNode at the same time. After Close, c should not be used any more.
+@return
+	error(error): nil if no error
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (c *Client) Close(ctx context.Context) error {
+	close(c.stop)
+	req := fsrpc.CloseSessionRequest{Session: c.currentSession()}
+	_reply, err := c.ensureMasterRPCWithRetry("CloseSession", ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	reply := _reply.(*fsrpc.CloseSessionReply)
+	switch reply.Status {
+	case fsrpc.Status_OK, fsrpc.Status_Expired:
+		return nil
+	default:
+		return NewUnexpectedStatusError(reply.Status)
+	}
+}
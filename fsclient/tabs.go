@@ -0,0 +1,127 @@
+package fsclient
+
+import (
+	"context"
+	fsrpc "github.com/fourstring/sheetfs/protocol"
+	"io/fs"
+)
+
+/*
+editTabs
+Call one of RPCs listing or editing tabs of f. Those RPCs only change metadata cells of
+tabs on MasterNode, so no DataNode is contacted.
+@return
+	reply(interface{}): the reply of MasterNode if its status is OK.
+	error(error): see CreateTab.
+*/
+func (f *File) editTabs(ctx context.Context, name string, req interface{}) (interface{}, error) {
+	_r, err := f.client.ensureMasterRPCWithRetry(name, ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := _r.(statusReply)
+	switch reply.GetStatus() {
+	case fsrpc.Status_OK:
+		return _r, nil
+	case fsrpc.Status_NotFound:
+		return nil, fs.ErrClosed
+	case fsrpc.Status_Invalid:
+		return nil, fs.ErrInvalid
+	case fsrpc.Status_Exist:
+		return nil, fs.ErrExist
+	default:
+		return nil, NewUnexpectedStatusError(reply.GetStatus())
+	}
+}
+
+/*
+ListTabs
+List all tabs of the sheet in order.
+@return
+	tabs([]*fsrpc.Tab): all tabs of the sheet, there is at least one tab.
+	error(error): nil if no error
+		fs.ErrClosed: f has been closed before
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (f *File) ListTabs(ctx context.Context) ([]*fsrpc.Tab, error) {
+	req := fsrpc.ListTabsRequest{Fd: f.fd}
+	_r, err := f.editTabs(ctx, "ListTabs", &req)
+	if err != nil {
+		return nil, err
+	}
+	return _r.(*fsrpc.ListTabsReply).Tabs, nil
+}
+
+/*
+CreateTab
+Create a tab after all tabs of the sheet, cells of which can be accessed by WithTab.
+@para
+	name(string): name of the new tab, a default name is given if it's empty
+@return
+	tab(uint32): the new tab
+	error(error): nil if no error
+		fs.ErrClosed: f has been closed before
+		fs.ErrExist: there has been a tab named name
+		fs.ErrInvalid: the sheet has too many tabs
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (f *File) CreateTab(ctx context.Context, name string) (uint32, error) {
+	req := fsrpc.CreateTabRequest{Fd: f.fd, Name: name}
+	_r, err := f.editTabs(ctx, "CreateTab", &req)
+	if err != nil {
+		return 0, err
+	}
+	return _r.(*fsrpc.CreateTabReply).Tab, nil
+}
+
+/*
+RenameTab
+Rename tab to name.
+@return
+	error(error): nil if no error
+		fs.ErrClosed: f has been closed before
+		fs.ErrExist: there has been another tab named name
+		fs.ErrInvalid: there is no such tab, or name is empty
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (f *File) RenameTab(ctx context.Context, tab uint32, name string) error {
+	req := fsrpc.RenameTabRequest{Fd: f.fd, Tab: tab, Name: name}
+	_, err := f.editTabs(ctx, "RenameTab", &req)
+	return err
+}
+
+/*
+MoveTab
+Move tab to position, tabs between its old and new positions are shifted by one.
+@return
+	error(error): nil if no error
+		fs.ErrClosed: f has been closed before
+		fs.ErrInvalid: there is no such tab, or position is not less than the number of tabs
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (f *File) MoveTab(ctx context.Context, tab uint32, position uint32) error {
+	req := fsrpc.MoveTabRequest{Fd: f.fd, Tab: tab, Position: position}
+	_, err := f.editTabs(ctx, "MoveTab", &req)
+	return err
+}
+
+/*
+DeleteTab
+Delete tab and all of its cells, without rewriting their data.
+@return
+	error(error): nil if no error
+		fs.ErrClosed: f has been closed before
+		fs.ErrInvalid: there is no such tab, or it's the last tab of the sheet
+		*UnexpectedStatusError: MasterNode returns a unexpected status
+		some other errors returned by rpc
+*/
+func (f *File) DeleteTab(ctx context.Context, tab uint32) error {
+	req := fsrpc.DeleteTabRequest{Fd: f.fd, Tab: tab}
+	_, err := f.editTabs(ctx, "DeleteTab", &req)
+	return err
+}
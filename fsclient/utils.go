@@ -1,15 +1,31 @@
 package fsclient
 
-func connect(data []byte, metadata []byte) []byte {
-	totalData := []byte("{\"celldata\": [") //header
-	if len(data) > 0 {                      // delete the final ","
-		for i := len(data) - 1; i >= 0; i-- {
-			if data[i] == ',' {
-				data[i] = ' '
-				break
-			}
+import (
+	"bytes"
+	"strings"
+)
+
+/*
+isValidPath
+Returns true if p is a valid path of a file or directory, made of non-empty names
+separated by '/'. Names can't be '.' or '..', and '\' is not allowed.
+*/
+func isValidPath(p string) bool {
+	if p == "" || strings.Contains(p, "\\") {
+		return false
+	}
+	for _, name := range strings.Split(p, "/") {
+		if name == "" || name == "." || name == ".." {
+			return false
 		}
 	}
+	return true
+}
+
+func connect(data []byte, metadata []byte) []byte {
+	totalData := []byte("{\"celldata\": [") //header
+	// every cell ends with a ",", delete the final one
+	data = bytes.TrimSuffix(data, []byte(","))
 	totalData = append(totalData, data...) //data
 	if len(metadata) > 0 {
 		totalData = append(totalData, "],"...)     // add "]," to become "{celldata:[...], metadata: ,...}"
@@ -27,3 +43,14 @@ func DynamicCopy(dst *[]byte, src []byte) {
 	}
 	*dst = append(*dst, src[len(*dst):]...)
 }
+
+/*
+truncate
+Returns the first n bytes of b, or b itself if it's shorter.
+*/
+func truncate(b []byte, n uint64) []byte {
+	if uint64(len(b)) > n {
+		return b[:n]
+	}
+	return b
+}
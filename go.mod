@@ -7,6 +7,7 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.7 // indirect
 	github.com/segmentio/kafka-go v0.4.16
 	github.com/smartystreets/goconvey v1.6.4
+	go.etcd.io/bbolt v1.3.6
 	go.uber.org/atomic v1.8.0 // indirect
 	go.uber.org/multierr v1.7.0 // indirect
 	go.uber.org/zap v1.18.1
@@ -1,14 +1,24 @@
 package config
 
 import (
+	dnconfig "github.com/fourstring/sheetfs/config"
 	"math"
 	"time"
 )
 
 const (
-	MaxCellsPerChunk   = 4
-	BytesPerChunk      = uint64(8192)
-	MaxBytesPerCell    = BytesPerChunk / MaxCellsPerChunk
+	// Chunks and their version slots are laid out by DataNodes, so sizes of them are
+	// derived from the config of DataNodes rather than defined again here.
+	BytesPerChunk = uint64(dnconfig.FILE_SIZE)
+	// Cells of unknown size take DefaultBytesPerCell, so DefaultCellsPerChunk of them are
+	// stored in a Chunk.
+	DefaultCellsPerChunk = 4
+	DefaultBytesPerCell  = BytesPerChunk / DefaultCellsPerChunk
+	// The smallest and the biggest size classes of Cells, see CellSizeClasses.
+	MinBytesPerCell    = uint64(dnconfig.SLOT_SIZE)
+	MaxBytesPerCell    = BytesPerChunk / 2
+	SlotsPerChunk      = BytesPerChunk / MinBytesPerCell
+	MaxOverflowChunks  = 64
 	DBName             = "master.db"
 	SheetMetaCellRow   = uint32(math.MaxUint32)
 	SheetMetaCellCol   = uint32(math.MaxUint32)
@@ -16,10 +26,36 @@ const (
 	ElectionAck        = "/master_election_ack"
 	ElectionPrefix     = "a20ffeb5-319a-4e0b-b54d-646fb93d3158-n_"
 	ElectionTimeout    = 1 * time.Second
+	DataNodeAckPrefix  = "/datanode_election_ack_"
 	CheckpointInterval = 1 * time.Minute
+	MonitorInterval    = 1 * time.Minute
+	CompactInterval    = 10 * time.Minute
+	RecycleRetention   = 7 * 24 * time.Hour
+	SessionLease       = 30 * time.Second
+	SessionCheckPeriod = 5 * time.Second
+	ListPageSize       = 100
+	MaxListPageSize    = 1000
+	// Max number of files compacted every CompactInterval.
+	SheetsPerCompaction = 64
+	// Default backend of the metadata store of MasterNode, see metastore.Open.
+	MetadataBackend = "sqlite"
+	// Max number of Chunks loaded by a single query, which is kept under the limit of
+	// variables in a sqlite statement.
+	ChunksPerLoad = 500
+	// Tabs of a workbook are numbered in [0, MaxTabs), and rows of every tab are numbered
+	// in [0, MaxRows), see sheetfile.GetCellID.
+	MaxTabs = 1 << 8
+	MaxRows = 1<<24 - 1
 )
 
 var SheetMetaCellID = int64(0)
+
+// Sizes of slots which Cells are allocated from, in ascending order from MinBytesPerCell
+// to MaxBytesPerCell. A Cell takes the smallest slot its data fits in, and
+// DefaultBytesPerCell if the size of data is unknown. Data beyond MaxBytesPerCell is
+// stored in overflow Chunks. Every MinBytesPerCell bytes of a Chunk are versioned
+// separately, see sheetfile.Chunk.
+var CellSizeClasses = []uint64{MinBytesPerCell, 1024, DefaultBytesPerCell, MaxBytesPerCell}
 var ElectionServers = []string{
 	"127.0.0.1:2181",
 	"127.0.0.1:2182",
@@ -0,0 +1,205 @@
+package datanode_conn
+
+import (
+	"context"
+	fs_rpc "github.com/fourstring/sheetfs/protocol"
+	"github.com/go-zookeeper/zk"
+	"google.golang.org/grpc"
+	"sync"
+	"time"
+)
+
+/*
+DataNodeConnector
+Maintains gRPC connections from MasterNode to primaries of DataNode groups.
+
+MasterNode only knows names of DataNode groups(see datanode_alloc), but the address
+of the primary DataNode of a group is elected through Zookeeper, and written to Znode
+'{ackPrefix}{group}' by the winner. So DataNodeConnector resolves addresses in the
+same way as fsclient does, and caches connected clients. When a RPC to some group
+fails, the cached client is dropped, and the address will be resolved again next time,
+by which a failover of DataNode group can be handled.
+
+It's a goroutine-safe data structure.
+*/
+type DataNodeConnector struct {
+	mu        sync.Mutex
+	zk        *zk.Conn
+	ackPrefix string
+	clients   map[string]fs_rpc.DataNodeClient
+}
+
+/*
+NewDataNodeConnector
+
+@para
+	servers: a list of Zookeeper servers
+	timeout: Zookeeper session timeout
+	ackPrefix: prefix of Znodes where primaries of DataNode groups write their addresses
+
+@return
+	*DataNodeConnector
+	error: not nil if failed to connect to Zookeeper
+*/
+func NewDataNodeConnector(servers []string, timeout time.Duration, ackPrefix string) (*DataNodeConnector, error) {
+	conn, _, err := zk.Connect(servers, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &DataNodeConnector{
+		zk:        conn,
+		ackPrefix: ackPrefix,
+		clients:   map[string]fs_rpc.DataNodeClient{},
+	}, nil
+}
+
+/*
+getClient
+Returns a cached client of given DataNode group, or resolves the address of its
+primary and connects to it.
+*/
+func (c *DataNodeConnector) getClient(group string) (fs_rpc.DataNodeClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if client, ok := c.clients[group]; ok {
+		return client, nil
+	}
+	addr, _, err := c.zk.Get(c.ackPrefix + group)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(string(addr), grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	client := fs_rpc.NewDataNodeClient(conn)
+	c.clients[group] = client
+	return client, nil
+}
+
+/*
+invalidate
+Drop the cached client of given group, so its address will be resolved again.
+*/
+func (c *DataNodeConnector) invalidate(group string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.clients, group)
+}
+
+/*
+DeleteChunk
+Ask the primary of DataNode group to delete a Chunk permanently.
+
+@para
+	ctx: context.Context used to cancel operation
+	group: name of DataNode group storing the Chunk
+	id: Chunk.ID
+
+@return
+	error:
+		errors while resolving or connecting to the DataNode group
+		*UnexpectedStatusError if the DataNode replies a status other than OK
+*/
+func (c *DataNodeConnector) DeleteChunk(ctx context.Context, group string, id uint64) error {
+	client, err := c.getClient(group)
+	if err != nil {
+		return err
+	}
+	reply, err := client.DeleteChunk(ctx, &fs_rpc.DeleteChunkRequest{Id: id})
+	if err != nil {
+		c.invalidate(group)
+		return err
+	}
+	if reply.Status != fs_rpc.Status_OK {
+		return NewUnexpectedStatusError(group, reply.Status)
+	}
+	return nil
+}
+
+/*
+CopyChunk
+Ask the primary of DataNode group to copy a Chunk to a new Chunk, including its
+Version. If the Chunk has never been written, there is no data to copy, and it's not
+considered as an error.
+
+@para
+	ctx: context.Context used to cancel operation
+	group: name of DataNode group storing the Chunk
+	id: Chunk.ID of the Chunk to be copied
+	newID: Chunk.ID of the copy
+
+@return
+	error:
+		errors while resolving or connecting to the DataNode group
+		*UnexpectedStatusError if the DataNode replies a status other than OK or NotFound
+*/
+func (c *DataNodeConnector) CopyChunk(ctx context.Context, group string, id uint64, newID uint64) error {
+	client, err := c.getClient(group)
+	if err != nil {
+		return err
+	}
+	reply, err := client.CopyChunk(ctx, &fs_rpc.CopyChunkRequest{Id: id, NewId: newID})
+	if err != nil {
+		c.invalidate(group)
+		return err
+	}
+	if reply.Status != fs_rpc.Status_OK && reply.Status != fs_rpc.Status_NotFound {
+		return NewUnexpectedStatusError(group, reply.Status)
+	}
+	return nil
+}
+
+/*
+SlotCopy
+Describes copying Size bytes at Offset of Chunk ID, whose slot is at Version, to NewOffset
+of Chunk NewID, whose slot will be at NewVersion after copying.
+*/
+type SlotCopy struct {
+	ID         uint64
+	Offset     uint64
+	Size       uint64
+	Version    uint64
+	NewID      uint64
+	NewOffset  uint64
+	NewVersion uint64
+}
+
+/*
+CopySlot
+Ask the primary of DataNode group to copy a slot to another slot, see SlotCopy. Both
+slots must be stored in the DataNode group.
+
+@para
+	ctx: context.Context used to cancel operation
+	group: name of DataNode group storing both slots
+	sc: the slots to copy from and to
+
+@return
+	error:
+		errors while resolving or connecting to the DataNode group
+		*UnexpectedStatusError if the DataNode replies a status other than OK
+*/
+func (c *DataNodeConnector) CopySlot(ctx context.Context, group string, sc *SlotCopy) error {
+	client, err := c.getClient(group)
+	if err != nil {
+		return err
+	}
+	reply, err := client.CopySlot(ctx, &fs_rpc.CopySlotRequest{
+		Id:         sc.ID,
+		Offset:     sc.Offset,
+		Size:       sc.Size,
+		Version:    sc.Version,
+		NewId:      sc.NewID,
+		NewOffset:  sc.NewOffset,
+		NewVersion: sc.NewVersion,
+	})
+	if err != nil {
+		c.invalidate(group)
+		return err
+	}
+	if reply.Status != fs_rpc.Status_OK {
+		return NewUnexpectedStatusError(group, reply.Status)
+	}
+	return nil
+}
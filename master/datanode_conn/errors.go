@@ -0,0 +1,19 @@
+package datanode_conn
+
+import (
+	"fmt"
+	fs_rpc "github.com/fourstring/sheetfs/protocol"
+)
+
+type UnexpectedStatusError struct {
+	group  string
+	status fs_rpc.Status
+}
+
+func NewUnexpectedStatusError(group string, status fs_rpc.Status) *UnexpectedStatusError {
+	return &UnexpectedStatusError{group: group, status: status}
+}
+
+func (u *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("DataNode group %s returned unexpected status: %s", u.group, u.status)
+}
@@ -0,0 +1,192 @@
+package filemgr
+
+import (
+	"context"
+	"github.com/fourstring/sheetfs/master/config"
+	"github.com/fourstring/sheetfs/master/datanode_conn"
+	"github.com/fourstring/sheetfs/master/journal/journal_entry"
+	"github.com/fourstring/sheetfs/master/sheetfile"
+	"go.uber.org/zap"
+	"sort"
+	"time"
+)
+
+/*
+copyDataSlot
+Copy a slot of Chunk src to a slot of dst on the DataNode storing them, see
+sheetfile.SlotCopier. Do nothing if there is no DataNodeConnector.
+*/
+func (f *FileManager) copyDataSlot(src *sheetfile.Chunk, srcOffset uint64, dst *sheetfile.Chunk, dstOffset uint64, size uint64) error {
+	if f.conn == nil {
+		return nil
+	}
+	return f.conn.CopySlot(context.TODO(), src.DataNode, &datanode_conn.SlotCopy{
+		ID:         src.ID,
+		Offset:     srcOffset,
+		Size:       size,
+		Version:    src.SlotVersion(srcOffset),
+		NewID:      dst.ID,
+		NewOffset:  dstOffset,
+		NewVersion: dst.SlotVersion(dstOffset),
+	})
+}
+
+/*
+Compact
+Continuously packing Cells of files into fewer Chunks, see SheetFile.Compact. Every
+interval, a batch of files is compacted in turn, see compactSheets.
+
+Moves of Cells are journaled, so secondaries only need to replay them. This method
+should only be run by the primary node, and it blocks until ctx is cancelled, which is
+supposed to happen when the node loses its leadership.
+
+@para
+	ctx: context.Context used to stop compacting
+	interval: time between two compactions
+*/
+func (f *FileManager) Compact(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.compactSheets(config.SheetsPerCompaction)
+		}
+	}
+}
+
+/*
+compactSheets
+Compact at most n files in ascending order of SheetIDs, starting after the file compacted
+last time and wrapping around after the last one, so that all files are compacted in
+turn whether they're opened or not. A file not opened is loaded for compacting and
+evicted afterwards. If it's left dirty by moves of Cells, it's evicted once persisted by
+next checkpoint instead, see EvictClosedSheets.
+
+@return
+	int: number of moved Cells
+*/
+func (f *FileManager) compactSheets(n int) int {
+	f.mu.Lock()
+	ids := make([]uint64, 0, len(f.names))
+	for sheetID := range f.names {
+		ids = append(ids, sheetID)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	start := sort.Search(len(ids), func(i int) bool {
+		return ids[i] > f.compactCursor
+	})
+	batch := append(ids[start:len(ids):len(ids)], ids[:start]...)
+	if len(batch) > n {
+		batch = batch[:n]
+	}
+	if len(batch) > 0 {
+		f.compactCursor = batch[len(batch)-1]
+	}
+	f.mu.Unlock()
+
+	moved := 0
+	for _, sheetID := range batch {
+		f.mu.Lock()
+		if _, ok := f.names[sheetID]; !ok {
+			// deleted meanwhile
+			f.mu.Unlock()
+			continue
+		}
+		file, err := f.loadSheet(sheetID)
+		f.mu.Unlock()
+		if err == nil {
+			var m int
+			m, err = f.compactSheet(file)
+			moved += m
+		}
+		if err != nil && f.logger != nil {
+			f.logger.Error("error when compacting file.", zap.Uint64("sheet", sheetID), zap.Error(err))
+		}
+		f.mu.Lock()
+		if !f.isReferenced(sheetID) {
+			f.evictSheet(sheetID)
+		}
+		f.mu.Unlock()
+	}
+	return moved
+}
+
+/*
+compactSheet
+Compact file, and journal all moves of Cells as a single entry, in which a moved Cell is
+journaled as a deletion followed by a creation like WriteFileCell, so the switch of Cells
+to new slots is atomic. Data of files is not modified, so ModifiedAt is kept. Emptied
+Chunks are deleted from DataNodes after journaling.
+
+Slots are copied on DataNodes without holding any lock, so file can be written and
+checkpoints can be made meanwhile. Moves of Cells written during copying are abandoned,
+see SheetFile.CommitCompaction.
+
+@return
+	int: number of moved Cells, moves copied before an error are journaled too.
+	error: errors raised while compacting or journaling.
+*/
+func (f *FileManager) compactSheet(file *sheetfile.SheetFile) (int, error) {
+	plan := file.PlanCompaction()
+	err := plan.Copy(f.copyDataSlot)
+	return f.commitCompaction(file, plan, err)
+}
+
+/*
+commitCompaction
+Commit plan copied with err, journal moves of Cells and delete emptied Chunks, see
+compactSheet.
+*/
+func (f *FileManager) commitCompaction(file *sheetfile.SheetFile, plan *sheetfile.Compaction, err error) (int, error) {
+	f.ckptMu.RLock()
+	// Moves should be replayed in the same order as InsertLines and DeleteLines.
+	file.JournalMu.Lock()
+	moves, dropped := file.CommitCompaction(plan)
+	if len(moves) > 0 {
+		jErr := f.writeJournal(compactionEntry(moves))
+		if err == nil {
+			err = jErr
+		}
+	}
+	file.JournalMu.Unlock()
+	f.ckptMu.RUnlock()
+	// Contacting with DataNodes may be slow, so it's done without holding file.JournalMu.
+	f.deleteDataChunks(dropped)
+	moved := 0
+	for _, m := range moves {
+		if m.Cell != nil {
+			moved++
+		}
+	}
+	return moved, err
+}
+
+/*
+compactionEntry
+Build the journal entry of moves of Cells, see compactSheet.
+*/
+func compactionEntry(moves []*sheetfile.SlotMove) *journal_entry.MasterEntry {
+	entries := make([]*journal_entry.MasterEntry, 0, 2*len(moves))
+	for _, m := range moves {
+		// Moves don't touch ModifiedAt of the file, see entryTimestamp.
+		if m.Cell != nil {
+			entries = append(entries, cellRemovalEntry(m.Cell, m.From, time.Time{}))
+		}
+		entries = append(entries, cellWriteEntry(m.Moved, m.To, nil, time.Time{}))
+	}
+	return &journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromEmptyMgrEntry(),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
+		Batch:    entries,
+	}
+}
@@ -0,0 +1,325 @@
+package filemgr
+
+import (
+	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
+	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
+	"github.com/fourstring/sheetfs/master/journal/journal_entry"
+	"github.com/fourstring/sheetfs/master/sheetfile"
+	fs_rpc "github.com/fourstring/sheetfs/protocol"
+	"sort"
+	"strings"
+)
+
+/*
+isValidPath
+Returns true if p is a valid path of a file or a non-root directory. A valid path is
+made of non-empty names separated by '/', names can't be '.' or '..', and '\' is not
+allowed.
+*/
+func isValidPath(p string) bool {
+	if p == "" || strings.Contains(p, "\\") {
+		return false
+	}
+	for _, name := range strings.Split(p, "/") {
+		if name == "" || name == "." || name == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+parentDir
+Returns path of the directory containing p, which is empty for the root directory.
+*/
+func parentDir(p string) string {
+	i := strings.LastIndex(p, "/")
+	if i < 0 {
+		return ""
+	}
+	return p[:i]
+}
+
+/*
+isUnder
+Returns true if p is in directory dir or any of its subdirectories.
+*/
+func isUnder(p string, dir string) bool {
+	return dir == "" || strings.HasPrefix(p, dir+"/")
+}
+
+/*
+checkDir
+Check that dir is an existing directory. Caller should hold f.mu.
+
+@return
+	error:
+		*errors.InvalidPathError if dir is not a valid path.
+		*errors.DirNotFoundError if there is no such directory.
+*/
+func (f *FileManager) checkDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	if _, ok := f.Dirs[dir]; ok {
+		return nil
+	}
+	if !isValidPath(dir) {
+		return file_errors.NewInvalidPathError(dir)
+	}
+	return file_errors.NewDirNotFoundError(dir)
+}
+
+/*
+checkNewPath
+Check that a new file or directory can be added at p. Caller should hold f.mu.
+
+@return
+	error:
+		*errors.InvalidPathError if p is not a valid path.
+		*errors.FileExistsError if there has been a file or directory at p.
+		*errors.DirNotFoundError if the directory containing p doesn't exist.
+*/
+func (f *FileManager) checkNewPath(p string) error {
+	if !isValidPath(p) {
+		return file_errors.NewInvalidPathError(p)
+	}
+	if _, ok := f.Entries[p]; ok {
+		return file_errors.NewFileExistsError(p)
+	}
+	if _, ok := f.Dirs[p]; ok {
+		return file_errors.NewFileExistsError(p)
+	}
+	return f.checkDir(parentDir(p))
+}
+
+/*
+isEmptyDir
+Returns true if there is no file or directory in dir. Caller should hold f.mu.
+*/
+func (f *FileManager) isEmptyDir(dir string) bool {
+	for filename := range f.Entries {
+		if isUnder(filename, dir) {
+			return false
+		}
+	}
+	for p := range f.Dirs {
+		if isUnder(p, dir) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+MkDir
+Create a directory. The directory containing it must exist, directories are not
+created recursively.
+
+@para
+	p: path of the new directory
+
+@return
+	error:
+		*errors.InvalidPathError if p is not a valid path.
+		*errors.FileExistsError if there has been a file or directory at p.
+		*errors.DirNotFoundError if the directory containing p doesn't exist.
+		errors raised while journaling.
+*/
+func (f *FileManager) MkDir(p string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	err := f.checkNewPath(p)
+	if err != nil {
+		return err
+	}
+	err = f.writeJournal(&journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromEmptyMgrEntry(),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromDir(p),
+		XLines:   journal_entry.FromEmptyLines(),
+	})
+	if err != nil {
+		return err
+	}
+	f.addDir(p)
+	return nil
+}
+
+/*
+RmDir
+Remove a directory. If recursive is true, all files and subdirectories in it are
+deleted permanently as DeleteSheet does, otherwise the directory must be empty.
+The removal is journaled as a single entry, so it's applied atomically when replaying.
+
+@para
+	p: path of the directory, the root directory can't be removed
+	recursive: whether to remove files and subdirectories in the directory
+
+@return
+	error:
+		*errors.InvalidPathError if p is not a valid path.
+		*errors.DirNotFoundError if there is no such directory.
+		*errors.DirNotEmptyError if recursive is false but the directory is not empty.
+		errors raised while journaling or deleting metadata of files.
+*/
+func (f *FileManager) RmDir(p string, recursive bool) error {
+	f.mu.Lock()
+	if p == "" {
+		f.mu.Unlock()
+		return file_errors.NewInvalidPathError(p)
+	}
+	err := f.checkDir(p)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	if !recursive && !f.isEmptyDir(p) {
+		f.mu.Unlock()
+		return file_errors.NewDirNotEmptyError(p)
+	}
+	// Chunks of files are required to delete them, so they're loaded before journaling.
+	err = f.loadDirSheets(p)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	err = f.writeJournal(&journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromEmptyMgrEntry(),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromAbsentDir(p),
+		XLines:   journal_entry.FromEmptyLines(),
+	})
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	chunks, err := f.removeDir(p)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	// Contacting with DataNodes may be slow, so it's done without holding f.mu.
+	f.deleteDataChunks(chunks)
+	return nil
+}
+
+/*
+removeDir
+Remove a directory, with all files and subdirectories in it, from memory. They are
+removed from the MetadataStore by next checkpoint, see persistent. This method is shared
+by RmDir and journal replaying, so it won't write journal or contact with DataNodes.
+Caller should hold f.mu.
+
+@return
+	[]*sheetfile.Chunk: Chunks of removed files to be deleted from DataNodes.
+	error: errors raised while loading files, nothing is removed in such a case.
+*/
+func (f *FileManager) removeDir(dir string) ([]*sheetfile.Chunk, error) {
+	err := f.loadDirSheets(dir)
+	if err != nil {
+		return nil, err
+	}
+	var chunks []*sheetfile.Chunk
+	for filename, entry := range f.Entries {
+		if !isUnder(filename, dir) {
+			continue
+		}
+		removed, err := f.removeSheet(entry.SheetID)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, removed...)
+	}
+	for p := range f.Dirs {
+		if p != dir && !isUnder(p, dir) {
+			continue
+		}
+		delete(f.Dirs, p)
+		f.dirtyDirs[p] = true
+	}
+	return chunks, nil
+}
+
+/*
+addDir
+Add the directory at path p. Caller should hold f.mu.
+*/
+func (f *FileManager) addDir(p string) {
+	f.Dirs[p] = &mgr_entry.DirEntry{Path: p}
+	f.dirtyDirs[p] = true
+}
+
+/*
+loadDirSheets
+Load all files in a directory and all of its subdirectories. Caller should hold f.mu.
+*/
+func (f *FileManager) loadDirSheets(dir string) error {
+	for filename, entry := range f.Entries {
+		if !isUnder(filename, dir) {
+			continue
+		}
+		_, err := f.loadSheet(entry.SheetID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ListDir
+List subdirectories and files directly in a directory, sorted by their paths.
+
+@para
+	dir: path of the directory, empty for the root directory
+
+@return
+	[]string: full paths of subdirectories
+	[]*fs_rpc.Sheet: files in the directory, see GetAllSheets
+	error:
+		*errors.InvalidPathError if dir is not a valid path.
+		*errors.DirNotFoundError if there is no such directory.
+*/
+func (f *FileManager) ListDir(dir string) ([]string, []*fs_rpc.Sheet, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	err := f.checkDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	dirs := []string{}
+	for p := range f.Dirs {
+		if parentDir(p) == dir {
+			dirs = append(dirs, p)
+		}
+	}
+	sort.Strings(dirs)
+	sheets := []*fs_rpc.Sheet{}
+	for filename, entry := range f.Entries {
+		if parentDir(filename) == dir {
+			sheets = append(sheets, toPbSheet(entry))
+		}
+	}
+	sort.Slice(sheets, func(i, j int) bool {
+		return sheets[i].Filename < sheets[j].Filename
+	})
+	return dirs, sheets, nil
+}
+
+func (f *FileManager) handleDirEntry(dirEntry *journal_entry.DirEntry) error {
+	switch dirEntry.TargetState {
+	case journal_entry.State_PRESENT:
+		f.addDir(dirEntry.Path)
+	case journal_entry.State_ABSENT:
+		_, err := f.removeDir(dirEntry.Path)
+		return err
+	}
+	return nil
+}
@@ -49,3 +49,164 @@ func (c *CellNotFoundError) Error() string {
 func NewCellNotFoundError(row uint32, col uint32) *CellNotFoundError {
 	return &CellNotFoundError{row: row, col: col}
 }
+
+type SessionNotFoundError struct {
+	session uint64
+}
+
+func NewSessionNotFoundError(session uint64) *SessionNotFoundError {
+	return &SessionNotFoundError{session: session}
+}
+
+func (s *SessionNotFoundError) Error() string {
+	return fmt.Sprintf("Session %d not found or expired!", s.session)
+}
+
+type DirNotFoundError struct {
+	path string
+}
+
+func NewDirNotFoundError(path string) *DirNotFoundError {
+	return &DirNotFoundError{path: path}
+}
+
+func (d *DirNotFoundError) Error() string {
+	return fmt.Sprintf("Directory %s not found!", d.path)
+}
+
+type DirNotEmptyError struct {
+	path string
+}
+
+func NewDirNotEmptyError(path string) *DirNotEmptyError {
+	return &DirNotEmptyError{path: path}
+}
+
+func (d *DirNotEmptyError) Error() string {
+	return fmt.Sprintf("Directory %s is not empty!", d.path)
+}
+
+type InvalidPathError struct {
+	path string
+}
+
+func NewInvalidPathError(path string) *InvalidPathError {
+	return &InvalidPathError{path: path}
+}
+
+func (i *InvalidPathError) Error() string {
+	return fmt.Sprintf("Path %s is invalid!", i.path)
+}
+
+type InvalidPageTokenError struct {
+	token string
+}
+
+func NewInvalidPageTokenError(token string) *InvalidPageTokenError {
+	return &InvalidPageTokenError{token: token}
+}
+
+func (i *InvalidPageTokenError) Error() string {
+	return fmt.Sprintf("Page token %s is invalid!", i.token)
+}
+
+type CellTooLargeError struct {
+	row  uint32
+	col  uint32
+	size uint64
+}
+
+func NewCellTooLargeError(row uint32, col uint32, size uint64) *CellTooLargeError {
+	return &CellTooLargeError{row: row, col: col, size: size}
+}
+
+func (c *CellTooLargeError) Error() string {
+	return fmt.Sprintf("Cell %d,%d can't store %d bytes!", c.row, c.col, c.size)
+}
+
+type InvalidLinesError struct {
+	at    uint32
+	count uint32
+}
+
+func NewInvalidLinesError(at uint32, count uint32) *InvalidLinesError {
+	return &InvalidLinesError{at: at, count: count}
+}
+
+func (i *InvalidLinesError) Error() string {
+	return fmt.Sprintf("Can't insert or delete %d lines at %d!", i.count, i.at)
+}
+
+type DuplicateCellError struct {
+	row uint32
+	col uint32
+}
+
+func NewDuplicateCellError(row uint32, col uint32) *DuplicateCellError {
+	return &DuplicateCellError{row: row, col: col}
+}
+
+func (d *DuplicateCellError) Error() string {
+	return fmt.Sprintf("Cell %d,%d is written more than once!", d.row, d.col)
+}
+
+type InvalidCellError struct {
+	row uint32
+	col uint32
+}
+
+func NewInvalidCellError(row uint32, col uint32) *InvalidCellError {
+	return &InvalidCellError{row: row, col: col}
+}
+
+func (i *InvalidCellError) Error() string {
+	return fmt.Sprintf("Cell %d,%d is out of the sheet!", i.row, i.col)
+}
+
+type TabNotFoundError struct {
+	tab uint32
+}
+
+func NewTabNotFoundError(tab uint32) *TabNotFoundError {
+	return &TabNotFoundError{tab: tab}
+}
+
+func (t *TabNotFoundError) Error() string {
+	return fmt.Sprintf("Tab %d not found!", t.tab)
+}
+
+type TabExistsError struct {
+	name string
+}
+
+func NewTabExistsError(name string) *TabExistsError {
+	return &TabExistsError{name: name}
+}
+
+func (t *TabExistsError) Error() string {
+	return fmt.Sprintf("Tab %s exists!", t.name)
+}
+
+type InvalidTabError struct {
+	tab uint32
+}
+
+func NewInvalidTabError(tab uint32) *InvalidTabError {
+	return &InvalidTabError{tab: tab}
+}
+
+func (i *InvalidTabError) Error() string {
+	return fmt.Sprintf("Invalid operation on tab %d!", i.tab)
+}
+
+type ChunkNotFoundError struct {
+	id uint64
+}
+
+func NewChunkNotFoundError(id uint64) *ChunkNotFoundError {
+	return &ChunkNotFoundError{id: id}
+}
+
+func (c *ChunkNotFoundError) Error() string {
+	return fmt.Sprintf("Chunk %d not found!", c.id)
+}
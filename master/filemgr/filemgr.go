@@ -3,16 +3,18 @@ package filemgr
 import (
 	"context"
 	"github.com/fourstring/sheetfs/common_journal"
+	"github.com/fourstring/sheetfs/master/config"
 	"github.com/fourstring/sheetfs/master/datanode_alloc"
+	"github.com/fourstring/sheetfs/master/datanode_conn"
 	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
 	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
-	"github.com/fourstring/sheetfs/master/journal/checkpoint"
 	"github.com/fourstring/sheetfs/master/journal/journal_entry"
+	"github.com/fourstring/sheetfs/master/metastore"
 	"github.com/fourstring/sheetfs/master/sheetfile"
 	fs_rpc "github.com/fourstring/sheetfs/protocol"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
-	"gorm.io/gorm"
+	"sort"
 	"sync"
 	"time"
 )
@@ -20,15 +22,26 @@ import (
 /*
 FileManager
 Represents a top-level directory of SheetFiles stored in the filesystem.
-There is only one level of directory, mapping filename to SheetFile directly, or
-raise errors for invalid filename.
+Directories are hierarchical. A filename is the full path of a file, made of names of
+its parent directories and itself separated by '/'(see mgr_entry.DirEntry). Entries maps
+full paths to SheetFiles directly, and Dirs keeps all directories, so looking up a file
+don't have to walk through directories. Every SheetFile is identified by an immutable
+SheetID(see mgr_entry.MapEntry), so the filename is only used to lookup the directory,
+and a file can be renamed, or moved to another directory, without affecting opened fds.
 
 FileManager exposes both filename-oriented and fd-oriented API at the same time.
 The relationship between them is Unix-alike. In other words, applications need to
 Open a file with its filename, then a fd will be returned. Subsequently operations
 must be invoked by a fd. The management of fds is also Unix-alike: multiple fds can
-be pointed to the same underlying SheetFile. The only difference is that there is
-only a global fd namespace, not one for per client.
+be pointed to the same underlying SheetFile. Unlike Unix, fds are not numbered per
+client. They are allocated from a global counter and never reused, so a fd identifies
+the same open file for every client.
+
+To release fds held by crashed or disconnected clients, a client opens a session first,
+and fds opened in a session are owned by it, see Owners. The client keeps its session alive by
+renewing the lease periodically. Once a lease expires, the session is closed and all
+fds owned by it are released, as if the client has closed them. Fds opened with
+NoSession are not leased, they are valid until closed explicitly.
 
 FileManager is responsible for implementing almost all APIs provided to outer applications.
 It's a goroutine-safe data structure. To maximize concurrency, it exploits a two-level
@@ -39,26 +52,82 @@ access to specific SheetFiles.
 As a directory, FileManager should be persisted during checkpointing. Besides, it manages
 all SheetFiles at the same time, so it should not only persist itself, but also persist those
 files' metadata as a helper. It also plays as an in-memory cache for filesystem metadata of those
-files, loading them into memory on-demand. So a gorm connection is required.
+files, loading them into memory on-demand. So a MetadataStore is required.
+
+When a file is deleted permanently, data of its Chunks on DataNodes should be deleted too.
+FileManager contacts with DataNodes through a DataNodeConnector for this purpose. If the
+connector is nil(e.g. in a secondary node or for testing), only metadata will be deleted.
+The connector is also used to copy data of Chunks shared copy-on-write by copies of files,
+see CopySheet.
 */
 type FileManager struct {
 	mu sync.RWMutex
+	// Barrier between checkpointing and mutations of Cells. Such mutations are applied and
+	// journaled without holding mu, so they hold ckptMu shared until they are journaled and
+	// ModifiedAt is touched, while DoCheckpoint holds it exclusively. Acquired before mu.
+	ckptMu sync.RWMutex
 	// All directory entries in the directory. All of them should be loaded into memory once.
 	Entries map[string]*mgr_entry.MapEntry
-	// Maps filename to a already opened SheetFile. This map is fulfilled on-demand. If a SheetFile
+	// Maps SheetID to filename of every directory entry, an index of Entries by SheetID.
+	names map[uint64]string
+	// All directories except for the root directory, maps path to DirEntry.
+	Dirs map[string]*mgr_entry.DirEntry
+	// Maps SheetID to a already opened SheetFile. This map is fulfilled on-demand. If a SheetFile
 	// is not being opened currently, it's not presented in the map.
-	Opened map[string]*sheetfile.SheetFile
-	// Maps a fd to a opened filename. Multiple fds are allowed to be pointed to the same file. So
-	// their entries in this map will contain same filename.
-	Fds map[uint64]string
+	Opened map[uint64]*sheetfile.SheetFile
+	// Maps a fd to SheetID of a opened file. Multiple fds are allowed to be pointed to the same file.
+	// So their entries in this map will contain same SheetID.
+	Fds map[uint64]uint64
 	// Next available fd to be allocated to respond a Open or Create file operation.
-	nextFd        uint64
-	db            *gorm.DB
+	// Both Fds and nextFd are maintained through journal entries, so a secondary node
+	// taking over the primary keeps the same fd table.
+	nextFd uint64
+	// Maps an ID of session to the deadline of its lease. Only the existence of sessions is
+	// journaled, deadlines are renewed when a node becomes primary, see ResetLeases.
+	Sessions map[uint64]time.Time
+	// Maps a fd to the session owning it. Fds opened with NoSession are not presented.
+	Owners map[uint64]uint64
+	// Next available session ID, maintained in the same way as nextFd.
+	nextSession uint64
+	// Next available SheetID, maintained in the same way as nextFd.
+	nextSheetID uint64
+	// SheetIDs of MapEntries mutated since last checkpoint, see Persistent.
+	dirtyEntries map[uint64]bool
+	// Fds, session IDs and paths of directories added or removed since last checkpoint,
+	// see persistentFds.
+	dirtyFds      map[uint64]bool
+	dirtySessions map[uint64]bool
+	dirtyDirs     map[string]bool
+	// Maps SheetIDs of files deleted since last checkpoint to their Chunks to be dropped from
+	// the MetadataStore by next checkpoint, see removeSheet.
+	deletedSheets map[uint64][]*sheetfile.Chunk
+	// SheetID of the last file compacted, files are compacted in turn, see compactSheets.
+	compactCursor uint64
+	// Reference counts of Chunks shared by copies of files.
+	refs          *sheetfile.ChunkRefs
+	lease         time.Duration
+	db            metastore.MetadataStore
 	alloc         *datanode_alloc.DataNodeAllocator
-	journalWriter *common_journal.Writer
+	journalWriter JournalWriter
+	conn          *datanode_conn.DataNodeConnector
 	logger        *zap.Logger
 }
 
+/*
+JournalWriter
+Journal of mutations in a primary MasterNode, implemented by common_journal.Writer.
+*/
+type JournalWriter interface {
+	// Commit an entry to the journal.
+	CommitEntry(ctx context.Context, entry []byte) error
+	// Wait for pending CommitEntry to finish and block incoming ones until ExitCheckpoint.
+	PrepareCheckpoint() int64
+	// Allow CommitEntry to be executed again.
+	ExitCheckpoint()
+	// Commit a checkpoint marker, returns the offset to replay journal from.
+	Checkpoint(ctx context.Context) (int64, error)
+}
+
 func (f *FileManager) writeJournal(jEntry *journal_entry.MasterEntry) error {
 	if f.journalWriter != nil {
 		buf, err := proto.Marshal(jEntry)
@@ -71,10 +140,13 @@ func (f *FileManager) writeJournal(jEntry *journal_entry.MasterEntry) error {
 	return nil
 }
 
+// NoSession is used to open fds which are not owned by any session.
+const NoSession = uint64(0)
+
 /*
 allocFd
 allocate a new fd to respond a Open or Create file operation. It simply return nextFd and increase
-it by 1 currently.
+it by 1 currently. So fds are never reused.
 */
 func (f *FileManager) allocFd() uint64 {
 	fd := f.nextFd
@@ -82,6 +154,87 @@ func (f *FileManager) allocFd() uint64 {
 	return fd
 }
 
+/*
+allocSheetID
+allocate a new SheetID for a new file. SheetIDs are never reused, and 0 is not a
+valid SheetID.
+*/
+func (f *FileManager) allocSheetID() uint64 {
+	id := f.nextSheetID
+	f.nextSheetID += 1
+	return id
+}
+
+/*
+addEntry
+Add a directory entry and index it by SheetID. Caller should hold f.mu.
+*/
+func (f *FileManager) addEntry(entry *mgr_entry.MapEntry) {
+	f.Entries[entry.FileName] = entry
+	f.names[entry.SheetID] = entry.FileName
+	f.dirtyEntries[entry.SheetID] = true
+	// Keep nextSheetID the same as the primary's when replaying journal.
+	if entry.SheetID >= f.nextSheetID {
+		f.nextSheetID = entry.SheetID + 1
+	}
+}
+
+/*
+allocSession
+allocate a new session ID. Like fds, session IDs are never reused.
+*/
+func (f *FileManager) allocSession() uint64 {
+	id := f.nextSession
+	f.nextSession += 1
+	return id
+}
+
+/*
+checkSession
+Returns *errors.SessionNotFoundError if session is neither NoSession nor a live
+session. Caller should hold f.mu.
+*/
+func (f *FileManager) checkSession(session uint64) error {
+	if session == NoSession {
+		return nil
+	}
+	if _, ok := f.Sessions[session]; !ok {
+		return file_errors.NewSessionNotFoundError(session)
+	}
+	return nil
+}
+
+/*
+addFd
+Add a fd to fd table, points to sheetID and owned by session. Caller should hold f.mu.
+*/
+func (f *FileManager) addFd(fd uint64, sheetID uint64, session uint64) {
+	f.Fds[fd] = sheetID
+	if session != NoSession {
+		f.Owners[fd] = session
+	}
+	f.dirtyFds[fd] = true
+}
+
+/*
+releaseFd
+Remove a fd from fd table. Caller should hold f.mu.
+*/
+func (f *FileManager) releaseFd(fd uint64) {
+	delete(f.Fds, fd)
+	delete(f.Owners, fd)
+	f.dirtyFds[fd] = true
+}
+
+/*
+addSession
+Add a session whose lease starts from now. Caller should hold f.mu.
+*/
+func (f *FileManager) addSession(session uint64) {
+	f.Sessions[session] = time.Now().Add(f.lease)
+	f.dirtySessions[session] = true
+}
+
 /*
 openFile
 Open an existing SheetFile and allocate a fd which can be used to subsequently
@@ -89,16 +242,23 @@ access to this file. If this file is not loaded into memory, do it on-demand.
 
 @para
 	filename
+	session: session owning the fd, or NoSession
 
 @return
 	uint64: fd to access this file if filename is valid
 	error:
 		*errors.FileNotFoundError if the filename is invalid or file has been
 		recycled.
+		*errors.SessionNotFoundError if the session is invalid or expired.
+		errors raised while loading the file, see sheetfile.LoadSheetFile.
 */
-func (f *FileManager) openFile(filename string) (uint64, error) {
+func (f *FileManager) openFile(filename string, session uint64) (uint64, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	err := f.checkSession(session)
+	if err != nil {
+		return 0, err
+	}
 	// Lookup in directory entries to check validity of filename.
 	entry, ok := f.Entries[filename]
 	// If there is no such an entry, or the entry has been marked as recycled,
@@ -106,18 +266,54 @@ func (f *FileManager) openFile(filename string) (uint64, error) {
 	if !ok || entry.Recycled {
 		return 0, file_errors.NewFileNotFoundError(filename)
 	}
-	openedFile, ok := f.Opened[filename]
-	if !ok {
-		// Load file metadata into memory from sqlite on-demand.
-		openedFile = sheetfile.LoadSheetFile(f.db, f.alloc, filename)
-		f.Opened[filename] = openedFile
+	_, err = f.loadSheet(entry.SheetID)
+	if err != nil {
+		return 0, err
 	}
 	fd := f.allocFd()
-	// Add new allocated fd to fd table, points to the filename of opened file.
-	f.Fds[fd] = filename
+	err = f.writeJournal(&journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromEmptyMgrEntry(),
+		XFd:      journal_entry.FromFd(fd, entry.SheetID, session),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	// Add new allocated fd to fd table, points to the SheetID of opened file.
+	f.addFd(fd, entry.SheetID, session)
 	return fd, nil
 }
 
+/*
+loadSheet
+Returns an opened file. If this file is not loaded into memory, do it on-demand.
+Caller should hold f.mu.
+
+@para
+	sheetID: The validity of sheetID won't be checked.
+
+@return
+	*sheetfile.SheetFile: the opened file
+	error: errors raised by sheetfile.LoadSheetFile
+*/
+func (f *FileManager) loadSheet(sheetID uint64) (*sheetfile.SheetFile, error) {
+	openedFile, ok := f.Opened[sheetID]
+	if !ok {
+		// Load file metadata into memory from sqlite on-demand.
+		var err error
+		openedFile, err = sheetfile.LoadSheetFile(f.db, f.alloc, f.refs, sheetID)
+		if err != nil {
+			return nil, err
+		}
+		f.Opened[sheetID] = openedFile
+	}
+	return openedFile, nil
+}
+
 /*
 getFileByFd
 @para
@@ -130,12 +326,28 @@ getFileByFd
 */
 func (f *FileManager) getFileByFd(fd uint64) (*sheetfile.SheetFile, error) {
 	f.mu.RLock()
-	defer f.mu.RUnlock()
-	filename, ok := f.Fds[fd]
+	sheetID, ok := f.Fds[fd]
+	if !ok {
+		f.mu.RUnlock()
+		return nil, file_errors.NewFdNotFoundError(fd)
+	}
+	file, ok := f.Opened[sheetID]
+	f.mu.RUnlock()
+	if ok {
+		return file, nil
+	}
+	// Fds recovered from journal or checkpoint may point to files which have not been
+	// loaded into memory, load them on-demand.
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sheetID, ok = f.Fds[fd]
 	if !ok {
 		return nil, file_errors.NewFdNotFoundError(fd)
 	}
-	return f.Opened[filename], nil
+	if _, ok := f.names[sheetID]; !ok {
+		return nil, file_errors.NewFdNotFoundError(fd)
+	}
+	return f.loadSheet(sheetID)
 }
 
 /*
@@ -144,49 +356,66 @@ Creates a new SheetFile and open it immediately.
 
 @para
 	filename
+	session: session owning the returned fd, or NoSession
 
 @return
 	uint64: fd of the newly created file.
 	error:
-		*errors.FileExistsError if there has been a file with the same filename.
+		*errors.InvalidPathError if filename is not a valid path.
+		*errors.DirNotFoundError if the directory containing the file doesn't exist.
+		*errors.FileExistsError if there has been a file or directory with the same filename.
 		Although the existing file has been recycled, creating a file with the
 		same filename is not allowed.
+		*errors.SessionNotFoundError if the session is invalid or expired.
 		errors raised during sheetfile.CreateSheetFile
 */
-func (f *FileManager) CreateSheet(filename string) (uint64, error) {
+func (f *FileManager) CreateSheet(filename string, session uint64) (uint64, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	_, ok := f.Entries[filename]
-	if ok {
-		return 0, file_errors.NewFileExistsError(filename)
+	err := f.checkSession(session)
+	if err != nil {
+		return 0, err
+	}
+	err = f.checkNewPath(filename)
+	if err != nil {
+		return 0, err
 	}
 	/*
 		TODO: refactor sheetfile creation to a two-stage one, allocate newCell and newChunk first,
 		And then actually create newCell, newChunk in DB. Such a design is more fit for journaling,
 		enabling journaling really comes first.
 	*/
-	sheet, newCell, newChunk, err := sheetfile.CreateSheetFile(f.db, f.alloc, filename)
+	sheetID := f.allocSheetID()
+	sheet, newCell, newChunk, err := sheetfile.CreateSheetFile(f.db, f.alloc, f.refs, sheetID)
 	if err != nil {
 		return 0, err
 	}
+	now := time.Now()
 	newEntry := &mgr_entry.MapEntry{
-		FileName:       filename,
-		CellsTableName: sheetfile.GetCellTableName(filename),
-		Recycled:       false,
+		FileName:   filename,
+		SheetID:    sheetID,
+		Recycled:   false,
+		ModifiedAt: now,
 	}
+	newEntry.CreatedAt = now
+	// Allocate an fd right after creation, it's journaled together with the new file.
+	fd := f.allocFd()
 	err = f.writeJournal(&journal_entry.MasterEntry{
 		XCell:    journal_entry.FromSheetCell(newCell),
 		XChunk:   journal_entry.FromSheetChunk(newChunk),
 		XFileMap: journal_entry.FromMgrEntry(newEntry),
+		XFd:      journal_entry.FromFd(fd, sheetID, session),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
 	})
 	if err != nil {
 		return 0, err
 	}
-	f.Entries[filename] = newEntry
-	// Add the new file to opened table and allocate an fd right after creation.
-	f.Opened[filename] = sheet
-	fd := f.allocFd()
-	f.Fds[fd] = filename
+	f.addEntry(newEntry)
+	// Add the new file to opened table and fd table.
+	f.Opened[sheetID] = sheet
+	f.addFd(fd, sheetID, session)
 	return fd, nil
 }
 
@@ -194,21 +423,118 @@ func (f *FileManager) CreateSheet(filename string) (uint64, error) {
 OpenSheet
 @para
 	filename
+	session: session owning the returned fd, or NoSession
 
 @return
 	uint64: fd to access this file if filename is valid
 	error:
 		*errors.FileNotFoundError if the filename is invalid or file has been
 		recycled.
+		*errors.SessionNotFoundError if the session is invalid or expired.
+		errors raised while loading the file, see sheetfile.LoadSheetFile.
 */
-func (f *FileManager) OpenSheet(filename string) (uint64, error) {
-	fd, err := f.openFile(filename)
+func (f *FileManager) OpenSheet(filename string, session uint64) (uint64, error) {
+	fd, err := f.openFile(filename, session)
 	if err != nil {
 		return 0, err
 	}
 	return fd, nil
 }
 
+/*
+CloseSheet
+Release a fd. After that, the fd is invalid. It will never be allocated again, see allocFd.
+If the fd is the last one pointing to its file, the file will be dropped from f.Opened once
+it has been persisted by a checkpoint, so memory used by FileManager is bounded by files
+which are actually in use. The dropped file will be loaded again on-demand.
+
+@para
+	fd
+
+@return
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		errors raised while journaling
+*/
+func (f *FileManager) CloseSheet(fd uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sheetID, ok := f.Fds[fd]
+	if !ok {
+		return file_errors.NewFdNotFoundError(fd)
+	}
+	err := f.writeJournal(&journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromEmptyMgrEntry(),
+		XFd:      journal_entry.FromAbsentFd(fd, sheetID, f.Owners[fd]),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
+	})
+	if err != nil {
+		return err
+	}
+	f.releaseFd(fd)
+	if !f.isReferenced(sheetID) {
+		f.evictSheet(sheetID)
+	}
+	return nil
+}
+
+/*
+isReferenced
+Returns true if there is any fd pointing to sheetID. Caller should hold f.mu.
+*/
+func (f *FileManager) isReferenced(sheetID uint64) bool {
+	for _, id := range f.Fds {
+		if id == sheetID {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+evictSheet
+Drop an opened file from f.Opened if it has no mutation to be flushed. Dirty files are
+kept until they are persisted by next checkpoint, because the MetadataStore should only
+contain metadata as of a checkpoint, see DoCheckpoint. Do nothing if the file has not
+been opened. Caller should hold f.mu.
+*/
+func (f *FileManager) evictSheet(sheetID uint64) {
+	file, ok := f.Opened[sheetID]
+	if !ok || file.IsDirty() {
+		return
+	}
+	delete(f.Opened, sheetID)
+}
+
+/*
+EvictClosedSheets
+Drop all clean opened files which no fd points to. Files can be loaded into f.Opened
+without being opened by any fd, for example when journal entries are replayed by a
+secondary node, or they may be dirty when their last fd is closed. This method should
+be called after persisting a checkpoint to release them.
+*/
+func (f *FileManager) EvictClosedSheets() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.evictClosedSheets()
+}
+
+/*
+evictClosedSheets
+Implementation of EvictClosedSheets. Caller should hold f.mu exclusively.
+*/
+func (f *FileManager) evictClosedSheets() {
+	for sheetID := range f.Opened {
+		if !f.isReferenced(sheetID) {
+			f.evictSheet(sheetID)
+		}
+	}
+}
+
 /*
 RecycleSheet
 Mark a file as recycled and record RecycledAt. Do nothing if the filename is invalid.
@@ -232,11 +558,16 @@ func (f *FileManager) RecycleSheet(filename string) error {
 		XCell:    journal_entry.FromEmptySheetCell(),
 		XChunk:   journal_entry.FromEmptyChunk(),
 		XFileMap: journal_entry.FromMgrEntry(&tempEntry),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
 	})
 	if err != nil {
 		return err
 	}
 	f.Entries[filename] = &tempEntry
+	f.dirtyEntries[tempEntry.SheetID] = true
 
 	return nil
 }
@@ -259,208 +590,1351 @@ func (f *FileManager) ResumeSheet(filename string) error {
 		XCell:    journal_entry.FromEmptySheetCell(),
 		XChunk:   journal_entry.FromEmptyChunk(),
 		XFileMap: journal_entry.FromMgrEntry(&tempEntry),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
 	})
 	if err != nil {
 		return err
 	}
 	f.Entries[filename] = &tempEntry
+	f.dirtyEntries[tempEntry.SheetID] = true
 	return nil
 }
 
 /*
-Monitor
-Continuously monitoring all files marked as recycled, and if some file has
-exceeded configured retain time period, delete it forever.
-*/
-func (f *FileManager) Monitor() {
-	// TODO
-}
-
-/*
-ReadSheet
-Read all contents of a SheetFile. This method returns all Chunk of a sheet,
-applications should then contact with DataNodes to fetch actual content of
-those Chunks.
+RenameSheet
+Rename a file atomically. The file keeps its SheetID, so fds opened before renaming
+are still valid, and metadata of the file need not to be touched. Renaming a recycled
+file is allowed, it's still recycled after renaming. A file can be moved to another
+directory by renaming it to a path in that directory.
 
 @para
-	fd
+	filename: current filename of the file
+	newFilename: new filename of the file
 
-@returns
-	[]*sheetfile.Chunk: List of all Chunks in a file.
+@return
 	error:
-		*errors.FdNotFoundError if the fd is invalid
+		*errors.FileNotFoundError if the filename is invalid.
+		*errors.FileExistsError if there has been a file named newFilename, no matter
+		it has been recycled or not, or a directory at newFilename.
+		*errors.InvalidPathError if newFilename is not a valid path.
+		*errors.DirNotFoundError if the directory containing newFilename doesn't exist.
+		errors raised while journaling.
 */
-func (f *FileManager) ReadSheet(fd uint64) ([]*sheetfile.Chunk, error) {
-	file, err := f.getFileByFd(fd)
+func (f *FileManager) RenameSheet(filename string, newFilename string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, ok := f.Entries[filename]
+	if !ok {
+		return file_errors.NewFileNotFoundError(filename)
+	}
+	err := f.checkNewPath(newFilename)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	var tempEntry mgr_entry.MapEntry
+	tempEntry = *entry
+	tempEntry.FileName = newFilename
+	// A renaming is journaled as a single MapEntry carrying the same SheetID and the new
+	// filename, so it's applied atomically when replaying.
+	err = f.writeJournal(&journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromMgrEntry(&tempEntry),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
+	})
+	if err != nil {
+		return err
 	}
-	return file.GetAllChunks(), nil
+	delete(f.Entries, filename)
+	f.addEntry(&tempEntry)
+	return nil
 }
 
 /*
-ReadFileCell
-Read Cell located at (row, col) in a file pointed by fd.
+CopySheet
+Create a copy of a file named newFilename. The copy shares all Chunks of the original
+file copy-on-write, so no data is copied until either of them is written. See
+sheetfile.ChunkRefs for details. The whole copy is journaled as a single MapEntry of
+the new file carrying the SheetID of the original file.
+
+The semantic follows OpenSheet, a recycled file can't be copied.
 
 @para
-	fd, row, col
+	filename: filename of the original file
+	newFilename: filename of the copy
 
 @return
-	*Cell, *Chunk: snapshots of corresponding Cell and Chunk
 	error:
-		*errors.FdNotFoundError if the fd is invalid
-		*errors.CellNotFoundError if row, col passed in is invalid.
+		*errors.FileNotFoundError if the filename is invalid or file has been
+		recycled.
+		*errors.FileExistsError if there has been a file named newFilename, no matter
+		it has been recycled or not, or a directory at newFilename.
+		*errors.InvalidPathError if newFilename is not a valid path.
+		*errors.DirNotFoundError if the directory containing newFilename doesn't exist.
+		errors raised while copying the file or journaling.
 */
-func (f *FileManager) ReadFileCell(fd uint64, row, col uint32) (*sheetfile.Cell, *sheetfile.Chunk, error) {
-	file, err := f.getFileByFd(fd)
+func (f *FileManager) CopySheet(filename string, newFilename string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, ok := f.Entries[filename]
+	if !ok || entry.Recycled {
+		return file_errors.NewFileNotFoundError(filename)
+	}
+	err := f.checkNewPath(newFilename)
 	if err != nil {
-		return nil, nil, err
+		return err
+	}
+	sheetID := f.allocSheetID()
+	now := time.Now()
+	newEntry := &mgr_entry.MapEntry{
+		FileName:   newFilename,
+		SheetID:    sheetID,
+		Recycled:   false,
+		ModifiedAt: now,
 	}
-	cell, dataChunk, err := file.GetCellChunk(row, col)
+	newEntry.CreatedAt = now
+	err = f.copySheet(entry.SheetID, newEntry)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
-	return cell, dataChunk, nil
+	err = f.writeJournal(&journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromCopiedMgrEntry(newEntry, entry.SheetID),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
+	})
+	if err != nil {
+		// Undo the copy, nothing has been written to the copy yet. The copy has been
+		// opened, so removing it never fails.
+		_, _ = f.removeSheet(sheetID)
+		return err
+	}
+	return nil
 }
 
 /*
-WriteFileCell
-Write Cell located at (row, col) in a file pointed by fd. Create Cell if not existed.
+copySheet
+Copy the file identified by srcID and add the copy with given entry. This method is
+shared by CopySheet and journal replaying, so it won't write journal. Caller should
+hold f.mu.
 
 @para
-	fd, row, col
+	srcID: The validity of srcID won't be checked.
+	entry: MapEntry of the copy, whose SheetID has been allocated
 
 @return
-	*Cell, *Chunk: snapshots of corresponding Cell and Chunk
-	error:
-		*errors.FdNotFoundError if the fd is invalid
-		*errors.NoDataNodeError if there is no DataNode registered.
+	error: errors raised while loading the original file or by sheetfile.SheetFile.Copy
 */
-func (f *FileManager) WriteFileCell(fd uint64, row, col uint32) (*sheetfile.Cell, *sheetfile.Chunk, error) {
-	file, err := f.getFileByFd(fd)
+func (f *FileManager) copySheet(srcID uint64, entry *mgr_entry.MapEntry) error {
+	src, err := f.loadSheet(srcID)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
-	cell, dataChunk, err := file.WriteCellChunk(row, col, f.db)
+	copied, err := src.Copy(f.db, entry.SheetID)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
-	// TODO: refactor SheetFile.WriteCellChunk to a two-stage one.
-	// Currently, we can only assume that Kafka is highly-available due to lack of time.
-	_ = f.writeJournal(&journal_entry.MasterEntry{
-		XCell:    journal_entry.FromSheetCell(cell),
-		XChunk:   journal_entry.FromSheetChunk(dataChunk),
-		XFileMap: journal_entry.FromEmptyMgrEntry(),
-	})
-	return cell, dataChunk, nil
+	f.addEntry(entry)
+	f.Opened[entry.SheetID] = copied
+	return nil
 }
 
 /*
-GetAllSheets
-List all sheets stored in the directory.
+DeleteSheet
+Delete a file permanently, no matter it has been recycled or not. All metadata of
+this file, including its MapEntry, Cells and Chunks will be removed, and fds pointing
+to this file will be invalid afterwards. Finally, Chunks of this file are deleted
+from DataNodes.
+
+@para
+	filename
 
 @return
-	[]*fs_rpc.Sheet: a slice of protobuf fs_rpc.Sheet model, contains
-	filename and recycled field.
+	error:
+		*errors.FileNotFoundError if the filename is invalid.
+		errors raised while journaling or deleting metadata of the file.
 */
-func (f *FileManager) GetAllSheets() []*fs_rpc.Sheet {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-
-	pbSheets := make([]*fs_rpc.Sheet, len(f.Entries))
-	i := 0
-	for _, entry := range f.Entries {
-		pbSheets[i] = &fs_rpc.Sheet{
-			Filename: entry.FileName,
-			Recycled: entry.Recycled,
-		}
-		i += 1
-	}
-	return pbSheets
+func (f *FileManager) DeleteSheet(filename string) error {
+	_, err := f.deleteSheet(filename, nil)
+	return err
 }
 
 /*
-Persistent
-Flush the MapEntry and SheetFile data stored in a FileManager to sqlite in
-a transaction.
+deleteSheet
+Implementation of DeleteSheet. If shouldDelete is not nil, it will be checked against
+the MapEntry of file while holding f.mu, and the file is deleted only if it returns true.
+So the decision of deleting can be made atomically.
 
 @return
-	error: error during the persistent transaction.
+	bool: whether the file is deleted or not.
+	error: same as DeleteSheet
 */
-func (f *FileManager) Persistent() error {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-	err := f.db.Transaction(func(tx *gorm.DB) error {
-		for _, entry := range f.Entries {
-			tx.Save(entry)
-		}
-		for _, file := range f.Opened {
-			err := file.Persistent(tx)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
+func (f *FileManager) deleteSheet(filename string, shouldDelete func(entry *mgr_entry.MapEntry) bool) (bool, error) {
+	f.mu.Lock()
+	entry, ok := f.Entries[filename]
+	if !ok {
+		f.mu.Unlock()
+		return false, file_errors.NewFileNotFoundError(filename)
+	}
+	if shouldDelete != nil && !shouldDelete(entry) {
+		f.mu.Unlock()
+		return false, nil
+	}
+	// Chunks of the file are required to delete it, so it's loaded before journaling.
+	_, err := f.loadSheet(entry.SheetID)
+	if err != nil {
+		f.mu.Unlock()
+		return false, err
+	}
+	err = f.writeJournal(&journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromAbsentMgrEntry(entry),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromEmptySession(),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
 	})
 	if err != nil {
-		return err
+		f.mu.Unlock()
+		return false, err
 	}
-	return nil
+	chunks, err := f.removeSheet(entry.SheetID)
+	f.mu.Unlock()
+	if err != nil {
+		return false, err
+	}
+	// Contacting with DataNodes may be slow, so it's done without holding f.mu.
+	f.deleteDataChunks(chunks)
+	return true, nil
 }
 
 /*
-LoadFileManager
-Load all MapEntry from database and construct FileManager.Entries. Opened file
-table and fd table may be recovered from journal.
+removeSheet
+Remove all metadata of a file from memory. This method is shared by DeleteSheet and
+journal replaying, so it won't write journal or contact with DataNodes. Caller should
+hold f.mu.
 
-This method should only be used to load checkpoints in sqlite.
+The file is dropped from the MetadataStore by next checkpoint rather than immediately,
+so the persisted metadata keeps consistent with the offset of journal to replay from.
 
 @para
-	db: a gorm connection. It can be a transaction
+	sheetID: The validity of sheetID won't be checked.
 
 @return
-	*FileManager
+	[]*sheetfile.Chunk: snapshots of all Chunks of the removed file, except for those
+	still shared with other files.
+	error: errors raised while loading the file, nothing is removed in such a case.
 */
-func LoadFileManager(db *gorm.DB, alloc *datanode_alloc.DataNodeAllocator, writer *common_journal.Writer) *FileManager {
-	fm := &FileManager{
+func (f *FileManager) removeSheet(sheetID uint64) ([]*sheetfile.Chunk, error) {
+	file, err := f.loadSheet(sheetID)
+	if err != nil {
+		return nil, err
+	}
+	// Chunks shared with other files are kept.
+	chunks := f.refs.ReleaseChunks(file.GetAllChunks())
+	// Chunks dropped from the file before are deleted from the Store along with it.
+	f.deletedSheets[sheetID] = append(file.DroppedChunks(), chunks...)
+	delete(f.Entries, f.names[sheetID])
+	delete(f.names, sheetID)
+	delete(f.dirtyEntries, sheetID)
+	delete(f.Opened, sheetID)
+	for fd, id := range f.Fds {
+		if id == sheetID {
+			f.releaseFd(fd)
+		}
+	}
+	return chunks, nil
+}
+
+/*
+deleteDataChunks
+Delete data of Chunks from DataNodes storing them. Failures are only logged, because
+metadata of those Chunks have been removed, they are unreachable anyway.
+*/
+func (f *FileManager) deleteDataChunks(chunks []*sheetfile.Chunk) {
+	if f.conn == nil {
+		return
+	}
+	for _, c := range chunks {
+		err := f.conn.DeleteChunk(context.TODO(), c.DataNode, c.ID)
+		if err != nil && f.logger != nil {
+			f.logger.Error("error when deleting chunk.", zap.Uint64("chunk", c.ID), zap.Error(err))
+		}
+	}
+}
+
+/*
+copyDataChunk
+Copy data of Chunk src to dst on the DataNode storing them, see sheetfile.ChunkCopier.
+Do nothing if there is no DataNodeConnector.
+*/
+func (f *FileManager) copyDataChunk(src *sheetfile.Chunk, dst *sheetfile.Chunk) error {
+	if f.conn == nil {
+		return nil
+	}
+	return f.conn.CopyChunk(context.TODO(), src.DataNode, src.ID, dst.ID)
+}
+
+/*
+Monitor
+Continuously monitoring all files marked as recycled, and if some file has
+exceeded configured retain time period, delete it forever.
+
+Deletions are journaled as DeleteSheet does, so secondaries only need to replay
+them. This method should only be run by the primary node, and it blocks until ctx
+is cancelled, which is supposed to happen when the node loses its leadership.
+
+@para
+	ctx: context.Context used to stop monitoring
+	interval: time between two scans over recycled files
+	retention: how long a recycled file is kept before being deleted
+*/
+func (f *FileManager) Monitor(ctx context.Context, interval time.Duration, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			f.reapRecycledSheets(now.Add(-retention))
+		}
+	}
+}
+
+/*
+reapRecycledSheets
+Delete all files which have been recycled before deadline.
+
+@para
+	deadline: files recycled before it are deleted
+
+@return
+	int: number of deleted files
+*/
+func (f *FileManager) reapRecycledSheets(deadline time.Time) int {
+	expired := func(entry *mgr_entry.MapEntry) bool {
+		return entry.Recycled && entry.RecycledAt.Before(deadline)
+	}
+	f.mu.RLock()
+	var candidates []string
+	for filename, entry := range f.Entries {
+		if expired(entry) {
+			candidates = append(candidates, filename)
+		}
+	}
+	f.mu.RUnlock()
+
+	deleted := 0
+	for _, filename := range candidates {
+		// A candidate may have been resumed or deleted after f.mu is released above, so
+		// check it again while deleting.
+		ok, err := f.deleteSheet(filename, expired)
+		if err != nil {
+			if _, notFound := err.(*file_errors.FileNotFoundError); !notFound && f.logger != nil {
+				f.logger.Error("error when deleting recycled file.", zap.String("filename", filename), zap.Error(err))
+			}
+			continue
+		}
+		if ok {
+			deleted += 1
+		}
+	}
+	return deleted
+}
+
+/*
+OpenSession
+Open a new session whose lease expires after f.lease, unless it's renewed by KeepAlive.
+
+@return
+	uint64: ID of the new session
+	time.Duration: duration of the lease
+	error: errors raised while journaling
+*/
+func (f *FileManager) OpenSession() (uint64, time.Duration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.allocSession()
+	err := f.writeJournal(&journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromEmptyMgrEntry(),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromSession(id),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	f.addSession(id)
+	return id, f.lease, nil
+}
+
+/*
+KeepAlive
+Renew the lease of a session. Renewals are not journaled, a new primary renews
+all leases when taking over, see ResetLeases.
+
+@para
+	session
+
+@return
+	error:
+		*errors.SessionNotFoundError if the session is invalid or expired.
+*/
+func (f *FileManager) KeepAlive(session uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.Sessions[session]; !ok {
+		return file_errors.NewSessionNotFoundError(session)
+	}
+	f.Sessions[session] = time.Now().Add(f.lease)
+	return nil
+}
+
+/*
+CloseSession
+Close a session and release all fds owned by it.
+
+@para
+	session
+
+@return
+	error:
+		*errors.SessionNotFoundError if the session is invalid or expired.
+		errors raised while journaling.
+*/
+func (f *FileManager) CloseSession(session uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.Sessions[session]; !ok {
+		return file_errors.NewSessionNotFoundError(session)
+	}
+	return f.closeSession(session)
+}
+
+/*
+closeSession
+Implementation of CloseSession. Closing a session is journaled by a single entry, fds
+owned by the session are released implicitly when replaying it. Files which are no
+longer referenced by any fd are evicted as CloseSheet does. Caller should hold f.mu.
+*/
+func (f *FileManager) closeSession(session uint64) error {
+	err := f.writeJournal(&journal_entry.MasterEntry{
+		XCell:    journal_entry.FromEmptySheetCell(),
+		XChunk:   journal_entry.FromEmptyChunk(),
+		XFileMap: journal_entry.FromEmptyMgrEntry(),
+		XFd:      journal_entry.FromEmptyFd(),
+		XSession: journal_entry.FromAbsentSession(session),
+		XDir:     journal_entry.FromEmptyDir(),
+		XLines:   journal_entry.FromEmptyLines(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, sheetID := range f.releaseSession(session) {
+		if !f.isReferenced(sheetID) {
+			f.evictSheet(sheetID)
+		}
+	}
+	return nil
+}
+
+/*
+releaseSession
+Remove a session and all fds owned by it. This method is shared by closeSession and
+journal replaying. Caller should hold f.mu.
+
+@return
+	[]uint64: SheetIDs pointed by released fds
+*/
+func (f *FileManager) releaseSession(session uint64) []uint64 {
+	var sheetIDs []uint64
+	for fd, owner := range f.Owners {
+		if owner == session {
+			sheetIDs = append(sheetIDs, f.Fds[fd])
+			f.releaseFd(fd)
+		}
+	}
+	delete(f.Sessions, session)
+	f.dirtySessions[session] = true
+	return sheetIDs
+}
+
+/*
+ResetLeases
+Renew leases of all sessions. Sessions are recovered from journal or checkpoint without
+their leases, so this method must be called when a node becomes primary, giving clients
+a chance to reconnect to it before their sessions expire.
+*/
+func (f *FileManager) ResetLeases() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	deadline := time.Now().Add(f.lease)
+	for id := range f.Sessions {
+		f.Sessions[id] = deadline
+	}
+}
+
+/*
+MonitorSessions
+Continuously checking leases of all sessions, and closing sessions whose lease has
+expired. Like Monitor, this method should only be run by the primary node, and it blocks
+until ctx is cancelled.
+
+@para
+	ctx: context.Context used to stop monitoring
+	interval: time between two checks of leases
+*/
+func (f *FileManager) MonitorSessions(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			f.expireSessions(now)
+		}
+	}
+}
+
+/*
+expireSessions
+Close all sessions whose lease expired before now.
+
+@return
+	int: number of closed sessions
+*/
+func (f *FileManager) expireSessions(now time.Time) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	closed := 0
+	for id, deadline := range f.Sessions {
+		if !deadline.Before(now) {
+			continue
+		}
+		err := f.closeSession(id)
+		if err != nil {
+			if f.logger != nil {
+				f.logger.Error("error when closing expired session.", zap.Uint64("session", id), zap.Error(err))
+			}
+			continue
+		}
+		closed += 1
+	}
+	return closed
+}
+
+/*
+StatSheet
+Collect metadata and statistics of a file, including recycled ones.
+
+@para
+	filename
+
+@return
+	*fs_rpc.SheetStat
+	error:
+		*errors.FileNotFoundError if the filename is invalid.
+*/
+func (f *FileManager) StatSheet(filename string) (*fs_rpc.SheetStat, error) {
+	f.mu.Lock()
+	entry, ok := f.Entries[filename]
+	if !ok {
+		f.mu.Unlock()
+		return nil, file_errors.NewFileNotFoundError(filename)
+	}
+	stat, file, err := f.statEntry(entry)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return fillSheetStat(stat, file), nil
+}
+
+/*
+StatSheetByFd
+Same as StatSheet, but the file is pointed by fd.
+
+@para
+	fd
+
+@return
+	*fs_rpc.SheetStat
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+*/
+func (f *FileManager) StatSheetByFd(fd uint64) (*fs_rpc.SheetStat, error) {
+	f.mu.Lock()
+	sheetID, ok := f.Fds[fd]
+	if !ok {
+		f.mu.Unlock()
+		return nil, file_errors.NewFdNotFoundError(fd)
+	}
+	// A fd may refer to a file which doesn't exist, if it's recovered along with the
+	// deletion of the file, see handleJournalMapEntry.
+	entry, ok := f.Entries[f.names[sheetID]]
+	if !ok {
+		f.mu.Unlock()
+		return nil, file_errors.NewFdNotFoundError(fd)
+	}
+	stat, file, err := f.statEntry(entry)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return fillSheetStat(stat, file), nil
+}
+
+/*
+statEntry
+Collect metadata of a file from its MapEntry and the fd table, and load the file.
+Caller should hold f.mu.
+
+@return
+	*fs_rpc.SheetStat: metadata of the file, statistics of Cells and Chunks are not
+	filled, see fillSheetStat.
+	*sheetfile.SheetFile: the file
+	error: errors raised while loading the file
+*/
+func (f *FileManager) statEntry(entry *mgr_entry.MapEntry) (*fs_rpc.SheetStat, *sheetfile.SheetFile, error) {
+	stat := &fs_rpc.SheetStat{
+		Filename:   entry.FileName,
+		CreatedAt:  journal_entry.ToTimestamp(entry.CreatedAt),
+		ModifiedAt: journal_entry.ToTimestamp(entry.ModifiedAt),
+		Recycled:   entry.Recycled,
+	}
+	if entry.Recycled {
+		stat.RecycledAt = journal_entry.ToTimestamp(entry.RecycledAt)
+	}
+	for _, sheetID := range f.Fds {
+		if sheetID == entry.SheetID {
+			stat.OpenFds += 1
+		}
+	}
+	file, err := f.loadSheet(entry.SheetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stat, file, nil
+}
+
+/*
+fillSheetStat
+Fill statistics of Cells and Chunks of file into stat. Scanning over a large file may
+be slow, so it should be called without holding f.mu.
+*/
+func fillSheetStat(stat *fs_rpc.SheetStat, file *sheetfile.SheetFile) *fs_rpc.SheetStat {
+	fileStat := file.Stat()
+	stat.Cells = fileStat.Cells
+	stat.Chunks = fileStat.Chunks
+	stat.AllocatedBytes = fileStat.AllocatedBytes
+	stat.UsedBytes = fileStat.UsedBytes
+	stat.Datanodes = fileStat.DataNodes
+	return stat
+}
+
+/*
+touchSheet
+Record that the content of a file has been modified at t. Caller should hold f.mu.
+*/
+func (f *FileManager) touchSheet(sheetID uint64, t time.Time) {
+	entry, ok := f.Entries[f.names[sheetID]]
+	if ok && t.After(entry.ModifiedAt) {
+		entry.ModifiedAt = t
+		f.dirtyEntries[sheetID] = true
+	}
+}
+
+/*
+ReadSheet
+Read all contents of a tab of a SheetFile. This method returns all Chunk holding
+Cells of the tab, applications should then contact with DataNodes to fetch actual
+content of those Chunks.
+
+@para
+	fd, tab
+
+@returns
+	[]*sheetfile.Chunk: List of Chunks holding Cells of the tab, each of them only
+	contains Cells of the tab, see SheetFile.GetTabChunks.
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.TabNotFoundError if there is no such tab.
+*/
+func (f *FileManager) ReadSheet(fd uint64, tab uint32) ([]*sheetfile.Chunk, error) {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return nil, err
+	}
+	return file.GetTabChunks(tab)
+}
+
+/*
+ReadWorkbook
+Read all contents of all tabs of a SheetFile, see ReadSheet.
+
+@para
+	fd
+
+@returns
+	[]*sheetfile.Tab: all tabs in order.
+	[]*sheetfile.Chunk: List of all Chunks in a file.
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+*/
+func (f *FileManager) ReadWorkbook(fd uint64) ([]*sheetfile.Tab, []*sheetfile.Chunk, error) {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file.Tabs(), file.GetAllChunks(), nil
+}
+
+/*
+ReadFileCell
+Read Cell located at (row, col) in tab of a file pointed by fd.
+
+@para
+	fd, tab, row, col
+
+@return
+	*Cell, *Chunk: snapshots of corresponding Cell and Chunk
+	[]*Chunk: snapshots of overflow Chunks of the Cell
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.CellNotFoundError if row, col passed in is invalid.
+*/
+func (f *FileManager) ReadFileCell(fd uint64, tab, row, col uint32) (*sheetfile.Cell, *sheetfile.Chunk, []*sheetfile.Chunk, error) {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cell, dataChunk, overflow, err := file.GetCellChunk(tab, row, col)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cell, dataChunk, overflow, nil
+}
+
+/*
+ReadFileCells
+Read Cells in rows [rowStart, rowEnd) and columns [colStart, colEnd) in tab of a file
+pointed by fd. See SheetFile.GetRangeChunks.
+
+@para
+	fd, tab, rowStart, rowEnd, colStart, colEnd
+
+@return
+	[]*Chunk: snapshots of Chunks holding Cells in the range, each of them only contains
+	Cells in the range, followed by overflow Chunks of those Cells.
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+*/
+func (f *FileManager) ReadFileCells(fd uint64, tab, rowStart, rowEnd, colStart, colEnd uint32) ([]*sheetfile.Chunk, error) {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return nil, err
+	}
+	return file.GetRangeChunks(tab, rowStart, rowEnd, colStart, colEnd), nil
+}
+
+/*
+WriteFileCell
+Write Cell located at (row, col) in tab of a file pointed by fd. Create Cell if not existed.
+
+@para
+	fd, tab, row, col
+	size: bytes of data to write, the Cell is moved to a bigger slot or overflow Chunks
+	are allocated if it doesn't fit in the slot of the Cell. See SheetFile.WriteCellChunk.
+
+@return
+	*Cell, *Chunk: snapshots of corresponding Cell and Chunk
+	[]*Chunk: snapshots of overflow Chunks of the Cell
+	*sheetfile.CellMove: the old slot of the Cell if it's moved, or nil. The old slot should
+	be overwritten at its version in its Chunk, dropped Chunks are deleted from DataNodes here.
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.InvalidCellError if the Cell can't be addressed.
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.CellTooLargeError if size exceeds the limit of a Cell.
+		*errors.NoDataNodeError if there is no DataNode registered.
+*/
+func (f *FileManager) WriteFileCell(fd uint64, tab, row, col uint32, size uint64) (*sheetfile.Cell, *sheetfile.Chunk, []*sheetfile.Chunk, *sheetfile.CellMove, error) {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	// See FileManager.ckptMu.
+	f.ckptMu.RLock()
+	// Shared with other mutations of Cells, see sheetfile.SheetFile.JournalMu.
+	file.JournalMu.RLock()
+	cell, dataChunk, overflow, moved, err := file.WriteCellChunk(tab, row, col, size, f.db)
+	if err != nil {
+		file.JournalMu.RUnlock()
+		f.ckptMu.RUnlock()
+		return nil, nil, nil, nil, err
+	}
+	now := time.Now()
+	// TODO: refactor SheetFile.WriteCellChunk to a two-stage one.
+	// Currently, we can only assume that Kafka is highly-available due to lack of time.
+	if moved != nil {
+		// A moved Cell is replayed as a deletion followed by a creation.
+		_ = f.writeJournal(cellRemovalEntry(moved.Cell, moved.Chunk, now))
+	}
+	_ = f.writeJournal(cellWriteEntry(cell, dataChunk, overflow, now))
+	file.JournalMu.RUnlock()
+	f.mu.Lock()
+	f.touchSheet(cell.SheetID, now)
+	f.mu.Unlock()
+	f.ckptMu.RUnlock()
+	if moved != nil {
+		// Contacting with DataNodes may be slow, so it's done without holding f.mu.
+		f.deleteDataChunks(moved.Dropped)
+	}
+	return cell, dataChunk, overflow, moved, nil
+}
+
+/*
+WriteFileCells
+Write a batch of Cells in a file pointed by fd. See SheetFile.WriteCellsChunks.
+All writes are journaled as a single entry, in which a moved Cell is journaled as a
+deletion followed by a creation like WriteFileCell.
+
+@para
+	fd
+	writes: writes to different Cells
+
+@return
+	[]*sheetfile.CellWriteResult: results of writes in the order of writes. If an error is
+	raised while performing some write, results of writes performed before are returned
+	along with the error. Those writes have been journaled, so their slots must still be
+	written at the returned versions.
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.DuplicateCellError if a Cell is written more than once.
+		*errors.InvalidCellError if some Cell can't be addressed.
+		*errors.TabNotFoundError if the tab of some write doesn't exist.
+		*errors.CellTooLargeError if the size of some write exceeds the limit of a Cell.
+		*errors.NoDataNodeError if there is no DataNode registered.
+		errors raised while copying a shared Chunk or saving new Chunks.
+*/
+func (f *FileManager) WriteFileCells(fd uint64, writes []sheetfile.CellWrite) ([]*sheetfile.CellWriteResult, error) {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return nil, err
+	}
+	f.ckptMu.RLock()
+	file.JournalMu.RLock()
+	results, err := file.WriteCellsChunks(writes, f.db)
+	if len(results) == 0 {
+		file.JournalMu.RUnlock()
+		f.ckptMu.RUnlock()
+		return nil, err
+	}
+	now := time.Now()
+	var entries []*journal_entry.MasterEntry
+	var dropped []*sheetfile.Chunk
+	for _, r := range results {
+		if r.Moved != nil {
+			entries = append(entries, cellRemovalEntry(r.Moved.Cell, r.Moved.Chunk, now))
+			dropped = append(dropped, r.Moved.Dropped...)
+		}
+		entries = append(entries, cellWriteEntry(r.Cell, r.Chunk, r.Overflow, now))
+	}
+	// Writes performed before an error are journaled too, since they can't be undone.
+	_ = f.writeJournal(&journal_entry.MasterEntry{
+		XCell:     journal_entry.FromEmptySheetCell(),
+		XChunk:    journal_entry.FromEmptyChunk(),
+		XFileMap:  journal_entry.FromEmptyMgrEntry(),
+		XFd:       journal_entry.FromEmptyFd(),
+		XSession:  journal_entry.FromEmptySession(),
+		XDir:      journal_entry.FromEmptyDir(),
+		XLines:    journal_entry.FromEmptyLines(),
+		Timestamp: now.UnixNano(),
+		Batch:     entries,
+	})
+	file.JournalMu.RUnlock()
+	f.mu.Lock()
+	f.touchSheet(file.ID(), now)
+	f.mu.Unlock()
+	f.ckptMu.RUnlock()
+	// Contacting with DataNodes may be slow, so it's done without holding f.mu.
+	f.deleteDataChunks(dropped)
+	return results, err
+}
+
+/*
+entryTimestamp
+Returns the Timestamp of a journal entry of a mutation made at t. The zero t gives no
+Timestamp, so replaying the entry doesn't touch ModifiedAt of the file.
+*/
+func entryTimestamp(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+/*
+cellWriteEntry
+Build the journal entry of writing cell in dataChunk and its overflow Chunks at t, see
+entryTimestamp.
+*/
+func cellWriteEntry(cell *sheetfile.Cell, dataChunk *sheetfile.Chunk, overflow []*sheetfile.Chunk, t time.Time) *journal_entry.MasterEntry {
+	return &journal_entry.MasterEntry{
+		XCell:     journal_entry.FromSheetCell(cell),
+		XChunk:    journal_entry.FromSheetChunk(dataChunk),
+		XFileMap:  journal_entry.FromEmptyMgrEntry(),
+		XFd:       journal_entry.FromEmptyFd(),
+		XSession:  journal_entry.FromEmptySession(),
+		XDir:      journal_entry.FromEmptyDir(),
+		XLines:    journal_entry.FromEmptyLines(),
+		Timestamp: entryTimestamp(t),
+		Overflow:  journal_entry.FromOverflowChunks(overflow),
+	}
+}
+
+/*
+cellRemovalEntry
+Build the journal entry of removing cell at t, whose slot in dataChunk is freed. dataChunk
+is nil if it has been dropped along with cell.
+*/
+func cellRemovalEntry(cell *sheetfile.Cell, dataChunk *sheetfile.Chunk, t time.Time) *journal_entry.MasterEntry {
+	chunkEntry := journal_entry.FromAbsentSheetChunk(cell.ChunkID)
+	if dataChunk != nil {
+		chunkEntry = journal_entry.FromSheetChunk(dataChunk)
+	}
+	return &journal_entry.MasterEntry{
+		XCell:     journal_entry.FromAbsentSheetCell(cell),
+		XChunk:    chunkEntry,
+		XFileMap:  journal_entry.FromEmptyMgrEntry(),
+		XFd:       journal_entry.FromEmptyFd(),
+		XSession:  journal_entry.FromEmptySession(),
+		XDir:      journal_entry.FromEmptyDir(),
+		XLines:    journal_entry.FromEmptyLines(),
+		Timestamp: entryTimestamp(t),
+	}
+}
+
+/*
+DeleteFileCell
+Delete Cell located at (row, col) in tab of a file pointed by fd. See SheetFile.DeleteCell.
+
+@para
+	fd, tab, row, col
+
+@return
+	*Cell, *Chunk: snapshots of deleted Cell and its Chunk, the slot of the Cell should
+	be overwritten at its version in the Chunk. Chunk is nil if it has been dropped,
+	in which case it's deleted from DataNodes here.
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.CellNotFoundError if row, col passed in is invalid.
+		errors raised while copying a shared Chunk.
+*/
+func (f *FileManager) DeleteFileCell(fd uint64, tab, row, col uint32) (*sheetfile.Cell, *sheetfile.Chunk, error) {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.ckptMu.RLock()
+	file.JournalMu.RLock()
+	cell, dataChunk, dropped, err := file.DeleteCell(tab, row, col, f.db)
+	if err != nil {
+		file.JournalMu.RUnlock()
+		f.ckptMu.RUnlock()
+		return nil, nil, err
+	}
+	now := time.Now()
+	// Like WriteFileCell, SheetFile.DeleteCell is not a two-stage one, so Kafka is
+	// assumed to be highly-available.
+	_ = f.writeJournal(cellRemovalEntry(cell, dataChunk, now))
+	file.JournalMu.RUnlock()
+	f.mu.Lock()
+	f.touchSheet(cell.SheetID, now)
+	f.mu.Unlock()
+	f.ckptMu.RUnlock()
+	// Contacting with DataNodes may be slow, so it's done without holding f.mu.
+	f.deleteDataChunks(dropped)
+	return cell, dataChunk, nil
+}
+
+/*
+GetAllSheets
+List all sheets stored in a directory and all of its subdirectories, sorted by
+filename. The result is unbounded, use ListSheets to list sheets page by page.
+
+@para
+	dir: path of the directory, empty for the root directory, in which case all
+	sheets are listed.
+
+@return
+	[]*fs_rpc.Sheet: a slice of protobuf fs_rpc.Sheet model, contains
+	filename, recycled and modified_at field.
+	error:
+		*errors.InvalidPathError if dir is not a valid path.
+		*errors.DirNotFoundError if there is no such directory.
+*/
+func (f *FileManager) GetAllSheets(dir string) ([]*fs_rpc.Sheet, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	err := f.checkDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pbSheets := []*fs_rpc.Sheet{}
+	for filename, entry := range f.Entries {
+		if !isUnder(filename, dir) {
+			continue
+		}
+		pbSheets = append(pbSheets, toPbSheet(entry))
+	}
+	sort.Slice(pbSheets, func(i, j int) bool {
+		return pbSheets[i].Filename < pbSheets[j].Filename
+	})
+	return pbSheets, nil
+}
+
+/*
+Persistent
+Flush the MapEntry and SheetFile data mutated since last checkpoint to the MetadataStore
+in a transaction. See persistent.
+
+@return
+	error: error during the persistent transaction.
+*/
+func (f *FileManager) Persistent() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.persistent(noCheckpoint)
+}
+
+/*
+PersistentCheckpoint
+Same as Persistent, but also record offset as the offset of journal to replay from in
+the same transaction, so the recorded offset always matches the persisted metadata.
+Used by a secondary node replaying a checkpoint marker of journal.
+
+@para
+	offset: offset of journal to replay from.
+
+@return
+	error: error during the persistent transaction, nothing is persisted if it's not nil.
+*/
+func (f *FileManager) PersistentCheckpoint(offset int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.persistent(offset)
+}
+
+// noCheckpoint is passed to persistent if no checkpoint offset should be recorded.
+const noCheckpoint = int64(-1)
+
+/*
+persistent
+Implementation of Persistent. Only dirty MapEntries, fds, sessions, directories, reference
+counts of shared Chunks, Cells and Chunks are flushed, and files deleted since last
+checkpoint are dropped. They are marked clean after the transaction is
+committed, so they will be flushed by next checkpoint if it's rolled back.
+Caller should hold f.mu exclusively.
+
+@para
+	offset: offset of journal to replay from, recorded in the same transaction unless it's
+	noCheckpoint.
+*/
+func (f *FileManager) persistent(offset int64) error {
+	flushes := make(map[*sheetfile.SheetFile]uint64, len(f.Opened))
+	var refsFlush uint64
+	err := f.db.Transaction(func(tx metastore.MetadataStore) error {
+		for sheetID, chunks := range f.deletedSheets {
+			err := sheetfile.DropSheetFile(tx, sheetID, chunks)
+			if err != nil {
+				return err
+			}
+			err = tx.DeleteEntry(sheetID)
+			if err != nil {
+				return err
+			}
+		}
+		for sheetID := range f.dirtyEntries {
+			entry, ok := f.Entries[f.names[sheetID]]
+			if !ok {
+				continue
+			}
+			err := tx.SaveEntry(entry)
+			if err != nil {
+				return err
+			}
+		}
+		err := f.persistentFds(tx)
+		if err != nil {
+			return err
+		}
+		refsFlush, err = f.refs.Flush(tx)
+		if err != nil {
+			return err
+		}
+		for _, file := range f.Opened {
+			flush, err := file.Flush(tx)
+			if err != nil {
+				return err
+			}
+			flushes[file] = flush
+		}
+		if offset == noCheckpoint {
+			return nil
+		}
+		return tx.RecordCheckpoint(offset)
+	})
+	if err != nil {
+		return err
+	}
+	f.dirtyEntries = map[uint64]bool{}
+	f.dirtyFds = map[uint64]bool{}
+	f.dirtySessions = map[uint64]bool{}
+	f.dirtyDirs = map[string]bool{}
+	f.deletedSheets = map[uint64][]*sheetfile.Chunk{}
+	f.refs.MarkFlushed(refsFlush)
+	for file, flush := range flushes {
+		file.MarkFlushed(flush)
+	}
+	return nil
+}
+
+/*
+persistentFds
+Flush fds, sessions and directories added or removed since last checkpoint, and counters
+into the MetadataStore. Removed ones are deleted from it, others are saved.
+
+@para
+	tx: a MetadataStore, supposed to be a transaction.
+*/
+func (f *FileManager) persistentFds(tx metastore.MetadataStore) error {
+	for fd := range f.dirtyFds {
+		var err error
+		if sheetID, ok := f.Fds[fd]; ok {
+			err = tx.SaveFd(&mgr_entry.FdEntry{Fd: fd, SheetID: sheetID, Session: f.Owners[fd]})
+		} else {
+			err = tx.DeleteFd(fd)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for id := range f.dirtySessions {
+		var err error
+		if _, ok := f.Sessions[id]; ok {
+			err = tx.SaveSession(&mgr_entry.SessionEntry{ID: id})
+		} else {
+			err = tx.DeleteSession(id)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for p := range f.dirtyDirs {
+		var err error
+		if dir, ok := f.Dirs[p]; ok {
+			err = tx.SaveDir(dir)
+		} else {
+			err = tx.DeleteDir(p)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return tx.SaveCounters(&mgr_entry.Counters{NextFd: f.nextFd, NextSession: f.nextSession, NextSheetID: f.nextSheetID})
+}
+
+/*
+LoadFileManager
+Load all MapEntry from database and construct FileManager.Entries. Opened file
+table and fd table may be recovered from journal.
+
+The fd table, sessions, directories and counters are also loaded, so fds and sessions
+allocated before the checkpoint are still valid. Leases of loaded sessions start from now.
+
+MapEntry persisted before SheetIDs were introduced has no SheetID, it should have been
+upgraded by metastore.MigrateCells. Such entries are skipped.
+
+This method should only be used to load checkpoints in the MetadataStore. Errors raised
+by the MetadataStore are logged, and the metadata failed to be loaded is skipped.
+
+@para
+	db: a MetadataStore. It can be a transaction
+	alloc: DataNodeAllocator used to allocate Chunks
+	writer: journal writer, nil if journaling is not desired
+	conn: DataNodeConnector used to delete Chunks, nil if it's not desired
+
+@return
+	*FileManager
+*/
+func LoadFileManager(db metastore.MetadataStore, alloc *datanode_alloc.DataNodeAllocator, writer *common_journal.Writer, conn *datanode_conn.DataNodeConnector) *FileManager {
+	fm := &FileManager{
 		Entries:       map[string]*mgr_entry.MapEntry{},
-		Opened:        map[string]*sheetfile.SheetFile{},
-		Fds:           map[uint64]string{},
+		names:         map[uint64]string{},
+		Dirs:          map[string]*mgr_entry.DirEntry{},
+		Opened:        map[uint64]*sheetfile.SheetFile{},
+		Fds:           map[uint64]uint64{},
 		nextFd:        0,
+		Sessions:      map[uint64]time.Time{},
+		Owners:        map[uint64]uint64{},
+		nextSession:   NoSession + 1,
+		nextSheetID:   1,
+		dirtyEntries:  map[uint64]bool{},
+		dirtyFds:      map[uint64]bool{},
+		dirtySessions: map[uint64]bool{},
+		dirtyDirs:     map[string]bool{},
+		deletedSheets: map[uint64][]*sheetfile.Chunk{},
+		lease:         config.SessionLease,
 		db:            db,
 		alloc:         alloc,
-		journalWriter: writer,
+		conn:          conn,
 	}
-	var entries []*mgr_entry.MapEntry
-	db.Find(&entries)
-	for _, entry := range entries {
-		fm.Entries[entry.FileName] = entry
+	// A nil *common_journal.Writer must not be wrapped into a non-nil JournalWriter.
+	if writer != nil {
+		fm.journalWriter = writer
 	}
 	logger, err := zap.NewDevelopment(zap.Fields(zap.String("source", "FileManager")))
 	if err == nil {
 		fm.logger = logger
 	}
+	fm.refs, err = sheetfile.LoadChunkRefs(db, fm.copyDataChunk, fm.deleteDataChunks)
+	fm.logLoadError("chunk refs", err)
+	entries, err := db.LoadEntries()
+	fm.logLoadError("entries", err)
+	for _, entry := range entries {
+		if entry.SheetID == 0 {
+			if fm.logger != nil {
+				fm.logger.Error("legacy file is not migrated.", zap.String("filename", entry.FileName))
+			}
+			continue
+		}
+		fm.addEntry(entry)
+	}
+	// Loaded MapEntries are not dirty.
+	fm.dirtyEntries = map[uint64]bool{}
+	dirs, err := db.LoadDirs()
+	fm.logLoadError("directories", err)
+	for _, dir := range dirs {
+		fm.Dirs[dir.Path] = dir
+	}
+	fds, err := db.LoadFds()
+	fm.logLoadError("fds", err)
+	for _, fd := range fds {
+		fm.addFd(fd.Fd, fd.SheetID, fd.Session)
+	}
+	// Loaded fds are not dirty.
+	fm.dirtyFds = map[uint64]bool{}
+	sessions, err := db.LoadSessions()
+	fm.logLoadError("sessions", err)
+	for _, session := range sessions {
+		fm.Sessions[session.ID] = time.Now().Add(fm.lease)
+	}
+	counter, err := db.LoadCounters()
+	fm.logLoadError("counters", err)
+	if counter != nil {
+		fm.nextFd = counter.NextFd
+		if counter.NextSession > fm.nextSession {
+			fm.nextSession = counter.NextSession
+		}
+		// nextSheetID has been advanced by addEntry, SheetIDs of deleted files should
+		// not be reused either.
+		if counter.NextSheetID > fm.nextSheetID {
+			fm.nextSheetID = counter.NextSheetID
+		}
+	}
 	return fm
 }
 
-func (f *FileManager) handleJournalMapEntry(mapEntry *journal_entry.FileMapEntry) {
-	original, ok := f.Entries[mapEntry.Filename]
+func (f *FileManager) logLoadError(what string, err error) {
+	if err != nil && f.logger != nil {
+		f.logger.Error("error when loading "+what+".", zap.Error(err))
+	}
+}
+
+func (f *FileManager) handleJournalMapEntry(entry *journal_entry.MasterEntry) error {
+	mapEntry := entry.GetMapEntry()
+	filename, ok := f.names[mapEntry.SheetId]
 	if !ok {
 		switch mapEntry.TargetState {
 		case journal_entry.State_PRESENT:
 			e := &mgr_entry.MapEntry{}
 			journal_entry.ToMgrEntry(e, mapEntry)
-			f.Entries[mapEntry.Filename] = e
+			if mapEntry.CopyOf != 0 {
+				// The copy is flushed by the checkpoint after it, so the original file
+				// must be present when it's replayed.
+				if _, ok := f.names[mapEntry.CopyOf]; !ok {
+					return journal_entry.NewInvalidJournalEntryError(entry)
+				}
+				return f.copySheet(mapEntry.CopyOf, e)
+			}
+			f.addEntry(e)
 		case journal_entry.State_ABSENT:
-			// Do nothing
+			// The file is unknown, but fds recovered from the checkpoint may still point to
+			// it. Release them, so no fd refers to a file that doesn't exist.
+			for fd, id := range f.Fds {
+				if id == mapEntry.SheetId {
+					f.releaseFd(fd)
+				}
+			}
 		}
 	} else {
 		switch mapEntry.TargetState {
 		case journal_entry.State_PRESENT:
+			original := f.Entries[filename]
 			journal_entry.ToMgrEntry(original, mapEntry)
+			f.dirtyEntries[original.SheetID] = true
+			// The file has been renamed.
+			if filename != mapEntry.Filename {
+				delete(f.Entries, filename)
+				f.addEntry(original)
+			}
 		case journal_entry.State_ABSENT:
-			delete(f.Entries, mapEntry.Filename)
+			_, err := f.removeSheet(mapEntry.SheetId)
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FileManager) handleFdEntry(fdEntry *journal_entry.FdEntry) {
+	switch fdEntry.TargetState {
+	case journal_entry.State_PRESENT:
+		f.addFd(fdEntry.Fd, fdEntry.SheetId, fdEntry.Session)
+		// Keep nextFd the same as the primary's, so fds won't be reused after failover.
+		if fdEntry.Fd >= f.nextFd {
+			f.nextFd = fdEntry.Fd + 1
+		}
+	case journal_entry.State_ABSENT:
+		f.releaseFd(fdEntry.Fd)
+	}
+}
+
+func (f *FileManager) handleSessionEntry(sessionEntry *journal_entry.SessionEntry) {
+	switch sessionEntry.TargetState {
+	case journal_entry.State_PRESENT:
+		f.addSession(sessionEntry.Id)
+		if sessionEntry.Id >= f.nextSession {
+			f.nextSession = sessionEntry.Id + 1
 		}
+	case journal_entry.State_ABSENT:
+		f.releaseSession(sessionEntry.Id)
 	}
 }
 
@@ -470,7 +1944,13 @@ func (f *FileManager) handleChunkEntry(file *sheetfile.SheetFile, chunk *journal
 		case journal_entry.State_PRESENT:
 			c := &sheetfile.Chunk{}
 			journal_entry.ToSheetChunk(c, chunk)
+			if _, ok := file.Chunks[c.CopyOf]; c.CopyOf != 0 && ok {
+				// A shared Chunk has been copied on write.
+				file.ReplaceSharedChunk(c)
+				return
+			}
 			file.Chunks[chunk.Id] = c
+			file.MarkChunkDirty(c)
 		case journal_entry.State_ABSENT:
 			// Do nothing
 		}
@@ -478,12 +1958,20 @@ func (f *FileManager) handleChunkEntry(file *sheetfile.SheetFile, chunk *journal
 		switch chunk.TargetState {
 		case journal_entry.State_PRESENT:
 			journal_entry.ToSheetChunk(originalChunk, chunk)
+			file.MarkChunkDirty(originalChunk)
 		case journal_entry.State_ABSENT:
-			delete(file.Chunks, chunk.Id)
+			// A Chunk is dropped along with its last Cell, see handleCellEntry.
 		}
 	}
 }
 
+func (f *FileManager) handleOverflowChunkEntry(file *sheetfile.SheetFile, chunk *journal_entry.ChunkEntry) {
+	f.handleChunkEntry(file, chunk)
+	if c, ok := file.Chunks[chunk.Id]; ok {
+		c.Overflow = true
+	}
+}
+
 func (f *FileManager) ensureCellChunkConsistency(file *sheetfile.SheetFile, cell *sheetfile.Cell) {
 	chunk := file.Chunks[cell.ChunkID]
 	for i := 0; i < len(chunk.Cells); i++ {
@@ -501,13 +1989,14 @@ func (f *FileManager) handleCellEntry(file *sheetfile.SheetFile, cell *journal_e
 	if originalCell, ok := file.Cells[cell.CellId]; !ok {
 		switch cell.TargetState {
 		case journal_entry.State_PRESENT:
-			err := sheetfile.CreateCellTableIfNotExists(f.db, cell.SheetName)
+			err := f.db.CreateCells(cell.SheetId)
 			if err != nil {
 				return err
 			}
 			c := &sheetfile.Cell{}
 			journal_entry.ToSheetCell(c, cell)
 			file.Cells[cell.CellId] = c
+			file.MarkCellDirty(c)
 			f.ensureCellChunkConsistency(file, c)
 		case journal_entry.State_ABSENT:
 			// Do nothing
@@ -516,17 +2005,45 @@ func (f *FileManager) handleCellEntry(file *sheetfile.SheetFile, cell *journal_e
 		switch cell.TargetState {
 		case journal_entry.State_PRESENT:
 			journal_entry.ToSheetCell(originalCell, cell)
+			file.MarkCellDirty(originalCell)
 			f.ensureCellChunkConsistency(file, originalCell)
 		case journal_entry.State_ABSENT:
-			delete(file.Cells, cell.CellId)
+			file.RemoveCell(cell.CellId)
 		}
 	}
 	return nil
 }
 
 func (f *FileManager) HandleMasterEntry(entry *journal_entry.MasterEntry) error {
-	if mapEntry := entry.GetMapEntry(); mapEntry != nil {
-		f.handleJournalMapEntry(mapEntry)
+	for _, e := range entry.Batch {
+		err := f.HandleMasterEntry(e)
+		if err != nil {
+			return err
+		}
+	}
+	if entry.GetMapEntry() != nil {
+		err := f.handleJournalMapEntry(entry)
+		if err != nil {
+			return err
+		}
+	}
+	if fdEntry := entry.GetFd(); fdEntry != nil {
+		f.handleFdEntry(fdEntry)
+	}
+	if sessionEntry := entry.GetSession(); sessionEntry != nil {
+		f.handleSessionEntry(sessionEntry)
+	}
+	if dirEntry := entry.GetDir(); dirEntry != nil {
+		err := f.handleDirEntry(dirEntry)
+		if err != nil {
+			return err
+		}
+	}
+	if linesEntry := entry.GetLines(); linesEntry != nil {
+		err := f.handleLinesEntry(entry)
+		if err != nil {
+			return err
+		}
 	}
 	cell, chunk := entry.GetCell(), entry.GetChunk()
 	if cell == nil && chunk == nil {
@@ -535,46 +2052,70 @@ func (f *FileManager) HandleMasterEntry(entry *journal_entry.MasterEntry) error
 	if cell == nil || chunk == nil {
 		return journal_entry.NewInvalidJournalEntryError(entry)
 	}
-	if cell.ChunkId != chunk.Id || cell.TargetState != chunk.TargetState {
+	// The Chunk of a deleted Cell may be kept, but a present Cell must be in a
+	// present Chunk.
+	if cell.ChunkId != chunk.Id ||
+		(cell.TargetState == journal_entry.State_PRESENT && chunk.TargetState != journal_entry.State_PRESENT) {
 		return journal_entry.NewInvalidJournalEntryError(entry)
 	}
 	// After MapEntry recovery above, if this entry represents a file creation, the corresponding
 	// should have been added to f.Entries, or if this entry is an operation on an existing file,
 	// its MapEntry has already in f.Entries too. So if it's unable to find such an MapEntry until
 	// now, this journal entry is invalid.
-	if _, ok := f.Entries[cell.SheetName]; !ok {
+	if _, ok := f.names[cell.SheetId]; !ok {
 		return journal_entry.NewInvalidJournalEntryError(entry)
 	}
-	file, ok := f.Opened[cell.SheetName]
-	if !ok {
-		file = sheetfile.LoadSheetFile(f.db, f.alloc, cell.SheetName)
-		f.Opened[cell.SheetName] = file
+	file, err := f.loadSheet(cell.SheetId)
+	if err != nil {
+		return err
 	}
 	f.handleChunkEntry(file, chunk)
-	err := f.handleCellEntry(file, cell)
+	for _, overflow := range entry.Overflow {
+		f.handleOverflowChunkEntry(file, overflow)
+	}
+	err = f.handleCellEntry(file, cell)
+	if entry.Timestamp != 0 {
+		f.touchSheet(cell.SheetId, journal_entry.FromTimestamp(entry.Timestamp))
+	}
 
 	return err
 }
 
+/*
+DoCheckpoint
+Persist metadata into the MetadataStore and record the offset of journal to replay from.
+Mutations are quiesced while the checkpoint is taken: those of Cells by ckptMu, others by
+mu, and no entry can be committed between the checkpoint marker and the recorded offset.
+So replaying journal from the offset on the persisted metadata never misses or repeats
+any mutation.
+
+This holds because mutations only change the MetadataStore by persistent, with a few
+exceptions which are unreachable from persisted metadata until then: rows of new Chunks
+saved to allocate their IDs, and storage prepared for Cells of new files.
+*/
 func (f *FileManager) DoCheckpoint() {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
+	f.ckptMu.Lock()
+	defer f.ckptMu.Unlock()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.journalWriter.PrepareCheckpoint()
+	defer f.journalWriter.ExitCheckpoint()
 	offset, err := f.journalWriter.Checkpoint(context.Background())
-	if err != nil {
-		f.logger.Error("error when journaling checkpoint.", zap.Error(err))
-		return
-	}
-	err = f.Persistent()
 	if err != nil {
 		if f.logger != nil {
-			f.logger.Error("error when checkpointing.", zap.Error(err))
+			f.logger.Error("error when journaling checkpoint.", zap.Error(err))
 		}
 		return
 	}
-	err = checkpoint.RecordCheckpoint(f.db, offset)
+	// The offset is recorded along with the metadata, so a failure leaves the MetadataStore
+	// as of the previous checkpoint.
+	err = f.persistent(offset)
 	if err != nil {
 		if f.logger != nil {
-			f.logger.Error("error when persist checkpoint.", zap.Error(err))
+			f.logger.Error("error when checkpointing.", zap.Error(err))
 		}
+		return
 	}
+	// Files closed before the checkpoint are clean now.
+	f.evictClosedSheets()
 }
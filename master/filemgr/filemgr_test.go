@@ -1,16 +1,26 @@
 package filemgr
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/fourstring/sheetfs/master/config"
 	"github.com/fourstring/sheetfs/master/datanode_alloc"
 	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
 	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
+	"github.com/fourstring/sheetfs/master/journal/journal_entry"
+	"github.com/fourstring/sheetfs/master/metastore"
 	"github.com/fourstring/sheetfs/master/sheetfile"
+	fs_rpc "github.com/fourstring/sheetfs/protocol"
 	"github.com/fourstring/sheetfs/tests"
 	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
+	"path/filepath"
 	"sort"
+	"sync"
 	"testing"
+	"time"
 )
 
 func shouldBeSameEntry(actual interface{}, expected ...interface{}) string {
@@ -30,23 +40,48 @@ func shouldBeSameEntry(actual interface{}, expected ...interface{}) string {
 }
 
 func newTestFileManager() (*FileManager, *gorm.DB, *datanode_alloc.DataNodeAllocator, error) {
-	db, err := tests.GetTestDB(&sheetfile.Chunk{}, &mgr_entry.MapEntry{})
+	db, err := tests.GetTestDB(&sheetfile.Chunk{}, &sheetfile.Cell{}, &sheetfile.ChunkRef{}, &mgr_entry.MapEntry{}, &mgr_entry.FdEntry{}, &mgr_entry.SessionEntry{}, &mgr_entry.DirEntry{}, &mgr_entry.Counters{})
 	if err != nil {
 		return nil, nil, nil, err
 	}
 	alloc := datanode_alloc.NewDataNodeAllocator()
 	alloc.AddDataNode("node1")
 	fm := &FileManager{
-		Entries: map[string]*mgr_entry.MapEntry{},
-		Opened:  map[string]*sheetfile.SheetFile{},
-		Fds:     map[uint64]string{},
-		nextFd:  0,
-		db:      db,
-		alloc:   alloc,
+		Entries:       map[string]*mgr_entry.MapEntry{},
+		names:         map[uint64]string{},
+		Dirs:          map[string]*mgr_entry.DirEntry{},
+		Opened:        map[uint64]*sheetfile.SheetFile{},
+		Fds:           map[uint64]uint64{},
+		nextFd:        0,
+		Sessions:      map[uint64]time.Time{},
+		Owners:        map[uint64]uint64{},
+		nextSession:   NoSession + 1,
+		nextSheetID:   1,
+		dirtyEntries:  map[uint64]bool{},
+		dirtyFds:      map[uint64]bool{},
+		dirtySessions: map[uint64]bool{},
+		dirtyDirs:     map[string]bool{},
+		deletedSheets: map[uint64][]*sheetfile.Chunk{},
+		refs:          sheetfile.NewChunkRefs(nil, nil),
+		lease:         config.SessionLease,
+		db:            metastore.NewSQLite(db),
+		alloc:         alloc,
 	}
 	return fm, db, alloc, nil
 }
 
+func mustLoadSheetFile(db sheetfile.Store, alloc *datanode_alloc.DataNodeAllocator, refs *sheetfile.ChunkRefs, id uint64) *sheetfile.SheetFile {
+	file, err := sheetfile.LoadSheetFile(db, alloc, refs, id)
+	So(err, ShouldBeNil)
+	return file
+}
+
+func mustLoadSheet(fm *FileManager, id uint64) *sheetfile.SheetFile {
+	file, err := fm.loadSheet(id)
+	So(err, ShouldBeNil)
+	return file
+}
+
 func TestFileManager_CreateSheet(t *testing.T) {
 	Convey("Construct test FileManager", t, func() {
 		fm, _, _, err := newTestFileManager()
@@ -54,19 +89,19 @@ func TestFileManager_CreateSheet(t *testing.T) {
 		Convey("Create file", func() {
 			for i := 0; i < 2; i++ {
 				filename := fmt.Sprintf("sheet%d", i)
-				fd, err := fm.CreateSheet(filename)
+				fd, err := fm.CreateSheet(filename, NoSession)
 				So(err, ShouldBeNil)
 				So(fd, ShouldEqual, uint64(i))
 				entry := fm.Entries[filename]
 				So(entry, shouldBeSameEntry, &mgr_entry.MapEntry{
-					FileName:       filename,
-					CellsTableName: sheetfile.GetCellTableName(filename),
-					Recycled:       false,
+					FileName: filename,
+					SheetID:  uint64(i + 1),
+					Recycled: false,
 				})
 			}
 
 			Convey("Create existed file", func() {
-				_, err := fm.CreateSheet("sheet0")
+				_, err := fm.CreateSheet("sheet0", NoSession)
 				So(err, ShouldBeError, file_errors.NewFileExistsError("sheet0"))
 			})
 		})
@@ -77,17 +112,17 @@ func TestFileManager_OpenSheet(t *testing.T) {
 	Convey("Construct test FileManager", t, func() {
 		fm, _, _, err := newTestFileManager()
 		So(err, ShouldBeNil)
-		fd, err := fm.CreateSheet("sheet0")
+		fd, err := fm.CreateSheet("sheet0", NoSession)
 		Convey("Open created file", func() {
-			fd1, err := fm.OpenSheet("sheet0")
+			fd1, err := fm.OpenSheet("sheet0", NoSession)
 			So(err, ShouldBeNil)
 			So(fd1, ShouldEqual, 1)
-			fd2, err := fm.OpenSheet("sheet0")
+			fd2, err := fm.OpenSheet("sheet0", NoSession)
 			So(err, ShouldBeNil)
 			So(fd2, ShouldEqual, 2)
 			So(fm.Fds[fd] == fm.Fds[fd1] && fm.Fds[fd1] == fm.Fds[fd2], ShouldBeTrue)
 			Convey("Open non-existed file", func() {
-				_, err := fm.OpenSheet("non-existed")
+				_, err := fm.OpenSheet("non-existed", NoSession)
 				So(err, ShouldBeError, file_errors.NewFileNotFoundError("non-existed"))
 			})
 		})
@@ -98,16 +133,16 @@ func TestFileManager_Persistent(t *testing.T) {
 	Convey("Construct test FileManager", t, func() {
 		fm, db, alloc, err := newTestFileManager()
 		So(err, ShouldBeNil)
-		_, err = fm.CreateSheet("sheet0")
+		_, err = fm.CreateSheet("sheet0", NoSession)
 		So(err, ShouldBeNil)
-		_, err = fm.CreateSheet("sheet1")
+		_, err = fm.CreateSheet("sheet1", NoSession)
 		So(err, ShouldBeNil)
-		_, err = fm.CreateSheet("sheet2")
+		_, err = fm.CreateSheet("sheet2", NoSession)
 		So(err, ShouldBeNil)
 		Convey("Persist FileManager", func() {
 			// Cells data of a newly created SheetFile is not flushed into sqlite
 			// until FileManager.Persistent() is called.
-			sheet0 := sheetfile.LoadSheetFile(db, alloc, "sheet0")
+			sheet0 := mustLoadSheetFile(fm.db, alloc, fm.refs, fm.Entries["sheet0"].SheetID)
 			So(len(sheet0.Cells), ShouldEqual, 0)
 			err = fm.Persistent()
 			So(err, ShouldBeNil)
@@ -116,27 +151,98 @@ func TestFileManager_Persistent(t *testing.T) {
 			So(len(entries), ShouldEqual, 3)
 			for i := 0; i < 3; i++ {
 				filename := fmt.Sprintf("sheet%d", i)
-				sheet := sheetfile.LoadSheetFile(db, alloc, filename)
+				sheet := mustLoadSheetFile(fm.db, alloc, fm.refs, fm.Entries[filename].SheetID)
 				So(len(sheet.Cells), ShouldEqual, 1)
 			}
 		})
+		Convey("Persist dirty metadata only", func() {
+			So(fm.Persistent(), ShouldBeNil)
+			So(len(fm.dirtyEntries), ShouldEqual, 0)
+			// Rows of clean entries and Cells are not rewritten, so changes made to them
+			// behind FileManager are kept.
+			So(db.Model(&mgr_entry.MapEntry{}).Where("file_name = ?", "sheet0").Update("recycled", true).Error, ShouldBeNil)
+			So(db.Model(&sheetfile.Cell{}).Where("sheet_id = ?", fm.Entries["sheet0"].SheetID).Update("length", 42).Error, ShouldBeNil)
+			fd, err := fm.OpenSheet("sheet1", NoSession)
+			So(err, ShouldBeNil)
+			_, _, _, _, err = fm.WriteFileCell(fd, 0, 1, 1, 100)
+			So(err, ShouldBeNil)
+			So(len(fm.dirtyEntries), ShouldEqual, 1)
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			So(fm.Persistent(), ShouldBeNil)
+			loaded := LoadFileManager(fm.db, alloc, nil, nil)
+			So(loaded.Entries["sheet0"].Recycled, ShouldBeTrue)
+			So(loaded.Entries["sheet1"].ModifiedAt, ShouldHappenAfter, loaded.Entries["sheet2"].ModifiedAt)
+			sheet0 := mustLoadSheetFile(fm.db, alloc, fm.refs, fm.Entries["sheet0"].SheetID)
+			for _, cell := range sheet0.Cells {
+				So(cell.Length, ShouldEqual, 42)
+			}
+			sheet1 := mustLoadSheetFile(fm.db, alloc, fm.refs, fm.Entries["sheet1"].SheetID)
+			So(len(sheet1.Cells), ShouldEqual, 2)
+			Convey("Replayed metadata is dirty", func() {
+				fd, err := fm.OpenSheet("sheet2", NoSession)
+				So(err, ShouldBeNil)
+				cell, chunk, _, _, err := fm.WriteFileCell(fd, 0, 2, 2, 100)
+				So(err, ShouldBeNil)
+				So(secondary.HandleMasterEntry(cellWriteEntry(cell, chunk, nil, time.Now())), ShouldBeNil)
+				// The primary hasn't flushed the Cell, so it's flushed by the secondary.
+				So(secondary.Persistent(), ShouldBeNil)
+				sheet2 := mustLoadSheetFile(fm.db, alloc, fm.refs, cell.SheetID)
+				So(len(sheet2.Cells), ShouldEqual, 2)
+				So(sheet2.Cells[cell.CellID].Length, ShouldEqual, 100)
+			})
+		})
+		Convey("Persist added and removed fds, sessions and directories only", func() {
+			fd0, err := fm.OpenSheet("sheet0", NoSession)
+			So(err, ShouldBeNil)
+			fd1, err := fm.OpenSheet("sheet1", NoSession)
+			So(err, ShouldBeNil)
+			session, _, err := fm.OpenSession()
+			So(err, ShouldBeNil)
+			So(fm.MkDir("dir0"), ShouldBeNil)
+			So(fm.MkDir("dir1"), ShouldBeNil)
+			So(fm.CopySheet("sheet0", "sheet0-copy"), ShouldBeNil)
+			So(fm.Persistent(), ShouldBeNil)
+			var refs []*sheetfile.ChunkRef
+			So(db.Find(&refs).Error, ShouldBeNil)
+			So(len(refs), ShouldEqual, 1)
+			// Rows of unchanged fds are not rewritten.
+			So(db.Model(&mgr_entry.FdEntry{}).Where("fd = ?", fd0).Update("session", 42).Error, ShouldBeNil)
+			So(fm.CloseSheet(fd1), ShouldBeNil)
+			So(fm.CloseSession(session), ShouldBeNil)
+			So(fm.RmDir("dir1", false), ShouldBeNil)
+			So(fm.DeleteSheet("sheet0-copy"), ShouldBeNil)
+			So(len(fm.dirtyFds), ShouldEqual, 1)
+			So(fm.Persistent(), ShouldBeNil)
+			So(len(fm.dirtyFds), ShouldEqual, 0)
+			var fds []*mgr_entry.FdEntry
+			So(db.Where("fd IN ?", []uint64{fd0, fd1}).Find(&fds).Error, ShouldBeNil)
+			So(fds, ShouldResemble, []*mgr_entry.FdEntry{{Fd: fd0, SheetID: fm.Fds[fd0], Session: 42}})
+			var sessions []*mgr_entry.SessionEntry
+			So(db.Find(&sessions).Error, ShouldBeNil)
+			So(sessions, ShouldBeEmpty)
+			var dirs []*mgr_entry.DirEntry
+			So(db.Find(&dirs).Error, ShouldBeNil)
+			So(dirs, ShouldResemble, []*mgr_entry.DirEntry{{Path: "dir0"}})
+			So(db.Find(&refs).Error, ShouldBeNil)
+			So(refs, ShouldBeEmpty)
+		})
 	})
 }
 
 func TestLoadFileManager(t *testing.T) {
 	Convey("Construct test FileManager and persist it", t, func() {
-		fm, db, alloc, err := newTestFileManager()
+		fm, _, alloc, err := newTestFileManager()
 		So(err, ShouldBeNil)
-		_, err = fm.CreateSheet("sheet0")
+		_, err = fm.CreateSheet("sheet0", NoSession)
 		So(err, ShouldBeNil)
-		_, err = fm.CreateSheet("sheet1")
+		_, err = fm.CreateSheet("sheet1", NoSession)
 		So(err, ShouldBeNil)
-		_, err = fm.CreateSheet("sheet2")
+		_, err = fm.CreateSheet("sheet2", NoSession)
 		So(err, ShouldBeNil)
 		err = fm.Persistent()
 		So(err, ShouldBeNil)
 		Convey("Load FileManager", func() {
-			fm = LoadFileManager(db, alloc, nil)
+			fm = LoadFileManager(fm.db, alloc, nil, nil)
 			So(len(fm.Entries), ShouldEqual, 3)
 			for i := 0; i < 3; i++ {
 				filename := fmt.Sprintf("sheet%d", i)
@@ -146,16 +252,552 @@ func TestLoadFileManager(t *testing.T) {
 	})
 }
 
+func TestLoadFileManager_Bolt(t *testing.T) {
+	Convey("Construct test FileManager over bolt and persist it", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		db, err := metastore.OpenBolt(filepath.Join(t.TempDir(), "test.db"))
+		So(err, ShouldBeNil)
+		defer db.Close()
+		fm.db = db
+		So(fm.MkDir("team"), ShouldBeNil)
+		fd, err := fm.CreateSheet("team/sheet0", NoSession)
+		So(err, ShouldBeNil)
+		for i := 0; i < 10; i++ {
+			_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), 0)
+			So(err, ShouldBeNil)
+		}
+		_, err = fm.CreateSheet("sheet1", NoSession)
+		So(err, ShouldBeNil)
+		So(fm.Persistent(), ShouldBeNil)
+		So(db.RecordCheckpoint(42), ShouldBeNil)
+		Convey("Load FileManager", func() {
+			loaded := LoadFileManager(db, alloc, nil, nil)
+			So(len(loaded.Entries), ShouldEqual, 2)
+			So(loaded.Entries["team/sheet0"], shouldBeSameEntry, fm.Entries["team/sheet0"])
+			So(loaded.Dirs, ShouldContainKey, "team")
+			So(loaded.Fds, ShouldResemble, fm.Fds)
+			So(loaded.nextFd, ShouldEqual, fm.nextFd)
+			So(loaded.nextSheetID, ShouldEqual, fm.nextSheetID)
+			cell, _, _, err := loaded.ReadFileCell(fd, 0, 9, 9)
+			So(err, ShouldBeNil)
+			So(cell.CellID, ShouldEqual, sheetfile.GetCellID(0, 9, 9))
+			offset, err := db.ReadCheckpoint()
+			So(err, ShouldBeNil)
+			So(offset, ShouldEqual, 42)
+		})
+		Convey("Delete a sheet and a directory", func() {
+			So(fm.CloseSheet(fd), ShouldBeNil)
+			So(fm.DeleteSheet("team/sheet0"), ShouldBeNil)
+			So(fm.RmDir("team", false), ShouldBeNil)
+			So(fm.Persistent(), ShouldBeNil)
+			loaded := LoadFileManager(db, alloc, nil, nil)
+			So(len(loaded.Entries), ShouldEqual, 1)
+			So(loaded.Dirs, ShouldBeEmpty)
+			So(loaded.Fds, ShouldNotContainKey, fd)
+			So(loaded.Fds, ShouldResemble, fm.Fds)
+		})
+	})
+}
+
+// A JournalWriter keeping entries in memory, offsets of entries are their indexes.
+type testJournal struct {
+	ckptMu  sync.RWMutex
+	mu      sync.Mutex
+	entries [][]byte
+}
+
+func (j *testJournal) CommitEntry(ctx context.Context, entry []byte) error {
+	j.ckptMu.RLock()
+	defer j.ckptMu.RUnlock()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+func (j *testJournal) PrepareCheckpoint() int64 {
+	j.ckptMu.Lock()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return int64(len(j.entries)) - 1
+}
+
+func (j *testJournal) ExitCheckpoint() {
+	j.ckptMu.Unlock()
+}
+
+func (j *testJournal) Checkpoint(ctx context.Context) (int64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return int64(len(j.entries)), nil
+}
+
+// A MetadataStore failing to record checkpoints, even in its transactions.
+type failingCheckpointStore struct {
+	metastore.MetadataStore
+}
+
+var errRecordCheckpoint = errors.New("failed to record checkpoint")
+
+func (s failingCheckpointStore) Transaction(fn func(tx metastore.MetadataStore) error) error {
+	return s.MetadataStore.Transaction(func(tx metastore.MetadataStore) error {
+		return fn(failingCheckpointStore{tx})
+	})
+}
+
+func (s failingCheckpointStore) RecordCheckpoint(offset int64) error {
+	return errRecordCheckpoint
+}
+
+func TestFileManager_DoCheckpoint(t *testing.T) {
+	Convey("Construct test FileManager with a journal", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		db, err := metastore.OpenSQLite(filepath.Join(t.TempDir(), "test.db"))
+		So(err, ShouldBeNil)
+		defer db.Close()
+		fm.db = db
+		journal := &testJournal{}
+		fm.journalWriter = journal
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		Convey("Checkpoint while writing Cells concurrently", func() {
+			var wg sync.WaitGroup
+			stop := make(chan struct{})
+			errs := make(chan error, 8)
+			for col := uint32(0); col < 8; col++ {
+				wg.Add(1)
+				go func(col uint32) {
+					defer wg.Done()
+					// Length of a Cell grows with every write to it, so the persisted Length
+					// tells exactly which writes are covered by the checkpoint.
+					for length := uint64(1); length < config.MinBytesPerCell; length++ {
+						for row := uint32(0); row < 8; row++ {
+							select {
+							case <-stop:
+								return
+							default:
+							}
+							_, _, _, _, err := fm.WriteFileCell(fd, 0, row, col, length)
+							if err != nil {
+								errs <- err
+								return
+							}
+						}
+					}
+				}(col)
+			}
+			for i := 0; i < 10; i++ {
+				fm.DoCheckpoint()
+			}
+			close(stop)
+			wg.Wait()
+			So(errs, ShouldBeEmpty)
+
+			offset, err := db.ReadCheckpoint()
+			So(err, ShouldBeNil)
+			So(offset, ShouldBeGreaterThan, 0)
+			// Replaying entries before the recorded offset from scratch must reproduce
+			// the persisted metadata exactly.
+			replayed, _, _, err := newTestFileManager()
+			So(err, ShouldBeNil)
+			for _, buf := range journal.entries[:offset] {
+				var entry journal_entry.MasterEntry
+				So(proto.Unmarshal(buf, &entry), ShouldBeNil)
+				So(replayed.HandleMasterEntry(&entry), ShouldBeNil)
+			}
+			sheetID := fm.Entries["sheet0"].SheetID
+			expected := replayed.Opened[sheetID]
+			persisted := mustLoadSheetFile(db, alloc, fm.refs, sheetID)
+			So(len(persisted.Cells), ShouldEqual, len(expected.Cells))
+			for id, cell := range expected.Cells {
+				So(persisted.Cells, ShouldContainKey, id)
+				So(persisted.Cells[id].Length, ShouldEqual, cell.Length)
+				So(persisted.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+				So(persisted.Cells[id].Offset, ShouldEqual, cell.Offset)
+				So(persisted.Chunks[cell.ChunkID].Version, ShouldEqual, expected.Chunks[cell.ChunkID].Version)
+			}
+			loaded := LoadFileManager(db, alloc, nil, nil)
+			So(loaded.Entries["sheet0"].ModifiedAt.UnixNano(), ShouldEqual, replayed.Entries["sheet0"].ModifiedAt.UnixNano())
+		})
+		Convey("Fail to record the offset of a checkpoint", func() {
+			for col := uint32(0); col < 4; col++ {
+				_, _, _, _, err := fm.WriteFileCell(fd, 0, 0, col, 0)
+				So(err, ShouldBeNil)
+			}
+			fm.DoCheckpoint()
+			offset, err := db.ReadCheckpoint()
+			So(err, ShouldBeNil)
+			sheetID := fm.Entries["sheet0"].SheetID
+			checkpointed := mustLoadSheetFile(db, alloc, fm.refs, sheetID)
+
+			_, _, err = fm.DeleteFileCell(fd, 0, 0, 1)
+			So(err, ShouldBeNil)
+			for col := uint32(0); col < 4; col++ {
+				_, _, _, _, err := fm.WriteFileCell(fd, 0, 1, col, config.MinBytesPerCell)
+				So(err, ShouldBeNil)
+			}
+			_, err = fm.CreateSheet("sheet1", NoSession)
+			So(err, ShouldBeNil)
+			fm.db = failingCheckpointStore{db}
+			fm.DoCheckpoint()
+
+			// Nothing is flushed without recording the offset.
+			recorded, err := db.ReadCheckpoint()
+			So(err, ShouldBeNil)
+			So(recorded, ShouldEqual, offset)
+			persisted := mustLoadSheetFile(db, alloc, fm.refs, sheetID)
+			So(len(persisted.Cells), ShouldEqual, len(checkpointed.Cells))
+			for id, cell := range checkpointed.Cells {
+				So(persisted.Cells, ShouldContainKey, id)
+				So(persisted.Cells[id].Length, ShouldEqual, cell.Length)
+				So(persisted.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+				So(persisted.Cells[id].Offset, ShouldEqual, cell.Offset)
+			}
+			loaded := LoadFileManager(db, alloc, nil, nil)
+			So(loaded.Entries, ShouldNotContainKey, "sheet1")
+			So(loaded.Fds, ShouldResemble, map[uint64]uint64{fd: sheetID})
+
+			Convey("Flush mutations by the next checkpoint", func() {
+				fm.db = db
+				fm.DoCheckpoint()
+				recorded, err := db.ReadCheckpoint()
+				So(err, ShouldBeNil)
+				So(recorded, ShouldEqual, len(journal.entries))
+				persisted := mustLoadSheetFile(db, alloc, fm.refs, sheetID)
+				So(len(persisted.Cells), ShouldEqual, len(fm.Opened[sheetID].Cells))
+				loaded := LoadFileManager(db, alloc, nil, nil)
+				So(loaded.Entries, ShouldContainKey, "sheet1")
+			})
+		})
+		Convey("Restart from a checkpoint and replay journal after it", func() {
+			So(fm.MkDir("team"), ShouldBeNil)
+			_, err := fm.CreateSheet("team/sheet1", NoSession)
+			So(err, ShouldBeNil)
+			for row := uint32(0); row < 6; row++ {
+				for col := uint32(0); col < 3; col++ {
+					_, _, _, _, err := fm.WriteFileCell(fd, 0, row, col, 0)
+					So(err, ShouldBeNil)
+				}
+			}
+			So(fm.CopySheet("sheet0", "copy0"), ShouldBeNil)
+			fm.DoCheckpoint()
+			offset, err := db.ReadCheckpoint()
+			So(err, ShouldBeNil)
+			So(offset, ShouldEqual, len(journal.entries))
+			sheetID := fm.Entries["sheet0"].SheetID
+			checkpointed := mustLoadSheetFile(db, alloc, fm.refs, sheetID)
+
+			_, _, err = fm.DeleteFileCell(fd, 0, 1, 1)
+			So(err, ShouldBeNil)
+			So(fm.DeleteRows(fd, 0, 2, 1), ShouldBeNil)
+			So(fm.InsertRows(fd, 0, 0, 1), ShouldBeNil)
+			_, _, _, _, err = fm.WriteFileCell(fd, 0, 7, 7, 0)
+			So(err, ShouldBeNil)
+			So(fm.CopySheet("sheet0", "copy1"), ShouldBeNil)
+			So(fm.DeleteSheet("copy0"), ShouldBeNil)
+			So(fm.RmDir("team", true), ShouldBeNil)
+			So(fm.CloseSheet(fd), ShouldBeNil)
+
+			// Nothing is written to the MetadataStore outside checkpoints.
+			persisted := mustLoadSheetFile(db, alloc, fm.refs, sheetID)
+			So(len(persisted.Cells), ShouldEqual, len(checkpointed.Cells))
+			for id, cell := range checkpointed.Cells {
+				So(persisted.Cells, ShouldContainKey, id)
+				So(persisted.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+				So(persisted.Cells[id].Offset, ShouldEqual, cell.Offset)
+			}
+			restarted := LoadFileManager(db, alloc, nil, nil)
+			So(restarted.Entries, ShouldContainKey, "copy0")
+			So(restarted.Entries, ShouldContainKey, "team/sheet1")
+			So(restarted.Dirs, ShouldContainKey, "team")
+
+			for _, buf := range journal.entries[offset:] {
+				var entry journal_entry.MasterEntry
+				So(proto.Unmarshal(buf, &entry), ShouldBeNil)
+				So(restarted.HandleMasterEntry(&entry), ShouldBeNil)
+			}
+			So(len(restarted.Entries), ShouldEqual, len(fm.Entries))
+			So(restarted.Dirs, ShouldBeEmpty)
+			for name, mapEntry := range fm.Entries {
+				So(restarted.Entries, ShouldContainKey, name)
+				So(restarted.Entries[name].SheetID, ShouldEqual, mapEntry.SheetID)
+				expected := mustLoadSheet(fm, mapEntry.SheetID)
+				replayed := mustLoadSheet(restarted, mapEntry.SheetID)
+				So(len(replayed.Cells), ShouldEqual, len(expected.Cells))
+				for id, cell := range expected.Cells {
+					So(replayed.Cells, ShouldContainKey, id)
+					So(replayed.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+					So(replayed.Cells[id].Offset, ShouldEqual, cell.Offset)
+					So(replayed.Chunks[cell.ChunkID].Version, ShouldEqual, expected.Chunks[cell.ChunkID].Version)
+				}
+			}
+
+			// Replayed mutations are persisted by the next checkpoint.
+			restarted.journalWriter = journal
+			restarted.DoCheckpoint()
+			reloaded := LoadFileManager(db, alloc, nil, nil)
+			So(len(reloaded.Entries), ShouldEqual, len(fm.Entries))
+			So(reloaded.Dirs, ShouldBeEmpty)
+			persisted = mustLoadSheetFile(db, alloc, fm.refs, sheetID)
+			So(len(persisted.Cells), ShouldEqual, len(mustLoadSheet(fm, sheetID).Cells))
+		})
+	})
+}
+
+func TestFileManager_CloseSheet(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd0, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		sheetID := fm.Entries["sheet0"].SheetID
+		fd1, err := fm.OpenSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		for i := 0; i < 10; i++ {
+			_, _, _, _, err := fm.WriteFileCell(fd0, 0, uint32(i), uint32(i), 0)
+			So(err, ShouldBeNil)
+		}
+		Convey("Close fds of test file", func() {
+			err := fm.CloseSheet(fd0)
+			So(err, ShouldBeNil)
+			_, ok := fm.Opened[sheetID]
+			So(ok, ShouldBeTrue)
+			_, err = fm.ReadSheet(fd0, 0)
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd0))
+			err = fm.CloseSheet(fd1)
+			So(err, ShouldBeNil)
+			// The file is dirty, it's kept until next checkpoint persists it.
+			_, ok = fm.Opened[sheetID]
+			So(ok, ShouldBeTrue)
+			So(len(mustLoadSheetFile(fm.db, alloc, fm.refs, sheetID).Cells), ShouldEqual, 0)
+			err = fm.Persistent()
+			So(err, ShouldBeNil)
+			fm.EvictClosedSheets()
+			_, ok = fm.Opened[sheetID]
+			So(ok, ShouldBeFalse)
+			So(len(mustLoadSheetFile(fm.db, alloc, fm.refs, sheetID).Cells), ShouldEqual, 11)
+			Convey("Close a closed fd", func() {
+				err := fm.CloseSheet(fd1)
+				So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd1))
+			})
+			Convey("Reopen test file", func() {
+				fd, err := fm.OpenSheet("sheet0", NoSession)
+				So(err, ShouldBeNil)
+				cell, _, _, err := fm.ReadFileCell(fd, 0, 9, 9)
+				So(err, ShouldBeNil)
+				So(cell.CellID, ShouldEqual, sheetfile.GetCellID(0, 9, 9))
+			})
+		})
+		Convey("Evict files not referenced by any fd", func() {
+			delete(fm.Fds, fd0)
+			fm.EvictClosedSheets()
+			_, ok := fm.Opened[sheetID]
+			So(ok, ShouldBeTrue)
+			delete(fm.Fds, fd1)
+			fm.EvictClosedSheets()
+			_, ok = fm.Opened[sheetID]
+			So(ok, ShouldBeTrue)
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			fm.EvictClosedSheets()
+			_, ok = fm.Opened[sheetID]
+			So(ok, ShouldBeFalse)
+			So(len(mustLoadSheetFile(fm.db, alloc, fm.refs, sheetID).Cells), ShouldEqual, 11)
+		})
+	})
+}
+
+func TestFileManager_RecoverFds(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd0, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		sheetID := fm.Entries["sheet0"].SheetID
+		fd1, err := fm.OpenSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		err = fm.CloseSheet(fd0)
+		So(err, ShouldBeNil)
+		Convey("Recover fds from checkpoint", func() {
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			fm = LoadFileManager(fm.db, alloc, nil, nil)
+			So(fm.Fds, ShouldResemble, map[uint64]uint64{fd1: sheetID})
+			_, err = fm.ReadSheet(fd1, 0)
+			So(err, ShouldBeNil)
+			fd, err := fm.OpenSheet("sheet0", NoSession)
+			So(err, ShouldBeNil)
+			So(fd, ShouldEqual, fd1+1)
+		})
+		Convey("Recover fds from journal", func() {
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			err := secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:    journal_entry.FromEmptySheetCell(),
+				XChunk:   journal_entry.FromEmptyChunk(),
+				XFileMap: journal_entry.FromMgrEntry(fm.Entries["sheet0"]),
+				XFd:      journal_entry.FromFd(5, sheetID, NoSession),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			So(err, ShouldBeNil)
+			So(secondary.Fds[5], ShouldEqual, sheetID)
+			err = secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:    journal_entry.FromEmptySheetCell(),
+				XChunk:   journal_entry.FromEmptyChunk(),
+				XFileMap: journal_entry.FromEmptyMgrEntry(),
+				XFd:      journal_entry.FromAbsentFd(5, sheetID, NoSession),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			So(err, ShouldBeNil)
+			_, ok := secondary.Fds[5]
+			So(ok, ShouldBeFalse)
+			fd, err := secondary.OpenSheet("sheet0", NoSession)
+			So(err, ShouldBeNil)
+			So(fd, ShouldEqual, 6)
+		})
+		Convey("Release fds of unknown files by journal", func() {
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			secondary.addFd(7, sheetID+1, NoSession)
+			secondary.addFd(8, sheetID, NoSession)
+			err := secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:    journal_entry.FromEmptySheetCell(),
+				XChunk:   journal_entry.FromEmptyChunk(),
+				XFileMap: journal_entry.FromAbsentMgrEntry(&mgr_entry.MapEntry{FileName: "sheet1", SheetID: sheetID + 1}),
+				XFd:      journal_entry.FromEmptyFd(),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			So(err, ShouldBeNil)
+			_, ok := secondary.Fds[7]
+			So(ok, ShouldBeFalse)
+			So(secondary.Fds[8], ShouldEqual, sheetID)
+		})
+	})
+}
+
+func TestFileManager_Sessions(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		session, lease, err := fm.OpenSession()
+		So(err, ShouldBeNil)
+		So(session, ShouldNotEqual, NoSession)
+		So(lease, ShouldEqual, config.SessionLease)
+		fd0, err := fm.CreateSheet("sheet0", session)
+		So(err, ShouldBeNil)
+		sheetID := fm.Entries["sheet0"].SheetID
+		fd1, err := fm.OpenSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		fd2, err := fm.OpenSheet("sheet0", session)
+		So(err, ShouldBeNil)
+		So(fm.Owners, ShouldResemble, map[uint64]uint64{fd0: session, fd2: session})
+		Convey("Open files with invalid session", func() {
+			_, err := fm.OpenSheet("sheet0", 0xdeadbeef)
+			So(err, ShouldBeError, file_errors.NewSessionNotFoundError(0xdeadbeef))
+			_, err = fm.CreateSheet("sheet1", 0xdeadbeef)
+			So(err, ShouldBeError, file_errors.NewSessionNotFoundError(0xdeadbeef))
+			So(fm.KeepAlive(0xdeadbeef), ShouldBeError, file_errors.NewSessionNotFoundError(0xdeadbeef))
+		})
+		Convey("Release fds when session closed", func() {
+			err := fm.CloseSession(session)
+			So(err, ShouldBeNil)
+			So(fm.Fds, ShouldResemble, map[uint64]uint64{fd1: sheetID})
+			So(len(fm.Owners), ShouldEqual, 0)
+			_, err = fm.OpenSheet("sheet0", session)
+			So(err, ShouldBeError, file_errors.NewSessionNotFoundError(session))
+		})
+		Convey("Release fds when lease expired", func() {
+			So(fm.expireSessions(time.Now()), ShouldEqual, 0)
+			err := fm.KeepAlive(session)
+			So(err, ShouldBeNil)
+			So(fm.expireSessions(time.Now().Add(2*config.SessionLease)), ShouldEqual, 1)
+			_, ok := fm.Sessions[session]
+			So(ok, ShouldBeFalse)
+			_, err = fm.ReadSheet(fd0, 0)
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd0))
+			_, err = fm.ReadSheet(fd1, 0)
+			So(err, ShouldBeNil)
+		})
+		Convey("Evict file when all fds released", func() {
+			// Only clean files are evicted.
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			err = fm.CloseSheet(fd1)
+			So(err, ShouldBeNil)
+			err = fm.CloseSession(session)
+			So(err, ShouldBeNil)
+			_, ok := fm.Opened[sheetID]
+			So(ok, ShouldBeFalse)
+		})
+		Convey("Recover sessions from checkpoint", func() {
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			fm = LoadFileManager(fm.db, alloc, nil, nil)
+			So(fm.Owners, ShouldResemble, map[uint64]uint64{fd0: session, fd2: session})
+			err = fm.KeepAlive(session)
+			So(err, ShouldBeNil)
+			s, _, err := fm.OpenSession()
+			So(err, ShouldBeNil)
+			So(s, ShouldEqual, session+1)
+		})
+		Convey("Recover sessions from journal", func() {
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			err := secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:    journal_entry.FromEmptySheetCell(),
+				XChunk:   journal_entry.FromEmptyChunk(),
+				XFileMap: journal_entry.FromMgrEntry(fm.Entries["sheet0"]),
+				XFd:      journal_entry.FromEmptyFd(),
+				XSession: journal_entry.FromSession(7),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			So(err, ShouldBeNil)
+			err = secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:    journal_entry.FromEmptySheetCell(),
+				XChunk:   journal_entry.FromEmptyChunk(),
+				XFileMap: journal_entry.FromEmptyMgrEntry(),
+				XFd:      journal_entry.FromFd(5, sheetID, 7),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			So(err, ShouldBeNil)
+			So(secondary.Owners[5], ShouldEqual, 7)
+			err = secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:    journal_entry.FromEmptySheetCell(),
+				XChunk:   journal_entry.FromEmptyChunk(),
+				XFileMap: journal_entry.FromEmptyMgrEntry(),
+				XFd:      journal_entry.FromEmptyFd(),
+				XSession: journal_entry.FromAbsentSession(7),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			So(err, ShouldBeNil)
+			_, ok := secondary.Fds[5]
+			So(ok, ShouldBeFalse)
+			s, _, err := secondary.OpenSession()
+			So(err, ShouldBeNil)
+			So(s, ShouldEqual, 8)
+		})
+	})
+}
+
 func TestFileManager_RecycleSheet(t *testing.T) {
 	Convey("Construct test FileManager", t, func() {
 		fm, _, _, err := newTestFileManager()
 		So(err, ShouldBeNil)
-		fd, err := fm.CreateSheet("sheet0")
+		fd, err := fm.CreateSheet("sheet0", NoSession)
 		Convey("Recycle a sheet", func() {
 			fm.RecycleSheet("sheet0")
-			_, err := fm.OpenSheet("sheet0")
+			_, err := fm.OpenSheet("sheet0", NoSession)
 			So(err, ShouldBeError, file_errors.NewFileNotFoundError("sheet0"))
-			_, _, err = fm.WriteFileCell(fd, 0, 0)
+			_, _, _, _, err = fm.WriteFileCell(fd, 0, 0, 0, 0)
 			So(err, ShouldBeNil)
 		})
 	})
@@ -165,21 +807,490 @@ func TestFileManager_ResumeSheet(t *testing.T) {
 	Convey("Construct test FileManager", t, func() {
 		fm, _, _, err := newTestFileManager()
 		So(err, ShouldBeNil)
-		fd, err := fm.CreateSheet("sheet0")
+		fd, err := fm.CreateSheet("sheet0", NoSession)
 		Convey("Recycle a sheet", func() {
 			fm.RecycleSheet("sheet0")
-			_, err := fm.OpenSheet("sheet0")
+			_, err := fm.OpenSheet("sheet0", NoSession)
 			So(err, ShouldBeError, file_errors.NewFileNotFoundError("sheet0"))
-			_, _, err = fm.WriteFileCell(fd, 0, 0)
+			_, _, _, _, err = fm.WriteFileCell(fd, 0, 0, 0, 0)
 			So(err, ShouldBeNil)
 			Convey("Resume a sheet", func() {
 				fm.ResumeSheet("sheet0")
-				fd, err = fm.OpenSheet("sheet0")
+				fd, err = fm.OpenSheet("sheet0", NoSession)
+				So(err, ShouldBeNil)
+				_, _, _, _, err = fm.WriteFileCell(fd, 0, 0, 0, 0)
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestFileManager_RenameSheet(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, db, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		sheetID := fm.Entries["sheet0"].SheetID
+		_, err = fm.CreateSheet("sheet1", NoSession)
+		So(err, ShouldBeNil)
+		for i := 0; i < 10; i++ {
+			_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), 0)
+			So(err, ShouldBeNil)
+		}
+		Convey("Rename a sheet", func() {
+			err := fm.RenameSheet("sheet0", "renamed")
+			So(err, ShouldBeNil)
+			_, ok := fm.Entries["sheet0"]
+			So(ok, ShouldBeFalse)
+			So(fm.Entries["renamed"].SheetID, ShouldEqual, sheetID)
+			_, err = fm.OpenSheet("sheet0", NoSession)
+			So(err, ShouldBeError, file_errors.NewFileNotFoundError("sheet0"))
+			chunks, err := fm.ReadSheet(fd, 0)
+			So(err, ShouldBeNil)
+			So(len(chunks), ShouldEqual, 4)
+			fd1, err := fm.OpenSheet("renamed", NoSession)
+			So(err, ShouldBeNil)
+			So(fm.Fds[fd1], ShouldEqual, sheetID)
+			Convey("Recover renamed sheet from checkpoint", func() {
+				err := fm.Persistent()
+				So(err, ShouldBeNil)
+				var entries []*mgr_entry.MapEntry
+				db.Find(&entries)
+				So(len(entries), ShouldEqual, 2)
+				fm = LoadFileManager(fm.db, alloc, nil, nil)
+				So(fm.Entries["renamed"].SheetID, ShouldEqual, sheetID)
+				cell, _, _, err := fm.ReadFileCell(fd1, 0, 9, 9)
+				So(err, ShouldBeNil)
+				So(cell.CellID, ShouldEqual, sheetfile.GetCellID(0, 9, 9))
+			})
+		})
+		Convey("Rename with invalid filenames", func() {
+			err := fm.RenameSheet("non-existed", "renamed")
+			So(err, ShouldBeError, file_errors.NewFileNotFoundError("non-existed"))
+			err = fm.RenameSheet("sheet0", "sheet1")
+			So(err, ShouldBeError, file_errors.NewFileExistsError("sheet1"))
+		})
+		Convey("Rename a sheet by journal entry", func() {
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			renamed := *fm.Entries["sheet0"]
+			renamed.FileName = "renamed"
+			err = secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:    journal_entry.FromEmptySheetCell(),
+				XChunk:   journal_entry.FromEmptyChunk(),
+				XFileMap: journal_entry.FromMgrEntry(&renamed),
+				XFd:      journal_entry.FromEmptyFd(),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			So(err, ShouldBeNil)
+			_, ok := secondary.Entries["sheet0"]
+			So(ok, ShouldBeFalse)
+			So(secondary.Entries["renamed"].SheetID, ShouldEqual, sheetID)
+		})
+	})
+}
+
+func chunkIDs(chunks []*sheetfile.Chunk) []uint64 {
+	ids := make([]uint64, len(chunks))
+	for i, c := range chunks {
+		ids[i] = c.ID
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func TestFileManager_CopySheet(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, db, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		for i := 0; i < 10; i++ {
+			_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), 0)
+			So(err, ShouldBeNil)
+		}
+		chunks, err := fm.ReadSheet(fd, 0)
+		So(err, ShouldBeNil)
+		Convey("Copy a sheet", func() {
+			err := fm.CopySheet("sheet0", "copy")
+			So(err, ShouldBeNil)
+			So(fm.Entries["copy"].SheetID, ShouldNotEqual, fm.Entries["sheet0"].SheetID)
+			copyFd, err := fm.OpenSheet("copy", NoSession)
+			So(err, ShouldBeNil)
+			copyChunks, err := fm.ReadSheet(copyFd, 0)
+			So(err, ShouldBeNil)
+			So(chunkIDs(copyChunks), ShouldResemble, chunkIDs(chunks))
+			for _, c := range chunks {
+				So(fm.refs.IsShared(c.ID), ShouldBeTrue)
+			}
+			Convey("Write to a shared chunk", func() {
+				_, origChunk, _, err := fm.ReadFileCell(fd, 0, 0, 0)
+				So(err, ShouldBeNil)
+				cell, dataChunk, _, _, err := fm.WriteFileCell(copyFd, 0, 0, 0, 0)
+				So(err, ShouldBeNil)
+				So(dataChunk.ID, ShouldNotEqual, origChunk.ID)
+				So(dataChunk.CopyOf, ShouldEqual, origChunk.ID)
+				So(dataChunk.Version, ShouldEqual, origChunk.Version+1)
+				So(cell.ChunkID, ShouldEqual, dataChunk.ID)
+				So(len(dataChunk.Cells), ShouldEqual, len(origChunk.Cells))
+				So(fm.refs.IsShared(origChunk.ID), ShouldBeFalse)
+				// Cells in the same chunk are moved together.
+				cell, _, _, err = fm.ReadFileCell(copyFd, 0, 1, 1)
+				So(err, ShouldBeNil)
+				So(cell.ChunkID, ShouldEqual, dataChunk.ID)
+				// The original file is not affected.
+				cell, c, _, err := fm.ReadFileCell(fd, 0, 0, 0)
+				So(err, ShouldBeNil)
+				So(cell.ChunkID, ShouldEqual, origChunk.ID)
+				So(c.Version, ShouldEqual, origChunk.Version)
+				_, c, _, _, err = fm.WriteFileCell(fd, 0, 0, 0, 0)
+				So(err, ShouldBeNil)
+				So(c.ID, ShouldEqual, origChunk.ID)
+			})
+			Convey("Add a new cell to a shared chunk", func() {
+				_, origChunk, _, err := fm.ReadFileCell(fd, 0, 9, 9)
+				So(err, ShouldBeNil)
+				cell, dataChunk, _, _, err := fm.WriteFileCell(copyFd, 0, 10, 10, 0)
+				So(err, ShouldBeNil)
+				So(dataChunk.CopyOf, ShouldEqual, origChunk.ID)
+				So(cell.ChunkID, ShouldEqual, dataChunk.ID)
+				So(len(dataChunk.Cells), ShouldEqual, len(origChunk.Cells)+1)
+				_, c, _, err := fm.ReadFileCell(fd, 0, 9, 9)
+				So(err, ShouldBeNil)
+				So(len(c.Cells), ShouldEqual, len(origChunk.Cells))
+			})
+			Convey("Delete the original file", func() {
+				err := fm.DeleteSheet("sheet0")
+				So(err, ShouldBeNil)
+				var count int64
+				db.Model(&sheetfile.Chunk{}).Count(&count)
+				So(count, ShouldEqual, len(chunks))
+				for _, c := range chunks {
+					So(fm.refs.IsShared(c.ID), ShouldBeFalse)
+				}
+				cell, _, _, err := fm.ReadFileCell(copyFd, 0, 9, 9)
+				So(err, ShouldBeNil)
+				So(cell.CellID, ShouldEqual, sheetfile.GetCellID(0, 9, 9))
+			})
+			Convey("Recover shared chunks from checkpoint", func() {
+				err := fm.Persistent()
+				So(err, ShouldBeNil)
+				fm = LoadFileManager(fm.db, alloc, nil, nil)
+				for _, c := range chunks {
+					So(fm.refs.IsShared(c.ID), ShouldBeTrue)
+				}
+				_, dataChunk, _, _, err := fm.WriteFileCell(copyFd, 0, 0, 0, 0)
+				So(err, ShouldBeNil)
+				So(dataChunk.CopyOf, ShouldNotEqual, 0)
+			})
+		})
+		Convey("Copy with invalid filenames", func() {
+			err := fm.CopySheet("non-existed", "copy")
+			So(err, ShouldBeError, file_errors.NewFileNotFoundError("non-existed"))
+			_, err = fm.CreateSheet("sheet1", NoSession)
+			So(err, ShouldBeNil)
+			err = fm.CopySheet("sheet0", "sheet1")
+			So(err, ShouldBeError, file_errors.NewFileExistsError("sheet1"))
+		})
+		Convey("Copy a sheet by journal entry", func() {
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			err = fm.CopySheet("sheet0", "copy")
+			So(err, ShouldBeNil)
+			copyFd, err := fm.OpenSheet("copy", NoSession)
+			So(err, ShouldBeNil)
+			cell, dataChunk, _, _, err := fm.WriteFileCell(copyFd, 0, 0, 0, 0)
+			So(err, ShouldBeNil)
+			entries := []*journal_entry.MasterEntry{
+				{
+					XCell:    journal_entry.FromEmptySheetCell(),
+					XChunk:   journal_entry.FromEmptyChunk(),
+					XFileMap: journal_entry.FromCopiedMgrEntry(fm.Entries["copy"], fm.Entries["sheet0"].SheetID),
+					XFd:      journal_entry.FromEmptyFd(),
+					XSession: journal_entry.FromEmptySession(),
+					XDir:     journal_entry.FromEmptyDir(),
+					XLines:   journal_entry.FromEmptyLines(),
+				},
+				{
+					XCell:    journal_entry.FromSheetCell(cell),
+					XChunk:   journal_entry.FromSheetChunk(dataChunk),
+					XFileMap: journal_entry.FromEmptyMgrEntry(),
+					XFd:      journal_entry.FromEmptyFd(),
+					XSession: journal_entry.FromEmptySession(),
+					XDir:     journal_entry.FromEmptyDir(),
+					XLines:   journal_entry.FromEmptyLines(),
+				},
+			}
+			for _, entry := range entries {
+				err := secondary.HandleMasterEntry(entry)
+				So(err, ShouldBeNil)
+			}
+			copyID := secondary.Entries["copy"].SheetID
+			So(copyID, ShouldEqual, fm.Entries["copy"].SheetID)
+			copied := secondary.Opened[copyID]
+			So(chunkIDs(copied.GetAllChunks()), ShouldResemble, chunkIDs(fm.Opened[copyID].GetAllChunks()))
+			So(copied.Cells[sheetfile.GetCellID(0, 0, 0)].ChunkID, ShouldEqual, dataChunk.ID)
+			So(secondary.refs.IsShared(dataChunk.CopyOf), ShouldBeFalse)
+		})
+		Convey("Refuse to copy a missing sheet by journal entry", func() {
+			So(fm.CopySheet("sheet0", "copy"), ShouldBeNil)
+			entry := &journal_entry.MasterEntry{
+				XCell:    journal_entry.FromEmptySheetCell(),
+				XChunk:   journal_entry.FromEmptyChunk(),
+				XFileMap: journal_entry.FromCopiedMgrEntry(fm.Entries["copy"], fm.nextSheetID),
+				XFd:      journal_entry.FromEmptyFd(),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			}
+			secondary, _, _, err := newTestFileManager()
+			So(err, ShouldBeNil)
+			err = secondary.HandleMasterEntry(entry)
+			So(err, ShouldHaveSameTypeAs, &journal_entry.InvalidJournalEntryError{})
+			So(secondary.Entries, ShouldNotContainKey, "copy")
+		})
+	})
+}
+
+func TestFileManager_Dirs(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		So(fm.MkDir("team"), ShouldBeNil)
+		So(fm.MkDir("team/proj"), ShouldBeNil)
+		fd, err := fm.CreateSheet("team/proj/sheet0", NoSession)
+		So(err, ShouldBeNil)
+		_, err = fm.CreateSheet("sheet1", NoSession)
+		So(err, ShouldBeNil)
+		Convey("Create with invalid paths", func() {
+			So(fm.MkDir("team"), ShouldBeError, file_errors.NewFileExistsError("team"))
+			So(fm.MkDir("team/proj/sheet0"), ShouldBeError, file_errors.NewFileExistsError("team/proj/sheet0"))
+			So(fm.MkDir("x/y"), ShouldBeError, file_errors.NewDirNotFoundError("x"))
+			So(fm.MkDir("/team"), ShouldBeError, file_errors.NewInvalidPathError("/team"))
+			So(fm.MkDir("team/../x"), ShouldBeError, file_errors.NewInvalidPathError("team/../x"))
+			_, err := fm.CreateSheet("x/sheet", NoSession)
+			So(err, ShouldBeError, file_errors.NewDirNotFoundError("x"))
+			_, err = fm.CreateSheet("team", NoSession)
+			So(err, ShouldBeError, file_errors.NewFileExistsError("team"))
+		})
+		Convey("List directories", func() {
+			dirs, sheets, err := fm.ListDir("")
+			So(err, ShouldBeNil)
+			So(dirs, ShouldResemble, []string{"team"})
+			So(len(sheets), ShouldEqual, 1)
+			So(sheets[0].Filename, ShouldEqual, "sheet1")
+			dirs, sheets, err = fm.ListDir("team/proj")
+			So(err, ShouldBeNil)
+			So(dirs, ShouldBeEmpty)
+			So(sheets[0].Filename, ShouldEqual, "team/proj/sheet0")
+			_, _, err = fm.ListDir("x")
+			So(err, ShouldBeError, file_errors.NewDirNotFoundError("x"))
+			all, err := fm.GetAllSheets("team")
+			So(err, ShouldBeNil)
+			So(len(all), ShouldEqual, 1)
+			So(all[0].Filename, ShouldEqual, "team/proj/sheet0")
+			all, err = fm.GetAllSheets("")
+			So(err, ShouldBeNil)
+			So(len(all), ShouldEqual, 2)
+		})
+		Convey("Move a sheet to another directory", func() {
+			err := fm.RenameSheet("team/proj/sheet0", "team/sheet0")
+			So(err, ShouldBeNil)
+			_, sheets, err := fm.ListDir("team")
+			So(err, ShouldBeNil)
+			So(sheets[0].Filename, ShouldEqual, "team/sheet0")
+			_, err = fm.ReadSheet(fd, 0)
+			So(err, ShouldBeNil)
+			err = fm.RenameSheet("team/sheet0", "x/sheet0")
+			So(err, ShouldBeError, file_errors.NewDirNotFoundError("x"))
+		})
+		Convey("Remove directories", func() {
+			err := fm.RmDir("team", false)
+			So(err, ShouldBeError, file_errors.NewDirNotEmptyError("team"))
+			err = fm.RmDir("x", false)
+			So(err, ShouldBeError, file_errors.NewDirNotFoundError("x"))
+			err = fm.RmDir("", true)
+			So(err, ShouldBeError, file_errors.NewInvalidPathError(""))
+			So(fm.MkDir("empty"), ShouldBeNil)
+			So(fm.RmDir("empty", false), ShouldBeNil)
+			err = fm.RmDir("team", true)
+			So(err, ShouldBeNil)
+			So(len(fm.Dirs), ShouldEqual, 0)
+			_, ok := fm.Entries["team/proj/sheet0"]
+			So(ok, ShouldBeFalse)
+			_, err = fm.ReadSheet(fd, 0)
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd))
+			_, ok = fm.Entries["sheet1"]
+			So(ok, ShouldBeTrue)
+		})
+		Convey("Recover directories from checkpoint", func() {
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			fm = LoadFileManager(fm.db, alloc, nil, nil)
+			dirs, sheets, err := fm.ListDir("team")
+			So(err, ShouldBeNil)
+			So(dirs, ShouldResemble, []string{"team/proj"})
+			So(sheets, ShouldBeEmpty)
+		})
+		Convey("Replay directory entries", func() {
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			for _, dirEntry := range []*journal_entry.MasterEntry_Dir{
+				journal_entry.FromDir("other"),
+				journal_entry.FromAbsentDir("team"),
+			} {
+				err := secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+					XCell:    journal_entry.FromEmptySheetCell(),
+					XChunk:   journal_entry.FromEmptyChunk(),
+					XFileMap: journal_entry.FromEmptyMgrEntry(),
+					XFd:      journal_entry.FromEmptyFd(),
+					XSession: journal_entry.FromEmptySession(),
+					XDir:     dirEntry,
+					XLines:   journal_entry.FromEmptyLines(),
+				})
 				So(err, ShouldBeNil)
-				_, _, err = fm.WriteFileCell(fd, 0, 0)
+			}
+			dirs, sheets, err := secondary.ListDir("")
+			So(err, ShouldBeNil)
+			So(dirs, ShouldResemble, []string{"other"})
+			So(len(sheets), ShouldEqual, 1)
+			_, ok := secondary.Entries["team/proj/sheet0"]
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
+
+func TestFileManager_DeleteSheet(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, db, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		sheetID := fm.Entries["sheet0"].SheetID
+		for i := 0; i < 10; i++ {
+			_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), 0)
+			So(err, ShouldBeNil)
+		}
+		err = fm.Persistent()
+		So(err, ShouldBeNil)
+		Convey("Delete a sheet", func() {
+			err := fm.DeleteSheet("sheet0")
+			So(err, ShouldBeNil)
+			_, ok := fm.Entries["sheet0"]
+			So(ok, ShouldBeFalse)
+			_, ok = fm.Opened[sheetID]
+			So(ok, ShouldBeFalse)
+			_, err = fm.ReadSheet(fd, 0)
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd))
+			_, err = fm.OpenSheet("sheet0", NoSession)
+			So(err, ShouldBeError, file_errors.NewFileNotFoundError("sheet0"))
+			// The file is dropped from the MetadataStore by next checkpoint.
+			var entries []*mgr_entry.MapEntry
+			db.Unscoped().Find(&entries)
+			So(len(entries), ShouldEqual, 1)
+			err = fm.Persistent()
+			So(err, ShouldBeNil)
+			db.Unscoped().Find(&entries)
+			So(len(entries), ShouldEqual, 0)
+			var chunks []*sheetfile.Chunk
+			db.Unscoped().Find(&chunks)
+			So(len(chunks), ShouldEqual, 0)
+			So(len(mustLoadSheetFile(fm.db, alloc, fm.refs, sheetID).Cells), ShouldEqual, 0)
+			Convey("Delete non-existed file", func() {
+				err := fm.DeleteSheet("sheet0")
+				So(err, ShouldBeError, file_errors.NewFileNotFoundError("sheet0"))
+			})
+			Convey("Create a file with the name of deleted one", func() {
+				_, err := fm.CreateSheet("sheet0", NoSession)
 				So(err, ShouldBeNil)
 			})
 		})
+		Convey("Delete a sheet by journal entry", func() {
+			var cell *sheetfile.Cell
+			for _, c := range fm.Opened[sheetID].Cells {
+				cell = c
+			}
+			chunk := fm.Opened[sheetID].Chunks[cell.ChunkID]
+			err := fm.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:    journal_entry.FromEmptySheetCell(),
+				XChunk:   journal_entry.FromEmptyChunk(),
+				XFileMap: journal_entry.FromAbsentMgrEntry(fm.Entries["sheet0"]),
+				XFd:      journal_entry.FromEmptyFd(),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			So(err, ShouldBeNil)
+			_, ok := fm.Entries["sheet0"]
+			So(ok, ShouldBeFalse)
+			err = fm.Persistent()
+			So(err, ShouldBeNil)
+			var chunks []*sheetfile.Chunk
+			db.Unscoped().Find(&chunks)
+			So(len(chunks), ShouldEqual, 0)
+			Convey("Entries of a deleted file are invalid", func() {
+				entry := &journal_entry.MasterEntry{
+					XCell:    journal_entry.FromSheetCell(cell),
+					XChunk:   journal_entry.FromSheetChunk(chunk),
+					XFileMap: journal_entry.FromEmptyMgrEntry(),
+					XFd:      journal_entry.FromEmptyFd(),
+					XSession: journal_entry.FromEmptySession(),
+					XDir:     journal_entry.FromEmptyDir(),
+					XLines:   journal_entry.FromEmptyLines(),
+				}
+				err := fm.HandleMasterEntry(entry)
+				So(err, ShouldBeError, journal_entry.NewInvalidJournalEntryError(entry))
+			})
+		})
+	})
+}
+
+func TestFileManager_Monitor(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, _, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		for i := 0; i < 3; i++ {
+			_, err := fm.CreateSheet(fmt.Sprintf("sheet%d", i), NoSession)
+			So(err, ShouldBeNil)
+		}
+		fm.RecycleSheet("sheet0")
+		fm.RecycleSheet("sheet1")
+		fm.Entries["sheet0"].RecycledAt = time.Now().Add(-time.Hour)
+		Convey("Reap expired recycled sheets", func() {
+			deleted := fm.reapRecycledSheets(time.Now().Add(-time.Minute))
+			So(deleted, ShouldEqual, 1)
+			_, ok := fm.Entries["sheet0"]
+			So(ok, ShouldBeFalse)
+			So(fm.Entries["sheet1"].Recycled, ShouldBeTrue)
+			So(fm.Entries["sheet2"].Recycled, ShouldBeFalse)
+		})
+		Convey("Resumed sheets are not reaped", func() {
+			fm.ResumeSheet("sheet0")
+			deleted := fm.reapRecycledSheets(time.Now())
+			So(deleted, ShouldEqual, 1)
+			_, ok := fm.Entries["sheet0"]
+			So(ok, ShouldBeTrue)
+			_, ok = fm.Entries["sheet1"]
+			So(ok, ShouldBeFalse)
+		})
+		Convey("Run Monitor until cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				fm.Monitor(ctx, 10*time.Millisecond, 0)
+				close(done)
+			}()
+			time.Sleep(100 * time.Millisecond)
+			cancel()
+			<-done
+			So(len(fm.Entries), ShouldEqual, 1)
+		})
 	})
 }
 
@@ -190,14 +1301,15 @@ func TestFileManager_GetAllSheets(t *testing.T) {
 		Convey("Create test files", func() {
 			for i := 0; i < 10; i++ {
 				filename := fmt.Sprintf("sheet%d", i)
-				_, err := fm.CreateSheet(filename)
+				_, err := fm.CreateSheet(filename, NoSession)
 				So(err, ShouldBeNil)
 				if i%2 == 0 {
 					fm.RecycleSheet(filename)
 				}
 			}
 			Convey("List test files", func() {
-				sheets := fm.GetAllSheets()
+				sheets, err := fm.GetAllSheets("")
+				So(err, ShouldBeNil)
 				sort.Slice(sheets, func(i, j int) bool {
 					return sheets[i].Filename < sheets[j].Filename
 				})
@@ -211,24 +1323,677 @@ func TestFileManager_GetAllSheets(t *testing.T) {
 	})
 }
 
+func TestFileManager_ListSheets(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, _, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		So(fm.MkDir("dir"), ShouldBeNil)
+		var fds []uint64
+		for i := 0; i < 10; i++ {
+			filename := fmt.Sprintf("sheet%d", i)
+			fd, err := fm.CreateSheet(filename, NoSession)
+			So(err, ShouldBeNil)
+			fds = append(fds, fd)
+			if i%2 == 0 {
+				So(fm.RecycleSheet(filename), ShouldBeNil)
+			}
+		}
+		_, err = fm.CreateSheet("dir/sheet", NoSession)
+		So(err, ShouldBeNil)
+		listAll := func(req *fs_rpc.ListSheetsRequest) []string {
+			var names []string
+			for {
+				sheets, next, err := fm.ListSheets(req)
+				So(err, ShouldBeNil)
+				So(len(sheets), ShouldBeLessThanOrEqualTo, req.PageSize)
+				for _, sheet := range sheets {
+					names = append(names, sheet.Filename)
+				}
+				if next == "" {
+					return names
+				}
+				req.PageToken = next
+			}
+		}
+		Convey("List page by page", func() {
+			names := listAll(&fs_rpc.ListSheetsRequest{PageSize: 3})
+			So(len(names), ShouldEqual, 11)
+			So(sort.StringsAreSorted(names), ShouldBeTrue)
+			sheets, next, err := fm.ListSheets(&fs_rpc.ListSheetsRequest{PageSize: 11})
+			So(err, ShouldBeNil)
+			So(len(sheets), ShouldEqual, 11)
+			So(next, ShouldBeEmpty)
+		})
+		Convey("List with filters", func() {
+			names := listAll(&fs_rpc.ListSheetsRequest{PageSize: 2, Prefix: "sheet", Recycled: fs_rpc.RecycledFilter_NOT_RECYCLED})
+			So(names, ShouldResemble, []string{"sheet1", "sheet3", "sheet5", "sheet7", "sheet9"})
+			names = listAll(&fs_rpc.ListSheetsRequest{PageSize: 2, Recycled: fs_rpc.RecycledFilter_RECYCLED_ONLY})
+			So(names, ShouldResemble, []string{"sheet0", "sheet2", "sheet4", "sheet6", "sheet8"})
+			names = listAll(&fs_rpc.ListSheetsRequest{PageSize: 2, Dir: "dir"})
+			So(names, ShouldResemble, []string{"dir/sheet"})
+			_, _, err := fm.ListSheets(&fs_rpc.ListSheetsRequest{Dir: "non-exist"})
+			So(err, ShouldBeError, file_errors.NewDirNotFoundError("non-exist"))
+		})
+		Convey("List by modified time", func() {
+			_, _, _, _, err := fm.WriteFileCell(fds[1], 0, 0, 0, 0)
+			So(err, ShouldBeNil)
+			names := listAll(&fs_rpc.ListSheetsRequest{PageSize: 4, Order: fs_rpc.SheetOrder_BY_MODIFIED})
+			So(len(names), ShouldEqual, 11)
+			So(names[10], ShouldEqual, "sheet1")
+		})
+		Convey("Sheets created between pages are not listed twice", func() {
+			sheets, next, err := fm.ListSheets(&fs_rpc.ListSheetsRequest{PageSize: 5})
+			So(err, ShouldBeNil)
+			So(sheets[4].Filename, ShouldEqual, "sheet3")
+			_, err = fm.CreateSheet("a", NoSession)
+			So(err, ShouldBeNil)
+			So(fm.DeleteSheet("sheet4"), ShouldBeNil)
+			sheets, _, err = fm.ListSheets(&fs_rpc.ListSheetsRequest{PageSize: 5, PageToken: next})
+			So(err, ShouldBeNil)
+			So(sheets[0].Filename, ShouldEqual, "sheet5")
+		})
+		Convey("List with invalid page tokens", func() {
+			_, next, err := fm.ListSheets(&fs_rpc.ListSheetsRequest{PageSize: 5})
+			So(err, ShouldBeNil)
+			_, _, err = fm.ListSheets(&fs_rpc.ListSheetsRequest{PageToken: next, Order: fs_rpc.SheetOrder_BY_MODIFIED})
+			So(err, ShouldBeError, file_errors.NewInvalidPageTokenError(next))
+			_, _, err = fm.ListSheets(&fs_rpc.ListSheetsRequest{PageToken: "!"})
+			So(err, ShouldBeError, file_errors.NewInvalidPageTokenError("!"))
+		})
+	})
+}
+
 func TestFileManager_WriteFileCell(t *testing.T) {
 	Convey("Construct test FileManager", t, func() {
 		fm, _, _, err := newTestFileManager()
 		So(err, ShouldBeNil)
-		fd, err := fm.CreateSheet("sheet0")
+		fd, err := fm.CreateSheet("sheet0", NoSession)
 		So(err, ShouldBeNil)
 		Convey("Write to test file", func() {
 			for i := 0; i < 10; i++ {
-				_, _, err := fm.WriteFileCell(fd, uint32(i), uint32(i))
+				_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), 0)
 				So(err, ShouldBeNil)
 			}
 			Convey("assert test file", func() {
-				sheet := fm.Opened["sheet0"]
+				sheet := fm.Opened[fm.Entries["sheet0"].SheetID]
 				So(len(sheet.Cells), ShouldEqual, 11)
 				So(len(sheet.Chunks), ShouldEqual, 4)
-				So(sheet.LastAvailableChunk.ID, ShouldEqual, 4)
+				So(sheet.LastAvailableChunks[config.DefaultBytesPerCell].ID, ShouldEqual, 4)
 			})
 		})
+		Convey("Write a large cell and replay it", func() {
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			secondary := LoadFileManager(fm.db, fm.alloc, nil, nil)
+			size := config.DefaultBytesPerCell + 2*config.BytesPerChunk
+			cell, chunk, overflow, _, err := fm.WriteFileCell(fd, 0, 1, 1, size)
+			So(err, ShouldBeNil)
+			So(len(overflow), ShouldEqual, 2)
+			So(secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:    journal_entry.FromSheetCell(cell),
+				XChunk:   journal_entry.FromSheetChunk(chunk),
+				XFileMap: journal_entry.FromEmptyMgrEntry(),
+				XFd:      journal_entry.FromEmptyFd(),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+				Overflow: journal_entry.FromOverflowChunks(overflow),
+			}), ShouldBeNil)
+			replayed := secondary.Opened[secondary.Entries["sheet0"].SheetID]
+			_, _, replayedOverflow, err := replayed.GetCellChunk(0, 1, 1)
+			So(err, ShouldBeNil)
+			So(len(replayedOverflow), ShouldEqual, 2)
+			for i, c := range replayedOverflow {
+				So(c.ID, ShouldEqual, overflow[i].ID)
+				So(c.Version, ShouldEqual, overflow[i].Version)
+				So(c.Overflow, ShouldBeTrue)
+			}
+			_, _, _, _, err = fm.WriteFileCell(fd, 0, 2, 2, size+config.MaxOverflowChunks*config.BytesPerChunk)
+			So(err, ShouldHaveSameTypeAs, &file_errors.CellTooLargeError{})
+		})
+		Convey("Move a cell and replay it", func() {
+			for i := uint32(0); i < 2; i++ {
+				_, _, _, _, err := fm.WriteFileCell(fd, 0, i, i, 100)
+				So(err, ShouldBeNil)
+			}
+			err := fm.Persistent()
+			So(err, ShouldBeNil)
+			secondary := LoadFileManager(fm.db, fm.alloc, nil, nil)
+			cell, chunk, _, moved, err := fm.WriteFileCell(fd, 0, 0, 0, 1000)
+			So(err, ShouldBeNil)
+			So(moved, ShouldNotBeNil)
+			So(moved.Chunk, ShouldNotBeNil)
+			entries := []*journal_entry.MasterEntry{
+				cellRemovalEntry(moved.Cell, moved.Chunk, time.Now()),
+				{
+					XCell:    journal_entry.FromSheetCell(cell),
+					XChunk:   journal_entry.FromSheetChunk(chunk),
+					XFileMap: journal_entry.FromEmptyMgrEntry(),
+					XFd:      journal_entry.FromEmptyFd(),
+					XSession: journal_entry.FromEmptySession(),
+					XDir:     journal_entry.FromEmptyDir(),
+					XLines:   journal_entry.FromEmptyLines(),
+				},
+			}
+			for _, entry := range entries {
+				So(secondary.HandleMasterEntry(entry), ShouldBeNil)
+			}
+			replayed := secondary.Opened[secondary.Entries["sheet0"].SheetID]
+			replayedCell, replayedChunk, _, err := replayed.GetCellChunk(0, 0, 0)
+			So(err, ShouldBeNil)
+			So(replayedCell.Size, ShouldEqual, 1024)
+			So(replayedCell.Length, ShouldEqual, 1000)
+			So(replayedChunk.ID, ShouldEqual, chunk.ID)
+			So(len(replayed.Chunks[moved.Chunk.ID].Cells), ShouldEqual, 1)
+			So(replayed.Chunks[moved.Chunk.ID].Version, ShouldEqual, moved.Chunk.Version)
+			So(replayed.FreeSlots, ShouldContain, sheetfile.Slot{ChunkID: moved.Chunk.ID, Offset: moved.Cell.Offset})
+		})
+	})
+}
+
+func TestFileManager_StatSheet(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		Convey("Stat a new file", func() {
+			stat, err := fm.StatSheet("sheet0")
+			So(err, ShouldBeNil)
+			So(stat.Filename, ShouldEqual, "sheet0")
+			So(stat.Cells, ShouldEqual, 1)
+			So(stat.Chunks, ShouldEqual, 1)
+			So(stat.AllocatedBytes, ShouldEqual, config.BytesPerChunk)
+			So(stat.UsedBytes, ShouldEqual, 0)
+			So(stat.Datanodes, ShouldResemble, []string{"node1"})
+			So(stat.OpenFds, ShouldEqual, 1)
+			So(stat.CreatedAt, ShouldBeGreaterThan, 0)
+			So(stat.ModifiedAt, ShouldEqual, stat.CreatedAt)
+			So(stat.Recycled, ShouldBeFalse)
+			_, err = fm.StatSheet("non-exist")
+			So(err, ShouldBeError, file_errors.NewFileNotFoundError("non-exist"))
+			_, err = fm.StatSheetByFd(fd + 1)
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd+1))
+			// A fd pointing to a file which doesn't exist.
+			fm.addFd(fd+1, fm.Entries["sheet0"].SheetID+1, NoSession)
+			_, err = fm.StatSheetByFd(fd + 1)
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd+1))
+			_, err = fm.ReadSheet(fd+1, 0)
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd+1))
+		})
+		Convey("Stat a written file", func() {
+			before, err := fm.StatSheetByFd(fd)
+			So(err, ShouldBeNil)
+			for i := 0; i < 10; i++ {
+				_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), config.DefaultBytesPerCell)
+				So(err, ShouldBeNil)
+			}
+			_, err = fm.OpenSheet("sheet0", NoSession)
+			So(err, ShouldBeNil)
+			stat, err := fm.StatSheetByFd(fd)
+			So(err, ShouldBeNil)
+			So(stat.Cells, ShouldEqual, 11)
+			So(stat.Chunks, ShouldEqual, 4)
+			So(stat.AllocatedBytes, ShouldEqual, 4*config.BytesPerChunk)
+			So(stat.UsedBytes, ShouldEqual, 10*config.DefaultBytesPerCell)
+			So(stat.OpenFds, ShouldEqual, 2)
+			So(stat.CreatedAt, ShouldEqual, before.CreatedAt)
+			So(stat.ModifiedAt, ShouldBeGreaterThan, before.ModifiedAt)
+		})
+		Convey("Stat a recycled file", func() {
+			So(fm.RecycleSheet("sheet0"), ShouldBeNil)
+			stat, err := fm.StatSheet("sheet0")
+			So(err, ShouldBeNil)
+			So(stat.Recycled, ShouldBeTrue)
+			So(stat.RecycledAt, ShouldBeGreaterThan, 0)
+		})
+		Convey("Recover times from checkpoint and journal", func() {
+			_, _, _, _, err := fm.WriteFileCell(fd, 0, 0, 0, 0)
+			So(err, ShouldBeNil)
+			before, err := fm.StatSheet("sheet0")
+			So(err, ShouldBeNil)
+			err = fm.Persistent()
+			So(err, ShouldBeNil)
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			stat, err := secondary.StatSheet("sheet0")
+			So(err, ShouldBeNil)
+			So(stat.CreatedAt, ShouldEqual, before.CreatedAt)
+			So(stat.ModifiedAt, ShouldEqual, before.ModifiedAt)
+			cell, chunk, _, _, err := fm.WriteFileCell(fd, 0, 1, 1, 0)
+			So(err, ShouldBeNil)
+			modified := before.ModifiedAt + int64(time.Second)
+			err = secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:     journal_entry.FromSheetCell(cell),
+				XChunk:    journal_entry.FromSheetChunk(chunk),
+				XFileMap:  journal_entry.FromEmptyMgrEntry(),
+				XFd:       journal_entry.FromEmptyFd(),
+				XSession:  journal_entry.FromEmptySession(),
+				XDir:      journal_entry.FromEmptyDir(),
+				XLines:    journal_entry.FromEmptyLines(),
+				Timestamp: modified,
+			})
+			So(err, ShouldBeNil)
+			stat, err = secondary.StatSheet("sheet0")
+			So(err, ShouldBeNil)
+			So(stat.ModifiedAt, ShouldEqual, modified)
+		})
+	})
+}
+
+func TestFileManager_DeleteFileCell(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		for i := 0; i < 5; i++ {
+			_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), 0)
+			So(err, ShouldBeNil)
+		}
+		err = fm.Persistent()
+		So(err, ShouldBeNil)
+		secondary := LoadFileManager(fm.db, alloc, nil, nil)
+		Convey("Delete cells and replay deletions", func() {
+			var entries []*journal_entry.MasterEntry
+			cell, chunk, err := fm.DeleteFileCell(fd, 0, 1, 1)
+			So(err, ShouldBeNil)
+			So(chunk, ShouldNotBeNil)
+			freed := sheetfile.Slot{ChunkID: chunk.ID, Offset: cell.Offset}
+			entries = append(entries, &journal_entry.MasterEntry{
+				XCell:    journal_entry.FromAbsentSheetCell(cell),
+				XChunk:   journal_entry.FromSheetChunk(chunk),
+				XFileMap: journal_entry.FromEmptyMgrEntry(),
+				XFd:      journal_entry.FromEmptyFd(),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			cell, chunk, err = fm.DeleteFileCell(fd, 0, 4, 4)
+			So(err, ShouldBeNil)
+			So(chunk, ShouldBeNil)
+			entries = append(entries, &journal_entry.MasterEntry{
+				XCell:    journal_entry.FromAbsentSheetCell(cell),
+				XChunk:   journal_entry.FromAbsentSheetChunk(cell.ChunkID),
+				XFileMap: journal_entry.FromEmptyMgrEntry(),
+				XFd:      journal_entry.FromEmptyFd(),
+				XSession: journal_entry.FromEmptySession(),
+				XDir:     journal_entry.FromEmptyDir(),
+				XLines:   journal_entry.FromEmptyLines(),
+			})
+			sheet := fm.Opened[fm.Entries["sheet0"].SheetID]
+			So(len(sheet.Cells), ShouldEqual, 4)
+			So(len(sheet.Chunks), ShouldEqual, 2)
+			_, _, _, err = fm.ReadFileCell(fd, 0, 1, 1)
+			So(err, ShouldBeError, file_errors.NewCellNotFoundError(1, 1))
+
+			for _, entry := range entries {
+				So(secondary.HandleMasterEntry(entry), ShouldBeNil)
+			}
+			replayed := secondary.Opened[secondary.Entries["sheet0"].SheetID]
+			So(len(replayed.Cells), ShouldEqual, 4)
+			So(len(replayed.Chunks), ShouldEqual, 2)
+			So(replayed.FreeSlots, ShouldContain, freed)
+
+			cell, chunk, _, _, err = fm.WriteFileCell(fd, 0, 10, 10, 0)
+			So(err, ShouldBeNil)
+			So(sheetfile.Slot{ChunkID: chunk.ID, Offset: cell.Offset}, ShouldResemble, freed)
+		})
+		Convey("Delete invalid cells", func() {
+			_, _, err := fm.DeleteFileCell(fd+1, 0, 1, 1)
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd+1))
+			_, _, err = fm.DeleteFileCell(fd, 0, 10, 10)
+			So(err, ShouldBeError, file_errors.NewCellNotFoundError(10, 10))
+		})
+	})
+}
+
+func TestFileManager_Lines(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		for i := 0; i < 4; i++ {
+			_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), 0)
+			So(err, ShouldBeNil)
+		}
+		err = fm.Persistent()
+		So(err, ShouldBeNil)
+		secondary := LoadFileManager(fm.db, alloc, nil, nil)
+		sheetID := fm.Entries["sheet0"].SheetID
+		journal := &testJournal{}
+		fm.journalWriter = journal
+		Convey("Insert and delete lines and replay them", func() {
+			modified := fm.Entries["sheet0"].ModifiedAt
+			So(fm.InsertRows(fd, 0, 1, 2), ShouldBeNil)
+			So(fm.Entries["sheet0"].ModifiedAt.After(modified), ShouldBeTrue)
+			So(fm.DeleteColumns(fd, 0, 0, 1), ShouldBeNil)
+			So(fm.InsertColumns(fd, 0, 2, 1), ShouldBeNil)
+			So(fm.DeleteRows(fd, 0, 5, 1), ShouldBeNil)
+			sheet := fm.Opened[sheetID]
+			So(len(sheet.Cells), ShouldEqual, 3)
+			for _, id := range []int64{sheetfile.GetCellID(0, 3, 0), sheetfile.GetCellID(0, 4, 1), config.SheetMetaCellID} {
+				So(sheet.Cells, ShouldContainKey, id)
+			}
+
+			So(len(journal.entries), ShouldEqual, 4)
+			for _, buf := range journal.entries {
+				var entry journal_entry.MasterEntry
+				So(proto.Unmarshal(buf, &entry), ShouldBeNil)
+				So(secondary.HandleMasterEntry(&entry), ShouldBeNil)
+			}
+			replayed := secondary.Opened[sheetID]
+			So(len(replayed.Cells), ShouldEqual, len(sheet.Cells))
+			for id, cell := range sheet.Cells {
+				So(replayed.Cells, ShouldContainKey, id)
+				So(replayed.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+				So(replayed.Cells[id].Offset, ShouldEqual, cell.Offset)
+			}
+			So(secondary.Entries["sheet0"].ModifiedAt.Equal(fm.Entries["sheet0"].ModifiedAt), ShouldBeTrue)
+		})
+		Convey("Replay lines on Cells persisted after them", func() {
+			So(fm.InsertRows(fd, 0, 0, 1), ShouldBeNil)
+			So(fm.DeleteRows(fd, 0, 2, 1), ShouldBeNil)
+			So(fm.CloseSheet(fd), ShouldBeNil)
+			// Persist the file as if it's flushed without a checkpoint, so shifted CellIDs
+			// are persisted but the journal is replayed from the beginning.
+			So(fm.Persistent(), ShouldBeNil)
+			fm.EvictClosedSheets()
+			So(fm.Opened, ShouldNotContainKey, sheetID)
+			restarted := LoadFileManager(fm.db, alloc, nil, nil)
+			for _, buf := range journal.entries {
+				var entry journal_entry.MasterEntry
+				So(proto.Unmarshal(buf, &entry), ShouldBeNil)
+				So(restarted.HandleMasterEntry(&entry), ShouldBeNil)
+			}
+			replayed := mustLoadSheet(restarted, sheetID)
+			So(len(replayed.Cells), ShouldEqual, 4)
+			for _, id := range []int64{sheetfile.GetCellID(0, 1, 0), sheetfile.GetCellID(0, 2, 2), sheetfile.GetCellID(0, 3, 3), config.SheetMetaCellID} {
+				So(replayed.Cells, ShouldContainKey, id)
+			}
+		})
+		Convey("Insert and delete invalid lines", func() {
+			So(fm.InsertRows(fd+1, 0, 0, 1), ShouldBeError, file_errors.NewFdNotFoundError(fd+1))
+			So(fm.DeleteColumns(fd+1, 0, 0, 1), ShouldBeError, file_errors.NewFdNotFoundError(fd+1))
+			So(fm.InsertColumns(fd, 0, 0, 0), ShouldHaveSameTypeAs, &file_errors.InvalidLinesError{})
+			So(fm.DeleteRows(fd, 0, 0, 0), ShouldHaveSameTypeAs, &file_errors.InvalidLinesError{})
+		})
+	})
+}
+
+// A MetadataStore failing to save Chunks.
+type failingChunkStore struct {
+	metastore.MetadataStore
+}
+
+var errSaveChunk = errors.New("failed to save chunk")
+
+func (s failingChunkStore) SaveChunk(chunk *sheetfile.Chunk) error {
+	return errSaveChunk
+}
+
+func TestFileManager_WriteFileCells(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		_, _, _, _, err = fm.WriteFileCell(fd, 0, 0, 0, 0)
+		So(err, ShouldBeNil)
+		err = fm.Persistent()
+		So(err, ShouldBeNil)
+		secondary := LoadFileManager(fm.db, alloc, nil, nil)
+		sheetID := fm.Entries["sheet0"].SheetID
+		Convey("Write cells in a batch and replay it", func() {
+			modified := fm.Entries["sheet0"].ModifiedAt
+			results, err := fm.WriteFileCells(fd, []sheetfile.CellWrite{{Row: 0, Col: 0, Size: 3000}, {Row: 0, Col: 1, Size: 0}, {Row: 1, Col: 0, Size: 0}})
+			So(err, ShouldBeNil)
+			So(len(results), ShouldEqual, 3)
+			So(results[0].Moved, ShouldNotBeNil)
+			So(fm.Entries["sheet0"].ModifiedAt.After(modified), ShouldBeTrue)
+
+			now := time.Now()
+			var entries []*journal_entry.MasterEntry
+			for _, r := range results {
+				if r.Moved != nil {
+					entries = append(entries, cellRemovalEntry(r.Moved.Cell, r.Moved.Chunk, now))
+				}
+				entries = append(entries, cellWriteEntry(r.Cell, r.Chunk, r.Overflow, now))
+			}
+			err = secondary.HandleMasterEntry(&journal_entry.MasterEntry{
+				XCell:     journal_entry.FromEmptySheetCell(),
+				XChunk:    journal_entry.FromEmptyChunk(),
+				XFileMap:  journal_entry.FromEmptyMgrEntry(),
+				XFd:       journal_entry.FromEmptyFd(),
+				XSession:  journal_entry.FromEmptySession(),
+				XDir:      journal_entry.FromEmptyDir(),
+				XLines:    journal_entry.FromEmptyLines(),
+				Timestamp: now.UnixNano(),
+				Batch:     entries,
+			})
+			So(err, ShouldBeNil)
+			sheet, replayed := fm.Opened[sheetID], secondary.Opened[sheetID]
+			So(len(replayed.Cells), ShouldEqual, len(sheet.Cells))
+			for id, cell := range sheet.Cells {
+				So(replayed.Cells, ShouldContainKey, id)
+				So(replayed.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+				So(replayed.Cells[id].Offset, ShouldEqual, cell.Offset)
+				So(replayed.Chunks[cell.ChunkID].Version, ShouldEqual, sheet.Chunks[cell.ChunkID].Version)
+				So(replayed.Chunks[cell.ChunkID].Versions, ShouldResemble, sheet.Chunks[cell.ChunkID].Versions)
+			}
+			So(secondary.Entries["sheet0"].ModifiedAt.Equal(now), ShouldBeTrue)
+		})
+		Convey("Fail in the middle of a batch", func() {
+			journal := &testJournal{}
+			fm.journalWriter = journal
+			sheet := fm.Opened[sheetID]
+			cell := sheet.Cells[sheetfile.GetCellID(0, 0, 0)].Snapshot()
+			version := sheet.Chunks[cell.ChunkID].SlotVersion(cell.Offset)
+			fm.db = failingChunkStore{fm.db}
+			// The second write needs a new Chunk, which can't be saved.
+			results, err := fm.WriteFileCells(fd, []sheetfile.CellWrite{{Row: 0, Col: 0, Size: 0}, {Row: 0, Col: 1, Size: 3000}})
+			So(err, ShouldBeError, errSaveChunk)
+			// The performed write is returned and journaled, so it can be finished.
+			So(len(results), ShouldEqual, 1)
+			So(results[0].Cell.CellID, ShouldEqual, cell.CellID)
+			So(results[0].Chunk.SlotVersion(cell.Offset), ShouldEqual, version+1)
+			So(len(journal.entries), ShouldEqual, 1)
+		})
+		Convey("Write invalid batches", func() {
+			_, err := fm.WriteFileCells(fd+1, []sheetfile.CellWrite{{Row: 0, Col: 0, Size: 0}})
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd+1))
+			_, err = fm.WriteFileCells(fd, []sheetfile.CellWrite{{Row: 0, Col: 1, Size: 0}, {Row: 0, Col: 1, Size: 0}})
+			So(err, ShouldBeError, file_errors.NewDuplicateCellError(0, 1))
+		})
+	})
+}
+
+func TestFileManager_Compact(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		// Leave a Cell in each of 4 Chunks, which are allocated from 2 DataNodes.
+		for i := uint32(0); i < 16; i++ {
+			_, _, _, _, err := fm.WriteFileCell(fd, 0, i, i, 0)
+			So(err, ShouldBeNil)
+		}
+		for i := uint32(0); i < 16; i++ {
+			if i%4 != 3 {
+				_, _, err := fm.DeleteFileCell(fd, 0, i, i)
+				So(err, ShouldBeNil)
+			}
+		}
+		err = fm.Persistent()
+		So(err, ShouldBeNil)
+		sheetID := fm.Entries["sheet0"].SheetID
+		Convey("Compact a file and replay it", func() {
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			// Dropped Chunks are deleted from the shared sqlite by the primary.
+			replayed := mustLoadSheet(secondary, sheetID)
+			modified := secondary.Entries["sheet0"].ModifiedAt
+			sheet := fm.Opened[sheetID]
+			moves, dropped, err := sheet.Compact(nil)
+			So(err, ShouldBeNil)
+			So(len(moves), ShouldEqual, 2)
+			So(len(dropped), ShouldEqual, 2)
+			So(len(sheet.Chunks), ShouldEqual, 3)
+
+			err = secondary.HandleMasterEntry(compactionEntry(moves))
+			So(err, ShouldBeNil)
+			So(len(replayed.Chunks), ShouldEqual, len(sheet.Chunks))
+			So(len(replayed.Cells), ShouldEqual, len(sheet.Cells))
+			for id, cell := range sheet.Cells {
+				So(replayed.Cells, ShouldContainKey, id)
+				So(replayed.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+				So(replayed.Cells[id].Offset, ShouldEqual, cell.Offset)
+				So(replayed.Chunks[cell.ChunkID].Versions, ShouldResemble, sheet.Chunks[cell.ChunkID].Versions)
+				So(len(replayed.Chunks[cell.ChunkID].Cells), ShouldEqual, len(sheet.Chunks[cell.ChunkID].Cells))
+			}
+			So(secondary.Entries["sheet0"].ModifiedAt, ShouldResemble, modified)
+		})
+		Convey("Write a file while copying slots", func() {
+			journal := &testJournal{}
+			fm.journalWriter = journal
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			replayed := mustLoadSheet(secondary, sheetID)
+			sheet := fm.Opened[sheetID]
+			plan := sheet.PlanCompaction()
+			written := false
+			err := plan.Copy(func(src *sheetfile.Chunk, srcOffset uint64, dst *sheetfile.Chunk, dstOffset uint64, size uint64) error {
+				if !written {
+					written = true
+					// Neither the file nor the FileManager is locked while copying.
+					_, _, _, _, err := fm.WriteFileCell(fd, 0, 15, 15, 0)
+					So(err, ShouldBeNil)
+					fm.DoCheckpoint()
+				}
+				return nil
+			})
+			So(err, ShouldBeNil)
+			moved, err := fm.commitCompaction(sheet, plan, nil)
+			So(err, ShouldBeNil)
+			So(moved, ShouldEqual, 1)
+
+			for _, buf := range journal.entries {
+				entry := &journal_entry.MasterEntry{}
+				So(proto.Unmarshal(buf, entry), ShouldBeNil)
+				So(secondary.HandleMasterEntry(entry), ShouldBeNil)
+			}
+			So(len(replayed.Cells), ShouldEqual, len(sheet.Cells))
+			for id, cell := range sheet.Cells {
+				So(replayed.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+				So(replayed.Cells[id].Offset, ShouldEqual, cell.Offset)
+				So(replayed.Chunks[cell.ChunkID].Versions, ShouldResemble, sheet.Chunks[cell.ChunkID].Versions)
+			}
+		})
+		Convey("Compact closed files in turn", func() {
+			fd1, err := fm.CreateSheet("sheet1", NoSession)
+			So(err, ShouldBeNil)
+			So(fm.CloseSheet(fd), ShouldBeNil)
+			So(fm.CloseSheet(fd1), ShouldBeNil)
+			So(fm.Persistent(), ShouldBeNil)
+			fm.EvictClosedSheets()
+			So(fm.Opened, ShouldNotContainKey, sheetID)
+			// sheet1 has nothing to compact
+			So(fm.compactSheets(1), ShouldEqual, 2)
+			// Left dirty by moves until next checkpoint.
+			So(fm.Opened, ShouldContainKey, sheetID)
+			So(fm.compactSheets(1), ShouldEqual, 0)
+			So(fm.Opened, ShouldNotContainKey, fm.Entries["sheet1"].SheetID)
+			So(fm.Persistent(), ShouldBeNil)
+			fm.EvictClosedSheets()
+			So(fm.Opened, ShouldNotContainKey, sheetID)
+			// Wrap around to sheet0 again.
+			So(fm.compactSheets(1), ShouldEqual, 0)
+			So(fm.Opened, ShouldNotContainKey, sheetID)
+			fd, err := fm.OpenSheet("sheet0", NoSession)
+			So(err, ShouldBeNil)
+			for i := uint32(3); i < 16; i += 4 {
+				_, _, _, err := fm.ReadFileCell(fd, 0, i, i)
+				So(err, ShouldBeNil)
+			}
+		})
+	})
+}
+
+func TestFileManager_Tabs(t *testing.T) {
+	Convey("Construct test FileManager", t, func() {
+		fm, _, alloc, err := newTestFileManager()
+		So(err, ShouldBeNil)
+		fd, err := fm.CreateSheet("sheet0", NoSession)
+		So(err, ShouldBeNil)
+		err = fm.Persistent()
+		So(err, ShouldBeNil)
+		sheetID := fm.Entries["sheet0"].SheetID
+		Convey("Edit tabs and replay them", func() {
+			secondary := LoadFileManager(fm.db, alloc, nil, nil)
+			replayed := mustLoadSheet(secondary, sheetID)
+			sheet := fm.Opened[sheetID]
+			now := time.Now()
+			var entries []*journal_entry.MasterEntry
+			for _, name := range []string{"", "data"} {
+				r, err := sheet.CreateTab(name, fm.db)
+				So(err, ShouldBeNil)
+				entries = append(entries, tabsEntry(nil, []*sheetfile.CellWriteResult{r}, now))
+			}
+			for tab := uint32(0); tab < 3; tab++ {
+				cell, chunk, overflow, _, err := sheet.WriteCellChunk(tab, 0, 0, 0, fm.db)
+				So(err, ShouldBeNil)
+				entries = append(entries, cellWriteEntry(cell.Snapshot(), chunk.Snapshot(), overflow, now))
+			}
+			r, err := sheet.RenameTab(0, "first")
+			So(err, ShouldBeNil)
+			entries = append(entries, tabsEntry(nil, []*sheetfile.CellWriteResult{r}, now))
+			written, err := sheet.MoveTab(2, 0)
+			So(err, ShouldBeNil)
+			entries = append(entries, tabsEntry(nil, written, now))
+			removed, written, _, err := sheet.DeleteTab(1)
+			So(err, ShouldBeNil)
+			So(len(removed), ShouldEqual, 2)
+			entries = append(entries, tabsEntry(removed, written, now))
+			for _, entry := range entries {
+				So(secondary.HandleMasterEntry(entry), ShouldBeNil)
+			}
+			So(replayed.Tabs(), ShouldResemble, []*sheetfile.Tab{
+				{ID: 2, Name: "data", Position: 0},
+				{ID: 0, Name: "first", Position: 1},
+			})
+			So(len(replayed.Cells), ShouldEqual, len(sheet.Cells))
+			for id, cell := range sheet.Cells {
+				So(replayed.Cells, ShouldContainKey, id)
+				So(replayed.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+				So(replayed.Cells[id].Offset, ShouldEqual, cell.Offset)
+			}
+			So(secondary.Entries["sheet0"].ModifiedAt.Equal(now), ShouldBeTrue)
+		})
+		Convey("Access cells of tabs", func() {
+			tab, err := fm.CreateTab(fd, "")
+			So(err, ShouldBeNil)
+			So(tab, ShouldEqual, 1)
+			_, _, _, _, err = fm.WriteFileCell(fd, tab, 0, 0, 0)
+			So(err, ShouldBeNil)
+			_, _, _, _, err = fm.WriteFileCell(fd, 2, 0, 0, 0)
+			So(err, ShouldHaveSameTypeAs, &file_errors.TabNotFoundError{})
+			_, _, _, err = fm.ReadFileCell(fd, 0, 0, 0)
+			So(err, ShouldHaveSameTypeAs, &file_errors.CellNotFoundError{})
+			So(fm.RenameTab(fd, tab, "Sheet1"), ShouldHaveSameTypeAs, &file_errors.TabExistsError{})
+			So(fm.MoveTab(fd, tab, 0), ShouldBeNil)
+			tabs, err := fm.ListTabs(fd)
+			So(err, ShouldBeNil)
+			So(tabs[0].ID, ShouldEqual, tab)
+			workbook, chunks, err := fm.ReadWorkbook(fd)
+			So(err, ShouldBeNil)
+			So(workbook, ShouldResemble, tabs)
+			So(len(chunks), ShouldEqual, 3)
+			So(fm.DeleteTab(fd, tab), ShouldBeNil)
+			_, err = fm.ReadSheet(fd, tab)
+			So(err, ShouldHaveSameTypeAs, &file_errors.TabNotFoundError{})
+			So(fm.DeleteTab(fd, 0), ShouldHaveSameTypeAs, &file_errors.InvalidTabError{})
+			_, err = fm.ListTabs(fd + 1)
+			So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd+1))
+		})
 	})
 }
 
@@ -236,17 +2001,17 @@ func TestFileManager_ReadSheet(t *testing.T) {
 	Convey("Construct test FileManager", t, func() {
 		fm, _, _, err := newTestFileManager()
 		So(err, ShouldBeNil)
-		fd, err := fm.CreateSheet("sheet0")
+		fd, err := fm.CreateSheet("sheet0", NoSession)
 		So(err, ShouldBeNil)
 		Convey("Write to test file", func() {
 			for i := 0; i < 10; i++ {
-				_, _, err := fm.WriteFileCell(fd, uint32(i), uint32(i))
+				_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), 0)
 				So(err, ShouldBeNil)
 			}
 			Convey("Read entire test file", func() {
-				_, err := fm.ReadSheet(0xdeafbeef)
+				_, err := fm.ReadSheet(0xdeafbeef, 0)
 				So(err, ShouldBeError, file_errors.NewFdNotFoundError(0xdeafbeef))
-				chunks, err := fm.ReadSheet(fd)
+				chunks, err := fm.ReadSheet(fd, 0)
 				So(err, ShouldBeNil)
 				So(len(chunks), ShouldEqual, 4)
 			})
@@ -258,22 +2023,40 @@ func TestFileManager_ReadFileCell(t *testing.T) {
 	Convey("Construct test FileManager", t, func() {
 		fm, _, _, err := newTestFileManager()
 		So(err, ShouldBeNil)
-		fd, err := fm.CreateSheet("sheet0")
+		fd, err := fm.CreateSheet("sheet0", NoSession)
 		So(err, ShouldBeNil)
 		Convey("Write to test file", func() {
 			for i := 0; i < 10; i++ {
-				_, _, err := fm.WriteFileCell(fd, uint32(i), uint32(i))
+				_, _, _, _, err := fm.WriteFileCell(fd, 0, uint32(i), uint32(i), 0)
 				So(err, ShouldBeNil)
 			}
 			Convey("Read cells in test file", func() {
 				for i := uint32(0); i < 10; i++ {
-					cell, chunk, err := fm.ReadFileCell(fd, i, i)
+					cell, chunk, _, err := fm.ReadFileCell(fd, 0, i, i)
 					So(err, ShouldBeNil)
 					So(cell.ChunkID, ShouldEqual, chunk.ID)
 				}
-				_, _, err := fm.ReadFileCell(fd, 1111, 1111)
+				_, _, _, err := fm.ReadFileCell(fd, 0, 1111, 1111)
 				So(err, ShouldBeError, file_errors.NewCellNotFoundError(1111, 1111))
 			})
+			Convey("Read a range of cells in test file", func() {
+				chunks, err := fm.ReadFileCells(fd, 0, 2, 6, 0, 5)
+				So(err, ShouldBeNil)
+				So(len(chunks), ShouldEqual, 2)
+				n := 0
+				for _, c := range chunks {
+					for _, cell := range c.Cells {
+						_, row, col := sheetfile.SplitCellID(cell.CellID)
+						So(row, ShouldEqual, col)
+						So(row, ShouldBeBetweenOrEqual, 2, 4)
+						So(cell.ChunkID, ShouldEqual, c.ID)
+						n++
+					}
+				}
+				So(n, ShouldEqual, 3)
+				_, err = fm.ReadFileCells(fd+1, 0, 0, 1, 0, 1)
+				So(err, ShouldBeError, file_errors.NewFdNotFoundError(fd+1))
+			})
 		})
 	})
 }
@@ -0,0 +1,159 @@
+package filemgr
+
+import (
+	"github.com/fourstring/sheetfs/master/journal/journal_entry"
+	"github.com/fourstring/sheetfs/master/sheetfile"
+	"time"
+)
+
+/*
+linesEntry
+Build the journal entry of inserting or deleting lines, see journal_entry.LinesEntry.
+*/
+func linesEntry(lines *journal_entry.MasterEntry_Lines, t time.Time) *journal_entry.MasterEntry {
+	return &journal_entry.MasterEntry{
+		XCell:     journal_entry.FromEmptySheetCell(),
+		XChunk:    journal_entry.FromEmptyChunk(),
+		XFileMap:  journal_entry.FromEmptyMgrEntry(),
+		XFd:       journal_entry.FromEmptyFd(),
+		XSession:  journal_entry.FromEmptySession(),
+		XDir:      journal_entry.FromEmptyDir(),
+		XLines:    lines,
+		Timestamp: t.UnixNano(),
+	}
+}
+
+/*
+insertLines
+Insert lines into tab of a file pointed by fd and journal it as a single entry. The SheetFile
+is locked exclusively until the entry is journaled, so that no mutations of its Cells
+are applied before the insertion but journaled after it.
+*/
+func (f *FileManager) insertLines(fd uint64, tab uint32, axis sheetfile.Axis, at uint32, count uint32) error {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return err
+	}
+	f.ckptMu.RLock()
+	defer f.ckptMu.RUnlock()
+	file.JournalMu.Lock()
+	defer file.JournalMu.Unlock()
+	remap, err := file.InsertLines(tab, axis, at, count)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	// Like WriteFileCell, SheetFile.InsertLines is not a two-stage one, so Kafka is
+	// assumed to be highly-available.
+	_ = f.writeJournal(linesEntry(journal_entry.FromLines(file.ID(), tab, axis, at, count, remap), now))
+	f.mu.Lock()
+	f.touchSheet(file.ID(), now)
+	f.mu.Unlock()
+	return nil
+}
+
+/*
+deleteLines
+Delete lines from tab of a file pointed by fd and journal it as a single entry, see insertLines.
+Dropped Chunks are deleted from DataNodes after the SheetFile is unlocked.
+*/
+func (f *FileManager) deleteLines(fd uint64, tab uint32, axis sheetfile.Axis, at uint32, count uint32) error {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return err
+	}
+	f.ckptMu.RLock()
+	file.JournalMu.Lock()
+	remap, dropped, err := file.DeleteLines(tab, axis, at, count)
+	if err != nil {
+		file.JournalMu.Unlock()
+		f.ckptMu.RUnlock()
+		return err
+	}
+	now := time.Now()
+	_ = f.writeJournal(linesEntry(journal_entry.FromAbsentLines(file.ID(), tab, axis, at, count, remap), now))
+	file.JournalMu.Unlock()
+	f.mu.Lock()
+	f.touchSheet(file.ID(), now)
+	f.mu.Unlock()
+	f.ckptMu.RUnlock()
+	// Contacting with DataNodes may be slow, so it's done without holding any lock.
+	f.deleteDataChunks(dropped)
+	return nil
+}
+
+/*
+InsertRows
+Insert count empty rows before row in tab of a file pointed by fd. Only CellIDs are
+remapped, data on DataNodes is not touched. See SheetFile.InsertLines.
+
+@para
+	fd, tab, row, count
+
+@return
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidLinesError if count is 0, or some Cell would be shifted out of
+		the sheet.
+*/
+func (f *FileManager) InsertRows(fd uint64, tab uint32, row uint32, count uint32) error {
+	return f.insertLines(fd, tab, sheetfile.RowAxis, row, count)
+}
+
+/*
+DeleteRows
+Delete count rows from row in tab of a file pointed by fd, Cells below them are shifted
+up. See SheetFile.DeleteLines.
+
+@para
+	fd, tab, row, count
+
+@return
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidLinesError if count is 0 or the rows exceed the sheet.
+*/
+func (f *FileManager) DeleteRows(fd uint64, tab uint32, row uint32, count uint32) error {
+	return f.deleteLines(fd, tab, sheetfile.RowAxis, row, count)
+}
+
+/*
+InsertColumns
+Insert count empty columns before col in tab of a file pointed by fd, see InsertRows.
+*/
+func (f *FileManager) InsertColumns(fd uint64, tab uint32, col uint32, count uint32) error {
+	return f.insertLines(fd, tab, sheetfile.ColumnAxis, col, count)
+}
+
+/*
+DeleteColumns
+Delete count columns from col in tab of a file pointed by fd, Cells on the right of them
+are shifted left, see DeleteRows.
+*/
+func (f *FileManager) DeleteColumns(fd uint64, tab uint32, col uint32, count uint32) error {
+	return f.deleteLines(fd, tab, sheetfile.ColumnAxis, col, count)
+}
+
+/*
+handleLinesEntry
+Replay an insertion or deletion of lines by its resulting CellIDs rather than by at and
+count, because Cells of the file may have been persisted after the insertion or deletion,
+see SheetFile.ApplyLinesRemap. Chunks dropped by a deletion have been deleted from
+DataNodes by the primary node, so they are only removed from metadata here.
+*/
+func (f *FileManager) handleLinesEntry(entry *journal_entry.MasterEntry) error {
+	linesEntry := entry.GetLines()
+	// Like cell entries, the file must be present, see HandleMasterEntry.
+	if _, ok := f.names[linesEntry.SheetId]; !ok {
+		return journal_entry.NewInvalidJournalEntryError(entry)
+	}
+	file, err := f.loadSheet(linesEntry.SheetId)
+	if err != nil {
+		return err
+	}
+	file.ApplyLinesRemap(journal_entry.ToLinesRemap(linesEntry))
+	f.touchSheet(linesEntry.SheetId, journal_entry.FromTimestamp(entry.Timestamp))
+	return nil
+}
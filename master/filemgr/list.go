@@ -0,0 +1,183 @@
+package filemgr
+
+import (
+	"encoding/base64"
+	"github.com/fourstring/sheetfs/master/config"
+	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
+	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
+	"github.com/fourstring/sheetfs/master/journal/journal_entry"
+	fs_rpc "github.com/fourstring/sheetfs/protocol"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+toPbSheet
+Convert a MapEntry to the protobuf fs_rpc.Sheet model returned by listing methods.
+*/
+func toPbSheet(entry *mgr_entry.MapEntry) *fs_rpc.Sheet {
+	return &fs_rpc.Sheet{
+		Filename:   entry.FileName,
+		Recycled:   entry.Recycled,
+		ModifiedAt: journal_entry.ToTimestamp(entry.ModifiedAt),
+	}
+}
+
+/*
+sheetKey
+Position of a sheet in a listing. Sheets are ordered by filename, or by modified
+and then filename, depending on the requested order.
+*/
+type sheetKey struct {
+	modified int64
+	filename string
+}
+
+func (k sheetKey) less(o sheetKey, order fs_rpc.SheetOrder) bool {
+	if order == fs_rpc.SheetOrder_BY_MODIFIED && k.modified != o.modified {
+		return k.modified < o.modified
+	}
+	return k.filename < o.filename
+}
+
+/*
+encodePageToken
+Encode the key of the last sheet in a page into an opaque page token. The order is
+encoded too, so that a token can't be used with another order.
+*/
+func encodePageToken(key sheetKey, order fs_rpc.SheetOrder) string {
+	var raw string
+	if order == fs_rpc.SheetOrder_BY_MODIFIED {
+		raw = "m/" + strconv.FormatInt(key.modified, 10) + "/" + key.filename
+	} else {
+		raw = "n/" + key.filename
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+/*
+decodePageToken
+Decode a page token produced by encodePageToken with the same order.
+
+@return
+	sheetKey: key of the last sheet in the previous page
+	error:
+		*errors.InvalidPageTokenError if token is malformed or produced with another order.
+*/
+func decodePageToken(token string, order fs_rpc.SheetOrder) (sheetKey, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return sheetKey{}, file_errors.NewInvalidPageTokenError(token)
+	}
+	raw := string(b)
+	if order == fs_rpc.SheetOrder_BY_MODIFIED {
+		parts := strings.SplitN(raw, "/", 3)
+		if len(parts) != 3 || parts[0] != "m" {
+			return sheetKey{}, file_errors.NewInvalidPageTokenError(token)
+		}
+		modified, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return sheetKey{}, file_errors.NewInvalidPageTokenError(token)
+		}
+		return sheetKey{modified: modified, filename: parts[2]}, nil
+	}
+	if !strings.HasPrefix(raw, "n/") {
+		return sheetKey{}, file_errors.NewInvalidPageTokenError(token)
+	}
+	return sheetKey{filename: strings.TrimPrefix(raw, "n/")}, nil
+}
+
+/*
+matchRecycled
+Returns true if a sheet whose recycled flag is recycled passes filter.
+*/
+func matchRecycled(recycled bool, filter fs_rpc.RecycledFilter) bool {
+	switch filter {
+	case fs_rpc.RecycledFilter_NOT_RECYCLED:
+		return !recycled
+	case fs_rpc.RecycledFilter_RECYCLED_ONLY:
+		return recycled
+	default:
+		return true
+	}
+}
+
+/*
+ListSheets
+List sheets stored in a directory and all of its subdirectories page by page, in a
+deterministic order.
+
+Pages are positioned by the key of the last sheet in the previous page rather than
+an offset, so creating or deleting sheets between two pages never makes a sheet be
+listed twice or skipped, except sheets whose keys change. When listing by modified
+time, a sheet written between two pages may be listed again or skipped.
+
+@para
+	req: the request from client
+		dir: path of the directory, empty for the root directory
+		prefix: only sheets whose filename starts with prefix are listed
+		recycled: filter sheets by whether they're recycled
+		order: sort sheets by filename or by last modified time
+		page_size: max number of sheets returned, 0 for config.ListPageSize, and
+		it's limited to config.MaxListPageSize
+		page_token: token returned with the previous page, empty for the first page
+
+@return
+	[]*fs_rpc.Sheet: a page of sheets
+	string: token of the next page, empty if there are no more sheets
+	error:
+		*errors.InvalidPathError if dir is not a valid path.
+		*errors.DirNotFoundError if there is no such directory.
+		*errors.InvalidPageTokenError if page_token is invalid.
+*/
+func (f *FileManager) ListSheets(req *fs_rpc.ListSheetsRequest) ([]*fs_rpc.Sheet, string, error) {
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = config.ListPageSize
+	}
+	if pageSize > config.MaxListPageSize {
+		pageSize = config.MaxListPageSize
+	}
+	var after *sheetKey
+	if req.PageToken != "" {
+		key, err := decodePageToken(req.PageToken, req.Order)
+		if err != nil {
+			return nil, "", err
+		}
+		after = &key
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	err := f.checkDir(req.Dir)
+	if err != nil {
+		return nil, "", err
+	}
+	var keys []sheetKey
+	for filename, entry := range f.Entries {
+		if !isUnder(filename, req.Dir) || !strings.HasPrefix(filename, req.Prefix) ||
+			!matchRecycled(entry.Recycled, req.Recycled) {
+			continue
+		}
+		key := sheetKey{modified: journal_entry.ToTimestamp(entry.ModifiedAt), filename: filename}
+		if after != nil && !after.less(key, req.Order) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j], req.Order)
+	})
+
+	next := ""
+	if len(keys) > pageSize {
+		keys = keys[:pageSize]
+		next = encodePageToken(keys[pageSize-1], req.Order)
+	}
+	sheets := make([]*fs_rpc.Sheet, 0, len(keys))
+	for _, key := range keys {
+		sheets = append(sheets, toPbSheet(f.Entries[key.filename]))
+	}
+	return sheets, next, nil
+}
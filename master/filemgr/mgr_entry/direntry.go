@@ -0,0 +1,13 @@
+package mgr_entry
+
+/*
+DirEntry
+Represents a directory of FileManager. Path is the full path of the directory, made
+of names separated by '/', without leading or trailing '/'. A file or directory belongs
+to the directory whose Path is the prefix of its own path before the last '/'.
+
+The root directory, whose Path is empty, always exists and is never persisted.
+*/
+type DirEntry struct {
+	Path string `gorm:"primaryKey"`
+}
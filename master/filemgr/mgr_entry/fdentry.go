@@ -0,0 +1,42 @@
+package mgr_entry
+
+import "github.com/fourstring/sheetfs/master/model"
+
+/*
+FdEntry
+Represents an entry of the fd table of FileManager, mapping a Fd to SheetID of
+the file it points to. Session is the ID of the client session owning this fd, or
+0 if the fd is not owned by any session.
+
+Fds are allocated and released through journal entries, so a secondary node can
+maintain the same fd table as the primary. The table is also flushed into sqlite
+during checkpointing, because journal entries before a checkpoint are skipped when
+a node recovers from the checkpoint.
+*/
+type FdEntry struct {
+	Fd      uint64 `gorm:"primaryKey;autoIncrement:false"`
+	SheetID uint64
+	Session uint64
+}
+
+/*
+SessionEntry
+Represents a client session known by FileManager. Only the existence of a session
+is persisted, its lease is kept in memory and renewed when a node becomes primary.
+*/
+type SessionEntry struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement:false"`
+}
+
+/*
+Counters
+Keeps the next fd, session ID and sheet ID to be allocated by FileManager. None
+of them is reused, so an outdated fd or session held by some client can't point to
+another file or session silently. There is only one Counters whose ID is 1.
+*/
+type Counters struct {
+	model.Model
+	NextFd      uint64
+	NextSession uint64
+	NextSheetID uint64
+}
@@ -8,8 +8,15 @@ import (
 /*
 MapEntry
 Represents a 'directory entry' of FileManager. Every entry maps a FileName
-to a CellsTableName which is the name of sqlite table storing Cells
-of the mapped SheetFile.
+to a SheetID, which is an immutable identity of the mapped SheetFile. Besides
+FileName, all references to a SheetFile, including its Cells table, fds and journal
+entries, are made through SheetID, so renaming a file only touches its MapEntry.
+CellsTableName is the name of sqlite table storing Cells of the mapped SheetFile before
+Cells of all SheetFiles were stored in a single table, it's only read by the migration and
+empty for files created since then.
+
+ModifiedAt is the last time when the content of the mapped file is written, and
+CreatedAt is the time when the file is created.
 
 Recycled is a flag indicates that whether the mapped file has been moved to
 'recycle bin' or not. When a file is recycled, time of this operation is recorded
@@ -19,7 +26,9 @@ before they are deleted, they can be resumed.
 type MapEntry struct {
 	gorm.Model
 	FileName       string `gorm:"index"`
+	SheetID        uint64 `gorm:"uniqueIndex"`
 	CellsTableName string
 	Recycled       bool
 	RecycledAt     time.Time
+	ModifiedAt     time.Time
 }
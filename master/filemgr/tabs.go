@@ -0,0 +1,188 @@
+package filemgr
+
+import (
+	"github.com/fourstring/sheetfs/master/journal/journal_entry"
+	"github.com/fourstring/sheetfs/master/sheetfile"
+	"time"
+)
+
+/*
+tabsEntry
+Build the journal entry of a tab operation, in which removed Cells are journaled as
+deletions, and changed MetaCells are journaled as writes, see WriteFileCells.
+*/
+func tabsEntry(removed []*sheetfile.Cell, written []*sheetfile.CellWriteResult, t time.Time) *journal_entry.MasterEntry {
+	entries := make([]*journal_entry.MasterEntry, 0, len(removed)+len(written))
+	for _, cell := range removed {
+		// Chunks of removed Cells are not written, so they are kept as they are.
+		entries = append(entries, cellRemovalEntry(cell, nil, t))
+	}
+	for _, r := range written {
+		entries = append(entries, cellWriteEntry(r.Cell, r.Chunk, r.Overflow, t))
+	}
+	return &journal_entry.MasterEntry{
+		XCell:     journal_entry.FromEmptySheetCell(),
+		XChunk:    journal_entry.FromEmptyChunk(),
+		XFileMap:  journal_entry.FromEmptyMgrEntry(),
+		XFd:       journal_entry.FromEmptyFd(),
+		XSession:  journal_entry.FromEmptySession(),
+		XDir:      journal_entry.FromEmptyDir(),
+		XLines:    journal_entry.FromEmptyLines(),
+		Timestamp: t.UnixNano(),
+		Batch:     entries,
+	}
+}
+
+/*
+editTabs
+Apply a tab operation to a file pointed by fd and journal it as a single entry. Like
+insertLines, the SheetFile is locked exclusively until the entry is journaled. Dropped
+Chunks are deleted from DataNodes after the SheetFile is unlocked.
+
+@para
+	fd
+	edit: the tab operation, which returns removed Cells, changed MetaCells and dropped
+	Chunks like SheetFile.DeleteTab.
+
+@return
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		errors returned by edit.
+*/
+func (f *FileManager) editTabs(fd uint64, edit func(file *sheetfile.SheetFile) ([]*sheetfile.Cell, []*sheetfile.CellWriteResult, []*sheetfile.Chunk, error)) error {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return err
+	}
+	f.ckptMu.RLock()
+	file.JournalMu.Lock()
+	removed, written, dropped, err := edit(file)
+	if len(removed) == 0 && len(written) == 0 {
+		file.JournalMu.Unlock()
+		f.ckptMu.RUnlock()
+		return err
+	}
+	now := time.Now()
+	// Cells removed before an error are journaled too, since they can't be undone.
+	_ = f.writeJournal(tabsEntry(removed, written, now))
+	file.JournalMu.Unlock()
+	f.mu.Lock()
+	f.touchSheet(file.ID(), now)
+	f.mu.Unlock()
+	f.ckptMu.RUnlock()
+	// Contacting with DataNodes may be slow, so it's done without holding any lock.
+	f.deleteDataChunks(dropped)
+	return err
+}
+
+/*
+ListTabs
+List all tabs of a file pointed by fd, see SheetFile.Tabs.
+
+@para
+	fd
+
+@return
+	[]*sheetfile.Tab: all tabs in order.
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+*/
+func (f *FileManager) ListTabs(fd uint64) ([]*sheetfile.Tab, error) {
+	file, err := f.getFileByFd(fd)
+	if err != nil {
+		return nil, err
+	}
+	return file.Tabs(), nil
+}
+
+/*
+CreateTab
+Create a tab after all tabs of a file pointed by fd, see SheetFile.CreateTab.
+
+@para
+	fd
+	name: name of the new tab, a default name is given if it's empty.
+
+@return
+	uint32: number of the new tab.
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.TabExistsError if there has been a tab named name.
+		*errors.InvalidTabError if there are too many tabs.
+		*errors.NoDataNodeError if there is no DataNode registered.
+*/
+func (f *FileManager) CreateTab(fd uint64, name string) (uint32, error) {
+	var tab uint32
+	err := f.editTabs(fd, func(file *sheetfile.SheetFile) ([]*sheetfile.Cell, []*sheetfile.CellWriteResult, []*sheetfile.Chunk, error) {
+		r, err := file.CreateTab(name, f.db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		tab = r.Cell.Tab()
+		return nil, []*sheetfile.CellWriteResult{r}, nil, nil
+	})
+	return tab, err
+}
+
+/*
+RenameTab
+Rename a tab of a file pointed by fd, see SheetFile.RenameTab.
+
+@para
+	fd, tab, name
+
+@return
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidTabError if name is empty.
+		*errors.TabExistsError if there has been another tab named name.
+*/
+func (f *FileManager) RenameTab(fd uint64, tab uint32, name string) error {
+	return f.editTabs(fd, func(file *sheetfile.SheetFile) ([]*sheetfile.Cell, []*sheetfile.CellWriteResult, []*sheetfile.Chunk, error) {
+		r, err := file.RenameTab(tab, name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return nil, []*sheetfile.CellWriteResult{r}, nil, nil
+	})
+}
+
+/*
+MoveTab
+Move a tab of a file pointed by fd to position, see SheetFile.MoveTab.
+
+@para
+	fd, tab, position
+
+@return
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidTabError if position is not less than the number of tabs.
+*/
+func (f *FileManager) MoveTab(fd uint64, tab uint32, position uint32) error {
+	return f.editTabs(fd, func(file *sheetfile.SheetFile) ([]*sheetfile.Cell, []*sheetfile.CellWriteResult, []*sheetfile.Chunk, error) {
+		written, err := file.MoveTab(tab, position)
+		return nil, written, nil, err
+	})
+}
+
+/*
+DeleteTab
+Delete a tab and all of its Cells from a file pointed by fd, see SheetFile.DeleteTab.
+
+@para
+	fd, tab
+
+@return
+	error:
+		*errors.FdNotFoundError if the fd is invalid
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidTabError if tab is the only tab of the file.
+*/
+func (f *FileManager) DeleteTab(fd uint64, tab uint32) error {
+	return f.editTabs(fd, func(file *sheetfile.SheetFile) ([]*sheetfile.Cell, []*sheetfile.CellWriteResult, []*sheetfile.Chunk, error) {
+		return file.DeleteTab(tab)
+	})
+}
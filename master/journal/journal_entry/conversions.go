@@ -13,10 +13,20 @@ func FromSheetCell(c *sheetfile.Cell) *MasterEntry_Cell {
 		Offset:      c.Offset,
 		Size:        c.Size,
 		ChunkId:     c.ChunkID,
-		SheetName:   c.SheetName,
+		SheetId:     c.SheetID,
+		Overflow:    c.Overflow,
+		Length:      c.Length,
+		TabName:     c.TabName,
+		TabPosition: c.TabPosition,
 	}}
 }
 
+func FromAbsentSheetCell(c *sheetfile.Cell) *MasterEntry_Cell {
+	e := FromSheetCell(c)
+	e.Cell.TargetState = State_ABSENT
+	return e
+}
+
 func FromEmptySheetCell() *MasterEntry_E1 {
 	return &MasterEntry_E1{E1: &Empty{}}
 }
@@ -26,7 +36,11 @@ func ToSheetCell(scell *sheetfile.Cell, e *CellEntry) {
 	scell.Offset = e.Offset
 	scell.Size = e.Size
 	scell.ChunkID = e.ChunkId
-	scell.SheetName = e.SheetName
+	scell.SheetID = e.SheetId
+	scell.Overflow = append(sheetfile.ChunkIDs(nil), e.Overflow...)
+	scell.Length = e.Length
+	scell.TabName = e.TabName
+	scell.TabPosition = e.TabPosition
 }
 
 func FromSheetChunk(c *sheetfile.Chunk) *MasterEntry_Chunk {
@@ -35,9 +49,26 @@ func FromSheetChunk(c *sheetfile.Chunk) *MasterEntry_Chunk {
 		Id:          c.ID,
 		Version:     c.Version,
 		Datanode:    c.DataNode,
+		CopyOf:      c.CopyOf,
+		Versions:    c.Versions,
 	}}
 }
 
+func FromAbsentSheetChunk(id uint64) *MasterEntry_Chunk {
+	return &MasterEntry_Chunk{Chunk: &ChunkEntry{
+		TargetState: State_ABSENT,
+		Id:          id,
+	}}
+}
+
+func FromOverflowChunks(chunks []*sheetfile.Chunk) []*ChunkEntry {
+	entries := make([]*ChunkEntry, len(chunks))
+	for i, c := range chunks {
+		entries[i] = FromSheetChunk(c).Chunk
+	}
+	return entries
+}
+
 func FromEmptyChunk() *MasterEntry_E2 {
 	return &MasterEntry_E2{E2: &Empty{}}
 }
@@ -46,6 +77,8 @@ func ToSheetChunk(schunk *sheetfile.Chunk, e *ChunkEntry) {
 	schunk.ID = e.Id
 	schunk.Version = e.Version
 	schunk.DataNode = e.Datanode
+	schunk.CopyOf = e.CopyOf
+	schunk.Versions = append(sheetfile.SlotVersions(nil), e.Versions...)
 }
 
 func FromMgrEntry(mentry *mgr_entry.MapEntry) *MasterEntry_MapEntry {
@@ -55,9 +88,24 @@ func FromMgrEntry(mentry *mgr_entry.MapEntry) *MasterEntry_MapEntry {
 		CellsTableName:    mentry.CellsTableName,
 		Recycled:          mentry.Recycled,
 		RecycledTimestamp: mentry.RecycledAt.UnixNano(),
+		SheetId:           mentry.SheetID,
+		CreatedTimestamp:  ToTimestamp(mentry.CreatedAt),
+		ModifiedTimestamp: ToTimestamp(mentry.ModifiedAt),
 	}}
 }
 
+func FromCopiedMgrEntry(mentry *mgr_entry.MapEntry, copyOf uint64) *MasterEntry_MapEntry {
+	e := FromMgrEntry(mentry)
+	e.MapEntry.CopyOf = copyOf
+	return e
+}
+
+func FromAbsentMgrEntry(mentry *mgr_entry.MapEntry) *MasterEntry_MapEntry {
+	e := FromMgrEntry(mentry)
+	e.MapEntry.TargetState = State_ABSENT
+	return e
+}
+
 func FromEmptyMgrEntry() *MasterEntry_E3 {
 	return &MasterEntry_E3{E3: &Empty{}}
 }
@@ -67,4 +115,126 @@ func ToMgrEntry(mentry *mgr_entry.MapEntry, e *FileMapEntry) {
 	mentry.CellsTableName = e.CellsTableName
 	mentry.Recycled = e.Recycled
 	mentry.RecycledAt = time.Unix(0, e.RecycledTimestamp)
+	mentry.SheetID = e.SheetId
+	mentry.CreatedAt = FromTimestamp(e.CreatedTimestamp)
+	mentry.ModifiedAt = FromTimestamp(e.ModifiedTimestamp)
+}
+
+func FromFd(fd uint64, sheetID uint64, session uint64) *MasterEntry_Fd {
+	return &MasterEntry_Fd{Fd: &FdEntry{
+		TargetState: State_PRESENT,
+		Fd:          fd,
+		SheetId:     sheetID,
+		Session:     session,
+	}}
+}
+
+func FromAbsentFd(fd uint64, sheetID uint64, session uint64) *MasterEntry_Fd {
+	e := FromFd(fd, sheetID, session)
+	e.Fd.TargetState = State_ABSENT
+	return e
+}
+
+func FromEmptyFd() *MasterEntry_E4 {
+	return &MasterEntry_E4{E4: &Empty{}}
+}
+
+func FromSession(id uint64) *MasterEntry_Session {
+	return &MasterEntry_Session{Session: &SessionEntry{
+		TargetState: State_PRESENT,
+		Id:          id,
+	}}
+}
+
+func FromAbsentSession(id uint64) *MasterEntry_Session {
+	e := FromSession(id)
+	e.Session.TargetState = State_ABSENT
+	return e
+}
+
+func FromEmptySession() *MasterEntry_E5 {
+	return &MasterEntry_E5{E5: &Empty{}}
+}
+
+func FromDir(path string) *MasterEntry_Dir {
+	return &MasterEntry_Dir{Dir: &DirEntry{
+		TargetState: State_PRESENT,
+		Path:        path,
+	}}
+}
+
+func FromAbsentDir(path string) *MasterEntry_Dir {
+	e := FromDir(path)
+	e.Dir.TargetState = State_ABSENT
+	return e
+}
+
+func FromEmptyDir() *MasterEntry_E6 {
+	return &MasterEntry_E6{E6: &Empty{}}
+}
+
+// Values of Axis are the same as sheetfile.Axis.
+func FromLines(sheetID uint64, tab uint32, axis sheetfile.Axis, at uint32, count uint32, remap *sheetfile.LinesRemap) *MasterEntry_Lines {
+	return &MasterEntry_Lines{Lines: &LinesEntry{
+		TargetState: State_PRESENT,
+		SheetId:     sheetID,
+		Tab:         tab,
+		Axis:        Axis(axis),
+		At:          at,
+		Count:       count,
+		Moved:       fromCellRemaps(remap.Moved),
+		Removed:     fromCellRemaps(remap.Removed),
+	}}
+}
+
+func FromAbsentLines(sheetID uint64, tab uint32, axis sheetfile.Axis, at uint32, count uint32, remap *sheetfile.LinesRemap) *MasterEntry_Lines {
+	e := FromLines(sheetID, tab, axis, at, count, remap)
+	e.Lines.TargetState = State_ABSENT
+	return e
+}
+
+func fromCellRemaps(remaps []sheetfile.CellRemap) []*CellRemap {
+	entries := make([]*CellRemap, len(remaps))
+	for i, r := range remaps {
+		entries[i] = &CellRemap{ChunkId: r.ChunkID, Offset: r.Offset, CellId: r.CellID}
+	}
+	return entries
+}
+
+func toCellRemaps(entries []*CellRemap) []sheetfile.CellRemap {
+	remaps := make([]sheetfile.CellRemap, len(entries))
+	for i, e := range entries {
+		remaps[i] = sheetfile.CellRemap{ChunkID: e.ChunkId, Offset: e.Offset, CellID: e.CellId}
+	}
+	return remaps
+}
+
+func ToLinesRemap(e *LinesEntry) *sheetfile.LinesRemap {
+	return &sheetfile.LinesRemap{Moved: toCellRemaps(e.Moved), Removed: toCellRemaps(e.Removed)}
+}
+
+func FromEmptyLines() *MasterEntry_E7 {
+	return &MasterEntry_E7{E7: &Empty{}}
+}
+
+/*
+ToTimestamp
+Convert t to unix time in nanoseconds, a zero time.Time is converted to 0.
+*/
+func ToTimestamp(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+/*
+FromTimestamp
+Convert unix time in nanoseconds to time.Time, 0 is converted to a zero time.Time.
+*/
+func FromTimestamp(ts int64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ts)
 }
@@ -66,6 +66,52 @@ func (State) EnumDescriptor() ([]byte, []int) {
 	return file_entry_proto_rawDescGZIP(), []int{0}
 }
 
+type Axis int32
+
+const (
+	Axis_ROW    Axis = 0
+	Axis_COLUMN Axis = 1
+)
+
+// Enum value maps for Axis.
+var (
+	Axis_name = map[int32]string{
+		0: "ROW",
+		1: "COLUMN",
+	}
+	Axis_value = map[string]int32{
+		"ROW":    0,
+		"COLUMN": 1,
+	}
+)
+
+func (x Axis) Enum() *Axis {
+	p := new(Axis)
+	*p = x
+	return p
+}
+
+func (x Axis) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Axis) Descriptor() protoreflect.EnumDescriptor {
+	return file_entry_proto_enumTypes[1].Descriptor()
+}
+
+func (Axis) Type() protoreflect.EnumType {
+	return &file_entry_proto_enumTypes[1]
+}
+
+func (x Axis) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Axis.Descriptor instead.
+func (Axis) EnumDescriptor() ([]byte, []int) {
+	return file_entry_proto_rawDescGZIP(), []int{1}
+}
+
 type Empty struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -114,7 +160,14 @@ type CellEntry struct {
 	Offset      uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	Size        uint64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
 	ChunkId     uint64 `protobuf:"varint,5,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
-	SheetName   string `protobuf:"bytes,6,opt,name=sheet_name,json=sheetName,proto3" json:"sheet_name,omitempty"`
+	SheetId     uint64 `protobuf:"varint,7,opt,name=sheet_id,json=sheetId,proto3" json:"sheet_id,omitempty"`
+	// IDs of overflow chunks of the cell, in order.
+	Overflow []uint64 `protobuf:"varint,8,rep,packed,name=overflow,proto3" json:"overflow,omitempty"`
+	// Length of data written to the cell.
+	Length uint64 `protobuf:"varint,9,opt,name=length,proto3" json:"length,omitempty"`
+	// Name and position of the tab if the cell is the MetaCell of a tab.
+	TabName     string `protobuf:"bytes,10,opt,name=tab_name,json=tabName,proto3" json:"tab_name,omitempty"`
+	TabPosition uint32 `protobuf:"varint,11,opt,name=tab_position,json=tabPosition,proto3" json:"tab_position,omitempty"`
 }
 
 func (x *CellEntry) Reset() {
@@ -184,22 +237,52 @@ func (x *CellEntry) GetChunkId() uint64 {
 	return 0
 }
 
-func (x *CellEntry) GetSheetName() string {
+func (x *CellEntry) GetSheetId() uint64 {
+	if x != nil {
+		return x.SheetId
+	}
+	return 0
+}
+
+func (x *CellEntry) GetOverflow() []uint64 {
 	if x != nil {
-		return x.SheetName
+		return x.Overflow
+	}
+	return nil
+}
+
+func (x *CellEntry) GetLength() uint64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *CellEntry) GetTabName() string {
+	if x != nil {
+		return x.TabName
 	}
 	return ""
 }
 
+func (x *CellEntry) GetTabPosition() uint32 {
+	if x != nil {
+		return x.TabPosition
+	}
+	return 0
+}
+
 type ChunkEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TargetState State  `protobuf:"varint,1,opt,name=target_state,json=targetState,proto3,enum=common_journal.State" json:"target_state,omitempty"`
-	Id          uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	Version     uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	Datanode    string `protobuf:"bytes,4,opt,name=datanode,proto3" json:"datanode,omitempty"`
+	TargetState State    `protobuf:"varint,1,opt,name=target_state,json=targetState,proto3,enum=common_journal.State" json:"target_state,omitempty"`
+	Id          uint64   `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Version     uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Datanode    string   `protobuf:"bytes,4,opt,name=datanode,proto3" json:"datanode,omitempty"`
+	CopyOf      uint64   `protobuf:"varint,5,opt,name=copy_of,json=copyOf,proto3" json:"copy_of,omitempty"`
+	Versions    []uint64 `protobuf:"varint,6,rep,packed,name=versions,proto3" json:"versions,omitempty"`
 }
 
 func (x *ChunkEntry) Reset() {
@@ -262,6 +345,20 @@ func (x *ChunkEntry) GetDatanode() string {
 	return ""
 }
 
+func (x *ChunkEntry) GetCopyOf() uint64 {
+	if x != nil {
+		return x.CopyOf
+	}
+	return 0
+}
+
+func (x *ChunkEntry) GetVersions() []uint64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
 type FileMapEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -272,6 +369,11 @@ type FileMapEntry struct {
 	CellsTableName    string `protobuf:"bytes,3,opt,name=cells_table_name,json=cellsTableName,proto3" json:"cells_table_name,omitempty"`
 	Recycled          bool   `protobuf:"varint,4,opt,name=recycled,proto3" json:"recycled,omitempty"`
 	RecycledTimestamp int64  `protobuf:"varint,5,opt,name=recycled_timestamp,json=recycledTimestamp,proto3" json:"recycled_timestamp,omitempty"`
+	SheetId           uint64 `protobuf:"varint,6,opt,name=sheet_id,json=sheetId,proto3" json:"sheet_id,omitempty"`
+	// SheetID of the source file if the file is created by copying.
+	CopyOf            uint64 `protobuf:"varint,7,opt,name=copy_of,json=copyOf,proto3" json:"copy_of,omitempty"`
+	CreatedTimestamp  int64  `protobuf:"varint,8,opt,name=created_timestamp,json=createdTimestamp,proto3" json:"created_timestamp,omitempty"`
+	ModifiedTimestamp int64  `protobuf:"varint,9,opt,name=modified_timestamp,json=modifiedTimestamp,proto3" json:"modified_timestamp,omitempty"`
 }
 
 func (x *FileMapEntry) Reset() {
@@ -341,6 +443,386 @@ func (x *FileMapEntry) GetRecycledTimestamp() int64 {
 	return 0
 }
 
+func (x *FileMapEntry) GetSheetId() uint64 {
+	if x != nil {
+		return x.SheetId
+	}
+	return 0
+}
+
+func (x *FileMapEntry) GetCopyOf() uint64 {
+	if x != nil {
+		return x.CopyOf
+	}
+	return 0
+}
+
+func (x *FileMapEntry) GetCreatedTimestamp() int64 {
+	if x != nil {
+		return x.CreatedTimestamp
+	}
+	return 0
+}
+
+func (x *FileMapEntry) GetModifiedTimestamp() int64 {
+	if x != nil {
+		return x.ModifiedTimestamp
+	}
+	return 0
+}
+
+type FdEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetState State  `protobuf:"varint,1,opt,name=target_state,json=targetState,proto3,enum=common_journal.State" json:"target_state,omitempty"`
+	Fd          uint64 `protobuf:"varint,2,opt,name=fd,proto3" json:"fd,omitempty"`
+	Session     uint64 `protobuf:"varint,4,opt,name=session,proto3" json:"session,omitempty"`
+	SheetId     uint64 `protobuf:"varint,5,opt,name=sheet_id,json=sheetId,proto3" json:"sheet_id,omitempty"`
+}
+
+func (x *FdEntry) Reset() {
+	*x = FdEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_entry_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FdEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FdEntry) ProtoMessage() {}
+
+func (x *FdEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_entry_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FdEntry.ProtoReflect.Descriptor instead.
+func (*FdEntry) Descriptor() ([]byte, []int) {
+	return file_entry_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FdEntry) GetTargetState() State {
+	if x != nil {
+		return x.TargetState
+	}
+	return State_PRESENT
+}
+
+func (x *FdEntry) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *FdEntry) GetSession() uint64 {
+	if x != nil {
+		return x.Session
+	}
+	return 0
+}
+
+func (x *FdEntry) GetSheetId() uint64 {
+	if x != nil {
+		return x.SheetId
+	}
+	return 0
+}
+
+type SessionEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetState State  `protobuf:"varint,1,opt,name=target_state,json=targetState,proto3,enum=common_journal.State" json:"target_state,omitempty"`
+	Id          uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *SessionEntry) Reset() {
+	*x = SessionEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_entry_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionEntry) ProtoMessage() {}
+
+func (x *SessionEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_entry_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionEntry.ProtoReflect.Descriptor instead.
+func (*SessionEntry) Descriptor() ([]byte, []int) {
+	return file_entry_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SessionEntry) GetTargetState() State {
+	if x != nil {
+		return x.TargetState
+	}
+	return State_PRESENT
+}
+
+func (x *SessionEntry) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DirEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetState State  `protobuf:"varint,1,opt,name=target_state,json=targetState,proto3,enum=common_journal.State" json:"target_state,omitempty"`
+	Path        string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *DirEntry) Reset() {
+	*x = DirEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_entry_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DirEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirEntry) ProtoMessage() {}
+
+func (x *DirEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_entry_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirEntry.ProtoReflect.Descriptor instead.
+func (*DirEntry) Descriptor() ([]byte, []int) {
+	return file_entry_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DirEntry) GetTargetState() State {
+	if x != nil {
+		return x.TargetState
+	}
+	return State_PRESENT
+}
+
+func (x *DirEntry) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// A Cell identified by its slot, which is not changed by inserting or deleting lines.
+type CellRemap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChunkId uint64 `protobuf:"varint,1,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
+	Offset  uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	CellId  int64  `protobuf:"varint,3,opt,name=cell_id,json=cellId,proto3" json:"cell_id,omitempty"`
+}
+
+func (x *CellRemap) Reset() {
+	*x = CellRemap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_entry_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellRemap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellRemap) ProtoMessage() {}
+
+func (x *CellRemap) ProtoReflect() protoreflect.Message {
+	mi := &file_entry_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellRemap.ProtoReflect.Descriptor instead.
+func (*CellRemap) Descriptor() ([]byte, []int) {
+	return file_entry_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CellRemap) GetChunkId() uint64 {
+	if x != nil {
+		return x.ChunkId
+	}
+	return 0
+}
+
+func (x *CellRemap) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *CellRemap) GetCellId() int64 {
+	if x != nil {
+		return x.CellId
+	}
+	return 0
+}
+
+// Rows or columns [at, at+count) of a tab are inserted if target_state is PRESENT,
+// or deleted if target_state is ABSENT, shifting CellIDs of following Cells.
+// Resulting CellIDs of shifted Cells and CellIDs of deleted Cells are carried
+// instead of being derived from at and count, so replaying it again has no effect.
+type LinesEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetState State        `protobuf:"varint,1,opt,name=target_state,json=targetState,proto3,enum=common_journal.State" json:"target_state,omitempty"`
+	SheetId     uint64       `protobuf:"varint,2,opt,name=sheet_id,json=sheetId,proto3" json:"sheet_id,omitempty"`
+	Axis        Axis         `protobuf:"varint,3,opt,name=axis,proto3,enum=common_journal.Axis" json:"axis,omitempty"`
+	At          uint32       `protobuf:"varint,4,opt,name=at,proto3" json:"at,omitempty"`
+	Count       uint32       `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	Tab         uint32       `protobuf:"varint,6,opt,name=tab,proto3" json:"tab,omitempty"`
+	Moved       []*CellRemap `protobuf:"bytes,7,rep,name=moved,proto3" json:"moved,omitempty"`
+	Removed     []*CellRemap `protobuf:"bytes,8,rep,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *LinesEntry) Reset() {
+	*x = LinesEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_entry_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinesEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinesEntry) ProtoMessage() {}
+
+func (x *LinesEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_entry_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinesEntry.ProtoReflect.Descriptor instead.
+func (*LinesEntry) Descriptor() ([]byte, []int) {
+	return file_entry_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LinesEntry) GetTargetState() State {
+	if x != nil {
+		return x.TargetState
+	}
+	return State_PRESENT
+}
+
+func (x *LinesEntry) GetSheetId() uint64 {
+	if x != nil {
+		return x.SheetId
+	}
+	return 0
+}
+
+func (x *LinesEntry) GetAxis() Axis {
+	if x != nil {
+		return x.Axis
+	}
+	return Axis_ROW
+}
+
+func (x *LinesEntry) GetAt() uint32 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+func (x *LinesEntry) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LinesEntry) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+func (x *LinesEntry) GetMoved() []*CellRemap {
+	if x != nil {
+		return x.Moved
+	}
+	return nil
+}
+
+func (x *LinesEntry) GetRemoved() []*CellRemap {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
 type MasterEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -358,12 +840,35 @@ type MasterEntry struct {
 	//	*MasterEntry_E3
 	//	*MasterEntry_MapEntry
 	XFileMap isMasterEntry_XFileMap `protobuf_oneof:"_FileMap"`
+	// Types that are assignable to XFd:
+	//	*MasterEntry_E4
+	//	*MasterEntry_Fd
+	XFd isMasterEntry_XFd `protobuf_oneof:"_Fd"`
+	// Types that are assignable to XSession:
+	//	*MasterEntry_E5
+	//	*MasterEntry_Session
+	XSession isMasterEntry_XSession `protobuf_oneof:"_Session"`
+	// Types that are assignable to XDir:
+	//	*MasterEntry_E6
+	//	*MasterEntry_Dir
+	XDir isMasterEntry_XDir `protobuf_oneof:"_Dir"`
+	// Types that are assignable to XLines:
+	//	*MasterEntry_E7
+	//	*MasterEntry_Lines
+	XLines isMasterEntry_XLines `protobuf_oneof:"_Lines"`
+	// Unix time in nanoseconds when the content of a file is modified by this entry,
+	// 0 if the entry doesn't modify any file.
+	Timestamp int64 `protobuf:"varint,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Overflow chunks of the cell written by this entry.
+	Overflow []*ChunkEntry `protobuf:"bytes,14,rep,name=overflow,proto3" json:"overflow,omitempty"`
+	// Entries replayed in order as a part of this entry, such as writes of a batch.
+	Batch []*MasterEntry `protobuf:"bytes,17,rep,name=batch,proto3" json:"batch,omitempty"`
 }
 
 func (x *MasterEntry) Reset() {
 	*x = MasterEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_entry_proto_msgTypes[4]
+		mi := &file_entry_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -376,7 +881,7 @@ func (x *MasterEntry) String() string {
 func (*MasterEntry) ProtoMessage() {}
 
 func (x *MasterEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_entry_proto_msgTypes[4]
+	mi := &file_entry_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -389,7 +894,7 @@ func (x *MasterEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MasterEntry.ProtoReflect.Descriptor instead.
 func (*MasterEntry) Descriptor() ([]byte, []int) {
-	return file_entry_proto_rawDescGZIP(), []int{4}
+	return file_entry_proto_rawDescGZIP(), []int{9}
 }
 
 func (m *MasterEntry) GetXCell() isMasterEntry_XCell {
@@ -455,6 +960,111 @@ func (x *MasterEntry) GetMapEntry() *FileMapEntry {
 	return nil
 }
 
+func (m *MasterEntry) GetXFd() isMasterEntry_XFd {
+	if m != nil {
+		return m.XFd
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetE4() *Empty {
+	if x, ok := x.GetXFd().(*MasterEntry_E4); ok {
+		return x.E4
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetFd() *FdEntry {
+	if x, ok := x.GetXFd().(*MasterEntry_Fd); ok {
+		return x.Fd
+	}
+	return nil
+}
+
+func (m *MasterEntry) GetXSession() isMasterEntry_XSession {
+	if m != nil {
+		return m.XSession
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetE5() *Empty {
+	if x, ok := x.GetXSession().(*MasterEntry_E5); ok {
+		return x.E5
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetSession() *SessionEntry {
+	if x, ok := x.GetXSession().(*MasterEntry_Session); ok {
+		return x.Session
+	}
+	return nil
+}
+
+func (m *MasterEntry) GetXDir() isMasterEntry_XDir {
+	if m != nil {
+		return m.XDir
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetE6() *Empty {
+	if x, ok := x.GetXDir().(*MasterEntry_E6); ok {
+		return x.E6
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetDir() *DirEntry {
+	if x, ok := x.GetXDir().(*MasterEntry_Dir); ok {
+		return x.Dir
+	}
+	return nil
+}
+
+func (m *MasterEntry) GetXLines() isMasterEntry_XLines {
+	if m != nil {
+		return m.XLines
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetE7() *Empty {
+	if x, ok := x.GetXLines().(*MasterEntry_E7); ok {
+		return x.E7
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetLines() *LinesEntry {
+	if x, ok := x.GetXLines().(*MasterEntry_Lines); ok {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MasterEntry) GetOverflow() []*ChunkEntry {
+	if x != nil {
+		return x.Overflow
+	}
+	return nil
+}
+
+func (x *MasterEntry) GetBatch() []*MasterEntry {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
 type isMasterEntry_XCell interface {
 	isMasterEntry_XCell()
 }
@@ -503,12 +1113,76 @@ func (*MasterEntry_E3) isMasterEntry_XFileMap() {}
 
 func (*MasterEntry_MapEntry) isMasterEntry_XFileMap() {}
 
+type isMasterEntry_XFd interface {
+	isMasterEntry_XFd()
+}
+
+type MasterEntry_E4 struct {
+	E4 *Empty `protobuf:"bytes,7,opt,name=e4,proto3,oneof"`
+}
+
+type MasterEntry_Fd struct {
+	Fd *FdEntry `protobuf:"bytes,8,opt,name=fd,proto3,oneof"`
+}
+
+func (*MasterEntry_E4) isMasterEntry_XFd() {}
+
+func (*MasterEntry_Fd) isMasterEntry_XFd() {}
+
+type isMasterEntry_XSession interface {
+	isMasterEntry_XSession()
+}
+
+type MasterEntry_E5 struct {
+	E5 *Empty `protobuf:"bytes,9,opt,name=e5,proto3,oneof"`
+}
+
+type MasterEntry_Session struct {
+	Session *SessionEntry `protobuf:"bytes,10,opt,name=session,proto3,oneof"`
+}
+
+func (*MasterEntry_E5) isMasterEntry_XSession() {}
+
+func (*MasterEntry_Session) isMasterEntry_XSession() {}
+
+type isMasterEntry_XDir interface {
+	isMasterEntry_XDir()
+}
+
+type MasterEntry_E6 struct {
+	E6 *Empty `protobuf:"bytes,11,opt,name=e6,proto3,oneof"`
+}
+
+type MasterEntry_Dir struct {
+	Dir *DirEntry `protobuf:"bytes,12,opt,name=dir,proto3,oneof"`
+}
+
+func (*MasterEntry_E6) isMasterEntry_XDir() {}
+
+func (*MasterEntry_Dir) isMasterEntry_XDir() {}
+
+type isMasterEntry_XLines interface {
+	isMasterEntry_XLines()
+}
+
+type MasterEntry_E7 struct {
+	E7 *Empty `protobuf:"bytes,15,opt,name=e7,proto3,oneof"`
+}
+
+type MasterEntry_Lines struct {
+	Lines *LinesEntry `protobuf:"bytes,16,opt,name=lines,proto3,oneof"`
+}
+
+func (*MasterEntry_E7) isMasterEntry_XLines() {}
+
+func (*MasterEntry_Lines) isMasterEntry_XLines() {}
+
 var File_entry_proto protoreflect.FileDescriptor
 
 var file_entry_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x63,
 	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x22, 0x07, 0x0a,
-	0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0xc4, 0x01, 0x0a, 0x09, 0x43, 0x65, 0x6c, 0x6c, 0x45,
+	0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0xb8, 0x02, 0x0a, 0x09, 0x43, 0x65, 0x6c, 0x6c, 0x45,
 	0x6e, 0x74, 0x72, 0x79, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73,
 	0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d,
 	0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74,
@@ -518,60 +1192,159 @@ var file_entry_proto_rawDesc = []byte{
 	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
 	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
 	0x69, 0x7a, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x69, 0x64, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x64, 0x12, 0x1d,
-	0x0a, 0x0a, 0x73, 0x68, 0x65, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x73, 0x68, 0x65, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x8c, 0x01,
-	0x0a, 0x0a, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x38, 0x0a, 0x0c,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72,
-	0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0xd9, 0x01, 0x0a,
-	0x0c, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x38, 0x0a,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x73, 0x68, 0x65, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x73, 0x68, 0x65, 0x65, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x76, 0x65,
+	0x72, 0x66, 0x6c, 0x6f, 0x77, 0x18, 0x08, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x6f, 0x76, 0x65,
+	0x72, 0x66, 0x6c, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x19, 0x0a,
+	0x08, 0x74, 0x61, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x74, 0x61, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61, 0x62, 0x5f,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x74, 0x61, 0x62, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x04, 0x08, 0x06, 0x10,
+	0x07, 0x22, 0xc1, 0x01, 0x0a, 0x0a, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x38, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f,
+	0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x63, 0x6f, 0x70, 0x79, 0x5f, 0x6f, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xe9, 0x02, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61,
+	0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10,
+	0x63, 0x65, 0x6c, 0x6c, 0x73, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11,
+	0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x65, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x68, 0x65, 0x65, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x63, 0x6f, 0x70, 0x79, 0x5f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x63,
+	0x6f, 0x70, 0x79, 0x4f, 0x66, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x2d, 0x0a, 0x12, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11,
+	0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x22, 0x8e, 0x01, 0x0a, 0x07, 0x46, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x38, 0x0a,
 	0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75,
 	0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x5f, 0x74, 0x61, 0x62,
-	0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
-	0x65, 0x6c, 0x6c, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x63,
-	0x79, 0x63, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xc9, 0x02, 0x0a, 0x0b, 0x4d, 0x61, 0x73,
-	0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x02, 0x65, 0x31, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f,
-	0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00, 0x52, 0x02, 0x65,
-	0x31, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c,
-	0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x04, 0x63, 0x65,
-	0x6c, 0x6c, 0x12, 0x27, 0x0a, 0x02, 0x65, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x65, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x68, 0x65, 0x65, 0x74, 0x49, 0x64, 0x4a, 0x04, 0x08, 0x03,
+	0x10, 0x04, 0x22, 0x58, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x58, 0x0a, 0x08,
+	0x44, 0x69, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x57, 0x0a, 0x09, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65,
+	0x6d, 0x61, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22,
+	0xa9, 0x02, 0x0a, 0x0a, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x38,
+	0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f,
+	0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x65, 0x65,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x68, 0x65, 0x65,
+	0x74, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x61, 0x78, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e,
+	0x61, 0x6c, 0x2e, 0x41, 0x78, 0x69, 0x73, 0x52, 0x04, 0x61, 0x78, 0x69, 0x73, 0x12, 0x0e, 0x0a,
+	0x02, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x61, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x74, 0x61, 0x62, 0x12, 0x2f, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f,
+	0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x6d, 0x61, 0x70, 0x52,
+	0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x64, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x6d,
+	0x61, 0x70, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0xe2, 0x06, 0x0a, 0x0b,
+	0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x02, 0x65,
+	0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00,
+	0x52, 0x02, 0x65, 0x31, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72,
+	0x6e, 0x61, 0x6c, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52,
+	0x04, 0x63, 0x65, 0x6c, 0x6c, 0x12, 0x27, 0x0a, 0x02, 0x65, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e,
+	0x61, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x01, 0x52, 0x02, 0x65, 0x32, 0x12, 0x32,
+	0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x01, 0x52, 0x05, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x12, 0x27, 0x0a, 0x02, 0x65, 0x33, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x02, 0x52, 0x02, 0x65, 0x33, 0x12, 0x3b, 0x0a, 0x09, 0x6d,
+	0x61, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
 	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x01, 0x52, 0x02, 0x65, 0x32, 0x12, 0x32, 0x0a, 0x05, 0x63,
-	0x68, 0x75, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x43, 0x68, 0x75, 0x6e,
-	0x6b, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x01, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12,
-	0x27, 0x0a, 0x02, 0x65, 0x33, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f,
+	0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x02, 0x52, 0x08,
+	0x6d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x02, 0x65, 0x34, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f,
+	0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x03, 0x52, 0x02, 0x65,
+	0x34, 0x12, 0x29, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x46,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x03, 0x52, 0x02, 0x66, 0x64, 0x12, 0x27, 0x0a, 0x02,
+	0x65, 0x35, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48,
+	0x04, 0x52, 0x02, 0x65, 0x35, 0x12, 0x38, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f,
+	0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x48, 0x04, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x27, 0x0a, 0x02, 0x65, 0x36, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f,
 	0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x48, 0x02, 0x52, 0x02, 0x65, 0x33, 0x12, 0x3b, 0x0a, 0x09, 0x6d, 0x61, 0x70, 0x5f,
-	0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x46, 0x69, 0x6c,
-	0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x02, 0x52, 0x08, 0x6d, 0x61, 0x70,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x43, 0x65, 0x6c, 0x6c, 0x42, 0x08,
-	0x0a, 0x06, 0x5f, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x46, 0x69, 0x6c,
-	0x65, 0x4d, 0x61, 0x70, 0x2a, 0x20, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a,
-	0x07, 0x50, 0x52, 0x45, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x42,
-	0x53, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x42, 0x42, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x6f, 0x75, 0x72, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x2f,
-	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x6a,
-	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x3b, 0x6a, 0x6f, 0x75,
-	0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x74, 0x79, 0x48, 0x05, 0x52, 0x02, 0x65, 0x36, 0x12, 0x2c, 0x0a, 0x03, 0x64, 0x69, 0x72, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a,
+	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x44, 0x69, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48,
+	0x05, 0x52, 0x03, 0x64, 0x69, 0x72, 0x12, 0x27, 0x0a, 0x02, 0x65, 0x37, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72,
+	0x6e, 0x61, 0x6c, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x06, 0x52, 0x02, 0x65, 0x37, 0x12,
+	0x32, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e,
+	0x4c, 0x69, 0x6e, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x06, 0x52, 0x05, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x36, 0x0a, 0x08, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x18, 0x0e, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x75,
+	0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x08, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x12, 0x31, 0x0a, 0x05, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x5f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x42, 0x07, 0x0a, 0x05,
+	0x5f, 0x43, 0x65, 0x6c, 0x6c, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x42,
+	0x0a, 0x0a, 0x08, 0x5f, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x42, 0x05, 0x0a, 0x03, 0x5f,
+	0x46, 0x64, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x06,
+	0x0a, 0x04, 0x5f, 0x44, 0x69, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x4c, 0x69, 0x6e, 0x65, 0x73,
+	0x2a, 0x20, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x45,
+	0x53, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x42, 0x53, 0x45, 0x4e, 0x54,
+	0x10, 0x01, 0x2a, 0x1b, 0x0a, 0x04, 0x41, 0x78, 0x69, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x4f,
+	0x57, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4f, 0x4c, 0x55, 0x4d, 0x4e, 0x10, 0x01, 0x42,
+	0x42, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x6f,
+	0x75, 0x72, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73,
+	0x2f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x65, 0x6e, 0x74, 0x72, 0x79, 0x3b, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x65, 0x6e,
+	0x74, 0x72, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -586,31 +1359,54 @@ func file_entry_proto_rawDescGZIP() []byte {
 	return file_entry_proto_rawDescData
 }
 
-var file_entry_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_entry_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_entry_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_entry_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_entry_proto_goTypes = []interface{}{
 	(State)(0),           // 0: common_journal.State
-	(*Empty)(nil),        // 1: common_journal.Empty
-	(*CellEntry)(nil),    // 2: common_journal.CellEntry
-	(*ChunkEntry)(nil),   // 3: common_journal.ChunkEntry
-	(*FileMapEntry)(nil), // 4: common_journal.FileMapEntry
-	(*MasterEntry)(nil),  // 5: common_journal.MasterEntry
+	(Axis)(0),            // 1: common_journal.Axis
+	(*Empty)(nil),        // 2: common_journal.Empty
+	(*CellEntry)(nil),    // 3: common_journal.CellEntry
+	(*ChunkEntry)(nil),   // 4: common_journal.ChunkEntry
+	(*FileMapEntry)(nil), // 5: common_journal.FileMapEntry
+	(*FdEntry)(nil),      // 6: common_journal.FdEntry
+	(*SessionEntry)(nil), // 7: common_journal.SessionEntry
+	(*DirEntry)(nil),     // 8: common_journal.DirEntry
+	(*CellRemap)(nil),    // 9: common_journal.CellRemap
+	(*LinesEntry)(nil),   // 10: common_journal.LinesEntry
+	(*MasterEntry)(nil),  // 11: common_journal.MasterEntry
 }
 var file_entry_proto_depIdxs = []int32{
-	0, // 0: common_journal.CellEntry.target_state:type_name -> common_journal.State
-	0, // 1: common_journal.ChunkEntry.target_state:type_name -> common_journal.State
-	0, // 2: common_journal.FileMapEntry.target_state:type_name -> common_journal.State
-	1, // 3: common_journal.MasterEntry.e1:type_name -> common_journal.Empty
-	2, // 4: common_journal.MasterEntry.cell:type_name -> common_journal.CellEntry
-	1, // 5: common_journal.MasterEntry.e2:type_name -> common_journal.Empty
-	3, // 6: common_journal.MasterEntry.chunk:type_name -> common_journal.ChunkEntry
-	1, // 7: common_journal.MasterEntry.e3:type_name -> common_journal.Empty
-	4, // 8: common_journal.MasterEntry.map_entry:type_name -> common_journal.FileMapEntry
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	0,  // 0: common_journal.CellEntry.target_state:type_name -> common_journal.State
+	0,  // 1: common_journal.ChunkEntry.target_state:type_name -> common_journal.State
+	0,  // 2: common_journal.FileMapEntry.target_state:type_name -> common_journal.State
+	0,  // 3: common_journal.FdEntry.target_state:type_name -> common_journal.State
+	0,  // 4: common_journal.SessionEntry.target_state:type_name -> common_journal.State
+	0,  // 5: common_journal.DirEntry.target_state:type_name -> common_journal.State
+	0,  // 6: common_journal.LinesEntry.target_state:type_name -> common_journal.State
+	1,  // 7: common_journal.LinesEntry.axis:type_name -> common_journal.Axis
+	9,  // 8: common_journal.LinesEntry.moved:type_name -> common_journal.CellRemap
+	9,  // 9: common_journal.LinesEntry.removed:type_name -> common_journal.CellRemap
+	2,  // 10: common_journal.MasterEntry.e1:type_name -> common_journal.Empty
+	3,  // 11: common_journal.MasterEntry.cell:type_name -> common_journal.CellEntry
+	2,  // 12: common_journal.MasterEntry.e2:type_name -> common_journal.Empty
+	4,  // 13: common_journal.MasterEntry.chunk:type_name -> common_journal.ChunkEntry
+	2,  // 14: common_journal.MasterEntry.e3:type_name -> common_journal.Empty
+	5,  // 15: common_journal.MasterEntry.map_entry:type_name -> common_journal.FileMapEntry
+	2,  // 16: common_journal.MasterEntry.e4:type_name -> common_journal.Empty
+	6,  // 17: common_journal.MasterEntry.fd:type_name -> common_journal.FdEntry
+	2,  // 18: common_journal.MasterEntry.e5:type_name -> common_journal.Empty
+	7,  // 19: common_journal.MasterEntry.session:type_name -> common_journal.SessionEntry
+	2,  // 20: common_journal.MasterEntry.e6:type_name -> common_journal.Empty
+	8,  // 21: common_journal.MasterEntry.dir:type_name -> common_journal.DirEntry
+	2,  // 22: common_journal.MasterEntry.e7:type_name -> common_journal.Empty
+	10, // 23: common_journal.MasterEntry.lines:type_name -> common_journal.LinesEntry
+	4,  // 24: common_journal.MasterEntry.overflow:type_name -> common_journal.ChunkEntry
+	11, // 25: common_journal.MasterEntry.batch:type_name -> common_journal.MasterEntry
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_entry_proto_init() }
@@ -668,6 +1464,66 @@ func file_entry_proto_init() {
 			}
 		}
 		file_entry_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FdEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_entry_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_entry_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DirEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_entry_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellRemap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_entry_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinesEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_entry_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MasterEntry); i {
 			case 0:
 				return &v.state
@@ -680,21 +1536,29 @@ func file_entry_proto_init() {
 			}
 		}
 	}
-	file_entry_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_entry_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*MasterEntry_E1)(nil),
 		(*MasterEntry_Cell)(nil),
 		(*MasterEntry_E2)(nil),
 		(*MasterEntry_Chunk)(nil),
 		(*MasterEntry_E3)(nil),
 		(*MasterEntry_MapEntry)(nil),
+		(*MasterEntry_E4)(nil),
+		(*MasterEntry_Fd)(nil),
+		(*MasterEntry_E5)(nil),
+		(*MasterEntry_Session)(nil),
+		(*MasterEntry_E6)(nil),
+		(*MasterEntry_Dir)(nil),
+		(*MasterEntry_E7)(nil),
+		(*MasterEntry_Lines)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_entry_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -6,11 +6,10 @@ import (
 	"github.com/fourstring/sheetfs/common_journal"
 	"github.com/fourstring/sheetfs/election"
 	"github.com/fourstring/sheetfs/master/filemgr"
-	"github.com/fourstring/sheetfs/master/journal/checkpoint"
 	entry2 "github.com/fourstring/sheetfs/master/journal/journal_entry"
+	"github.com/fourstring/sheetfs/master/metastore"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
-	"gorm.io/gorm"
 )
 
 type ListenerConfig struct {
@@ -19,14 +18,14 @@ type ListenerConfig struct {
 	KafkaServer string
 	KafkaTopic  string
 	FileManager *filemgr.FileManager
-	DB          *gorm.DB
+	DB          metastore.MetadataStore
 }
 
 type Listener struct {
 	elector  *election.Elector
 	receiver *common_journal.Receiver
 	fm       *filemgr.FileManager
-	db       *gorm.DB
+	db       metastore.MetadataStore
 	logger   *zap.Logger
 }
 
@@ -54,8 +53,10 @@ func NewListener(config *ListenerConfig) (*Listener, error) {
 func (l *Listener) RunAsSecondary() error {
 	defer l.logger.Sync()
 
-	ckptOffset := checkpoint.ReadCheckpoint(l.db)
-	err := l.receiver.SetOffset(ckptOffset)
+	ckptOffset, err := l.db.ReadCheckpoint()
+	if err == nil {
+		err = l.receiver.SetOffset(ckptOffset)
+	}
 
 	if err != nil {
 		l.logger.Error("error when loading checkpoint offset.", zap.Error(err))
@@ -108,11 +109,12 @@ func (l *Listener) RunAsSecondary() error {
 }
 
 func (l *Listener) handleCheckpoint(ckpt *common_journal.Checkpoint) error {
-	err := l.fm.Persistent()
+	err := l.fm.PersistentCheckpoint(ckpt.NextEntryOffset)
 	if err != nil {
 		return err
 	}
-	return checkpoint.RecordCheckpoint(l.db, ckpt.NextEntryOffset)
+	l.fm.EvictClosedSheets()
+	return nil
 }
 
 func (l *Listener) handleJournal(entry []byte, ckpt *common_journal.Checkpoint) error {
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"github.com/fourstring/sheetfs/master/config"
+	"github.com/fourstring/sheetfs/master/metastore"
 	"github.com/fourstring/sheetfs/master/node"
 	"log"
 	"strings"
@@ -17,6 +18,9 @@ var electionAck = flag.String("elack", "", "path of znode for acknowledge primar
 var kafkaServer = flag.String("kfserver", "", "address of kafka server")
 var kafkaTopic = flag.String("kftopic", "", "name of kafka topic to rw journals")
 var dataNodeGroups = flag.String("dngroups", "", "comma separated list of datanode groupss")
+var recycleRetention = flag.Duration("retention", config.RecycleRetention, "how long a recycled sheet is kept before deleted permanently")
+var dataNodeAckPrefix = flag.String("dnack", config.DataNodeAckPrefix, "prefix of znodes for acknowledge datanode primaries")
+var metadataBackend = flag.String("store", config.MetadataBackend, "backend of metadata store, sqlite or bolt")
 
 func parseCommaList(l string) []string {
 	return strings.Split(l, ",")
@@ -24,7 +28,7 @@ func parseCommaList(l string) []string {
 
 func main() {
 	flag.Parse()
-	db, err := connectDB(*nodeId)
+	db, err := metastore.Open(*metadataBackend, *nodeId)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -41,7 +45,12 @@ func main() {
 		KafkaTopic:         *kafkaTopic,
 		DB:                 db,
 		CheckpointInterval: config.CheckpointInterval,
+		MonitorInterval:    config.MonitorInterval,
+		CompactInterval:    config.CompactInterval,
+		SessionCheckPeriod: config.SessionCheckPeriod,
+		RecycleRetention:   *recycleRetention,
 		DataNodeGroups:     parseCommaList(*dataNodeGroups),
+		DataNodeAckPrefix:  *dataNodeAckPrefix,
 	}
 	log.Printf("%v\n", cfg)
 	mnode, err := node.NewMasterNode(cfg)
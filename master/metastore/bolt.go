@@ -0,0 +1,273 @@
+package metastore
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
+	"github.com/fourstring/sheetfs/master/sheetfile"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// Maps SheetID to MapEntry.
+	boltEntriesBucket = []byte("entries")
+	// Maps Path to DirEntry.
+	boltDirsBucket     = []byte("dirs")
+	boltFdsBucket      = []byte("fds")
+	boltSessionsBucket = []byte("sessions")
+	// Keeps Counters and the checkpoint.
+	boltMetaBucket    = []byte("meta")
+	boltCountersKey   = []byte("counters")
+	boltCheckpointKey = []byte("checkpoint")
+)
+
+/*
+Bolt
+A MetadataStore backed by bbolt, see sheetfile.BoltStore. It doesn't require cgo, so a
+MasterNode can be built without it.
+
+Metadata of FileManager are stored in their own buckets, encoded in JSON like Cells.
+*/
+type Bolt struct {
+	*sheetfile.BoltStore
+	db *bolt.DB
+}
+
+/*
+OpenBolt
+Open the bbolt database at path, creating it if it doesn't exist.
+*/
+func OpenBolt(path string) (*Bolt, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Bolt{BoltStore: sheetfile.NewBoltStore(db), db: db}, nil
+}
+
+func (s *Bolt) Transaction(fn func(tx MetadataStore) error) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		return fn(&Bolt{BoltStore: s.BoltStore.WithTx(tx), db: s.db})
+	})
+}
+
+func (s *Bolt) Close() error {
+	return s.db.Close()
+}
+
+/*
+forEach
+Decode every value in the bucket with name by decode. Nothing is done if the bucket
+doesn't exist.
+*/
+func (s *Bolt) forEach(name []byte, decode func(v []byte) error) error {
+	return s.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(name)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			return decode(v)
+		})
+	})
+}
+
+/*
+put
+Put value encoded in JSON with key into the bucket with name, creating the bucket if it
+doesn't exist.
+*/
+func (s *Bolt) put(name []byte, key []byte, value interface{}) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(name)
+		if err != nil {
+			return err
+		}
+		return sheetfile.BoltPut(b, key, value)
+	})
+}
+
+/*
+delete
+Delete key from the bucket with name. Nothing is done if the bucket doesn't exist.
+*/
+func (s *Bolt) delete(name []byte, key []byte) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(name)
+		if b == nil {
+			return nil
+		}
+		return b.Delete(key)
+	})
+}
+
+func (s *Bolt) LoadEntries() ([]*mgr_entry.MapEntry, error) {
+	var entries []*mgr_entry.MapEntry
+	err := s.forEach(boltEntriesBucket, func(v []byte) error {
+		var entry mgr_entry.MapEntry
+		err := json.Unmarshal(v, &entry)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, &entry)
+		return nil
+	})
+	return entries, err
+}
+
+func (s *Bolt) SaveEntry(entry *mgr_entry.MapEntry) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(boltEntriesBucket)
+		if err != nil {
+			return err
+		}
+		if entry.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			entry.ID = uint(id)
+		}
+		return sheetfile.BoltPut(b, sheetfile.BoltKey(entry.SheetID), entry)
+	})
+}
+
+func (s *Bolt) DeleteEntry(sheetID uint64) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltEntriesBucket)
+		if b == nil {
+			return nil
+		}
+		return b.Delete(sheetfile.BoltKey(sheetID))
+	})
+}
+
+func (s *Bolt) LoadDirs() ([]*mgr_entry.DirEntry, error) {
+	var dirs []*mgr_entry.DirEntry
+	err := s.forEach(boltDirsBucket, func(v []byte) error {
+		var dir mgr_entry.DirEntry
+		err := json.Unmarshal(v, &dir)
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, &dir)
+		return nil
+	})
+	return dirs, err
+}
+
+func (s *Bolt) SaveDir(dir *mgr_entry.DirEntry) error {
+	return s.put(boltDirsBucket, []byte(dir.Path), dir)
+}
+
+func (s *Bolt) DeleteDir(path string) error {
+	return s.delete(boltDirsBucket, []byte(path))
+}
+
+func (s *Bolt) LoadFds() ([]*mgr_entry.FdEntry, error) {
+	var fds []*mgr_entry.FdEntry
+	err := s.forEach(boltFdsBucket, func(v []byte) error {
+		var fd mgr_entry.FdEntry
+		err := json.Unmarshal(v, &fd)
+		if err != nil {
+			return err
+		}
+		fds = append(fds, &fd)
+		return nil
+	})
+	return fds, err
+}
+
+func (s *Bolt) SaveFd(fd *mgr_entry.FdEntry) error {
+	return s.put(boltFdsBucket, sheetfile.BoltKey(fd.Fd), fd)
+}
+
+func (s *Bolt) DeleteFd(fd uint64) error {
+	return s.delete(boltFdsBucket, sheetfile.BoltKey(fd))
+}
+
+func (s *Bolt) LoadSessions() ([]*mgr_entry.SessionEntry, error) {
+	var sessions []*mgr_entry.SessionEntry
+	err := s.forEach(boltSessionsBucket, func(v []byte) error {
+		var session mgr_entry.SessionEntry
+		err := json.Unmarshal(v, &session)
+		if err != nil {
+			return err
+		}
+		sessions = append(sessions, &session)
+		return nil
+	})
+	return sessions, err
+}
+
+func (s *Bolt) SaveSession(session *mgr_entry.SessionEntry) error {
+	return s.put(boltSessionsBucket, sheetfile.BoltKey(session.ID), session)
+}
+
+func (s *Bolt) DeleteSession(id uint64) error {
+	return s.delete(boltSessionsBucket, sheetfile.BoltKey(id))
+}
+
+/*
+getMeta
+Returns the value of key in the meta bucket, or nil if it doesn't exist.
+*/
+func (s *Bolt) getMeta(key []byte) ([]byte, error) {
+	var buf []byte
+	err := s.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltMetaBucket)
+		if b == nil {
+			return nil
+		}
+		if v := b.Get(key); v != nil {
+			// v is only valid in the transaction.
+			buf = append([]byte{}, v...)
+		}
+		return nil
+	})
+	return buf, err
+}
+
+func (s *Bolt) putMeta(key []byte, value []byte) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(boltMetaBucket)
+		if err != nil {
+			return err
+		}
+		return b.Put(key, value)
+	})
+}
+
+func (s *Bolt) LoadCounters() (*mgr_entry.Counters, error) {
+	buf, err := s.getMeta(boltCountersKey)
+	if err != nil || buf == nil {
+		return nil, err
+	}
+	var counters mgr_entry.Counters
+	err = json.Unmarshal(buf, &counters)
+	if err != nil {
+		return nil, err
+	}
+	return &counters, nil
+}
+
+func (s *Bolt) SaveCounters(counters *mgr_entry.Counters) error {
+	counters.ID = 1
+	buf, err := json.Marshal(counters)
+	if err != nil {
+		return err
+	}
+	return s.putMeta(boltCountersKey, buf)
+}
+
+func (s *Bolt) ReadCheckpoint() (int64, error) {
+	buf, err := s.getMeta(boltCheckpointKey)
+	if err != nil || buf == nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(buf)), nil
+}
+
+func (s *Bolt) RecordCheckpoint(offset int64) error {
+	return s.putMeta(boltCheckpointKey, sheetfile.BoltKey(uint64(offset)))
+}
@@ -0,0 +1,90 @@
+package metastore
+
+import (
+	"fmt"
+	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
+	"github.com/fourstring/sheetfs/master/sheetfile"
+)
+
+/*
+MetadataStore
+Persists all metadata of a MasterNode: MapEntries, directories, the fd table, sessions
+and counters of FileManager, Cells, Chunks and ChunkRefs of SheetFiles(see
+sheetfile.Store), and the offset of the latest checkpoint of journal.
+
+Like sheetfile.Store, all metadata are maintained in memory and flushed during
+checkpointing, which should be done in a Transaction for atomicity.
+
+Implementations are SQLite and Bolt, a MasterNode chooses one of them by Open.
+*/
+type MetadataStore interface {
+	sheetfile.Store
+
+	// Transaction runs fn in a transaction, which is committed if fn returns nil, or
+	// rolled back otherwise. Calling Transaction in a transaction runs fn in it.
+	Transaction(fn func(tx MetadataStore) error) error
+	// Close closes the store, it can't be used any longer.
+	Close() error
+
+	// LoadEntries returns all MapEntries.
+	LoadEntries() ([]*mgr_entry.MapEntry, error)
+	// SaveEntry inserts or updates entry, assigning entry.ID if it's a new MapEntry.
+	SaveEntry(entry *mgr_entry.MapEntry) error
+	// DeleteEntry deletes the MapEntry of sheetID permanently.
+	DeleteEntry(sheetID uint64) error
+
+	// LoadDirs returns all directories.
+	LoadDirs() ([]*mgr_entry.DirEntry, error)
+	// SaveDir inserts dir if it doesn't exist.
+	SaveDir(dir *mgr_entry.DirEntry) error
+	// DeleteDir deletes the directory with path.
+	DeleteDir(path string) error
+
+	// LoadFds returns the whole fd table.
+	LoadFds() ([]*mgr_entry.FdEntry, error)
+	// SaveFd inserts or updates fd.
+	SaveFd(fd *mgr_entry.FdEntry) error
+	// DeleteFd deletes fd from the fd table.
+	DeleteFd(fd uint64) error
+	// LoadSessions returns all sessions.
+	LoadSessions() ([]*mgr_entry.SessionEntry, error)
+	// SaveSession inserts session if it doesn't exist.
+	SaveSession(session *mgr_entry.SessionEntry) error
+	// DeleteSession deletes the session with id.
+	DeleteSession(id uint64) error
+	// LoadCounters returns Counters, or nil if they have never been saved.
+	LoadCounters() (*mgr_entry.Counters, error)
+	// SaveCounters saves counters.
+	SaveCounters(counters *mgr_entry.Counters) error
+
+	// ReadCheckpoint returns the offset of journal to be replayed from, which is 0 if
+	// no checkpoint has been recorded.
+	ReadCheckpoint() (int64, error)
+	// RecordCheckpoint records the offset of journal to be replayed from.
+	RecordCheckpoint(offset int64) error
+}
+
+/*
+Open
+Open the MetadataStore of a MasterNode.
+
+@para
+	backend: "sqlite" or "bolt". The sqlite backend requires cgo, while the bolt one
+	doesn't.
+	name: name of the store, the store is kept in file '{name}.db'.
+
+@return
+	MetadataStore
+	error: errors raised while opening the store, or an unknown backend is given.
+*/
+func Open(backend string, name string) (MetadataStore, error) {
+	path := fmt.Sprintf("%s.db", name)
+	switch backend {
+	case "sqlite":
+		return OpenSQLite(path)
+	case "bolt":
+		return OpenBolt(path)
+	default:
+		return nil, fmt.Errorf("unknown metadata store backend %q", backend)
+	}
+}
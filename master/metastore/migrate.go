@@ -0,0 +1,263 @@
+package metastore
+
+import (
+	"errors"
+	"fmt"
+	"github.com/fourstring/sheetfs/master/config"
+	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+)
+
+/*
+ErrCellsNotMigrated
+Returned by OpenSQLite if Cells are still stored in per-SheetFile tables, see MigrateCells.
+*/
+var ErrCellsNotMigrated = errors.New("cells are stored in per-sheet tables, run the migration command first")
+
+/*
+ErrCellOutOfRange
+Returned by MigrateCells if a Cell created before tabs were introduced has a row which
+can't be addressed any more, see checkUntabbedCells.
+*/
+var ErrCellOutOfRange = errors.New("cell is out of the rows of a tab")
+
+// Prefix of tables storing Cells of a single SheetFile, followed by its sheet ID.
+const legacyCellTablePrefix = "cells_"
+
+/*
+legacyCellTables
+Returns names of all tables storing Cells of a single SheetFile, mapped to sheet IDs
+derived from their names. Tables named after filenames, which are created before sheet
+IDs were introduced, are mapped to 0.
+*/
+func legacyCellTables(db *gorm.DB) (map[string]uint64, error) {
+	var names []string
+	err := db.Raw("SELECT name FROM sqlite_master WHERE type = 'table' AND name LIKE ? ESCAPE '\\';",
+		strings.ReplaceAll(legacyCellTablePrefix, "_", "\\_")+"%").Scan(&names).Error
+	if err != nil {
+		return nil, err
+	}
+	tables := map[string]uint64{}
+	for _, name := range names {
+		id, err := strconv.ParseUint(strings.TrimPrefix(name, legacyCellTablePrefix), 10, 64)
+		if err != nil {
+			id = 0
+		}
+		tables[name] = id
+	}
+	return tables, nil
+}
+
+/*
+needsCellsMigration
+Returns true if there are Cells which are not stored in the table 'cells', that is, tables
+of Cells named after sheet IDs, or MapEntries created before sheet IDs were introduced.
+Tables named after filenames without a MapEntry are unreachable, they are ignored.
+*/
+func needsCellsMigration(db *gorm.DB) (bool, error) {
+	tables, err := legacyCellTables(db)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range tables {
+		if id != 0 {
+			return true, nil
+		}
+	}
+	var legacy int64
+	err = db.Model(&mgr_entry.MapEntry{}).Where("sheet_id = 0 OR sheet_id IS NULL").Count(&legacy).Error
+	return legacy > 0, err
+}
+
+/*
+addColumnIfNotExists
+Add a column with given name and type to table if it's missing.
+
+@return
+	bool: true if the column is added
+	error from execution of queries.
+*/
+func addColumnIfNotExists(db *gorm.DB, table string, name string, typ string) (bool, error) {
+	var n int64
+	err := db.Raw("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?;", table, name).Scan(&n).Error
+	if err != nil || n > 0 {
+		return false, err
+	}
+	err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN `%s` %s;", quoteTable(table), name, typ)).Error
+	return err == nil, err
+}
+
+func quoteTable(table string) string {
+	return "`" + strings.ReplaceAll(table, "`", "``") + "`"
+}
+
+/*
+checkUntabbedCells
+Before tabs were introduced, the CellID of a Cell was row<<32|col, and -1 for the MetaCell.
+They are the same as the CellIDs of those Cells in tab 0 by sheetfile.GetCellID, as long as
+rows are less than config.MaxRows, so such Cells are moved into tab 0 with their CellIDs
+kept. A larger row would collide with Cells of other tabs, so table can't be migrated.
+
+@return
+	ErrCellOutOfRange wrapped with table if some Cell can't be moved into tab 0.
+	error from execution of queries.
+*/
+func checkUntabbedCells(tx *gorm.DB, table string) error {
+	var n int64
+	err := tx.Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE `deleted_at` IS NULL AND `cell_id` <> ? "+
+		"AND (`cell_id` < 0 OR `cell_id` >= ?);", quoteTable(table)),
+		config.SheetMetaCellID, int64(config.MaxRows)<<32).Scan(&n).Error
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%w: %d cells of table %s", ErrCellOutOfRange, n, table)
+	}
+	return nil
+}
+
+/*
+migrateCellTable
+Move Cells in table into the table 'cells' with sheetID, and drop table. Columns introduced
+after table was created are added first. Cells of a table created before lengths were
+recorded are assumed to fill their slots, so their Length is set to Size. Cells of a table
+created before tabs were introduced are moved into tab 0, see checkUntabbedCells, and tabs
+get default names and positions.
+*/
+func migrateCellTable(tx *gorm.DB, table string, sheetID uint64) error {
+	_, err := addColumnIfNotExists(tx, table, "overflow", "text")
+	if err != nil {
+		return err
+	}
+	untabbed, err := addColumnIfNotExists(tx, table, "tab_name", "text DEFAULT ''")
+	if err != nil {
+		return err
+	}
+	if untabbed {
+		err = checkUntabbedCells(tx, table)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = addColumnIfNotExists(tx, table, "tab_position", "integer DEFAULT 0")
+	if err != nil {
+		return err
+	}
+	added, err := addColumnIfNotExists(tx, table, "length", "integer")
+	if err != nil {
+		return err
+	}
+	if added {
+		err = tx.Exec(fmt.Sprintf("UPDATE %s SET `length` = `size`;", quoteTable(table))).Error
+		if err != nil {
+			return err
+		}
+	}
+	// IDs of Cells are reassigned, they are only referenced by the store itself.
+	err = tx.Exec(fmt.Sprintf("INSERT INTO `cells` (`created_at`, `updated_at`, `cell_id`, `offset`, `size`, "+
+		"`chunk_id`, `overflow`, `length`, `tab_name`, `tab_position`, `sheet_id`) "+
+		"SELECT `created_at`, `updated_at`, `cell_id`, `offset`, `size`, `chunk_id`, `overflow`, `length`, "+
+		"`tab_name`, `tab_position`, ? FROM %s WHERE `deleted_at` IS NULL;", quoteTable(table)), sheetID).Error
+	if err != nil {
+		return err
+	}
+	return tx.Exec(fmt.Sprintf("DROP TABLE %s;", quoteTable(table))).Error
+}
+
+/*
+MigrateCells
+Convert a metadata database of MasterNode storing Cells of every SheetFile in a dedicated
+table, named as 'cells_{sheet ID}' or 'cells_{filename}' before sheet IDs were introduced,
+to the layout storing Cells of all SheetFiles in the table 'cells'. MapEntries without sheet
+IDs are assigned ones, which are never reused by FileManager.
+
+Migration is done in a single transaction, so it can be retried if it fails. It must be
+done offline, while no MasterNode is using the database, see also OpenSQLite.
+
+@para
+	db: a gorm connection to the sqlite database of a MasterNode.
+
+@return
+	int: number of migrated tables.
+	error: errors raised while migrating, nothing is changed if it's not nil.
+*/
+func MigrateCells(db *gorm.DB) (int, error) {
+	err := autoMigrate(db)
+	if err != nil {
+		return 0, err
+	}
+	migrated := 0
+	err = db.Transaction(func(tx *gorm.DB) error {
+		tables, err := legacyCellTables(tx)
+		if err != nil {
+			return err
+		}
+		var entries []*mgr_entry.MapEntry
+		err = tx.Find(&entries).Error
+		if err != nil {
+			return err
+		}
+		var counters mgr_entry.Counters
+		err = tx.Limit(1).Find(&counters, 1).Error
+		if err != nil {
+			return err
+		}
+		nextSheetID := counters.NextSheetID
+		for _, entry := range entries {
+			if entry.SheetID >= nextSheetID {
+				nextSheetID = entry.SheetID + 1
+			}
+		}
+		for _, id := range tables {
+			if id >= nextSheetID {
+				nextSheetID = id + 1
+			}
+		}
+		if nextSheetID == 0 {
+			nextSheetID = 1
+		}
+		for _, entry := range entries {
+			table := entry.CellsTableName
+			if entry.SheetID == 0 {
+				entry.SheetID = nextSheetID
+				nextSheetID++
+			} else if table == "" {
+				table = fmt.Sprintf("%s%d", legacyCellTablePrefix, entry.SheetID)
+			}
+			if _, ok := tables[table]; ok {
+				err = migrateCellTable(tx, table, entry.SheetID)
+				if err != nil {
+					return err
+				}
+				delete(tables, table)
+				migrated++
+			}
+			entry.CellsTableName = ""
+			err = tx.Save(entry).Error
+			if err != nil {
+				return err
+			}
+		}
+		// Tables of files created or copied after the last checkpoint have no MapEntry,
+		// they are recovered by replaying journal.
+		for table, id := range tables {
+			if id == 0 {
+				continue
+			}
+			err = migrateCellTable(tx, table, id)
+			if err != nil {
+				return err
+			}
+			migrated++
+		}
+		counters.ID = 1
+		counters.NextSheetID = nextSheetID
+		return tx.Save(&counters).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return migrated, nil
+}
@@ -0,0 +1,115 @@
+package metastore
+
+import (
+	"errors"
+	"fmt"
+	"github.com/fourstring/sheetfs/master/config"
+	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
+	"github.com/fourstring/sheetfs/master/sheetfile"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+// Layout of a Cell table before Cells were stored in a single table.
+const legacyCreateSQL = "CREATE TABLE `%s` (`id` integer,`created_at` datetime,`updated_at` datetime," +
+	"`deleted_at` datetime,`cell_id` integer,`offset` integer,`size` integer,`chunk_id` integer,`overflow` text," +
+	"`length` integer,`tab_name` text,`tab_position` integer,PRIMARY KEY (`id`));"
+
+// Layout of a Cell table before overflow Chunks, lengths and tabs were introduced.
+const oldestCreateSQL = "CREATE TABLE `%s` (`id` integer,`created_at` datetime,`updated_at` datetime," +
+	"`deleted_at` datetime,`cell_id` integer,`offset` integer,`size` integer,`chunk_id` integer,PRIMARY KEY (`id`));"
+
+func TestMigrateCells(t *testing.T) {
+	Convey("Create a database with per-sheet Cell tables", t, func() {
+		path := filepath.Join(t.TempDir(), "test.db")
+		store, err := OpenSQLite(path)
+		So(err, ShouldBeNil)
+		db := store.db
+		So(db.Create(&mgr_entry.MapEntry{FileName: "sheet1", SheetID: 1, CellsTableName: "cells_1"}).Error, ShouldBeNil)
+		So(db.Exec(fmt.Sprintf(legacyCreateSQL, "cells_1")).Error, ShouldBeNil)
+		for i := 0; i < 3; i++ {
+			So(db.Exec("INSERT INTO `cells_1` (`cell_id`, `offset`, `size`, `chunk_id`, `overflow`, `length`, "+
+				"`tab_name`, `tab_position`) VALUES (?, 0, 64, 1, '', 10, '', 0);", i).Error, ShouldBeNil)
+		}
+		// A file created before sheet IDs were introduced.
+		So(db.Exec("INSERT INTO `map_entries` (`file_name`, `cells_table_name`) VALUES ('sheet0', 'cells_sheet0');").Error, ShouldBeNil)
+		So(db.Exec(fmt.Sprintf(oldestCreateSQL, "cells_sheet0")).Error, ShouldBeNil)
+		for _, id := range []int64{-1, 3<<32 | 5} {
+			So(db.Exec("INSERT INTO `cells_sheet0` (`cell_id`, `offset`, `size`, `chunk_id`) VALUES (?, 0, 64, 2);", id).Error, ShouldBeNil)
+		}
+		// A file created after the last checkpoint.
+		So(db.Exec(fmt.Sprintf(legacyCreateSQL, "cells_7")).Error, ShouldBeNil)
+		So(db.Exec("INSERT INTO `cells_7` (`cell_id`, `offset`, `size`, `chunk_id`) VALUES (0, 0, 64, 3);").Error, ShouldBeNil)
+		So(store.Close(), ShouldBeNil)
+
+		Convey("Refuse to open an unmigrated database", func() {
+			_, err := OpenSQLite(path)
+			So(err, ShouldEqual, ErrCellsNotMigrated)
+		})
+
+		Convey("Refuse to migrate rows out of a tab", func() {
+			db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+			So(err, ShouldBeNil)
+			So(db.Exec("INSERT INTO `cells_sheet0` (`cell_id`, `offset`, `size`, `chunk_id`) VALUES (?, 64, 64, 2);",
+				int64(1<<24)<<32).Error, ShouldBeNil)
+			_, err = MigrateCells(db)
+			So(errors.Is(err, ErrCellOutOfRange), ShouldBeTrue)
+			tables, err := legacyCellTables(db)
+			So(err, ShouldBeNil)
+			So(len(tables), ShouldEqual, 3)
+			sqlDB, err := db.DB()
+			So(err, ShouldBeNil)
+			So(sqlDB.Close(), ShouldBeNil)
+		})
+
+		Convey("Migrate and open the database", func() {
+			db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+			So(err, ShouldBeNil)
+			migrated, err := MigrateCells(db)
+			So(err, ShouldBeNil)
+			So(migrated, ShouldEqual, 3)
+			migrated, err = MigrateCells(db)
+			So(err, ShouldBeNil)
+			So(migrated, ShouldEqual, 0)
+			sqlDB, err := db.DB()
+			So(err, ShouldBeNil)
+			So(sqlDB.Close(), ShouldBeNil)
+
+			store, err := OpenSQLite(path)
+			So(err, ShouldBeNil)
+			defer store.Close()
+			cells, err := store.LoadCells(1)
+			So(err, ShouldBeNil)
+			So(len(cells), ShouldEqual, 3)
+			So(cells[0].Length, ShouldEqual, 10)
+			entries, err := store.LoadEntries()
+			So(err, ShouldBeNil)
+			So(len(entries), ShouldEqual, 2)
+			for _, entry := range entries {
+				So(entry.CellsTableName, ShouldBeEmpty)
+				if entry.FileName == "sheet0" {
+					// Sheet IDs of files created after the last checkpoint are not reused.
+					So(entry.SheetID, ShouldEqual, 8)
+				}
+			}
+			cells, err = store.LoadCells(8)
+			So(err, ShouldBeNil)
+			So(len(cells), ShouldEqual, 2)
+			So(cells[0].Length, ShouldEqual, cells[0].Size)
+			So(cells[0].SheetID, ShouldEqual, 8)
+			// Cells created before tabs were introduced are in tab 0.
+			ids := []int64{cells[0].CellID, cells[1].CellID}
+			So(ids, ShouldContain, sheetfile.GetCellID(0, config.SheetMetaCellRow, config.SheetMetaCellCol))
+			So(ids, ShouldContain, sheetfile.GetCellID(0, 3, 5))
+			cells, err = store.LoadCells(7)
+			So(err, ShouldBeNil)
+			So(len(cells), ShouldEqual, 1)
+			counters, err := store.LoadCounters()
+			So(err, ShouldBeNil)
+			So(counters.NextSheetID, ShouldEqual, 9)
+		})
+	})
+}
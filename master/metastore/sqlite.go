@@ -0,0 +1,160 @@
+package metastore
+
+import (
+	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
+	"github.com/fourstring/sheetfs/master/journal/checkpoint"
+	"github.com/fourstring/sheetfs/master/sheetfile"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+/*
+SQLite
+A MetadataStore backed by sqlite through gorm, see sheetfile.SQLiteStore.
+*/
+type SQLite struct {
+	*sheetfile.SQLiteStore
+	db *gorm.DB
+}
+
+/*
+NewSQLite
+Create a SQLite over db, which can be a transaction. All tables should have been migrated,
+see OpenSQLite.
+*/
+func NewSQLite(db *gorm.DB) *SQLite {
+	return &SQLite{SQLiteStore: sheetfile.NewSQLiteStore(db), db: db}
+}
+
+/*
+OpenSQLite
+Open the sqlite database at path, and migrate all tables.
+
+@return
+	*SQLite
+	error: errors raised while opening the database, or ErrCellsNotMigrated if Cells of
+	the database should be migrated by MigrateCells.
+*/
+func OpenSQLite(path string) (*SQLite, error) {
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	err = autoMigrate(db)
+	if err != nil {
+		return nil, err
+	}
+	legacy, err := needsCellsMigration(db)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		return nil, ErrCellsNotMigrated
+	}
+	return NewSQLite(db), nil
+}
+
+/*
+autoMigrate
+Migrate all tables of SQLite.
+*/
+func autoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(&mgr_entry.MapEntry{}, &mgr_entry.FdEntry{}, &mgr_entry.SessionEntry{}, &mgr_entry.DirEntry{}, &mgr_entry.Counters{}, &sheetfile.Chunk{}, &sheetfile.Cell{}, &sheetfile.ChunkRef{}, &checkpoint.Checkpoint{})
+}
+
+func (s *SQLite) Transaction(fn func(tx MetadataStore) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewSQLite(tx))
+	})
+}
+
+func (s *SQLite) Close() error {
+	db, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+func (s *SQLite) LoadEntries() ([]*mgr_entry.MapEntry, error) {
+	var entries []*mgr_entry.MapEntry
+	err := s.db.Find(&entries).Error
+	return entries, err
+}
+
+func (s *SQLite) SaveEntry(entry *mgr_entry.MapEntry) error {
+	return s.db.Save(entry).Error
+}
+
+func (s *SQLite) DeleteEntry(sheetID uint64) error {
+	return s.db.Unscoped().Where("sheet_id = ?", sheetID).Delete(&mgr_entry.MapEntry{}).Error
+}
+
+func (s *SQLite) LoadDirs() ([]*mgr_entry.DirEntry, error) {
+	var dirs []*mgr_entry.DirEntry
+	err := s.db.Find(&dirs).Error
+	return dirs, err
+}
+
+func (s *SQLite) SaveDir(dir *mgr_entry.DirEntry) error {
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(dir).Error
+}
+
+func (s *SQLite) DeleteDir(path string) error {
+	return s.db.Delete(&mgr_entry.DirEntry{Path: path}).Error
+}
+
+func (s *SQLite) LoadFds() ([]*mgr_entry.FdEntry, error) {
+	var fds []*mgr_entry.FdEntry
+	err := s.db.Find(&fds).Error
+	return fds, err
+}
+
+func (s *SQLite) SaveFd(fd *mgr_entry.FdEntry) error {
+	return s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(fd).Error
+}
+
+func (s *SQLite) DeleteFd(fd uint64) error {
+	return s.db.Where("fd = ?", fd).Delete(&mgr_entry.FdEntry{}).Error
+}
+
+func (s *SQLite) LoadSessions() ([]*mgr_entry.SessionEntry, error) {
+	var sessions []*mgr_entry.SessionEntry
+	err := s.db.Find(&sessions).Error
+	return sessions, err
+}
+
+func (s *SQLite) SaveSession(session *mgr_entry.SessionEntry) error {
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(session).Error
+}
+
+func (s *SQLite) DeleteSession(id uint64) error {
+	return s.db.Where("id = ?", id).Delete(&mgr_entry.SessionEntry{}).Error
+}
+
+func (s *SQLite) LoadCounters() (*mgr_entry.Counters, error) {
+	var counters mgr_entry.Counters
+	result := s.db.Limit(1).Find(&counters, 1)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &counters, nil
+}
+
+/*
+SaveCounters
+There is only one Counters whose ID is 1.
+*/
+func (s *SQLite) SaveCounters(counters *mgr_entry.Counters) error {
+	counters.ID = 1
+	return s.db.Save(counters).Error
+}
+
+func (s *SQLite) ReadCheckpoint() (int64, error) {
+	return checkpoint.ReadCheckpoint(s.db), nil
+}
+
+func (s *SQLite) RecordCheckpoint(offset int64) error {
+	return checkpoint.RecordCheckpoint(s.db, offset)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/fourstring/sheetfs/master/metastore"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"log"
+)
+
+/*
+Offline migration of the sqlite metadata store of a MasterNode, which moves Cells
+stored in per-sheet tables into a single table, see metastore.MigrateCells. The
+MasterNode must be stopped while migrating.
+*/
+
+var nodeId = flag.String("i", "", "ID of the node whose database is migrated")
+
+func main() {
+	flag.Parse()
+	path := fmt.Sprintf("%s.db", *nodeId)
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	migrated, err := metastore.MigrateCells(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("migrated %d cell tables of %s\n", migrated, path)
+}
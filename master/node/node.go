@@ -1,16 +1,19 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"github.com/fourstring/sheetfs/common_journal"
 	"github.com/fourstring/sheetfs/election"
+	master_config "github.com/fourstring/sheetfs/master/config"
 	"github.com/fourstring/sheetfs/master/datanode_alloc"
+	"github.com/fourstring/sheetfs/master/datanode_conn"
 	"github.com/fourstring/sheetfs/master/filemgr"
 	"github.com/fourstring/sheetfs/master/journal"
+	"github.com/fourstring/sheetfs/master/metastore"
 	"github.com/fourstring/sheetfs/master/server"
 	fs_rpc "github.com/fourstring/sheetfs/protocol"
 	"google.golang.org/grpc"
-	"gorm.io/gorm"
 	"net"
 	"time"
 )
@@ -26,13 +29,18 @@ type MasterNodeConfig struct {
 	ElectionAck        string
 	KafkaServer        string
 	KafkaTopic         string
-	DB                 *gorm.DB
+	DB                 metastore.MetadataStore
 	CheckpointInterval time.Duration
+	MonitorInterval    time.Duration
+	CompactInterval    time.Duration
+	RecycleRetention   time.Duration
+	SessionCheckPeriod time.Duration
 	DataNodeGroups     []string
+	DataNodeAckPrefix  string
 }
 
 type MasterNode struct {
-	db           *gorm.DB
+	db           metastore.MetadataStore
 	fm           *filemgr.FileManager
 	jWriter      *common_journal.Writer
 	listener     *journal.Listener
@@ -40,16 +48,42 @@ type MasterNode struct {
 	port         uint
 	cAddr        string
 	alloc        *datanode_alloc.DataNodeAllocator
+	conn         *datanode_conn.DataNodeConnector
 	ckptInterval time.Duration
+	monInterval  time.Duration
+	cmpInterval  time.Duration
+	retention    time.Duration
+	sessCheck    time.Duration
 	rpcsrv       *server.Server
 }
 
+/*
+orDefault
+Returns d, or def if d is not set. Intervals are passed to time.NewTicker, which
+panics on non-positive durations.
+*/
+func orDefault(d time.Duration, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+/*
+NewMasterNode
+Construct a MasterNode from config. Intervals and durations left zero in config
+are replaced by defaults in master/config.
+*/
 func NewMasterNode(config *MasterNodeConfig) (*MasterNode, error) {
 	m := &MasterNode{
 		db:           config.DB,
 		port:         config.Port,
 		cAddr:        config.ForClientAddr,
-		ckptInterval: config.CheckpointInterval,
+		ckptInterval: orDefault(config.CheckpointInterval, master_config.CheckpointInterval),
+		monInterval:  orDefault(config.MonitorInterval, master_config.MonitorInterval),
+		cmpInterval:  orDefault(config.CompactInterval, master_config.CompactInterval),
+		retention:    orDefault(config.RecycleRetention, master_config.RecycleRetention),
+		sessCheck:    orDefault(config.SessionCheckPeriod, master_config.SessionCheckPeriod),
 	}
 	elector, err := election.NewElector(config.ZookeeperServers, config.ZookeeperTimeout, config.ElectionZnode, config.ElectionPrefix, config.ElectionAck)
 	if err != nil {
@@ -59,12 +93,18 @@ func NewMasterNode(config *MasterNodeConfig) (*MasterNode, error) {
 
 	m.alloc = datanode_alloc.NewDataNodeAllocatorWithGroups(config.DataNodeGroups)
 
+	conn, err := datanode_conn.NewDataNodeConnector(config.ZookeeperServers, config.ZookeeperTimeout, config.DataNodeAckPrefix)
+	if err != nil {
+		return nil, err
+	}
+	m.conn = conn
+
 	jw, err := common_journal.NewWriter(config.KafkaServer, config.KafkaTopic)
 	if err != nil {
 		return nil, err
 	}
 	m.jWriter = jw
-	m.fm = filemgr.LoadFileManager(config.DB, m.alloc, jw)
+	m.fm = filemgr.LoadFileManager(config.DB, m.alloc, jw, m.conn)
 
 	lis, err := journal.NewListener(&journal.ListenerConfig{
 		NodeID:      config.NodeID,
@@ -107,6 +147,19 @@ func (m *MasterNode) RunAsPrimary() error {
 	if err != nil {
 		return err
 	}
+	// Recycled files should only be deleted by the primary, sessions should only be
+	// expired and files should only be compacted by the primary too, so stop monitoring
+	// once the leadership is lost.
+	lost, err := m.elector.WatchLeadership()
+	if err != nil {
+		return err
+	}
+	monCtx := common_journal.NewZKEventCancelContext(context.Background(), lost)
+	// Leases are not journaled, give clients a full lease to reconnect to the new primary.
+	m.fm.ResetLeases()
+	go m.fm.Monitor(monCtx, m.monInterval, m.retention)
+	go m.fm.Compact(monCtx, m.cmpInterval)
+	go m.fm.MonitorSessions(monCtx, m.sessCheck)
 	go func() {
 		ticker := time.NewTicker(m.ckptInterval)
 		defer ticker.Stop()
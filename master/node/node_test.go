@@ -6,15 +6,13 @@ import (
 	"fmt"
 	"github.com/fourstring/sheetfs/master/config"
 	"github.com/fourstring/sheetfs/master/filemgr"
-	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
-	"github.com/fourstring/sheetfs/master/journal/checkpoint"
+	"github.com/fourstring/sheetfs/master/metastore"
 	"github.com/fourstring/sheetfs/master/server"
 	"github.com/fourstring/sheetfs/master/sheetfile"
 	fs_rpc "github.com/fourstring/sheetfs/protocol"
 	"github.com/fourstring/sheetfs/tests"
 	"github.com/go-zookeeper/zk"
 	. "github.com/smartystreets/goconvey/convey"
-	"gorm.io/gorm"
 	"log"
 	"testing"
 	"time"
@@ -23,7 +21,7 @@ import (
 var ckptInterval = 5 * time.Second
 
 func newTestNode(id string, port uint, caddr string) (*testNode, error) {
-	db, err := tests.GetPersistTestDB(id, &mgr_entry.MapEntry{}, &sheetfile.Chunk{}, &checkpoint.Checkpoint{})
+	db, err := metastore.Open("sqlite", id)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -40,7 +38,12 @@ func newTestNode(id string, port uint, caddr string) (*testNode, error) {
 		KafkaTopic:         config.KafkaTopic,
 		DB:                 db,
 		CheckpointInterval: ckptInterval,
+		MonitorInterval:    config.MonitorInterval,
+		CompactInterval:    config.CompactInterval,
+		SessionCheckPeriod: config.SessionCheckPeriod,
+		RecycleRetention:   config.RecycleRetention,
 		DataNodeGroups:     []string{"node1"},
+		DataNodeAckPrefix:  config.DataNodeAckPrefix,
 	}
 	mnode, err := NewMasterNode(cfg)
 	if err != nil {
@@ -84,7 +87,7 @@ func newTestNodesSet(startPort uint, num int) (map[string]*testNode, error) {
 	return set, nil
 }
 
-func newSuccessorTestNode(id string, port uint, caddr string, db *gorm.DB) (*testNode, error) {
+func newSuccessorTestNode(id string, port uint, caddr string, db metastore.MetadataStore) (*testNode, error) {
 	cfg := &MasterNodeConfig{
 		NodeID:             id,
 		Port:               port,
@@ -98,6 +101,10 @@ func newSuccessorTestNode(id string, port uint, caddr string, db *gorm.DB) (*tes
 		KafkaTopic:         config.KafkaTopic,
 		DB:                 db,
 		CheckpointInterval: ckptInterval,
+		MonitorInterval:    config.MonitorInterval,
+		CompactInterval:    config.CompactInterval,
+		RecycleRetention:   config.RecycleRetention,
+		SessionCheckPeriod: config.SessionCheckPeriod,
 		DataNodeGroups:     []string{"node1"},
 	}
 	mnode, err := NewMasterNode(cfg)
@@ -213,13 +220,13 @@ func populatePrimary(primary *testNode, totalFiles, rowsPerFile, colsPerFile int
 				So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
 				So(rep.Cell, shouldBeCompleteSameCell, &fs_rpc.Cell{
 					Chunk: &fs_rpc.Chunk{
-						Id:        11 + curCellNum/config.MaxCellsPerChunk,
+						Id:        11 + curCellNum/config.DefaultCellsPerChunk,
 						Datanode:  "node1",
 						Version:   1 + curCellNum%4,
 						HoldsMeta: false,
 					},
-					Offset: (curCellNum % 4) * config.MaxBytesPerCell,
-					Size:   config.MaxBytesPerCell,
+					Offset: (curCellNum % 4) * config.DefaultBytesPerCell,
+					Size:   config.DefaultBytesPerCell,
 				})
 			}
 		}
@@ -237,8 +244,10 @@ func populatePrimary(primary *testNode, totalFiles, rowsPerFile, colsPerFile int
 
 func populateCheckpointSuccessor(succ *testNode, totalFiles int) {
 	for i := 0; i < totalFiles; i++ {
-		filename := getTestFilename(i)
-		succ.FM().Opened[filename] = sheetfile.LoadSheetFile(succ.cfg.DB, succ.node.alloc, filename)
+		id := succ.FM().Entries[getTestFilename(i)].SheetID
+		file, err := sheetfile.LoadSheetFile(succ.cfg.DB, succ.node.alloc, nil, id)
+		So(err, ShouldBeNil)
+		succ.FM().Opened[id] = file
 	}
 }
 
@@ -250,19 +259,19 @@ func verifySecondary(secondary *testNode, totalFiles, rowsPerFile, colsPerFile i
 		So(ok, ShouldBeTrue)
 		So(e.FileName, ShouldEqual, filename)
 		So(e.Recycled, ShouldEqual, i%2 == 0)
-		sheet, ok := secondary.FM().Opened[filename]
+		sheet, ok := secondary.FM().Opened[e.SheetID]
 		So(ok, ShouldBeTrue)
 		for j := 0; j < rowsPerFile; j++ {
 			for k := 0; k < colsPerFile; k++ {
 				curCellNum := uint64(i*cellsPerFile + j*colsPerFile + k)
-				cell, ok := sheet.Cells[sheetfile.GetCellID(uint32(j), uint32(k))]
+				cell, ok := sheet.Cells[sheetfile.GetCellID(0, uint32(j), uint32(k))]
 				So(ok, ShouldBeTrue)
 				chunk, ok := sheet.Chunks[cell.ChunkID]
 				So(ok, ShouldBeTrue)
-				So(cell.SheetName, ShouldEqual, filename)
-				So(cell.Size, ShouldEqual, config.MaxBytesPerCell)
-				So(cell.Offset, ShouldEqual, (curCellNum%4)*config.MaxBytesPerCell)
-				So(chunk.ID, ShouldEqual, 11+curCellNum/config.MaxCellsPerChunk)
+				So(cell.SheetID, ShouldEqual, e.SheetID)
+				So(cell.Size, ShouldEqual, config.DefaultBytesPerCell)
+				So(cell.Offset, ShouldEqual, (curCellNum%4)*config.DefaultBytesPerCell)
+				So(chunk.ID, ShouldEqual, 11+curCellNum/config.DefaultCellsPerChunk)
 				So(chunk.DataNode, ShouldEqual, "node1")
 				So(chunk.Version, ShouldEqual, 4)
 				So(len(chunk.Cells), ShouldEqual, 4)
@@ -297,7 +306,7 @@ func TestMasterNodeReplication(t *testing.T) {
 			err = waitPrimaryAck(zkConn, ckptSuccessor)
 			So(err, ShouldBeNil)
 			verifySecondary(ckptSuccessor, totalFiles, rowsPerFile, colsPerFile)
-			db, err := tests.GetPersistTestDB("fresh-successor", &mgr_entry.MapEntry{}, &sheetfile.Chunk{}, &checkpoint.Checkpoint{})
+			db, err := metastore.Open("sqlite", "fresh-successor")
 			freshSuccessor, err := newSuccessorTestNode("fresh-successor", 18432, "127.0.0.1:18432", db)
 			So(err, ShouldBeNil)
 			err = waitPrimaryAck(zkConn, freshSuccessor)
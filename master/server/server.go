@@ -2,9 +2,11 @@ package server
 
 import (
 	context "context"
+	"github.com/fourstring/sheetfs/master/config"
 	"github.com/fourstring/sheetfs/master/datanode_alloc"
 	"github.com/fourstring/sheetfs/master/filemgr"
 	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
+	"github.com/fourstring/sheetfs/master/sheetfile"
 	fs_rpc "github.com/fourstring/sheetfs/protocol"
 	"go.uber.org/zap"
 )
@@ -53,6 +55,30 @@ func (s *Server) defaultErrorHandler(err error, status *fs_rpc.Status) {
 		*status = fs_rpc.Status_NotFound
 	case *file_errors.FdNotFoundError:
 		*status = fs_rpc.Status_NotFound
+	case *file_errors.SessionNotFoundError:
+		*status = fs_rpc.Status_Expired
+	case *file_errors.DirNotFoundError:
+		*status = fs_rpc.Status_NotFound
+	case *file_errors.DirNotEmptyError:
+		*status = fs_rpc.Status_NotEmpty
+	case *file_errors.InvalidPathError:
+		*status = fs_rpc.Status_Invalid
+	case *file_errors.InvalidPageTokenError:
+		*status = fs_rpc.Status_Invalid
+	case *file_errors.CellTooLargeError:
+		*status = fs_rpc.Status_Invalid
+	case *file_errors.InvalidLinesError:
+		*status = fs_rpc.Status_Invalid
+	case *file_errors.DuplicateCellError:
+		*status = fs_rpc.Status_Invalid
+	case *file_errors.InvalidCellError:
+		*status = fs_rpc.Status_Invalid
+	case *file_errors.TabNotFoundError:
+		*status = fs_rpc.Status_Invalid
+	case *file_errors.TabExistsError:
+		*status = fs_rpc.Status_Exist
+	case *file_errors.InvalidTabError:
+		*status = fs_rpc.Status_Invalid
 	case *datanode_alloc.NoDataNodeError:
 		*status = fs_rpc.Status_Unavailable
 	default:
@@ -61,6 +87,122 @@ func (s *Server) defaultErrorHandler(err error, status *fs_rpc.Status) {
 	s.logger.Error("MasterNode:", zap.Error(err))
 }
 
+/*
+checkCell
+Check that the Cell located at (row, col) can be addressed, that is, row is less than
+config.MaxRows unless it's a MetaCell, see sheetfile.GetCellID.
+
+@return
+	*errors.InvalidCellError if the Cell can't be addressed.
+*/
+func checkCell(row uint32, col uint32) error {
+	if row >= config.MaxRows && (row != config.SheetMetaCellRow || col != config.SheetMetaCellCol) {
+		return file_errors.NewInvalidCellError(row, col)
+	}
+	return nil
+}
+
+/*
+checkRows
+Check that rows inserted or deleted at row can be addressed, see checkCell.
+
+@return
+	*errors.InvalidLinesError if row is not less than config.MaxRows.
+*/
+func checkRows(row uint32, count uint32) error {
+	if row >= config.MaxRows {
+		return file_errors.NewInvalidLinesError(row, count)
+	}
+	return nil
+}
+
+/*
+toPbChunk
+Convert a Chunk to the protobuf fs_rpc.Chunk model.
+*/
+func toPbChunk(c *sheetfile.Chunk) *fs_rpc.Chunk {
+	return &fs_rpc.Chunk{
+		Id:        c.ID,
+		Datanode:  c.DataNode,
+		Version:   c.Version,
+		HoldsMeta: len(c.Cells) == 1 && c.Cells[0].IsMeta(),
+	}
+}
+
+/*
+toPbCell
+Convert a Cell, its Chunk and overflow Chunks to the protobuf fs_rpc.Cell model.
+*/
+func toPbCell(cell *sheetfile.Cell, dataChunk *sheetfile.Chunk, overflow []*sheetfile.Chunk) *fs_rpc.Cell {
+	pbCell := &fs_rpc.Cell{
+		Chunk:   toPbChunk(dataChunk),
+		Offset:  cell.Offset,
+		Size:    cell.Size,
+		Length:  cell.Length,
+		Version: dataChunk.SlotVersion(cell.Offset),
+	}
+	for _, c := range overflow {
+		pbCell.Overflow = append(pbCell.Overflow, toPbChunk(c))
+	}
+	return pbCell
+}
+
+/*
+toPbExtentChunks
+Convert Chunks to protobuf fs_rpc.Chunk models with extents of their Cells, so clients
+can strip padding. Overflow Chunks in chunks are returned along with the Cells owning
+them rather than on their own, so clients can splice them into the right place.
+*/
+func toPbExtentChunks(chunks []*sheetfile.Chunk) []*fs_rpc.Chunk {
+	overflows := map[uint64]*sheetfile.Chunk{}
+	for _, c := range chunks {
+		if c.Overflow {
+			overflows[c.ID] = c
+		}
+	}
+	pbChunks := make([]*fs_rpc.Chunk, 0, len(chunks)-len(overflows))
+	for _, c := range chunks {
+		if c.Overflow {
+			continue
+		}
+		pbChunk := toPbChunk(c)
+		for _, cell := range c.Cells {
+			tab, row, col := sheetfile.SplitCellID(cell.CellID)
+			pbExtent := &fs_rpc.CellExtent{
+				Offset:  cell.Offset,
+				Size:    cell.Size,
+				Length:  cell.Length,
+				Row:     row,
+				Column:  col,
+				Version: c.SlotVersion(cell.Offset),
+				Tab:     tab,
+			}
+			for _, id := range cell.Overflow {
+				pbExtent.Chunks = append(pbExtent.Chunks, toPbChunk(overflows[id]))
+			}
+			pbChunk.Extents = append(pbChunk.Extents, pbExtent)
+		}
+		pbChunks = append(pbChunks, pbChunk)
+	}
+	return pbChunks
+}
+
+/*
+toPbTabs
+Convert Tabs to protobuf fs_rpc.Tab models.
+*/
+func toPbTabs(tabs []*sheetfile.Tab) []*fs_rpc.Tab {
+	pbTabs := make([]*fs_rpc.Tab, len(tabs))
+	for i, tab := range tabs {
+		pbTabs[i] = &fs_rpc.Tab{
+			Id:       tab.ID,
+			Name:     tab.Name,
+			Position: tab.Position,
+		}
+	}
+	return pbTabs
+}
+
 func (s *Server) RegisterDataNode(ctx context.Context, request *fs_rpc.RegisterDataNodeRequest) (*fs_rpc.RegisterDataNodeReply, error) {
 	s.alloc.AddDataNode(request.Addr)
 	return &fs_rpc.RegisterDataNodeReply{Status: fs_rpc.Status_OK}, nil
@@ -68,7 +210,14 @@ func (s *Server) RegisterDataNode(ctx context.Context, request *fs_rpc.RegisterD
 
 func (s *Server) ReadSheet(ctx context.Context, request *fs_rpc.ReadSheetRequest) (*fs_rpc.ReadSheetReply, error) {
 	status := fs_rpc.Status_OK
-	chunks, err := s.fileMgr.ReadSheet(request.Fd)
+	var tabs []*sheetfile.Tab
+	var chunks []*sheetfile.Chunk
+	var err error
+	if request.AllTabs {
+		tabs, chunks, err = s.fileMgr.ReadWorkbook(request.Fd)
+	} else {
+		chunks, err = s.fileMgr.ReadSheet(request.Fd, request.Tab)
+	}
 
 	if err != nil {
 		s.defaultErrorHandler(err, &status)
@@ -77,25 +226,33 @@ func (s *Server) ReadSheet(ctx context.Context, request *fs_rpc.ReadSheetRequest
 		}, nil
 	}
 
-	pbChunks := make([]*fs_rpc.Chunk, len(chunks))
-	for i, c := range chunks {
-		pbChunks[i] = &fs_rpc.Chunk{
-			Id:        c.ID,
-			Datanode:  c.DataNode,
-			Version:   c.Version,
-			HoldsMeta: len(c.Cells) == 1 && c.Cells[0].IsMeta(),
-		}
-	}
 	reply := &fs_rpc.ReadSheetReply{
 		Status: status,
-		Chunks: pbChunks,
+		Chunks: toPbExtentChunks(chunks),
+		Tabs:   toPbTabs(tabs),
 	}
 	return reply, nil
 }
 
+func (s *Server) ReadCells(ctx context.Context, request *fs_rpc.ReadCellsRequest) (*fs_rpc.ReadCellsReply, error) {
+	status := fs_rpc.Status_OK
+	chunks, err := s.fileMgr.ReadFileCells(request.Fd, request.Tab, request.RowStart, request.RowEnd,
+		request.ColumnStart, request.ColumnEnd)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.ReadCellsReply{
+			Status: status,
+		}, nil
+	}
+	return &fs_rpc.ReadCellsReply{
+		Status: status,
+		Chunks: toPbExtentChunks(chunks),
+	}, nil
+}
+
 func (s *Server) CreateSheet(ctx context.Context, request *fs_rpc.CreateSheetRequest) (*fs_rpc.CreateSheetReply, error) {
 	status := fs_rpc.Status_OK
-	fd, err := s.fileMgr.CreateSheet(request.Filename)
+	fd, err := s.fileMgr.CreateSheet(request.Filename, request.Session)
 	if err != nil {
 		s.defaultErrorHandler(err, &status)
 		return &fs_rpc.CreateSheetReply{
@@ -110,12 +267,68 @@ func (s *Server) CreateSheet(ctx context.Context, request *fs_rpc.CreateSheetReq
 }
 
 func (s *Server) DeleteSheet(ctx context.Context, request *fs_rpc.DeleteSheetRequest) (*fs_rpc.DeleteSheetReply, error) {
-	panic("implement me")
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.DeleteSheet(request.Filename)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.DeleteSheetReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) RenameSheet(ctx context.Context, request *fs_rpc.RenameSheetRequest) (*fs_rpc.RenameSheetReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.RenameSheet(request.Filename, request.NewFilename)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.RenameSheetReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) CopySheet(ctx context.Context, request *fs_rpc.CopySheetRequest) (*fs_rpc.CopySheetReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.CopySheet(request.Filename, request.NewFilename)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.CopySheetReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) StatSheet(ctx context.Context, request *fs_rpc.StatSheetRequest) (*fs_rpc.StatSheetReply, error) {
+	status := fs_rpc.Status_OK
+	var stat *fs_rpc.SheetStat
+	var err error
+	switch target := request.Target.(type) {
+	case *fs_rpc.StatSheetRequest_Filename:
+		stat, err = s.fileMgr.StatSheet(target.Filename)
+	case *fs_rpc.StatSheetRequest_Fd:
+		stat, err = s.fileMgr.StatSheetByFd(target.Fd)
+	default:
+		return &fs_rpc.StatSheetReply{
+			Status: fs_rpc.Status_Invalid,
+		}, nil
+	}
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.StatSheetReply{
+			Status: status,
+		}, nil
+	}
+
+	return &fs_rpc.StatSheetReply{
+		Status: status,
+		Stat:   stat,
+	}, nil
 }
 
 func (s *Server) OpenSheet(ctx context.Context, request *fs_rpc.OpenSheetRequest) (*fs_rpc.OpenSheetReply, error) {
 	status := fs_rpc.Status_OK
-	fd, err := s.fileMgr.OpenSheet(request.Filename)
+	fd, err := s.fileMgr.OpenSheet(request.Filename, request.Session)
 	if err != nil {
 		s.defaultErrorHandler(err, &status)
 		return &fs_rpc.OpenSheetReply{
@@ -129,6 +342,56 @@ func (s *Server) OpenSheet(ctx context.Context, request *fs_rpc.OpenSheetRequest
 	}, nil
 }
 
+func (s *Server) CloseSheet(ctx context.Context, request *fs_rpc.CloseSheetRequest) (*fs_rpc.CloseSheetReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.CloseSheet(request.Fd)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.CloseSheetReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) OpenSession(ctx context.Context, empty *fs_rpc.Empty) (*fs_rpc.OpenSessionReply, error) {
+	status := fs_rpc.Status_OK
+	session, lease, err := s.fileMgr.OpenSession()
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.OpenSessionReply{
+			Status: status,
+		}, nil
+	}
+
+	return &fs_rpc.OpenSessionReply{
+		Status:  status,
+		Session: session,
+		Lease:   uint64(lease.Milliseconds()),
+	}, nil
+}
+
+func (s *Server) KeepAlive(ctx context.Context, request *fs_rpc.KeepAliveRequest) (*fs_rpc.KeepAliveReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.KeepAlive(request.Session)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.KeepAliveReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) CloseSession(ctx context.Context, request *fs_rpc.CloseSessionRequest) (*fs_rpc.CloseSessionReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.CloseSession(request.Session)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.CloseSessionReply{
+		Status: status,
+	}, nil
+}
+
 func (s *Server) RecycleSheet(ctx context.Context, request *fs_rpc.RecycleSheetRequest) (*fs_rpc.RecycleSheetReply, error) {
 	status := fs_rpc.Status_OK
 	s.fileMgr.RecycleSheet(request.Filename)
@@ -145,18 +408,70 @@ func (s *Server) ResumeSheet(ctx context.Context, request *fs_rpc.ResumeSheetReq
 	}, nil
 }
 
-func (s *Server) ListSheets(ctx context.Context, empty *fs_rpc.Empty) (*fs_rpc.ListSheetsReply, error) {
+func (s *Server) ListSheets(ctx context.Context, request *fs_rpc.ListSheetsRequest) (*fs_rpc.ListSheetsReply, error) {
 	status := fs_rpc.Status_OK
-	sheets := s.fileMgr.GetAllSheets()
+	sheets, next, err := s.fileMgr.ListSheets(request)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.ListSheetsReply{
+			Status: status,
+		}, nil
+	}
 	return &fs_rpc.ListSheetsReply{
+		Status:        status,
+		Sheets:        sheets,
+		NextPageToken: next,
+	}, nil
+}
+
+func (s *Server) MkDir(ctx context.Context, request *fs_rpc.MkDirRequest) (*fs_rpc.MkDirReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.MkDir(request.Path)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.MkDirReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) RmDir(ctx context.Context, request *fs_rpc.RmDirRequest) (*fs_rpc.RmDirReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.RmDir(request.Path, request.Recursive)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.RmDirReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) ListDir(ctx context.Context, request *fs_rpc.ListDirRequest) (*fs_rpc.ListDirReply, error) {
+	status := fs_rpc.Status_OK
+	dirs, sheets, err := s.fileMgr.ListDir(request.Path)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.ListDirReply{
+			Status: status,
+		}, nil
+	}
+	return &fs_rpc.ListDirReply{
 		Status: status,
+		Dirs:   dirs,
 		Sheets: sheets,
 	}, nil
 }
 
 func (s *Server) ReadCell(ctx context.Context, request *fs_rpc.ReadCellRequest) (*fs_rpc.ReadCellReply, error) {
 	status := fs_rpc.Status_OK
-	cell, dataChunk, err := s.fileMgr.ReadFileCell(request.Fd, request.Row, request.Column)
+	err := checkCell(request.Row, request.Column)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.ReadCellReply{
+			Status: status,
+		}, nil
+	}
+	cell, dataChunk, overflow, err := s.fileMgr.ReadFileCell(request.Fd, request.Tab, request.Row, request.Column)
 	if err != nil {
 		s.defaultErrorHandler(err, &status)
 		return &fs_rpc.ReadCellReply{
@@ -165,38 +480,204 @@ func (s *Server) ReadCell(ctx context.Context, request *fs_rpc.ReadCellRequest)
 	}
 	return &fs_rpc.ReadCellReply{
 		Status: status,
-		Cell: &fs_rpc.Cell{
-			Chunk: &fs_rpc.Chunk{
-				Id:        dataChunk.ID,
-				Datanode:  dataChunk.DataNode,
-				Version:   dataChunk.Version,
-				HoldsMeta: len(dataChunk.Cells) == 1 && dataChunk.Cells[0].IsMeta(),
-			},
-			Offset: cell.Offset,
-			Size:   cell.Size,
-		},
+		Cell:   toPbCell(cell, dataChunk, overflow),
 	}, nil
 }
 
 func (s *Server) WriteCell(ctx context.Context, request *fs_rpc.WriteCellRequest) (*fs_rpc.WriteCellReply, error) {
 	status := fs_rpc.Status_OK
-	cell, dataChunk, err := s.fileMgr.WriteFileCell(request.Fd, request.Row, request.Column)
+	err := checkCell(request.Row, request.Column)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.WriteCellReply{
+			Status: status,
+		}, nil
+	}
+	cell, dataChunk, overflow, moved, err := s.fileMgr.WriteFileCell(request.Fd, request.Tab, request.Row, request.Column, request.Size)
 	if err != nil {
 		s.defaultErrorHandler(err, &status)
 		return &fs_rpc.WriteCellReply{
 			Status: status,
 		}, nil
 	}
-	return &fs_rpc.WriteCellReply{
+	reply := &fs_rpc.WriteCellReply{
+		Status: status,
+		Cell:   toPbCell(cell, dataChunk, overflow),
+	}
+	if moved != nil && moved.Chunk != nil {
+		reply.Vacated = toPbCell(moved.Cell, moved.Chunk, nil)
+	}
+	return reply, nil
+}
+
+func (s *Server) WriteCells(ctx context.Context, request *fs_rpc.WriteCellsRequest) (*fs_rpc.WriteCellsReply, error) {
+	status := fs_rpc.Status_OK
+	writes := make([]sheetfile.CellWrite, len(request.Cells))
+	for i, w := range request.Cells {
+		err := checkCell(w.Row, w.Column)
+		if err != nil {
+			s.defaultErrorHandler(err, &status)
+			return &fs_rpc.WriteCellsReply{
+				Status: status,
+			}, nil
+		}
+		writes[i] = sheetfile.CellWrite{Tab: w.Tab, Row: w.Row, Col: w.Column, Size: w.Size}
+	}
+	results, err := s.fileMgr.WriteFileCells(request.Fd, writes)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	// Writes performed before an error have increased versions of their slots, so they
+	// are returned along with the error status for the client to finish them.
+	reply := &fs_rpc.WriteCellsReply{
+		Status: status,
+		Cells:  make([]*fs_rpc.Cell, len(results)),
+	}
+	for i, r := range results {
+		reply.Cells[i] = toPbCell(r.Cell, r.Chunk, r.Overflow)
+		if r.Moved != nil && r.Moved.Chunk != nil {
+			reply.Vacated = append(reply.Vacated, toPbCell(r.Moved.Cell, r.Moved.Chunk, nil))
+		}
+	}
+	return reply, nil
+}
+
+func (s *Server) DeleteCell(ctx context.Context, request *fs_rpc.DeleteCellRequest) (*fs_rpc.DeleteCellReply, error) {
+	status := fs_rpc.Status_OK
+	err := checkCell(request.Row, request.Column)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.DeleteCellReply{
+			Status: status,
+		}, nil
+	}
+	cell, dataChunk, err := s.fileMgr.DeleteFileCell(request.Fd, request.Tab, request.Row, request.Column)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.DeleteCellReply{
+			Status: status,
+		}, nil
+	}
+	if dataChunk == nil {
+		return &fs_rpc.DeleteCellReply{
+			Status: status,
+		}, nil
+	}
+	return &fs_rpc.DeleteCellReply{
+		Status: status,
+		Cell:   toPbCell(cell, dataChunk, nil),
+	}, nil
+}
+
+func (s *Server) InsertRows(ctx context.Context, request *fs_rpc.InsertRowsRequest) (*fs_rpc.InsertRowsReply, error) {
+	status := fs_rpc.Status_OK
+	err := checkRows(request.Row, request.Count)
+	if err == nil {
+		err = s.fileMgr.InsertRows(request.Fd, request.Tab, request.Row, request.Count)
+	}
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.InsertRowsReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) DeleteRows(ctx context.Context, request *fs_rpc.DeleteRowsRequest) (*fs_rpc.DeleteRowsReply, error) {
+	status := fs_rpc.Status_OK
+	err := checkRows(request.Row, request.Count)
+	if err == nil {
+		err = s.fileMgr.DeleteRows(request.Fd, request.Tab, request.Row, request.Count)
+	}
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.DeleteRowsReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) InsertColumns(ctx context.Context, request *fs_rpc.InsertColumnsRequest) (*fs_rpc.InsertColumnsReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.InsertColumns(request.Fd, request.Tab, request.Column, request.Count)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.InsertColumnsReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) DeleteColumns(ctx context.Context, request *fs_rpc.DeleteColumnsRequest) (*fs_rpc.DeleteColumnsReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.DeleteColumns(request.Fd, request.Tab, request.Column, request.Count)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.DeleteColumnsReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) ListTabs(ctx context.Context, request *fs_rpc.ListTabsRequest) (*fs_rpc.ListTabsReply, error) {
+	status := fs_rpc.Status_OK
+	tabs, err := s.fileMgr.ListTabs(request.Fd)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.ListTabsReply{
+			Status: status,
+		}, nil
+	}
+	return &fs_rpc.ListTabsReply{
+		Status: status,
+		Tabs:   toPbTabs(tabs),
+	}, nil
+}
+
+func (s *Server) CreateTab(ctx context.Context, request *fs_rpc.CreateTabRequest) (*fs_rpc.CreateTabReply, error) {
+	status := fs_rpc.Status_OK
+	tab, err := s.fileMgr.CreateTab(request.Fd, request.Name)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+		return &fs_rpc.CreateTabReply{
+			Status: status,
+		}, nil
+	}
+	return &fs_rpc.CreateTabReply{
+		Status: status,
+		Tab:    tab,
+	}, nil
+}
+
+func (s *Server) RenameTab(ctx context.Context, request *fs_rpc.RenameTabRequest) (*fs_rpc.RenameTabReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.RenameTab(request.Fd, request.Tab, request.Name)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.RenameTabReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) MoveTab(ctx context.Context, request *fs_rpc.MoveTabRequest) (*fs_rpc.MoveTabReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.MoveTab(request.Fd, request.Tab, request.Position)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.MoveTabReply{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) DeleteTab(ctx context.Context, request *fs_rpc.DeleteTabRequest) (*fs_rpc.DeleteTabReply, error) {
+	status := fs_rpc.Status_OK
+	err := s.fileMgr.DeleteTab(request.Fd, request.Tab)
+	if err != nil {
+		s.defaultErrorHandler(err, &status)
+	}
+	return &fs_rpc.DeleteTabReply{
 		Status: status,
-		Cell: &fs_rpc.Cell{
-			Chunk: &fs_rpc.Chunk{
-				Id:       dataChunk.ID,
-				Datanode: dataChunk.DataNode,
-				Version:  dataChunk.Version,
-			},
-			Offset: cell.Offset,
-			Size:   cell.Size,
-		},
 	}, nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/fourstring/sheetfs/master/filemgr"
 	"github.com/fourstring/sheetfs/master/filemgr/mgr_entry"
 	"github.com/fourstring/sheetfs/master/journal/checkpoint"
+	"github.com/fourstring/sheetfs/master/metastore"
 	"github.com/fourstring/sheetfs/master/sheetfile"
 	fs_rpc "github.com/fourstring/sheetfs/protocol"
 	"github.com/fourstring/sheetfs/tests"
@@ -19,13 +20,13 @@ import (
 var ctx = goctx.Background()
 
 func newTestServer() (*Server, error) {
-	db, err := tests.GetTestDB(&mgr_entry.MapEntry{}, &sheetfile.Chunk{}, &checkpoint.Checkpoint{})
+	db, err := tests.GetTestDB(&mgr_entry.MapEntry{}, &mgr_entry.FdEntry{}, &mgr_entry.SessionEntry{}, &mgr_entry.DirEntry{}, &mgr_entry.Counters{}, &sheetfile.Chunk{}, &sheetfile.Cell{}, &sheetfile.ChunkRef{}, &checkpoint.Checkpoint{})
 	if err != nil {
 		return nil, err
 	}
 	alloc := datanode_alloc.NewDataNodeAllocator()
 	alloc.AddDataNode("node1")
-	fm := filemgr.LoadFileManager(db, alloc, nil)
+	fm := filemgr.LoadFileManager(metastore.NewSQLite(db), alloc, nil, nil)
 	s, err := NewServer(fm, alloc)
 	if err != nil {
 		return nil, err
@@ -69,10 +70,10 @@ func shouldBeSameCell(actual interface{}, expected ...interface{}) string {
 
 func TestServer_RegisterDataNode(t *testing.T) {
 	Convey("Build test server", t, func() {
-		db, err := tests.GetTestDB(&mgr_entry.MapEntry{}, &sheetfile.Chunk{}, &checkpoint.Checkpoint{})
+		db, err := tests.GetTestDB(&mgr_entry.MapEntry{}, &mgr_entry.FdEntry{}, &mgr_entry.SessionEntry{}, &mgr_entry.DirEntry{}, &mgr_entry.Counters{}, &sheetfile.Chunk{}, &sheetfile.Cell{}, &sheetfile.ChunkRef{}, &checkpoint.Checkpoint{})
 		So(err, ShouldBeNil)
 		alloc := datanode_alloc.NewDataNodeAllocator()
-		fm := filemgr.LoadFileManager(db, alloc, nil)
+		fm := filemgr.LoadFileManager(metastore.NewSQLite(db), alloc, nil, nil)
 		s, err := NewServer(fm, alloc)
 		So(err, ShouldBeNil)
 		Convey("Call rpc method", func() {
@@ -155,6 +156,95 @@ func TestServer_ReadCell(t *testing.T) {
 	})
 }
 
+func TestServer_ReadCells(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file and write cells", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			for _, pos := range [][2]uint32{{0, 0}, {0, 1}, {1, 0}} {
+				rep2, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: rep.Fd, Row: pos[0], Column: pos[1]})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+			}
+			Convey("Read a range of cells", func() {
+				rep2, err := s.ReadCells(ctx, &fs_rpc.ReadCellsRequest{Fd: rep.Fd, RowStart: 0, RowEnd: 1, ColumnStart: 0, ColumnEnd: 2})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(len(rep2.Chunks), ShouldEqual, 1)
+				extents := rep2.Chunks[0].Extents
+				So(len(extents), ShouldEqual, 2)
+				So(extents[0].Row, ShouldEqual, 0)
+				So(extents[0].Column, ShouldEqual, 0)
+				So(extents[1].Row, ShouldEqual, 0)
+				So(extents[1].Column, ShouldEqual, 1)
+				So(extents[1].Offset, ShouldEqual, extents[0].Offset+extents[0].Size)
+				// Cells sharing the Chunk are versioned separately.
+				So(extents[0].Version, ShouldEqual, 1)
+				So(extents[1].Version, ShouldEqual, 1)
+				So(rep2.Chunks[0].Version, ShouldEqual, 3)
+			})
+			Convey("Read a range of an invalid fd", func() {
+				rep2, err := s.ReadCells(ctx, &fs_rpc.ReadCellsRequest{Fd: rep.Fd + 1, RowEnd: 1, ColumnEnd: 1})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_NotFound)
+			})
+		})
+	})
+}
+
+func TestServer_WriteCells(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file and write cells", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			rep2, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: rep.Fd, Row: 5, Column: 5})
+			So(err, ShouldBeNil)
+			So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+			rep2, err = s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: rep.Fd, Row: 0, Column: 0})
+			So(err, ShouldBeNil)
+			So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+			Convey("Write cells in a batch", func() {
+				rep3, err := s.WriteCells(ctx, &fs_rpc.WriteCellsRequest{Fd: rep.Fd, Cells: []*fs_rpc.CellWrite{
+					{Row: 0, Column: 0, Size: 3000},
+					{Row: 0, Column: 1},
+					{Row: 1, Column: 0},
+				}})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(len(rep3.Cells), ShouldEqual, 3)
+				So(rep3.Cells[0].Chunk.Id, ShouldNotEqual, rep2.Cell.Chunk.Id)
+				So(rep3.Cells[1].Chunk.Id, ShouldEqual, rep2.Cell.Chunk.Id)
+				So(rep3.Cells[0].Version, ShouldEqual, 1)
+				So(len(rep3.Vacated), ShouldEqual, 1)
+				So(rep3.Vacated[0].Offset, ShouldEqual, rep2.Cell.Offset)
+				So(rep3.Vacated[0].Version, ShouldEqual, rep2.Cell.Version+1)
+				// The vacated slot is reused by (0,1) in the same batch.
+				So(rep3.Cells[1].Offset, ShouldEqual, rep2.Cell.Offset)
+				So(rep3.Cells[1].Version, ShouldEqual, rep3.Vacated[0].Version)
+				So(rep3.Cells[2].Version, ShouldEqual, 1)
+				So(rep3.Cells[2].Chunk.Version, ShouldEqual, rep2.Cell.Chunk.Version+2)
+			})
+			Convey("Write invalid batches", func() {
+				rep3, err := s.WriteCells(ctx, &fs_rpc.WriteCellsRequest{Fd: rep.Fd, Cells: []*fs_rpc.CellWrite{
+					{Row: 1, Column: 1},
+					{Row: 1, Column: 1},
+				}})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep3, err = s.WriteCells(ctx, &fs_rpc.WriteCellsRequest{Fd: rep.Fd + 1, Cells: []*fs_rpc.CellWrite{{}}})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_NotFound)
+			})
+		})
+	})
+}
+
 func TestServer_WriteCell(t *testing.T) {
 	Convey("Build test server", t, func() {
 		s, err := newTestServer()
@@ -179,8 +269,8 @@ func TestServer_WriteCell(t *testing.T) {
 							Version:   uint64(1 + (i % 4)),
 							HoldsMeta: false,
 						},
-						Offset: (uint64(i) % 4) * config.MaxBytesPerCell,
-						Size:   config.MaxBytesPerCell,
+						Offset: (uint64(i) % 4) * config.DefaultBytesPerCell,
+						Size:   config.DefaultBytesPerCell,
 					})
 				}
 				Convey("Read cells written", func() {
@@ -203,8 +293,8 @@ func TestServer_WriteCell(t *testing.T) {
 								Version:   finalVersion,
 								HoldsMeta: false,
 							},
-							Offset: (uint64(i) % 4) * config.MaxBytesPerCell,
-							Size:   config.MaxBytesPerCell,
+							Offset: (uint64(i) % 4) * config.DefaultBytesPerCell,
+							Size:   config.DefaultBytesPerCell,
 						})
 					}
 				})
@@ -213,6 +303,251 @@ func TestServer_WriteCell(t *testing.T) {
 	})
 }
 
+func TestServer_DeleteCell(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file and write cells", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			for i := uint32(0); i < 2; i++ {
+				rep2, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: rep.Fd, Row: i, Column: i})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+			}
+			Convey("Delete cells", func() {
+				rep2, err := s.DeleteCell(ctx, &fs_rpc.DeleteCellRequest{Fd: rep.Fd, Row: 0, Column: 0})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(rep2.Cell.Offset, ShouldEqual, 0)
+				So(rep2.Cell.Chunk.Version, ShouldEqual, 3)
+				rep3, err := s.DeleteCell(ctx, &fs_rpc.DeleteCellRequest{Fd: rep.Fd, Row: 1, Column: 1})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(rep3.Cell, ShouldBeNil)
+				rep4, err := s.ReadCell(ctx, &fs_rpc.ReadCellRequest{Fd: rep.Fd, Row: 0, Column: 0})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_Invalid)
+			})
+			Convey("Delete invalid cells", func() {
+				rep2, err := s.DeleteCell(ctx, &fs_rpc.DeleteCellRequest{Fd: rep.Fd, Row: 10, Column: 10})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep3, err := s.DeleteCell(ctx, &fs_rpc.DeleteCellRequest{Fd: rep.Fd + 1, Row: 0, Column: 0})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_NotFound)
+			})
+			Convey("Reject rows out of the sheet", func() {
+				rep2, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: rep.Fd, Row: config.MaxRows, Column: 0})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep3, err := s.WriteCells(ctx, &fs_rpc.WriteCellsRequest{Fd: rep.Fd, Cells: []*fs_rpc.CellWrite{
+					{Row: 2, Column: 2}, {Row: 1 << 24, Column: 0},
+				}})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep4, err := s.ReadCell(ctx, &fs_rpc.ReadCellRequest{Fd: rep.Fd, Row: 1<<24 | 1, Column: 1})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep5, err := s.DeleteCell(ctx, &fs_rpc.DeleteCellRequest{Fd: rep.Fd, Row: 1<<24 | 1, Column: 1})
+				So(err, ShouldBeNil)
+				So(rep5.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep6, err := s.ReadCell(ctx, &fs_rpc.ReadCellRequest{Fd: rep.Fd, Row: 1, Column: 1})
+				So(err, ShouldBeNil)
+				So(rep6.Status, ShouldEqual, fs_rpc.Status_OK)
+			})
+		})
+	})
+}
+
+func TestServer_Lines(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file and write cells", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			rep2, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: rep.Fd, Row: 1, Column: 1})
+			So(err, ShouldBeNil)
+			So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+			Convey("Insert and delete lines", func() {
+				rep3, err := s.InsertRows(ctx, &fs_rpc.InsertRowsRequest{Fd: rep.Fd, Row: 0, Count: 2})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep4, err := s.InsertColumns(ctx, &fs_rpc.InsertColumnsRequest{Fd: rep.Fd, Column: 1, Count: 1})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep5, err := s.ReadCell(ctx, &fs_rpc.ReadCellRequest{Fd: rep.Fd, Row: 3, Column: 2})
+				So(err, ShouldBeNil)
+				So(rep5.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(rep5.Cell.Chunk.Id, ShouldEqual, rep2.Cell.Chunk.Id)
+				So(rep5.Cell.Offset, ShouldEqual, rep2.Cell.Offset)
+				rep6, err := s.DeleteRows(ctx, &fs_rpc.DeleteRowsRequest{Fd: rep.Fd, Row: 0, Count: 1})
+				So(err, ShouldBeNil)
+				So(rep6.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep7, err := s.DeleteColumns(ctx, &fs_rpc.DeleteColumnsRequest{Fd: rep.Fd, Column: 2, Count: 1})
+				So(err, ShouldBeNil)
+				So(rep7.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep8, err := s.ReadCell(ctx, &fs_rpc.ReadCellRequest{Fd: rep.Fd, Row: 2, Column: 2})
+				So(err, ShouldBeNil)
+				So(rep8.Status, ShouldEqual, fs_rpc.Status_Invalid)
+			})
+			Convey("Insert and delete invalid lines", func() {
+				rep3, err := s.InsertRows(ctx, &fs_rpc.InsertRowsRequest{Fd: rep.Fd, Row: 0, Count: 0})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep5, err := s.InsertRows(ctx, &fs_rpc.InsertRowsRequest{Fd: rep.Fd, Row: config.MaxRows, Count: 1})
+				So(err, ShouldBeNil)
+				So(rep5.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep4, err := s.DeleteColumns(ctx, &fs_rpc.DeleteColumnsRequest{Fd: rep.Fd + 1, Column: 0, Count: 1})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_NotFound)
+			})
+		})
+	})
+}
+
+func TestServer_Tabs(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file and tabs", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			rep2, err := s.CreateTab(ctx, &fs_rpc.CreateTabRequest{Fd: rep.Fd, Name: "data"})
+			So(err, ShouldBeNil)
+			So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+			So(rep2.Tab, ShouldEqual, 1)
+			rep3, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: rep.Fd, Tab: rep2.Tab, Row: 1, Column: 1})
+			So(err, ShouldBeNil)
+			So(rep3.Status, ShouldEqual, fs_rpc.Status_OK)
+			Convey("Read and edit tabs", func() {
+				rep4, err := s.MoveTab(ctx, &fs_rpc.MoveTabRequest{Fd: rep.Fd, Tab: rep2.Tab, Position: 0})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep5, err := s.ListTabs(ctx, &fs_rpc.ListTabsRequest{Fd: rep.Fd})
+				So(err, ShouldBeNil)
+				So(rep5.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(len(rep5.Tabs), ShouldEqual, 2)
+				So(rep5.Tabs[0].Name, ShouldEqual, "data")
+				So(rep5.Tabs[1].Name, ShouldEqual, "Sheet1")
+				rep6, err := s.ReadSheet(ctx, &fs_rpc.ReadSheetRequest{Fd: rep.Fd, AllTabs: true})
+				So(err, ShouldBeNil)
+				So(rep6.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(len(rep6.Tabs), ShouldEqual, 2)
+				So(len(rep6.Chunks), ShouldEqual, 3)
+				tabs := map[uint32]int{}
+				for _, chunk := range rep6.Chunks {
+					for _, extent := range chunk.Extents {
+						tabs[extent.Tab]++
+					}
+				}
+				So(tabs, ShouldResemble, map[uint32]int{0: 1, 1: 2})
+				rep7, err := s.ReadSheet(ctx, &fs_rpc.ReadSheetRequest{Fd: rep.Fd, Tab: rep2.Tab})
+				So(err, ShouldBeNil)
+				So(rep7.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(len(rep7.Chunks), ShouldEqual, 2)
+				rep8, err := s.DeleteTab(ctx, &fs_rpc.DeleteTabRequest{Fd: rep.Fd, Tab: 0})
+				So(err, ShouldBeNil)
+				So(rep8.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep9, err := s.ReadCell(ctx, &fs_rpc.ReadCellRequest{Fd: rep.Fd, Tab: rep2.Tab, Row: 1, Column: 1})
+				So(err, ShouldBeNil)
+				So(rep9.Status, ShouldEqual, fs_rpc.Status_OK)
+			})
+			Convey("Edit invalid tabs", func() {
+				rep4, err := s.CreateTab(ctx, &fs_rpc.CreateTabRequest{Fd: rep.Fd, Name: "data"})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_Exist)
+				rep5, err := s.RenameTab(ctx, &fs_rpc.RenameTabRequest{Fd: rep.Fd, Tab: 2, Name: "tab"})
+				So(err, ShouldBeNil)
+				So(rep5.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep6, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: rep.Fd, Tab: 2, Row: 1, Column: 1})
+				So(err, ShouldBeNil)
+				So(rep6.Status, ShouldEqual, fs_rpc.Status_Invalid)
+				rep7, err := s.ListTabs(ctx, &fs_rpc.ListTabsRequest{Fd: rep.Fd + 1})
+				So(err, ShouldBeNil)
+				So(rep7.Status, ShouldEqual, fs_rpc.Status_NotFound)
+			})
+		})
+	})
+}
+
+func TestServer_WriteLargeCell(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			fd := rep.Fd
+			maxSlot := config.MaxBytesPerCell
+			Convey("Write a large cell", func() {
+				rep, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{
+					Fd:     fd,
+					Row:    0,
+					Column: 0,
+					Size:   maxSlot + 2*config.BytesPerChunk,
+				})
+				So(err, ShouldBeNil)
+				So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(rep.Cell.Size, ShouldEqual, maxSlot)
+				So(len(rep.Cell.Overflow), ShouldEqual, 2)
+				Convey("Read the sheet with overflow chunks", func() {
+					rep2, err := s.ReadSheet(ctx, &fs_rpc.ReadSheetRequest{Fd: fd})
+					So(err, ShouldBeNil)
+					So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+					So(len(rep2.Chunks), ShouldEqual, 2)
+					for _, chunk := range rep2.Chunks {
+						So(len(chunk.Extents), ShouldEqual, 1)
+						if chunk.HoldsMeta {
+							So(chunk.Extents[0].Chunks, ShouldBeEmpty)
+							So(chunk.Extents[0].Length, ShouldEqual, 0)
+							continue
+						}
+						So(chunk.Id, ShouldEqual, rep.Cell.Chunk.Id)
+						So(chunk.Extents[0].Offset, ShouldEqual, 0)
+						So(chunk.Extents[0].Size, ShouldEqual, maxSlot)
+						So(chunk.Extents[0].Length, ShouldEqual, maxSlot+2*config.BytesPerChunk)
+						for i, c := range chunk.Extents[0].Chunks {
+							So(c, shouldBeSameChunk, rep.Cell.Overflow[i])
+						}
+					}
+				})
+			})
+			Convey("Move a cell to a bigger slot", func() {
+				for i := uint32(0); i < 2; i++ {
+					rep, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: fd, Row: i, Column: i, Size: 100})
+					So(err, ShouldBeNil)
+					So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+					So(rep.Vacated, ShouldBeNil)
+				}
+				rep, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{Fd: fd, Row: 0, Column: 0, Size: 1000})
+				So(err, ShouldBeNil)
+				So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(rep.Cell.Size, ShouldEqual, 1024)
+				So(rep.Cell.Length, ShouldEqual, 1000)
+				So(rep.Vacated.Size, ShouldEqual, 256)
+				So(rep.Vacated.Offset, ShouldEqual, 0)
+				So(rep.Vacated.Chunk.Id, ShouldNotEqual, rep.Cell.Chunk.Id)
+			})
+			Convey("Write a too large cell", func() {
+				rep, err := s.WriteCell(ctx, &fs_rpc.WriteCellRequest{
+					Fd:     fd,
+					Row:    0,
+					Column: 0,
+					Size:   maxSlot + (config.MaxOverflowChunks+1)*config.BytesPerChunk,
+				})
+				So(err, ShouldBeNil)
+				So(rep.Status, ShouldEqual, fs_rpc.Status_Invalid)
+			})
+		})
+	})
+}
+
 func TestServer_ReadSheet(t *testing.T) {
 	Convey("Build test server", t, func() {
 		s, err := newTestServer()
@@ -297,8 +632,88 @@ func TestServer_RecycleResumeSheet(t *testing.T) {
 	})
 }
 
-// TODO
+func TestServer_CloseSheet(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			Convey("Close test file", func() {
+				rep2, err := s.CloseSheet(ctx, &fs_rpc.CloseSheetRequest{Fd: rep.Fd})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep3, err := s.ReadSheet(ctx, &fs_rpc.ReadSheetRequest{Fd: rep.Fd})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_NotFound)
+				rep4, err := s.CloseSheet(ctx, &fs_rpc.CloseSheetRequest{Fd: rep.Fd})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_NotFound)
+			})
+		})
+	})
+}
+
+func TestServer_Session(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Open session", func() {
+			rep, err := s.OpenSession(ctx, &fs_rpc.Empty{})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			So(rep.Lease, ShouldBeGreaterThan, 0)
+			rep2, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0", Session: rep.Session})
+			So(err, ShouldBeNil)
+			So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+			rep3, err := s.KeepAlive(ctx, &fs_rpc.KeepAliveRequest{Session: rep.Session})
+			So(err, ShouldBeNil)
+			So(rep3.Status, ShouldEqual, fs_rpc.Status_OK)
+			Convey("Close session", func() {
+				rep4, err := s.CloseSession(ctx, &fs_rpc.CloseSessionRequest{Session: rep.Session})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep5, err := s.ReadSheet(ctx, &fs_rpc.ReadSheetRequest{Fd: rep2.Fd})
+				So(err, ShouldBeNil)
+				So(rep5.Status, ShouldEqual, fs_rpc.Status_NotFound)
+				rep6, err := s.KeepAlive(ctx, &fs_rpc.KeepAliveRequest{Session: rep.Session})
+				So(err, ShouldBeNil)
+				So(rep6.Status, ShouldEqual, fs_rpc.Status_Expired)
+				rep7, err := s.OpenSheet(ctx, &fs_rpc.OpenSheetRequest{Filename: "sheet0", Session: rep.Session})
+				So(err, ShouldBeNil)
+				So(rep7.Status, ShouldEqual, fs_rpc.Status_Expired)
+			})
+		})
+	})
+}
+
 func TestServer_DeleteSheet(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			Convey("Delete test file", func() {
+				rep2, err := s.DeleteSheet(ctx, &fs_rpc.DeleteSheetRequest{Filename: "sheet0"})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep3, err := s.OpenSheet(ctx, &fs_rpc.OpenSheetRequest{Filename: "sheet0"})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_NotFound)
+				rep4, err := s.ReadSheet(ctx, &fs_rpc.ReadSheetRequest{Fd: rep.Fd})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_NotFound)
+				Convey("Delete non-existed file", func() {
+					rep5, err := s.DeleteSheet(ctx, &fs_rpc.DeleteSheetRequest{Filename: "sheet0"})
+					So(err, ShouldBeNil)
+					So(rep5.Status, ShouldEqual, fs_rpc.Status_NotFound)
+				})
+			})
+		})
+	})
 }
 
 func TestServer_ListSheets(t *testing.T) {
@@ -319,7 +734,7 @@ func TestServer_ListSheets(t *testing.T) {
 				}
 			}
 			Convey("List test files", func() {
-				rep, err := s.ListSheets(ctx, &fs_rpc.Empty{})
+				rep, err := s.ListSheets(ctx, &fs_rpc.ListSheetsRequest{})
 				So(err, ShouldBeNil)
 				So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
 				sheets := rep.Sheets
@@ -332,6 +747,153 @@ func TestServer_ListSheets(t *testing.T) {
 					So(sheet.Recycled, ShouldEqual, i%2 == 0)
 				}
 			})
+			Convey("List test files page by page", func() {
+				rep, err := s.ListSheets(ctx, &fs_rpc.ListSheetsRequest{PageSize: 3, Recycled: fs_rpc.RecycledFilter_NOT_RECYCLED})
+				So(err, ShouldBeNil)
+				So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(len(rep.Sheets), ShouldEqual, 3)
+				So(rep.Sheets[0].Filename, ShouldEqual, "sheet1")
+				rep, err = s.ListSheets(ctx, &fs_rpc.ListSheetsRequest{PageSize: 3, Recycled: fs_rpc.RecycledFilter_NOT_RECYCLED, PageToken: rep.NextPageToken})
+				So(err, ShouldBeNil)
+				So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(len(rep.Sheets), ShouldEqual, 2)
+				So(rep.Sheets[0].Filename, ShouldEqual, "sheet7")
+				So(rep.NextPageToken, ShouldBeEmpty)
+				rep, err = s.ListSheets(ctx, &fs_rpc.ListSheetsRequest{PageToken: "!"})
+				So(err, ShouldBeNil)
+				So(rep.Status, ShouldEqual, fs_rpc.Status_Invalid)
+			})
+		})
+	})
+}
+
+func TestServer_RenameSheet(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test files", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			rep, err = s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet1"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			Convey("Rename test file", func() {
+				rep2, err := s.RenameSheet(ctx, &fs_rpc.RenameSheetRequest{Filename: "sheet0", NewFilename: "renamed"})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep3, err := s.OpenSheet(ctx, &fs_rpc.OpenSheetRequest{Filename: "renamed"})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep4, err := s.OpenSheet(ctx, &fs_rpc.OpenSheetRequest{Filename: "sheet0"})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_NotFound)
+			})
+			Convey("Rename to existed file", func() {
+				rep2, err := s.RenameSheet(ctx, &fs_rpc.RenameSheetRequest{Filename: "sheet1", NewFilename: "sheet0"})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_Exist)
+			})
+		})
+	})
+}
+
+func TestServer_CopySheet(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			Convey("Copy test file", func() {
+				rep2, err := s.CopySheet(ctx, &fs_rpc.CopySheetRequest{Filename: "sheet0", NewFilename: "copy"})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep3, err := s.OpenSheet(ctx, &fs_rpc.OpenSheetRequest{Filename: "copy"})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep4, err := s.ReadSheet(ctx, &fs_rpc.ReadSheetRequest{Fd: rep3.Fd})
+				So(err, ShouldBeNil)
+				rep5, err := s.ReadSheet(ctx, &fs_rpc.ReadSheetRequest{Fd: rep.Fd})
+				So(err, ShouldBeNil)
+				So(rep4.Chunks[0].Id, ShouldEqual, rep5.Chunks[0].Id)
+			})
+			Convey("Copy non-exist and to existed file", func() {
+				rep2, err := s.CopySheet(ctx, &fs_rpc.CopySheetRequest{Filename: "non-exist", NewFilename: "copy"})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_NotFound)
+				rep3, err := s.CopySheet(ctx, &fs_rpc.CopySheetRequest{Filename: "sheet0", NewFilename: "sheet0"})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_Exist)
+			})
+		})
+	})
+}
+
+func TestServer_StatSheet(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Create test file", func() {
+			rep, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "sheet0"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			Convey("Stat test file by filename and fd", func() {
+				rep2, err := s.StatSheet(ctx, &fs_rpc.StatSheetRequest{Target: &fs_rpc.StatSheetRequest_Filename{Filename: "sheet0"}})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(rep2.Stat.Filename, ShouldEqual, "sheet0")
+				So(rep2.Stat.OpenFds, ShouldEqual, 1)
+				rep3, err := s.StatSheet(ctx, &fs_rpc.StatSheetRequest{Target: &fs_rpc.StatSheetRequest_Fd{Fd: rep.Fd}})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_OK)
+				So(rep3.Stat.Cells, ShouldEqual, rep2.Stat.Cells)
+			})
+			Convey("Stat non-exist file", func() {
+				rep2, err := s.StatSheet(ctx, &fs_rpc.StatSheetRequest{Target: &fs_rpc.StatSheetRequest_Filename{Filename: "non-exist"}})
+				So(err, ShouldBeNil)
+				So(rep2.Status, ShouldEqual, fs_rpc.Status_NotFound)
+				rep3, err := s.StatSheet(ctx, &fs_rpc.StatSheetRequest{Target: &fs_rpc.StatSheetRequest_Fd{Fd: rep.Fd + 1}})
+				So(err, ShouldBeNil)
+				So(rep3.Status, ShouldEqual, fs_rpc.Status_NotFound)
+				rep4, err := s.StatSheet(ctx, &fs_rpc.StatSheetRequest{})
+				So(err, ShouldBeNil)
+				So(rep4.Status, ShouldEqual, fs_rpc.Status_Invalid)
+			})
+		})
+	})
+}
+
+func TestServer_Dirs(t *testing.T) {
+	Convey("Build test server", t, func() {
+		s, err := newTestServer()
+		So(err, ShouldBeNil)
+		Convey("Make directory and create file in it", func() {
+			rep, err := s.MkDir(ctx, &fs_rpc.MkDirRequest{Path: "team"})
+			So(err, ShouldBeNil)
+			So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+			rep2, err := s.CreateSheet(ctx, &fs_rpc.CreateSheetRequest{Filename: "team/sheet0"})
+			So(err, ShouldBeNil)
+			So(rep2.Status, ShouldEqual, fs_rpc.Status_OK)
+			rep3, err := s.ListDir(ctx, &fs_rpc.ListDirRequest{Path: "team"})
+			So(err, ShouldBeNil)
+			So(rep3.Status, ShouldEqual, fs_rpc.Status_OK)
+			So(rep3.Sheets[0].Filename, ShouldEqual, "team/sheet0")
+			rep4, err := s.ListSheets(ctx, &fs_rpc.ListSheetsRequest{Dir: "non-exist"})
+			So(err, ShouldBeNil)
+			So(rep4.Status, ShouldEqual, fs_rpc.Status_NotFound)
+			Convey("Remove directory", func() {
+				rep, err := s.RmDir(ctx, &fs_rpc.RmDirRequest{Path: "team"})
+				So(err, ShouldBeNil)
+				So(rep.Status, ShouldEqual, fs_rpc.Status_NotEmpty)
+				rep, err = s.RmDir(ctx, &fs_rpc.RmDirRequest{Path: "team", Recursive: true})
+				So(err, ShouldBeNil)
+				So(rep.Status, ShouldEqual, fs_rpc.Status_OK)
+				rep2, err := s.ListSheets(ctx, &fs_rpc.ListSheetsRequest{})
+				So(err, ShouldBeNil)
+				So(rep2.Sheets, ShouldBeEmpty)
+			})
 		})
 	})
 }
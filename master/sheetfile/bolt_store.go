@@ -0,0 +1,404 @@
+package sheetfile
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	boltChunksBucket = []byte("chunks")
+	boltRefsBucket   = []byte("chunk_refs")
+	boltSheetsBucket = []byte("sheets")
+	// Nested in the bucket of a SheetFile, which maps Cell.ID to the Cell.
+	boltCellsBucket = []byte("cells")
+)
+
+/*
+BoltStore
+A Store backed by bbolt, an embedded key-value store which doesn't require cgo.
+
+Chunks are stored in the bucket 'chunks' keyed by ID. Every SheetFile has a bucket in
+'sheets' keyed by its sheet ID, in which Cells are keyed by ID. IDs are assigned from
+sequences of buckets, so they are never reused. Integers in keys are encoded in big endian
+to be iterated in order, and values are encoded in JSON.
+
+A BoltStore over a transaction runs all its methods in the transaction, otherwise every
+method runs in its own transaction.
+*/
+type BoltStore struct {
+	db *bolt.DB
+	tx *bolt.Tx
+}
+
+/*
+boltCell
+Persistent form of a Cell in BoltStore.
+*/
+type boltCell struct {
+	ID          uint
+	CellID      int64
+	Offset      uint64
+	Size        uint64
+	ChunkID     uint64
+	Overflow    []uint64
+	Length      uint64
+	TabName     string
+	TabPosition uint32
+}
+
+/*
+boltChunk
+Persistent form of a Chunk in BoltStore.
+*/
+type boltChunk struct {
+	ID       uint64
+	DataNode string
+	Version  uint64
+	Versions []uint64
+	CopyOf   uint64
+}
+
+/*
+NewBoltStore
+Create a BoltStore over db. Buckets are created on demand.
+*/
+func NewBoltStore(db *bolt.DB) *BoltStore {
+	return &BoltStore{db: db}
+}
+
+/*
+WithTx
+Returns a BoltStore running all methods in tx, which should be a writable transaction of
+the db of s.
+*/
+func (s *BoltStore) WithTx(tx *bolt.Tx) *BoltStore {
+	return &BoltStore{db: s.db, tx: tx}
+}
+
+/*
+Update
+Run fn in the transaction of s, or in a new writable transaction if s is not over one.
+*/
+func (s *BoltStore) Update(fn func(tx *bolt.Tx) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+	return s.db.Update(fn)
+}
+
+/*
+View
+Run fn in the transaction of s, or in a new read-only transaction if s is not over one.
+*/
+func (s *BoltStore) View(fn func(tx *bolt.Tx) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+	return s.db.View(fn)
+}
+
+/*
+BoltKey
+Encode n as a key of BoltStore.
+*/
+func BoltKey(n uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, n)
+	return key
+}
+
+/*
+BoltPut
+Encode v in JSON and put it into b with key.
+*/
+func BoltPut(b *bolt.Bucket, key []byte, v interface{}) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, buf)
+}
+
+/*
+nextID
+Returns id if it's not 0, or the next sequence of b. The sequence of b is kept not less
+than any ID in b, so IDs assigned later never collide with id.
+*/
+func nextID(b *bolt.Bucket, id uint64) (uint64, error) {
+	if id == 0 {
+		return b.NextSequence()
+	}
+	if id > b.Sequence() {
+		return id, b.SetSequence(id)
+	}
+	return id, nil
+}
+
+/*
+sheetBucket
+Returns the bucket of Cells of sheetID, creating it if create is true. It's nil if it
+doesn't exist and create is false.
+*/
+func sheetBucket(tx *bolt.Tx, sheetID uint64, create bool) (*bolt.Bucket, error) {
+	if !create {
+		sheets := tx.Bucket(boltSheetsBucket)
+		if sheets == nil {
+			return nil, nil
+		}
+		sheet := sheets.Bucket(BoltKey(sheetID))
+		if sheet == nil {
+			return nil, nil
+		}
+		return sheet.Bucket(boltCellsBucket), nil
+	}
+	sheets, err := tx.CreateBucketIfNotExists(boltSheetsBucket)
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := sheets.CreateBucketIfNotExists(BoltKey(sheetID))
+	if err != nil {
+		return nil, err
+	}
+	return sheet.CreateBucketIfNotExists(boltCellsBucket)
+}
+
+func decodeCell(buf []byte, sheetID uint64) (*Cell, error) {
+	var r boltCell
+	err := json.Unmarshal(buf, &r)
+	if err != nil {
+		return nil, err
+	}
+	cell := NewCell(r.CellID, r.Offset, r.Size, r.ChunkID, sheetID)
+	cell.ID = r.ID
+	cell.Overflow = r.Overflow
+	cell.Length = r.Length
+	cell.TabName = r.TabName
+	cell.TabPosition = r.TabPosition
+	return cell, nil
+}
+
+func (s *BoltStore) CreateCells(sheetID uint64) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		_, err := sheetBucket(tx, sheetID, true)
+		return err
+	})
+}
+
+func (s *BoltStore) LoadCells(sheetID uint64) ([]*Cell, error) {
+	cells := []*Cell{}
+	err := s.View(func(tx *bolt.Tx) error {
+		b, err := sheetBucket(tx, sheetID, false)
+		if err != nil || b == nil {
+			return err
+		}
+		return b.ForEach(func(_, v []byte) error {
+			cell, err := decodeCell(v, sheetID)
+			if err != nil {
+				return err
+			}
+			cells = append(cells, cell)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cells, nil
+}
+
+/*
+saveCell
+Put cell into b, assigning cell.ID if it's a new Cell.
+*/
+func saveCell(b *bolt.Bucket, cell *Cell) error {
+	id, err := nextID(b, uint64(cell.ID))
+	if err != nil {
+		return err
+	}
+	cell.ID = uint(id)
+	return BoltPut(b, BoltKey(id), &boltCell{
+		ID:          cell.ID,
+		CellID:      cell.CellID,
+		Offset:      cell.Offset,
+		Size:        cell.Size,
+		ChunkID:     cell.ChunkID,
+		Overflow:    cell.Overflow,
+		Length:      cell.Length,
+		TabName:     cell.TabName,
+		TabPosition: cell.TabPosition,
+	})
+}
+
+func (s *BoltStore) SaveCell(cell *Cell) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		b, err := sheetBucket(tx, cell.SheetID, true)
+		if err != nil {
+			return err
+		}
+		return saveCell(b, cell)
+	})
+}
+
+func (s *BoltStore) DeleteCell(cell *Cell) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		b, err := sheetBucket(tx, cell.SheetID, false)
+		if err != nil || b == nil {
+			return err
+		}
+		return b.Delete(BoltKey(uint64(cell.ID)))
+	})
+}
+
+func (s *BoltStore) ReplaceCells(sheetID uint64, cells []*Cell) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		err := dropSheetBucket(tx, sheetID)
+		if err != nil {
+			return err
+		}
+		b, err := sheetBucket(tx, sheetID, true)
+		if err != nil {
+			return err
+		}
+		for _, cell := range cells {
+			err = saveCell(b, cell)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func dropSheetBucket(tx *bolt.Tx, sheetID uint64) error {
+	sheets := tx.Bucket(boltSheetsBucket)
+	if sheets == nil || sheets.Bucket(BoltKey(sheetID)) == nil {
+		return nil
+	}
+	return sheets.DeleteBucket(BoltKey(sheetID))
+}
+
+func (s *BoltStore) DropCells(sheetID uint64) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		return dropSheetBucket(tx, sheetID)
+	})
+}
+
+func (s *BoltStore) SaveChunk(chunk *Chunk) error {
+	return s.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(boltChunksBucket)
+		if err != nil {
+			return err
+		}
+		id, err := nextID(b, chunk.ID)
+		if err != nil {
+			return err
+		}
+		chunk.ID = id
+		return BoltPut(b, BoltKey(id), &boltChunk{
+			ID:       chunk.ID,
+			DataNode: chunk.DataNode,
+			Version:  chunk.Version,
+			Versions: chunk.Versions,
+			CopyOf:   chunk.CopyOf,
+		})
+	})
+}
+
+func (s *BoltStore) LoadChunks(ids []uint64) ([]*Chunk, error) {
+	var chunks []*Chunk
+	err := s.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltChunksBucket)
+		if b == nil {
+			return nil
+		}
+		for _, id := range ids {
+			buf := b.Get(BoltKey(id))
+			if buf == nil {
+				continue
+			}
+			var r boltChunk
+			err := json.Unmarshal(buf, &r)
+			if err != nil {
+				return err
+			}
+			chunk := &Chunk{DataNode: r.DataNode, Version: r.Version, Versions: r.Versions, CopyOf: r.CopyOf}
+			chunk.ID = r.ID
+			chunks = append(chunks, chunk)
+		}
+		return nil
+	})
+	return chunks, err
+}
+
+func (s *BoltStore) DeleteChunks(ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltChunksBucket)
+		if b == nil {
+			return nil
+		}
+		for _, id := range ids {
+			err := b.Delete(BoltKey(id))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) LoadChunkRefs() ([]*ChunkRef, error) {
+	var refs []*ChunkRef
+	err := s.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltRefsBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			refs = append(refs, &ChunkRef{ChunkID: binary.BigEndian.Uint64(k), Refs: binary.BigEndian.Uint64(v)})
+			return nil
+		})
+	})
+	return refs, err
+}
+
+func (s *BoltStore) SaveChunkRefs(refs []*ChunkRef) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	return s.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(boltRefsBucket)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			err = b.Put(BoltKey(ref.ChunkID), BoltKey(ref.Refs))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) DeleteChunkRefs(ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltRefsBucket)
+		if b == nil {
+			return nil
+		}
+		for _, id := range ids {
+			err := b.Delete(BoltKey(id))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -1,56 +1,105 @@
 package sheetfile
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"github.com/fourstring/sheetfs/master/config"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
+	"strconv"
 	"strings"
-	"text/template"
 )
 
 /*
 Cell
 Represent a cell of a sheet.
 Every Cell is stored in a Chunk, starting at a fixed offset(see SheetFile),
-and every Chunk contains multiple Cells. The number of cells stored in a Chunk
-is specified by config.MaxCellsPerChunk.
-Because every Chunk is composed of slots for config.MaxCellsPerChunk, the Size
-of a Cell is set to config.BytesPerChunk / config.MaxCellsPerChunk, except for
-the special MetaCell, where the metadata of a SheetFile are stored(see SheetFile too).
+and every Chunk contains multiple Cells. Every Chunk is composed of slots of the
+same size, so the number of cells stored in a Chunk is config.BytesPerChunk / Size.
+The Size of a Cell is one of config.CellSizeClasses, except for the special MetaCell,
+where the metadata of a SheetFile are stored(see SheetFile too).
+
+A Cell whose data is larger than its slot spans overflow Chunks. Data beyond Size is stored
+in Chunks listed in Overflow in order, config.BytesPerChunk bytes per Chunk. Every overflow
+Chunk is dedicated to a single Cell, and is never used to store other Cells.
 
 Cell plays as an index from row, column number to concrete Chunk which actually stores data,
 providing applications an interface to manipulate cell directly, instead of computing offset
 of some cell manually.
 This index is critical to API of our filesystem, and must be persistent. Cell is also a gorm
-model. All Cell of a SheetFile are stored in a sqlite table, named as 'cells_{filename}'. Cell
-belongs to different SheetFile will be stored in different tables, we implement this by
-executing templated SQL. See also create_tmpl below.
+model. Cells of all SheetFiles are stored in a single sqlite table 'cells', distinguished by
+SheetID and indexed by (SheetID, CellID). The sheet ID is immutable, so Cells don't have to
+be touched when the SheetFile is renamed.
+
+Before this table was introduced, every SheetFile has its own table named 'cells_{sheet ID}',
+they are merged into 'cells' by the offline migration, see metastore.MigrateCells.
 */
 type Cell struct {
 	gorm.Model
 	// CellID is used to accelerate looking up cell by row and column number
-	// CellID is composed of row and column number, which makes CellID a standalone sqlite index,
-	// rather than maintaining a joined index on (row,col)
+	// CellID is composed of row and column number, which makes (SheetID, CellID) a sqlite index,
+	// rather than maintaining a joined index on (sheet,row,col)
 	// uint64 is not supported in sqlite, use int64 as a workaround
-	CellID  int64 `gorm:"index"`
+	CellID  int64 `gorm:"index:idx_cells_sheet_cell,priority:2"`
 	Offset  uint64
 	Size    uint64
 	ChunkID uint64
+	// IDs of overflow Chunks, empty if all data fits in the slot.
+	Overflow ChunkIDs
+	// Length of data written to the Cell, the rest of the slot and overflow Chunks are
+	// filled with padding.
+	Length uint64
+	// Name and position of the tab, only used by MetaCells, see SheetFile.Tabs.
+	TabName     string
+	TabPosition uint32
+
+	SheetID uint64 `gorm:"index:idx_cells_sheet_cell,priority:1"`
+}
 
-	SheetName string `gorm:"-"`
+/*
+ChunkIDs
+A list of Chunk IDs, stored in sqlite as comma-separated text.
+*/
+type ChunkIDs []uint64
+
+func (ids ChunkIDs) GormDataType() string {
+	return "text"
 }
 
-func NewCell(cellID int64, offset uint64, size uint64, chunkID uint64, sheetName string) *Cell {
-	return &Cell{CellID: cellID, Offset: offset, Size: size, ChunkID: chunkID, SheetName: sheetName}
+func (ids ChunkIDs) Value() (driver.Value, error) {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = strconv.FormatUint(id, 10)
+	}
+	return strings.Join(strs, ","), nil
 }
 
-/*
-TableName
-Returns the table name which contains cells of some SheetFile.
-*/
-func (c *Cell) TableName() string {
-	return GetCellTableName(c.SheetName)
+func (ids *ChunkIDs) Scan(src interface{}) error {
+	var str string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		return fmt.Errorf("can't scan %T into ChunkIDs", src)
+	}
+	*ids = nil
+	if str == "" {
+		return nil
+	}
+	for _, s := range strings.Split(str, ",") {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		*ids = append(*ids, id)
+	}
+	return nil
+}
+
+func NewCell(cellID int64, offset uint64, size uint64, chunkID uint64, sheetID uint64) *Cell {
+	return &Cell{CellID: cellID, Offset: offset, Size: size, ChunkID: chunkID, SheetID: sheetID}
 }
 
 /*
@@ -64,131 +113,118 @@ See SheetFile for the necessity of Snapshot.
 func (c *Cell) Snapshot() *Cell {
 	var nc Cell
 	nc = *c
+	nc.Overflow = append(ChunkIDs(nil), c.Overflow...)
 	return &nc
 }
 
 /*
-GetCellTableName
-Same as Cell.TableName, for creation of Cell.
+GetCellID
+Compute CellID by tab, row and column number.
+It is almost impossible for a sheet to scale up to 4294967295 columns, so it's enough
+to use an uint32 to represent column, and rows of a tab are limited to config.MaxRows.
+Due to this, CellID is formed by put tab number in the highest 8bits of an uint64,
+row number in the following 24bits, and column number in lower 32bits.
+
+The MetaCell of a tab, located at (config.SheetMetaCellRow, config.SheetMetaCellCol),
+is the bitwise complement of tab in the highest 8bits with all other bits set, so the
+CellIDs of tab 0 are the same as those of a sheet created before tabs were introduced.
 
 @return
-	sqlite table name to store Cell of SheetName
+	uint64 CellID of Cell located at (row, col) in tab
 */
-func GetCellTableName(sheetName string) string {
-	return fmt.Sprintf("cells_%s", sheetName)
+func GetCellID(tab uint32, row uint32, col uint32) int64 {
+	if row == config.SheetMetaCellRow && col == config.SheetMetaCellCol {
+		return config.SheetMetaCellID ^ int64(tab)<<56
+	}
+	return int64(tab)<<56 | int64(row)<<32 | int64(col)
 }
 
 /*
-GetCellID
-Compute CellID by row and column number.
-It is almost impossible for a sheet to scale up to 4294967295x4294967295,
-so it's enough to use an uint32 to represent row and column.
-Due to this, CellID is formed by put row number in higher 32bits of an
-uint64, and put column number in lower 32bits.
+SplitCellID
+Inverse of GetCellID.
 
 @return
-	uint64 CellID of Cell located at (row, col)
+	tab number, row number and column number of Cell identified by cellID
 */
-func GetCellID(row uint32, col uint32) int64 {
-	return int64(row)<<32 | int64(col)
+func SplitCellID(cellID int64) (uint32, uint32, uint32) {
+	tab := uint32(uint64(cellID) >> 56)
+	row := uint32(uint64(cellID)>>32) & config.MaxRows
+	col := uint32(cellID)
+	if row == config.MaxRows && col == config.SheetMetaCellCol {
+		return ^tab & (config.MaxTabs - 1), config.SheetMetaCellRow, config.SheetMetaCellCol
+	}
+	return tab, row, col
+}
+
+/*
+isValidCell
+Returns true if the Cell located at (row, col) in tab can be addressed by GetCellID.
+*/
+func isValidCell(tab uint32, row uint32, col uint32) bool {
+	if tab >= config.MaxTabs {
+		return false
+	}
+	return row < config.MaxRows || (row == config.SheetMetaCellRow && col == config.SheetMetaCellCol)
 }
 
 /*
 GetSheetCellsAll
-Load all Cell of a SheetFile from sqlite database.
-This method should only be used to load checkpoints in sqlite.
-After loading from sqlite, subsequent mutations should be conducted in memory,
+Load all Cell of a SheetFile from db.
+This method should only be used to load checkpoints in db.
+After loading from db, subsequent mutations should be conducted in memory,
 and rely on journaling to tolerate failure, until checkpointing next time.
 
 @return
-	[]*Cell: All Cell stored in table corresponding to SheetName
+	[]*Cell: All Cell of the SheetFile identified by sheetID which can be loaded.
 */
-func GetSheetCellsAll(db *gorm.DB, sheetName string) []*Cell {
-	cells := []*Cell{}
-	db.Table(GetCellTableName(sheetName)).Find(&cells)
+func GetSheetCellsAll(db Store, sheetID uint64) []*Cell {
+	cells, _ := db.LoadCells(sheetID)
+	if cells == nil {
+		return []*Cell{}
+	}
 	return cells
 }
 
-type _tableName struct {
-	Name string
-}
-
 /*
-create_tmpl
-create_tmpl is a SQL template used to create Cell table for a new SheetFile.
-These SQLs is generated by gorm from currently definition of Cell. If Cell
-are modified, remember to update the template too.
-
-Currently there is no check against .Name in template to counter SQL injection,
-applications should take care of it.
+Persistent
+Flush Cell data in memory into tx.
+This method should be used only for checkpointing, and is supposed to be called
+in a transaction for atomicity.
 */
-var create_tmpl *template.Template
-
-func init() {
-	create_tmpl, _ = template.New("create_table").Parse("CREATE TABLE `{{ .Name}}` (`id` integer,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`cell_id` integer,`offset` integer,`size` integer,`chunk_id` integer,PRIMARY KEY (`id`),CONSTRAINT `fk_chunks_cells` FOREIGN KEY (`chunk_id`) REFERENCES `chunks`(`id`));" +
-		"CREATE INDEX `idx_{{ .Name}}_cell_id` ON `{{ .Name}}`(`cell_id`);" +
-		"CREATE INDEX `idx_{{ .Name}}_deleted_at` ON `{{ .Name}}`(`deleted_at`);")
+func (c *Cell) Persistent(tx Store) error {
+	return tx.SaveCell(c)
 }
 
 /*
-CreateCellTableIfNotExists
-Query the sqlite_master table to check whether Cell table for SheetName has existed or not.
-If not, create such a table with create_tmpl. Creating a table in transactions is not allowed
-in sqlite, so this function should not be called in db.Transaction.
-
-@para
-	db: a gorm connection, should not be a transaction.
-	SheetName: filename of Cell belongs to.
+Delete
+Delete c from tx permanently. A Cell removed from a SheetFile is deleted by the next
+Flush of the SheetFile, see SheetFile.removeCell.
 
-@return
-	error from execution of queries. If this function is called in a transaction, a 'database is locked'
-	will be returned.
+The CellID of c may have been changed by SheetFile.InsertLines or SheetFile.DeleteLines
+since last checkpoint, so c is deleted by its primary key. A Cell without ID has never
+been flushed, so there is nothing to delete.
 */
-func CreateCellTableIfNotExists(db *gorm.DB, sheetName string) error {
-	rawdb, err := db.DB()
-	if err != nil {
-		return err
-	}
-	rows, err := rawdb.Query("SELECT name FROM sqlite_master WHERE type='table' AND name= ?;", GetCellTableName(sheetName))
-	if err != nil {
-		return err
-	}
-
-	if !rows.Next() {
-		rows.Close()
-		tn := _tableName{Name: GetCellTableName(sheetName)}
-
-		var b strings.Builder
-		err = create_tmpl.Execute(&b, tn)
-		if err != nil {
-			return err
-		}
-		createQuery := b.String()
-		_, err = rawdb.Exec(createQuery)
-		if err != nil {
-			return err
-		}
+func (c *Cell) Delete(tx Store) error {
+	if c.ID == 0 {
+		return nil
 	}
-	rows.Close()
-	return nil
+	return tx.DeleteCell(c)
 }
 
 /*
-Persistent
-Flush Cell data in memory into sqlite.
-This method should be used only for checkpointing, and is supposed to be called
-in a transaction for atomicity.
+IsMeta
+Returns true if c is the MetaCell of some tab. (See SheetFile)
 */
-func (c *Cell) Persistent(tx *gorm.DB) {
-	tx.Table(c.TableName()).Clauses(clause.OnConflict{
-		UpdateAll: true,
-	}).Create(c)
+func (c *Cell) IsMeta() bool {
+	_, row, col := SplitCellID(c.CellID)
+	return row == config.SheetMetaCellRow && col == config.SheetMetaCellCol
 }
 
 /*
-IsMeta
-Returns true if c is the MetaCell. (See SheetFile)
+Tab
+Returns the tab number of c.
 */
-func (c *Cell) IsMeta() bool {
-	return c.CellID == config.SheetMetaCellID
+func (c *Cell) Tab() uint32 {
+	tab, _, _ := SplitCellID(c.CellID)
+	return tab
 }
@@ -5,10 +5,24 @@ import (
 	"github.com/fourstring/sheetfs/master/config"
 	"github.com/fourstring/sheetfs/tests"
 	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
 	"math"
 	"testing"
 )
 
+/*
+getTestStore
+Returns a SQLiteStore over an in-memory database, along with the database itself to
+inspect or prepare it directly.
+*/
+func getTestStore() (*SQLiteStore, *gorm.DB, error) {
+	db, err := tests.GetTestDB(&Chunk{}, &Cell{}, &ChunkRef{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewSQLiteStore(db), db, nil
+}
+
 func shouldBeSameCell(actual interface{}, expected ...interface{}) string {
 	ac, ok := actual.(Cell)
 	if !ok {
@@ -27,27 +41,29 @@ func shouldBeSameCell(actual interface{}, expected ...interface{}) string {
 
 func TestGetCellID(t *testing.T) {
 	Convey("Should compute cell ID correctly", t, func() {
-		So(GetCellID(0, 0), ShouldEqual, 0)
-		So(GetCellID(math.MaxUint32, math.MaxUint32), ShouldEqual, config.SheetMetaCellID)
-		So(GetCellID(0x7eadbeef, 0x7eadbaaf), ShouldEqual, int64(0x7eadbeef7eadbaaf))
+		So(GetCellID(0, 0, 0), ShouldEqual, 0)
+		So(GetCellID(0, math.MaxUint32, math.MaxUint32), ShouldEqual, config.SheetMetaCellID)
+		So(GetCellID(0, 0x7eadbe, 0x7eadbaaf), ShouldEqual, int64(0x7eadbe7eadbaaf))
+		So(GetCellID(2, 0x7eadbe, 0x7eadbaaf), ShouldEqual, int64(0x027eadbe7eadbaaf))
+		So(GetCellID(2, math.MaxUint32, math.MaxUint32), ShouldNotEqual, config.SheetMetaCellID)
 	})
-}
-
-func TestCellTableName(t *testing.T) {
-	Convey("Construct testing cell", t, func() {
-		filename := "test1"
-		c1 := NewCell(0, 0, 0, 0, filename)
-		Convey("Test cell table name", func() {
-			So(GetCellTableName(filename), ShouldEqual, "cells_test1")
-			So(c1.TableName(), ShouldEqual, "cells_test1")
-		})
+	Convey("Should split cell ID of every tab", t, func() {
+		for _, tab := range []uint32{0, 1, 0x7f, 0x80, config.MaxTabs - 1} {
+			for _, pos := range [][2]uint32{{0, 0}, {config.MaxRows - 1, math.MaxUint32}, {config.SheetMetaCellRow, config.SheetMetaCellCol}} {
+				ctab, row, col := SplitCellID(GetCellID(tab, pos[0], pos[1]))
+				So([]uint32{ctab, row, col}, ShouldResemble, []uint32{tab, pos[0], pos[1]})
+			}
+		}
+		So(isValidCell(1, config.MaxRows, 0), ShouldBeFalse)
+		So(isValidCell(config.MaxTabs, 0, 0), ShouldBeFalse)
+		So(isValidCell(1, config.SheetMetaCellRow, config.SheetMetaCellCol), ShouldBeTrue)
 	})
 }
 
 func TestCell_Snapshot(t *testing.T) {
 	Convey("Construct testing cell", t, func() {
-		filename := "test1"
-		c1 := NewCell(0, 0, 0, 0, filename)
+		sheetID := uint64(1)
+		c1 := NewCell(0, 0, 0, 0, sheetID)
 		Convey("Test snapshot", func() {
 			s := c1.Snapshot()
 			So(s, ShouldNotEqual, c1)
@@ -58,21 +74,19 @@ func TestCell_Snapshot(t *testing.T) {
 
 func TestCell_Persistent(t *testing.T) {
 	Convey("Get test db", t, func() {
-		db, err := tests.GetTestDB()
-		So(err, ShouldBeNil)
-		err = CreateCellTableIfNotExists(db, "sheet0")
+		db, gdb, err := getTestStore()
 		So(err, ShouldBeNil)
-		err = db.AutoMigrate(&Chunk{})
+		err = db.CreateCells(1)
 		So(err, ShouldBeNil)
 		Convey("create test chunk", func() {
 			chunk := Chunk{}
-			db.Save(&chunk)
+			gdb.Save(&chunk)
 			Convey("Create and persist test cell", func() {
-				cell := NewCell(0, 0, 0, chunk.ID, "sheet0")
+				cell := NewCell(0, 0, 0, chunk.ID, 1)
 				cell.Persistent(db)
 				Convey("Find test cell from db", func() {
 					var c1 Cell
-					db.Table(GetCellTableName("sheet0")).First(&c1, cell.ID)
+					gdb.First(&c1, cell.ID)
 					So(c1, shouldBeSameCell, *cell)
 				})
 			})
@@ -82,11 +96,14 @@ func TestCell_Persistent(t *testing.T) {
 
 func TestCell_IsMeta(t *testing.T) {
 	Convey("Construct test cells", t, func() {
-		cell := NewCell(0, 0, 0, 0, "sheet0")
-		metaCell := NewCell(GetCellID(config.SheetMetaCellRow, config.SheetMetaCellCol), 0, 0, 0, "sheet0")
+		cell := NewCell(0, 0, 0, 0, 1)
+		metaCell := NewCell(GetCellID(0, config.SheetMetaCellRow, config.SheetMetaCellCol), 0, 0, 0, 1)
+		tabMetaCell := NewCell(GetCellID(3, config.SheetMetaCellRow, config.SheetMetaCellCol), 0, 0, 0, 1)
 		Convey("test IsMeta", func() {
 			So(cell.IsMeta(), ShouldEqual, false)
 			So(metaCell.IsMeta(), ShouldEqual, true)
+			So(tabMetaCell.IsMeta(), ShouldEqual, true)
+			So(tabMetaCell.Tab(), ShouldEqual, 3)
 		})
 	})
 }
@@ -1,43 +1,139 @@
 package sheetfile
 
 import (
+	"database/sql/driver"
 	"github.com/fourstring/sheetfs/master/config"
+	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
 	"github.com/fourstring/sheetfs/master/model"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 /*
 Chunk
 Represent a fixed-size block of data stored on some DataNode.
-The size of a Chunk is given by config.BytesPerChunk.
-
-A Version is maintained by MasterNode and DataNode separately. Latest
-Version of a Chunk is stored in MasterNode, and the actual Version is placed
-on DataNode. Version is necessary for serializing write operations to a Chunk.
-When a client issues a write operation, MasterNode will increase the Version by
-1 and return it to client. Client must send both data to write and the Version
-to DataNode which actually stores the Chunk. This operation success iff version
-in request is equal to Version in DataNode plus 1, by which we achieve serialization
-of write operations.
-Version can also be utilized to select correct replication of a Chunk when quorums
+The size of a Chunk is given by config.BytesPerChunk. It's divided into slots of the
+same size, each of them stores a Cell.
+
+A version is maintained by MasterNode and DataNode separately for every
+config.MinBytesPerCell bytes of a Chunk, which are called version slots. Every slot
+of a Cell starts at a version slot, so Cells in a Chunk are versioned independently.
+Latest versions of a Chunk are stored in Versions in MasterNode, and the actual ones
+are placed on DataNode. Versions are necessary for serializing write operations to a
+Cell. When a client issues a write operation, MasterNode will increase the version of
+the slot of the Cell by 1 and return it to client. Client must send both data to write
+and the version to DataNode which actually stores the Chunk. This operation success iff
+version in request is equal to the version of the slot in DataNode plus 1, by which we
+achieve serialization of write operations to a Cell, while writes to different Cells
+of a Chunk don't wait for each other.
+Version counts all writes to a Chunk. An overflow Chunk is written as a whole slot at
+offset 0, so its Version is always the version of its only slot.
+Versions can also be utilized to select correct replication of a Chunk when quorums
 were introduced.
 
 As to other metadata datastructures, Chunk should be maintained in memory, with the
 aid of journaling to tolerate fault, and flushed to sqlite during checkpointing only.
+
+A Chunk may be shared by multiple SheetFiles copy-on-write(see ChunkRefs). CopyOf is
+the ID of the shared Chunk which a Chunk is copied from, or 0 if it's not a copy.
+
+An overflow Chunk stores data of a large Cell beyond its slot(see Cell). It contains no
+slot, so Cells of it is always empty. Whether a Chunk is an overflow one is derived from
+Cell.Overflow, so it's not persisted.
 */
 type Chunk struct {
 	model.Model
 	DataNode string
 	Version  uint64
+	// Versions of version slots, empty if nothing has been written to c.
+	Versions SlotVersions
+	CopyOf   uint64
+	Overflow bool `gorm:"-"`
 	Cells    []*Cell
 }
 
+/*
+SlotVersions
+Versions of version slots of a Chunk, stored in sqlite like ChunkIDs.
+*/
+type SlotVersions []uint64
+
+func (v SlotVersions) GormDataType() string {
+	return "text"
+}
+
+func (v SlotVersions) Value() (driver.Value, error) {
+	return ChunkIDs(v).Value()
+}
+
+func (v *SlotVersions) Scan(src interface{}) error {
+	return (*ChunkIDs)(v).Scan(src)
+}
+
+/*
+SlotVersion
+Returns the version of the slot at offset of c, which is 0 if the slot has never been
+written.
+*/
+func (c *Chunk) SlotVersion(offset uint64) uint64 {
+	i := offset / config.MinBytesPerCell
+	if i >= uint64(len(c.Versions)) {
+		return 0
+	}
+	return c.Versions[i]
+}
+
+/*
+fillVersions
+Fill Versions of a Chunk written before its slots were versioned separately. Such a
+Chunk was versioned as a whole by Version, which DataNodes take as the version of every
+slot of it, see datanode/utils.GetVersion.
+*/
+func (c *Chunk) fillVersions() {
+	if len(c.Versions) != 0 || c.Version == 0 {
+		return
+	}
+	c.Versions = make(SlotVersions, config.SlotsPerChunk)
+	for i := range c.Versions {
+		c.Versions[i] = c.Version
+	}
+}
+
+/*
+bumpSlot
+Increase the version of the slot at offset of c, along with Version of c, because the
+slot is about to be written.
+*/
+func (c *Chunk) bumpSlot(offset uint64) {
+	if len(c.Versions) == 0 {
+		c.Versions = make(SlotVersions, config.SlotsPerChunk)
+	}
+	c.Versions[offset/config.MinBytesPerCell] += 1
+	c.Version += 1
+}
+
+/*
+slotSize
+Returns the size of slots in c, or 0 if c contains no Cell. All Cells in a Chunk are
+allocated from the same size class(see SheetFile), so it's the Size of any Cell of c.
+*/
+func (c *Chunk) slotSize() uint64 {
+	if len(c.Cells) == 0 {
+		return 0
+	}
+	return c.Cells[0].Size
+}
+
 /*
 isAvailable
-Returns true if c is available to store a new Cell with given size.
+Returns true if c is available to store a new Cell with given size. A Chunk only stores
+Cells of the same size, an empty Chunk is available for any size.
 */
 func (c *Chunk) isAvailable(size uint64) bool {
+	if c.Overflow {
+		return false
+	}
+	if slotSize := c.slotSize(); slotSize != 0 && slotSize != size {
+		return false
+	}
 	used := uint64(0)
 	for _, cell := range c.Cells {
 		used += cell.Size
@@ -46,15 +142,28 @@ func (c *Chunk) isAvailable(size uint64) bool {
 	return size <= remains
 }
 
+/*
+cellAt
+Returns the Cell stored at offset of c, or nil if the slot is free.
+*/
+func (c *Chunk) cellAt(offset uint64) *Cell {
+	for _, cell := range c.Cells {
+		if cell.Offset == offset {
+			return cell
+		}
+	}
+	return nil
+}
+
 /*
 Persistent
-Flush Chunk data in memory into sqlite. But Chunk.Cells is not taken into consideration
-because dynamic table names are applied. They should be persisted manually.
+Flush Chunk data in memory into tx. But Chunk.Cells is not taken into consideration,
+they should be persisted manually.
 This method should be used only for checkpointing, and is supposed to be called
 in a transaction for atomicity.
 */
-func (c *Chunk) Persistent(tx *gorm.DB) {
-	tx.Omit(clause.Associations).Clauses(clause.OnConflict{UpdateAll: true}).Create(c)
+func (c *Chunk) Persistent(tx Store) error {
+	return tx.SaveChunk(c)
 }
 
 /*
@@ -68,6 +177,8 @@ See SheetFile for the necessity of Snapshot.
 func (c *Chunk) Snapshot() *Chunk {
 	var nc Chunk
 	nc = *c
+	nc.Versions = append(SlotVersions(nil), c.Versions...)
+	nc.Cells = make([]*Cell, len(c.Cells))
 	for i, cell := range c.Cells {
 		nc.Cells[i] = cell.Snapshot()
 	}
@@ -75,23 +186,44 @@ func (c *Chunk) Snapshot() *Chunk {
 }
 
 /*
-loadChunkForFile
-Load a chunk for a sheet with given id from sqlite. And preload all Cells simultaneously.
-This function do not check id passed in, so it's not exported. Caller should
-check against id.
+loadChunksForFile
+Load Chunks with given ids from tx in batches, without their Cells. Cells of a SheetFile
+are loaded all at once, so they are attached to Chunks in memory by caller rather than
+preloaded for every Chunk.
+This function do not check ids passed in, so it's not exported. Caller should
+check against ids.
 
 @para
-	tx: a gorm connection, it can be a transaction.
-	filename
-	id: Chunk.ID
+	tx: a Store, it can be a transaction.
+	ids: Chunk.ID of Chunks to be loaded
 
 @return
-	*Chunk
+	map[uint64]*Chunk: maps Chunk.ID to loaded Chunks.
+	error:
+		*errors.ChunkNotFoundError if some Chunk doesn't exist in tx.
+		errors raised by tx while loading a batch.
 */
-func loadChunkForFile(tx *gorm.DB, filename string, id uint64) *Chunk {
-	var c Chunk
-	tx.Preload("Cells", func(db *gorm.DB) *gorm.DB {
-		return db.Table(GetCellTableName(filename))
-	}).First(&c, id)
-	return &c
+func loadChunksForFile(tx Store, ids []uint64) (map[uint64]*Chunk, error) {
+	chunks := make(map[uint64]*Chunk, len(ids))
+	for start := 0; start < len(ids); start += config.ChunksPerLoad {
+		end := start + config.ChunksPerLoad
+		if end > len(ids) {
+			end = len(ids)
+		}
+		loaded, err := tx.LoadChunks(ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range loaded {
+			c.Cells = []*Cell{}
+			c.fillVersions()
+			chunks[c.ID] = c
+		}
+	}
+	for _, id := range ids {
+		if _, ok := chunks[id]; !ok {
+			return nil, file_errors.NewChunkNotFoundError(id)
+		}
+	}
+	return chunks, nil
 }
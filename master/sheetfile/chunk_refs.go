@@ -0,0 +1,256 @@
+package sheetfile
+
+import "sync"
+
+/*
+ChunkCopier
+Copy data of Chunk src to dst on DataNodes. dst is always allocated on the same
+DataNode as src.
+*/
+type ChunkCopier func(src *Chunk, dst *Chunk) error
+
+/*
+ChunkDeleter
+Delete data of chunks from DataNodes, errors are handled by itself since there is nothing
+to undo.
+*/
+type ChunkDeleter func(chunks []*Chunk)
+
+/*
+ChunkRef
+Persistent form of the reference count of a shared Chunk, see ChunkRefs.
+*/
+type ChunkRef struct {
+	ChunkID uint64 `gorm:"primaryKey"`
+	Refs    uint64
+}
+
+/*
+ChunkRefs
+Keeps track of Chunks shared by multiple SheetFiles.
+
+A copy of a SheetFile shares all Chunks of the source copy-on-write, only Cells are
+duplicated. A shared Chunk must not be modified in place, instead, the first write to
+it from any SheetFile allocates a new Chunk on the same DataNode, copies data of the
+shared one with the ChunkCopier, and moves Cells of the writing SheetFile to the new
+Chunk. See SheetFile.WriteCellChunk. A copy which turns out to be unnecessary, because
+the shared Chunk has been released by other SheetFiles meanwhile, is deleted with the
+ChunkDeleter.
+
+Every SheetFile holds its own *Chunk even if the Chunk is shared, so the number of
+SheetFiles referencing a Chunk is maintained here globally. Only Chunks referenced
+by more than one SheetFile are presented in refs, other Chunks are owned exclusively.
+
+It's a goroutine-safe data structure. All SheetFiles managed by the same FileManager
+must share the same ChunkRefs.
+*/
+type ChunkRefs struct {
+	mu sync.Mutex
+	// Maps ChunkID to the number of SheetFiles referencing it.
+	refs map[uint64]uint64
+	// Maps ChunkID of a shared Chunk being copied on write to a channel closed once the
+	// copy finishes, see SheetFile.copyChunk.
+	copying map[uint64]chan struct{}
+	copier  ChunkCopier
+	deleter ChunkDeleter
+	// ChunkIDs whose reference counts are changed since they were flushed, mapped to the
+	// number of the Flush they are changed before, like dirty Cells of SheetFile.
+	dirty map[uint64]uint64
+	// Number of Flush performed.
+	flushes uint64
+}
+
+/*
+NewChunkRefs
+Create a ChunkRefs without any shared Chunk.
+
+@para
+	copier: used to copy data of shared Chunks, nil if data should not be copied(e.g.
+	for testing)
+	deleter: used to delete data of unused copies, nil if data should not be deleted
+*/
+func NewChunkRefs(copier ChunkCopier, deleter ChunkDeleter) *ChunkRefs {
+	return &ChunkRefs{
+		refs:    map[uint64]uint64{},
+		copying: map[uint64]chan struct{}{},
+		copier:  copier,
+		deleter: deleter,
+		dirty:   map[uint64]uint64{},
+	}
+}
+
+/*
+LoadChunkRefs
+Load reference counts of all shared Chunks from db.
+This method should only be used to load checkpoints in db.
+*/
+func LoadChunkRefs(db Store, copier ChunkCopier, deleter ChunkDeleter) (*ChunkRefs, error) {
+	r := NewChunkRefs(copier, deleter)
+	refs, err := db.LoadChunkRefs()
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		r.refs[ref.ChunkID] = ref.Refs
+	}
+	return r, nil
+}
+
+/*
+Share
+Add a reference to every Chunk in chunks, because a new SheetFile sharing them is created.
+*/
+func (r *ChunkRefs) Share(chunks []*Chunk) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range chunks {
+		if refs, ok := r.refs[c.ID]; ok {
+			r.refs[c.ID] = refs + 1
+		} else {
+			r.refs[c.ID] = 2
+		}
+		r.dirty[c.ID] = r.flushes
+	}
+}
+
+/*
+IsShared
+Returns true if the Chunk is referenced by more than one SheetFile.
+*/
+func (r *ChunkRefs) IsShared(id uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.isShared(id)
+}
+
+func (r *ChunkRefs) isShared(id uint64) bool {
+	_, ok := r.refs[id]
+	return ok
+}
+
+/*
+beginCopy
+Mark a shared Chunk as being copied on write. If another SheetFile is copying it, wait
+for that copy to finish first, because the Chunk may be owned exclusively afterwards.
+
+@return
+	chan struct{}: to be passed to endCopy once the copy finishes, nil if the Chunk is
+	not shared, and it should not be copied at all.
+*/
+func (r *ChunkRefs) beginCopy(id uint64) chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for {
+		if !r.isShared(id) {
+			return nil
+		}
+		done, ok := r.copying[id]
+		if !ok {
+			break
+		}
+		r.mu.Unlock()
+		<-done
+		r.mu.Lock()
+	}
+	done := make(chan struct{})
+	r.copying[id] = done
+	return done
+}
+
+/*
+endCopy
+Finish a copy started by beginCopy. Caller should hold r.mu.
+*/
+func (r *ChunkRefs) endCopy(id uint64, done chan struct{}) {
+	delete(r.copying, id)
+	close(done)
+}
+
+/*
+release
+Drop a reference to a Chunk. Caller should hold r.mu.
+
+@return
+	true if the Chunk is still referenced by some other SheetFile.
+*/
+func (r *ChunkRefs) release(id uint64) bool {
+	refs, ok := r.refs[id]
+	if !ok {
+		return false
+	}
+	if refs <= 2 {
+		delete(r.refs, id)
+	} else {
+		r.refs[id] = refs - 1
+	}
+	r.dirty[id] = r.flushes
+	return true
+}
+
+/*
+ReleaseChunks
+Drop references to chunks, because the SheetFile referencing them is deleted.
+
+@return
+	[]*Chunk: Chunks not referenced by any other SheetFile, which should be deleted
+	along with the SheetFile.
+*/
+func (r *ChunkRefs) ReleaseChunks(chunks []*Chunk) []*Chunk {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var owned []*Chunk
+	for _, c := range chunks {
+		if !r.release(c.ID) {
+			owned = append(owned, c)
+		}
+	}
+	return owned
+}
+
+/*
+Flush
+Flush reference counts changed since they were flushed last time into tx, and delete
+those of Chunks not shared any more. They are kept dirty until MarkFlushed is called with
+the returned number, like SheetFile.Flush.
+This method should be used only for checkpointing, and is supposed to be called
+in a transaction for atomicity.
+
+@return
+	uint64: number of this Flush, which should be passed to MarkFlushed once tx is committed.
+	error: errors while flushing.
+*/
+func (r *ChunkRefs) Flush(tx Store) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	flush := r.flushes
+	r.flushes++
+	var refs []*ChunkRef
+	var released []uint64
+	for id := range r.dirty {
+		if n, ok := r.refs[id]; ok {
+			refs = append(refs, &ChunkRef{ChunkID: id, Refs: n})
+		} else {
+			released = append(released, id)
+		}
+	}
+	err := tx.DeleteChunkRefs(released)
+	if err != nil {
+		return flush, err
+	}
+	return flush, tx.SaveChunkRefs(refs)
+}
+
+/*
+MarkFlushed
+Mark reference counts flushed by Flush numbered flush clean, unless they have been changed
+again after that Flush.
+*/
+func (r *ChunkRefs) MarkFlushed(flush uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, n := range r.dirty {
+		if n <= flush {
+			delete(r.dirty, id)
+		}
+	}
+}
@@ -3,7 +3,7 @@ package sheetfile
 import (
 	"fmt"
 	"github.com/fourstring/sheetfs/master/config"
-	"github.com/fourstring/sheetfs/tests"
+	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
 	. "github.com/smartystreets/goconvey/convey"
 	"testing"
 )
@@ -41,31 +41,37 @@ func shouldBeSameChunk(actual interface{}, expected ...interface{}) string {
 
 func TestChunk_Persistent(t *testing.T) {
 	Convey("Get test db", t, func() {
-		db, err := tests.GetTestDB()
+		db, _, err := getTestStore()
 		So(err, ShouldBeNil)
-		err = db.AutoMigrate(&Chunk{})
-		So(err, ShouldBeNil)
-		err = CreateCellTableIfNotExists(db, "sheet0")
+		err = db.CreateCells(1)
 		So(err, ShouldBeNil)
 		Convey("test persist chunk", func() {
 			chunk := &Chunk{DataNode: "1", Version: 1}
 			chunk.Persistent(db)
-			cell1, cell2 := NewCell(0, 0, 0, chunk.ID, "sheet0"),
-				NewCell(0, 0, 0, chunk.ID, "sheet0")
+			cell1, cell2 := NewCell(0, 0, 0, chunk.ID, 1),
+				NewCell(0, 0, 0, chunk.ID, 1)
 			chunk.Cells = []*Cell{
 				cell1,
 				cell2,
 			}
 			chunk.Persistent(db)
-			c := loadChunkForFile(db, "sheet0", chunk.ID)
 			// No full association enabled, save chunk should not save its cells
-			So(len(c.Cells), ShouldEqual, 0)
+			So(len(GetSheetCellsAll(db, 1)), ShouldEqual, 0)
 			// Save cells manually
 			cell1.Persistent(db)
 			cell2.Persistent(db)
-			// load again, this time cells should be loaded
-			c = loadChunkForFile(db, "sheet0", chunk.ID)
+			So(len(GetSheetCellsAll(db, 1)), ShouldEqual, 2)
+			// Chunks are always loaded without their cells
+			chunks, err := loadChunksForFile(db, []uint64{chunk.ID})
+			So(err, ShouldBeNil)
+			So(len(chunks), ShouldEqual, 1)
+			c := chunks[chunk.ID]
+			So(len(c.Cells), ShouldEqual, 0)
+			c.Cells = chunk.Cells
 			So(*chunk, shouldBeSameChunk, *c)
+			// Missing Chunks are not skipped silently.
+			_, err = loadChunksForFile(db, []uint64{chunk.ID, chunk.ID + 1})
+			So(err, ShouldBeError, file_errors.NewChunkNotFoundError(chunk.ID+1))
 		})
 	})
 }
@@ -74,31 +80,31 @@ func TestChunk_IsAvailable(t *testing.T) {
 	Convey("Construct test chunk", t, func() {
 		chunk1 := &Chunk{DataNode: "1", Version: 1}
 		chunk1.Cells = []*Cell{
-			NewCell(0, 0, config.MaxBytesPerCell, 0, "sheet0"),
+			NewCell(0, 0, config.DefaultBytesPerCell, 0, 1),
 		}
 		chunk2 := &Chunk{DataNode: "1", Version: 1}
 		chunk2.Cells = []*Cell{
-			NewCell(0, 0, config.MaxBytesPerCell, 0, "sheet0"),
-			NewCell(0, 0, config.MaxBytesPerCell, 0, "sheet0"),
-			NewCell(0, 0, config.MaxBytesPerCell, 0, "sheet0"),
+			NewCell(0, 0, config.DefaultBytesPerCell, 0, 1),
+			NewCell(0, 0, config.DefaultBytesPerCell, 0, 1),
+			NewCell(0, 0, config.DefaultBytesPerCell, 0, 1),
 		}
 		chunk3 := &Chunk{DataNode: "1", Version: 1}
 		chunk3.Cells = []*Cell{
-			NewCell(0, 0, config.MaxBytesPerCell, 0, "sheet0"),
-			NewCell(0, 0, config.MaxBytesPerCell, 0, "sheet0"),
-			NewCell(0, 0, config.MaxBytesPerCell, 0, "sheet0"),
-			NewCell(0, 0, config.MaxBytesPerCell, 0, "sheet0"),
+			NewCell(0, 0, config.DefaultBytesPerCell, 0, 1),
+			NewCell(0, 0, config.DefaultBytesPerCell, 0, 1),
+			NewCell(0, 0, config.DefaultBytesPerCell, 0, 1),
+			NewCell(0, 0, config.DefaultBytesPerCell, 0, 1),
 		}
 		chunk4 := &Chunk{DataNode: "1", Version: 1}
 		chunk4.Cells = []*Cell{
-			NewCell(config.SheetMetaCellID, 0, config.BytesPerChunk, 0, "sheet0"),
+			NewCell(config.SheetMetaCellID, 0, config.BytesPerChunk, 0, 1),
 		}
 		Convey("test isAvailable", func() {
-			So(chunk1.isAvailable(config.MaxBytesPerCell), ShouldEqual, true)
+			So(chunk1.isAvailable(config.DefaultBytesPerCell), ShouldEqual, true)
 			So(chunk1.isAvailable(config.BytesPerChunk), ShouldEqual, false)
-			So(chunk2.isAvailable(config.MaxBytesPerCell), ShouldEqual, true)
-			So(chunk3.isAvailable(config.MaxBytesPerCell), ShouldEqual, false)
-			So(chunk4.isAvailable(config.MaxBytesPerCell), ShouldEqual, false)
+			So(chunk2.isAvailable(config.DefaultBytesPerCell), ShouldEqual, true)
+			So(chunk3.isAvailable(config.DefaultBytesPerCell), ShouldEqual, false)
+			So(chunk4.isAvailable(config.DefaultBytesPerCell), ShouldEqual, false)
 		})
 	})
 }
@@ -107,8 +113,8 @@ func TestChunk_Snapshot(t *testing.T) {
 	Convey("Construct test chunk", t, func() {
 		chunk1 := &Chunk{DataNode: "1", Version: 1}
 		chunk1.Cells = []*Cell{
-			NewCell(0, 0, 0, 0, "sheet0"),
-			NewCell(0, 0, 0, 0, "sheet0"),
+			NewCell(0, 0, 0, 0, 1),
+			NewCell(0, 0, 0, 0, 1),
 		}
 		Convey("test snapshot", func() {
 			c := chunk1.Snapshot()
@@ -118,3 +124,31 @@ func TestChunk_Snapshot(t *testing.T) {
 		})
 	})
 }
+
+func TestChunk_SlotVersions(t *testing.T) {
+	Convey("Get test db", t, func() {
+		db, gdb, err := getTestStore()
+		So(err, ShouldBeNil)
+		chunk := &Chunk{DataNode: "1"}
+		chunk.Persistent(db)
+		Convey("Slots are versioned separately", func() {
+			So(chunk.SlotVersion(0), ShouldEqual, 0)
+			chunk.bumpSlot(0)
+			chunk.bumpSlot(config.DefaultBytesPerCell)
+			chunk.bumpSlot(config.DefaultBytesPerCell)
+			So(chunk.SlotVersion(0), ShouldEqual, 1)
+			So(chunk.SlotVersion(config.DefaultBytesPerCell), ShouldEqual, 2)
+			So(chunk.SlotVersion(config.MinBytesPerCell), ShouldEqual, 0)
+			So(chunk.Version, ShouldEqual, 3)
+			c := chunk.Snapshot()
+			c.bumpSlot(0)
+			So(chunk.SlotVersion(0), ShouldEqual, 1)
+			Convey("Persist versions of slots", func() {
+				chunk.Persistent(db)
+				var c Chunk
+				gdb.First(&c, chunk.ID)
+				So(c.Versions, ShouldResemble, chunk.Versions)
+			})
+		})
+	})
+}
@@ -0,0 +1,396 @@
+package sheetfile
+
+import (
+	"github.com/fourstring/sheetfs/master/config"
+	"sort"
+)
+
+/*
+SlotCopier
+Copy size bytes at srcOffset of Chunk src to dstOffset of Chunk dst on DataNodes. dst is
+always stored on the same DataNode as src. The slot of src should be copied at its
+version in src, and the slot of dst will be at its version in dst after copying.
+*/
+type SlotCopier func(src *Chunk, srcOffset uint64, dst *Chunk, dstOffset uint64, size uint64) error
+
+/*
+SlotMove
+Describes a Cell moved to another slot by Compact, which should be journaled as a deletion
+of Cell followed by a creation of Moved like a Cell moved by WriteCellChunk.
+
+If a move is abandoned after data of the Cell has been copied, the version of the new slot
+is still increased to keep up with the DataNode. Such a SlotMove has no Cell, and Moved is
+an unchanged Cell of To, so the version is journaled along with it.
+*/
+type SlotMove struct {
+	// Snapshot of the Cell before moving, nil if the move is abandoned.
+	Cell *Cell
+	// Snapshot of the Chunk containing the old slot, or nil if it has been dropped.
+	From *Chunk
+	// Snapshot of the Cell after moving.
+	Moved *Cell
+	// Snapshot of the Chunk containing the new slot.
+	To *Chunk
+}
+
+/*
+compactionKey
+Chunks can only be packed into Chunks with slots of the same size on the same DataNode,
+because slots are copied by the DataNode.
+*/
+type compactionKey struct {
+	dataNode string
+	size     uint64
+}
+
+/*
+plannedMove
+A move of a Cell planned by PlanCompaction. Snapshots of both Chunks are taken when it's
+planned, so the move is committed only if neither slot has been written since then.
+*/
+type plannedMove struct {
+	cell      *Cell
+	src       *Chunk
+	srcOffset uint64
+	dst       *Chunk
+	dstOffset uint64
+	size      uint64
+	// Whether data of the Cell has been copied to the new slot.
+	copied bool
+}
+
+/*
+written
+Returns true if the old slot had been written when the move was planned. Nothing has been
+written to a slot whose version is 0, so it can be moved without copying.
+*/
+func (m *plannedMove) written() bool {
+	return m.src.SlotVersion(m.srcOffset) > 0
+}
+
+/*
+Compaction
+Moves of Cells planned by SheetFile.PlanCompaction. Compacting is split into three steps,
+so that the SheetFile is not locked while slots are copied on DataNodes:
+
+1. PlanCompaction picks Cells to be moved and reserves new slots for them, holding
+SheetFile.mu.
+2. Copy copies data of those Cells to new slots without holding any lock. The SheetFile may
+be written meanwhile.
+3. CommitCompaction moves Cells whose old and new slots have not been written since they
+were planned, holding SheetFile.mu. Other moves are abandoned.
+*/
+type Compaction struct {
+	moves []*plannedMove
+}
+
+/*
+Compact
+Pack Cells of s into fewer Chunks by PlanCompaction, Compaction.Copy and CommitCompaction.
+Chunks with slots of the same size on the same DataNode are packed together. The fullest
+of them are kept, and Cells in other ones are moved to free slots of kept Chunks, until
+they are emptied and dropped.
+
+@para
+	copier: used to copy slots on DataNodes, nil if data should not be copied(e.g. for
+	testing)
+
+@return
+	[]*SlotMove: Cells moved, even if an error is returned.
+	[]*Chunk: dropped Chunks, which should be deleted from DataNodes by caller.
+	error: errors raised by copier.
+*/
+func (s *SheetFile) Compact(copier SlotCopier) ([]*SlotMove, []*Chunk, error) {
+	plan := s.PlanCompaction()
+	err := plan.Copy(copier)
+	moves, dropped := s.CommitCompaction(plan)
+	return moves, dropped, err
+}
+
+/*
+PlanCompaction
+Plan moves of Cells to pack them into fewer Chunks, see Compact. New slots are reserved,
+so they are not allocated to other Cells until CommitCompaction. Only one compaction of
+s can be ongoing, an empty Compaction is returned if there has been one.
+
+Shared Chunks are never touched, and neither are Chunks containing Cells with overflow
+Chunks, which are rare and large.
+*/
+func (s *SheetFile) PlanCompaction() *Compaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	plan := &Compaction{}
+	if len(s.reserved) > 0 {
+		return plan
+	}
+	s.reserved = map[Slot]bool{}
+	for _, chunks := range s.compactionGroups() {
+		s.planChunks(chunks, plan)
+	}
+	return plan
+}
+
+/*
+compactionGroups
+Returns Chunks which can be compacted, grouped by compactionKey, in a deterministic
+order. Caller should hold s.mu.
+*/
+func (s *SheetFile) compactionGroups() [][]*Chunk {
+	groups := map[compactionKey][]*Chunk{}
+	for _, c := range s.Chunks {
+		size := c.slotSize()
+		if c.Overflow || size == 0 || (s.refs != nil && s.refs.IsShared(c.ID)) {
+			continue
+		}
+		overflowed := false
+		for _, cell := range c.Cells {
+			if len(cell.Overflow) > 0 {
+				overflowed = true
+				break
+			}
+		}
+		if overflowed {
+			continue
+		}
+		key := compactionKey{dataNode: c.DataNode, size: size}
+		groups[key] = append(groups[key], c)
+	}
+	keys := make([]compactionKey, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].dataNode != keys[j].dataNode {
+			return keys[i].dataNode < keys[j].dataNode
+		}
+		return keys[i].size < keys[j].size
+	})
+	result := make([][]*Chunk, len(keys))
+	for i, key := range keys {
+		result[i] = groups[key]
+	}
+	return result
+}
+
+/*
+planChunks
+Plan moves of Cells of the emptiest chunks to free slots of the fullest ones, until no
+more Chunk can be emptied. All chunks must have slots of the same size. Caller should
+hold s.mu.
+*/
+func (s *SheetFile) planChunks(chunks []*Chunk, plan *Compaction) {
+	size := chunks[0].slotSize()
+	total := uint64(0)
+	for _, c := range chunks {
+		total += uint64(len(c.Cells))
+	}
+	perChunk := config.BytesPerChunk / size
+	kept := int((total + perChunk - 1) / perChunk)
+	if kept >= len(chunks) {
+		return
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		if len(chunks[i].Cells) != len(chunks[j].Cells) {
+			return len(chunks[i].Cells) > len(chunks[j].Cells)
+		}
+		return chunks[i].ID < chunks[j].ID
+	})
+	targets := chunks[:kept]
+	for _, src := range chunks[kept:] {
+		// Cells of a loaded Chunk are not the same *Cell as those in s.Cells.
+		cells := make([]*Cell, len(src.Cells))
+		for i, cell := range src.Cells {
+			cells[i] = s.Cells[cell.CellID]
+		}
+		sort.Slice(cells, func(i, j int) bool {
+			return cells[i].Offset < cells[j].Offset
+		})
+		srcSnapshot := src.Snapshot()
+		for _, cell := range cells {
+			var dst *Chunk
+			for _, c := range targets {
+				if s.isAvailable(c, size) {
+					dst = c
+					break
+				}
+			}
+			offset := s.getCellOffset(dst, size)
+			s.reserved[Slot{ChunkID: dst.ID, Offset: offset}] = true
+			plan.moves = append(plan.moves, &plannedMove{
+				cell:      cell,
+				src:       srcSnapshot,
+				srcOffset: cell.Offset,
+				dst:       dst.Snapshot(),
+				dstOffset: offset,
+				size:      size,
+			})
+		}
+	}
+}
+
+/*
+Copy
+Copy data of Cells to their new slots by copier, in the order they are planned. The slot
+of a Cell is copied at its version when planned, and the new slot will be at the next
+version of it, as if it's written. No lock of the SheetFile is held, so the copy fails if
+the old slot has been written on the DataNode since then. Copying stops at the first
+failure, and the remaining moves are abandoned by CommitCompaction.
+
+@para
+	copier: used to copy slots on DataNodes, nil if data should not be copied
+
+@return
+	error: errors raised by copier.
+*/
+func (c *Compaction) Copy(copier SlotCopier) error {
+	for _, m := range c.moves {
+		if m.written() && copier != nil {
+			next := m.dst.Snapshot()
+			next.bumpSlot(m.dstOffset)
+			err := copier(m.src, m.srcOffset, next, m.dstOffset, m.size)
+			if err != nil {
+				return err
+			}
+		}
+		m.copied = true
+	}
+	return nil
+}
+
+/*
+CommitCompaction
+Move Cells copied by plan to their new slots, and release slots reserved by plan. A move
+is abandoned if the Cell has been deleted or moved, or either slot has been written or
+copied on write since it's planned. The version of the new slot of an abandoned move is
+still increased if data has been copied to it, because the DataNode has increased it.
+
+Emptied Chunks are dropped, see dropChunks.
+
+@para
+	plan: returned by PlanCompaction on s, and copied by Compaction.Copy
+
+@return
+	[]*SlotMove: Cells moved, and abandoned moves whose data has been copied.
+	[]*Chunk: dropped Chunks, which should be deleted from DataNodes by caller.
+*/
+func (s *SheetFile) CommitCompaction(plan *Compaction) ([]*SlotMove, []*Chunk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var moves []*SlotMove
+	var dropped []*Chunk
+	for _, m := range plan.moves {
+		delete(s.reserved, Slot{ChunkID: m.dst.ID, Offset: m.dstOffset})
+		dst, ok := s.Chunks[m.dst.ID]
+		if !ok || dst.SlotVersion(m.dstOffset) != m.dst.SlotVersion(m.dstOffset) {
+			// The new slot has been written or dropped.
+			continue
+		}
+		if !s.canCommit(m, dst) {
+			if move := s.abandonMove(m, dst); move != nil {
+				moves = append(moves, move)
+			}
+			continue
+		}
+		move, c := s.moveSlot(m.cell, dst, m.dstOffset, m.written())
+		moves = append(moves, move)
+		if c != nil {
+			dropped = append(dropped, c)
+		}
+	}
+	if len(plan.moves) > 0 {
+		s.resetLastAvailableChunks()
+		s.rebuildFreeSlots()
+	}
+	return moves, s.dropChunks(dropped)
+}
+
+/*
+canCommit
+Returns true if m can be committed, that is, the Cell is still in the old slot, both slots
+have not been written since m was planned, and neither Chunk is shared. Caller should
+hold s.mu.
+*/
+func (s *SheetFile) canCommit(m *plannedMove, dst *Chunk) bool {
+	cell := m.cell
+	if !m.copied || s.Cells[cell.CellID] != cell || cell.ChunkID != m.src.ID ||
+		cell.Offset != m.srcOffset || cell.Size != m.size || len(cell.Overflow) > 0 {
+		return false
+	}
+	src, ok := s.Chunks[m.src.ID]
+	if !ok || src.SlotVersion(m.srcOffset) != m.src.SlotVersion(m.srcOffset) {
+		return false
+	}
+	if dst.cellAt(m.dstOffset) != nil || dst.slotSize() != m.size {
+		return false
+	}
+	return s.refs == nil || (!s.refs.IsShared(src.ID) && !s.refs.IsShared(dst.ID))
+}
+
+/*
+abandonMove
+Abandon m. If data has been copied to the new slot, its version is increased to keep up
+with the DataNode. Caller should hold s.mu.
+
+@return
+	*SlotMove: an abandoned move to be journaled, see SlotMove, or nil if nothing has
+	been copied.
+*/
+func (s *SheetFile) abandonMove(m *plannedMove, dst *Chunk) *SlotMove {
+	if !m.copied || !m.written() || len(dst.Cells) == 0 ||
+		(s.refs != nil && s.refs.IsShared(dst.ID)) {
+		return nil
+	}
+	dst.bumpSlot(m.dstOffset)
+	s.markChunk(dst)
+	return &SlotMove{Moved: dst.Cells[0].Snapshot(), To: dst.Snapshot()}
+}
+
+/*
+moveSlot
+Move cell to the slot at offset of dst, whose data has been copied if written is true.
+Caller should hold s.mu.
+
+@return
+	*SlotMove: the move of cell.
+	*Chunk: the Chunk emptied by the move, which should be dropped by dropChunks, or nil.
+*/
+func (s *SheetFile) moveSlot(cell *Cell, dst *Chunk, offset uint64, written bool) (*SlotMove, *Chunk) {
+	src := s.Chunks[cell.ChunkID]
+	move := &SlotMove{Cell: cell.Snapshot()}
+	emptied := s.releaseSlot(cell)
+	if emptied == nil {
+		move.From = src.Snapshot()
+	}
+	cell.ChunkID = dst.ID
+	cell.Offset = offset
+	dst.Cells = append(dst.Cells, cell)
+	s.markCell(cell)
+	if written {
+		dst.bumpSlot(offset)
+		s.markChunk(dst)
+	}
+	move.Moved = cell.Snapshot()
+	move.To = dst.Snapshot()
+	return move, emptied
+}
+
+/*
+resetLastAvailableChunks
+Set the LastAvailableChunk of every size to the available Chunk with the most Cells, so
+that new Cells are packed into as few Chunks as possible. Ties are broken by the later
+allocated Chunk. Caller should hold s.mu.
+*/
+func (s *SheetFile) resetLastAvailableChunks() {
+	s.LastAvailableChunks = map[uint64]*Chunk{}
+	for _, c := range s.Chunks {
+		size := c.slotSize()
+		if size == 0 || !s.isAvailable(c, size) {
+			continue
+		}
+		last := s.LastAvailableChunks[size]
+		if last == nil || len(c.Cells) > len(last.Cells) ||
+			(len(c.Cells) == len(last.Cells) && c.ID > last.ID) {
+			s.LastAvailableChunks[size] = c
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package sheetfile
+
+import (
+	"github.com/fourstring/sheetfs/master/config"
+	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
+	"math"
+)
+
+/*
+Axis
+Direction of lines to insert or delete, rows or columns.
+*/
+type Axis int32
+
+const (
+	RowAxis Axis = iota
+	ColumnAxis
+)
+
+/*
+lineOf
+Returns the row number of cellID if axis is RowAxis, or its column number otherwise.
+*/
+func lineOf(cellID int64, axis Axis) uint32 {
+	_, row, col := SplitCellID(cellID)
+	if axis == RowAxis {
+		return row
+	}
+	return col
+}
+
+/*
+withLine
+Returns the CellID of Cell whose line along axis is line, and the tab and the other line
+are the same as cellID.
+*/
+func withLine(cellID int64, axis Axis, line uint32) int64 {
+	tab, row, col := SplitCellID(cellID)
+	if axis == RowAxis {
+		return GetCellID(tab, line, col)
+	}
+	return GetCellID(tab, row, line)
+}
+
+/*
+lineLimit
+Returns the number of lines along axis a tab can hold, see GetCellID.
+*/
+func lineLimit(axis Axis) uint64 {
+	if axis == RowAxis {
+		return config.MaxRows
+	}
+	return math.MaxUint32 + 1
+}
+
+/*
+CellRemap
+A Cell shifted or removed by InsertLines or DeleteLines. The Cell is identified by its
+slot, which is not changed by shifting, and CellID is its new CellID if it's shifted, or
+its CellID if it's removed.
+*/
+type CellRemap struct {
+	ChunkID uint64
+	Offset  uint64
+	CellID  int64
+}
+
+/*
+LinesRemap
+Result of InsertLines or DeleteLines in absolute CellIDs, to be journaled and replayed
+by ApplyLinesRemap. Unlike at and count of the lines, applying it more than once has the
+same effect as applying it once.
+*/
+type LinesRemap struct {
+	Moved   []CellRemap
+	Removed []CellRemap
+}
+
+func remapOf(cell *Cell) CellRemap {
+	return CellRemap{ChunkID: cell.ChunkID, Offset: cell.Offset, CellID: cell.CellID}
+}
+
+/*
+rekeyCells
+Change CellID of every Cell in cells to the corresponding one in ids, and re-key s.Cells.
+All Cells are removed from s.Cells before being added back, so shifted Cells never
+overwrite each other.
+
+@return
+	[]CellRemap: new CellIDs of cells
+*/
+func (s *SheetFile) rekeyCells(cells []*Cell, ids []int64) []CellRemap {
+	for _, cell := range cells {
+		if s.Cells[cell.CellID] == cell {
+			delete(s.Cells, cell.CellID)
+		}
+	}
+	moved := make([]CellRemap, len(cells))
+	for i, cell := range cells {
+		cell.CellID = ids[i]
+		s.Cells[cell.CellID] = cell
+		s.markCell(cell)
+		moved[i] = remapOf(cell)
+	}
+	return moved
+}
+
+/*
+shiftCells
+Move every Cell in cells by delta lines along axis, see rekeyCells.
+*/
+func (s *SheetFile) shiftCells(cells []*Cell, axis Axis, delta int64) []CellRemap {
+	ids := make([]int64, len(cells))
+	for i, cell := range cells {
+		line := int64(lineOf(cell.CellID, axis)) + delta
+		ids[i] = withLine(cell.CellID, axis, uint32(line))
+	}
+	return s.rekeyCells(cells, ids)
+}
+
+/*
+InsertLines
+Insert count empty rows or columns before line at of tab. Cells of tab from line at on
+are shifted by count lines along axis. Only CellIDs are changed, Cells are kept in their slots, so
+data on DataNodes is not touched at all. New CellIDs are flushed to the Store during
+checkpointing, like any other mutations of Cells.
+
+The MetaCell is never shifted.
+
+@para
+	tab: the tab to insert lines into
+	axis: RowAxis to insert rows, ColumnAxis to insert columns
+	at, count: the first line to shift and the number of lines to insert
+
+@return
+	*LinesRemap: new CellIDs of shifted Cells
+	error:
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidLinesError if count is 0, or some Cell would be shifted out of
+		the sheet, in which case nothing is changed.
+*/
+func (s *SheetFile) InsertLines(tab uint32, axis Axis, at uint32, count uint32) (*LinesRemap, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.hasTab(tab) {
+		return nil, file_errors.NewTabNotFoundError(tab)
+	}
+	if count == 0 {
+		return nil, file_errors.NewInvalidLinesError(at, count)
+	}
+	var shifted []*Cell
+	for _, cell := range s.Cells {
+		if cell.IsMeta() || cell.Tab() != tab || lineOf(cell.CellID, axis) < at {
+			continue
+		}
+		line := uint64(lineOf(cell.CellID, axis)) + uint64(count)
+		if line >= lineLimit(axis) {
+			return nil, file_errors.NewInvalidLinesError(at, count)
+		}
+		shifted = append(shifted, cell)
+	}
+	return &LinesRemap{Moved: s.shiftCells(shifted, axis, int64(count))}, nil
+}
+
+/*
+DeleteLines
+Delete count rows or columns from line at of tab. Cells in deleted lines are removed like
+DeleteCell, and Cells of tab after them are shifted back by count lines along axis. Like
+InsertLines, data on DataNodes is not touched. Slots of deleted Cells are not required
+to be overwritten, because clients only read data within extents of present Cells.
+
+The MetaCell is never deleted or shifted.
+
+@para
+	tab: the tab to delete lines from
+	axis: RowAxis to delete rows, ColumnAxis to delete columns
+	at, count: the first line to delete and the number of lines to delete
+
+@return
+	*LinesRemap: CellIDs of removed Cells and new CellIDs of shifted Cells
+	[]*Chunk: dropped Chunks to be deleted from DataNodes, see removeCell.
+	error:
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidLinesError if count is 0 or the lines exceed the sheet.
+*/
+func (s *SheetFile) DeleteLines(tab uint32, axis Axis, at uint32, count uint32) (*LinesRemap, []*Chunk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.hasTab(tab) {
+		return nil, nil, file_errors.NewTabNotFoundError(tab)
+	}
+	end := uint64(at) + uint64(count)
+	if count == 0 || end > lineLimit(axis) {
+		return nil, nil, file_errors.NewInvalidLinesError(at, count)
+	}
+	var removed, shifted []*Cell
+	for _, cell := range s.Cells {
+		if cell.IsMeta() || cell.Tab() != tab {
+			continue
+		}
+		line := uint64(lineOf(cell.CellID, axis))
+		if line >= end {
+			shifted = append(shifted, cell)
+		} else if line >= uint64(at) {
+			removed = append(removed, cell)
+		}
+	}
+	remap := &LinesRemap{Removed: make([]CellRemap, 0, len(removed))}
+	var dropped []*Chunk
+	for _, cell := range removed {
+		remap.Removed = append(remap.Removed, remapOf(cell))
+		dropped = append(dropped, s.removeCell(cell)...)
+	}
+	remap.Moved = s.shiftCells(shifted, axis, -int64(count))
+	return remap, dropped, nil
+}
+
+/*
+ApplyLinesRemap
+Replay an insertion or deletion of lines performed by InsertLines or DeleteLines on the
+primary node. Removed Cells are removed only if they are still in their slots, and shifted
+Cells are looked up by their slots, so it has no effect if remap has been applied.
+This method should only be used to replay journal entries.
+
+@para
+	remap: returned by InsertLines or DeleteLines
+
+@return
+	[]*Chunk: dropped Chunks, which have been deleted from DataNodes by the primary node.
+*/
+func (s *SheetFile) ApplyLinesRemap(remap *LinesRemap) []*Chunk {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var dropped []*Chunk
+	for _, r := range remap.Removed {
+		cell, ok := s.Cells[r.CellID]
+		if !ok || cell.ChunkID != r.ChunkID || cell.Offset != r.Offset {
+			continue
+		}
+		dropped = append(dropped, s.removeCell(cell)...)
+	}
+	bySlot := make(map[CellRemap]*Cell, len(s.Cells))
+	for _, cell := range s.Cells {
+		bySlot[CellRemap{ChunkID: cell.ChunkID, Offset: cell.Offset}] = cell
+	}
+	var cells []*Cell
+	var ids []int64
+	for _, r := range remap.Moved {
+		cell, ok := bySlot[CellRemap{ChunkID: r.ChunkID, Offset: r.Offset}]
+		if !ok || cell.CellID == r.CellID {
+			continue
+		}
+		cells = append(cells, cell)
+		ids = append(ids, r.CellID)
+	}
+	s.rekeyCells(cells, ids)
+	return dropped
+}
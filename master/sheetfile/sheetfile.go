@@ -1,22 +1,38 @@
 package sheetfile
 
 import (
+	"errors"
 	"github.com/fourstring/sheetfs/master/config"
 	"github.com/fourstring/sheetfs/master/datanode_alloc"
 	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
-	"gorm.io/gorm"
+	"sort"
 	"sync"
 )
 
+/*
+errCopyNotPrepared
+Raised if a mutation writes a shared Chunk not copied by lockWrite, which means the plan
+of the mutation misses it.
+*/
+var errCopyNotPrepared = errors.New("shared chunk is written without being copied")
+
 /*
 SheetFile
 Represents a file containing a sheet.
-Every SheetFile is made of lots of Cell. Almost every Cell has
-config.MaxBytesPerCell bytes of storage to store its data, and there
+Every SheetFile is made of lots of Cell. Every Cell is allocated a slot from one of
+config.CellSizeClasses to store its data, the smallest one its data fits in, and there
 is a special one called MetaCell, whose size will be config.BytesPerChunk.
 Applications should consider to make use of MetaCell to store data related
 to whole sheet. MetaCell can be accessed by (config.SheetMetaCellRow, config.SheetMetaCellCol).
 
+A SheetFile is a workbook made of tabs, every Cell belongs to a tab, see GetCellID. Every
+tab has its own MetaCell, which also keeps the name and position of the tab, so a tab
+exists as long as its MetaCell exists. A new SheetFile has a single tab 0, see Tabs.
+
+Slots of different size classes are allocated from different Chunks, so every Chunk is
+divided into slots of the same size. When a write outgrows the slot of a Cell, the Cell is
+moved to a slot of a bigger class, see WriteCellChunk.
+
 Composed of Cells, SheetFile provides a row/col-oriented API to applications.
 Cells is the index of such an API. So Cells must be persistent, so as Chunks storing those
 Cells. And as a logical collection of Cells and Chunks, SheetFile should be used as
@@ -27,6 +43,19 @@ Chunks and Cells are maintained in pointers. Sometimes those data will be return
 outside world, which implies we can't rely SheetFile.mu on accessing those pointers goroutine-safely.
 So all pointers returned will point to a copy, or snapshot of a Chunk or Cell. In other words,
 they are a 'goroutine-safe view' of Chunks/Cells at some point.
+
+Chunks of a SheetFile may be shared with its copies, see ChunkRefs and Copy.
+
+When a Cell is deleted or moved, its slot in the Chunk is put into FreeSlots, and will be
+reused by a new Cell of the same size before LastAvailableChunks or a new Chunk. A Chunk whose Cells are all deleted
+is dropped entirely. FreeSlots has not to be persisted either, LoadSheetFile rebuilds it
+by scanning over slots not occupied by any Cell.
+
+Cells and Chunks mutated in memory are marked dirty, and only dirty ones are flushed during
+checkpointing, so the cost of a checkpoint depends on how much s is written rather than how
+large it is. Deletions are tracked likewise, and applied to the Store by the same Flush. New
+Chunks are the only exception, which are saved at once to get their IDs, see Store, but they
+are unreachable until Cells referencing them are flushed.
 */
 type SheetFile struct {
 	mu sync.RWMutex
@@ -37,106 +66,287 @@ type SheetFile struct {
 	// Maps CellID to *Cell.
 	Cells map[int64]*Cell
 	// Keeps track of latest Chunk whose remaining space is capable of storing a new Cell.
-	LastAvailableChunk *Chunk
+	// Maps size of slots to *Chunk, since a Chunk only stores Cells of the same size.
+	LastAvailableChunks map[uint64]*Chunk
+	// Slots freed by deleted Cells. Slots are validated when popped, so stale slots
+	// are allowed here.
+	FreeSlots []Slot
+	// Slots whose versions have been increased in the ongoing batch of writes, nil if
+	// there is no such a batch, see WriteCellsChunks.
+	batch map[Slot]bool
+	// Slots reserved for Cells to be moved by the ongoing compaction, which are not
+	// allocated to other Cells, see PlanCompaction.
+	reserved map[Slot]bool
+	// Copies of shared Chunks prepared for the ongoing mutation, keyed by IDs of the
+	// shared Chunks, see lockWrite.
+	copies map[uint64]*Chunk
+	// Held by FileManager while a mutation of the SheetFile is applied and journaled.
+	// Mutations of different Cells commute, so they share it, but InsertLines and
+	// DeleteLines hold it exclusively to be journaled in the order they are applied.
+	JournalMu sync.RWMutex
 
-	filename string
-	alloc    *datanode_alloc.DataNodeAllocator
+	// Immutable ID of the SheetFile, see Cell.
+	id    uint64
+	alloc *datanode_alloc.DataNodeAllocator
+	// Reference counts of shared Chunks, nil if Chunks are never shared.
+	refs *ChunkRefs
+	// Cells and Chunks mutated since they were flushed, mapped to the number of the Flush
+	// they are mutated before.
+	dirtyCells  map[*Cell]uint64
+	dirtyChunks map[*Chunk]uint64
+	// Cells and Chunks removed from s but not deleted from the Store yet, mapped like
+	// dirtyCells and dirtyChunks.
+	deletedCells  map[*Cell]uint64
+	deletedChunks map[*Chunk]uint64
+	// Whether all Cells of s should replace those in the Store by next Flush, which is set
+	// for a copy, see Copy.
+	replacing bool
+	// Number of Flush performed.
+	flushes uint64
+}
+
+/*
+Slot
+Position of a slot in a Chunk. The size of the slot is given by the Chunk.
+*/
+type Slot struct {
+	ChunkID uint64
+	Offset  uint64
 }
 
 /*
 CreateSheetFile
-Create a SheetFile, corresponding sqlite table to store Cells of the SheetFile, MetaCell
+Create a SheetFile, the storage of Cells of the SheetFile in db, MetaCell
 and chunk used to store it.
 Theoretically, it's not required to flush the metadata of a new file to disk immediately.
-However, we make use of sqlite as some kind of alternative to general BTree. So we create
-the table here as a workaround.
+However, we make use of the Store as some kind of alternative to general BTree. So we
+create the storage here as a workaround.
 
 @para
-	db: a gorm connection. It should not be a transaction.(See CreateCellTableIfNotExists)
-	filename: filename of new SheetFile
+	db: a Store. It can be a transaction.
+	refs: ChunkRefs shared by all SheetFiles, can be nil
+	id: ID of new SheetFile, allocated by caller
 
 @return
 	*SheetFile: pointer of new SheetFile if success, or nil.
 	error:
-		some error happens while creating cell table.
+		some error happens while preparing the storage of Cells.
 		*errors.NoDataNodeError: This function must allocate a Chunk for MetaCell, if there
 		are no DataNodes for storing this cell, returns NoDateNodeError.
 */
-func CreateSheetFile(db *gorm.DB, alloc *datanode_alloc.DataNodeAllocator, filename string) (*SheetFile, *Cell, *Chunk, error) {
+func CreateSheetFile(db Store, alloc *datanode_alloc.DataNodeAllocator, refs *ChunkRefs, id uint64) (*SheetFile, *Cell, *Chunk, error) {
 	f := &SheetFile{
-		Chunks:             map[uint64]*Chunk{},
-		Cells:              map[int64]*Cell{},
-		LastAvailableChunk: nil,
-		filename:           filename,
-		alloc:              alloc,
+		Chunks:              map[uint64]*Chunk{},
+		Cells:               map[int64]*Cell{},
+		LastAvailableChunks: map[uint64]*Chunk{},
+		id:                  id,
+		alloc:               alloc,
+		refs:                refs,
 	}
 	err := f.persistentStructure(db)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	dataNode, err := alloc.AllocateNode()
+	// Create Chunk and MetaCell of the first tab
+	metaCell, chunk, err := f.addMetaCell(0, DefaultTabName(0), 0, db)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	// Create Chunk and MetaCell
-	chunk := &Chunk{DataNode: dataNode, Version: 0}
-	chunk.Persistent(db)
-	metaCell := NewCell(config.SheetMetaCellID, 0, config.BytesPerChunk, chunk.ID, filename)
-	chunk.Cells = []*Cell{metaCell}
-	// Add MetaCell and Chunk to new file
-	f.Chunks[chunk.ID] = chunk
-	f.Cells[metaCell.CellID] = metaCell
 	return f, metaCell, chunk, nil
 }
 
 /*
 LoadSheetFile
 Load a SheetFile from database. As mentioned above, SheetFile has not to be persisted.
-In fact, this function loads all Cells of given filename from database. Afterwards,
+In fact, this function loads all Cells of given id from database. Afterwards,
 this function scans over those cells, adding them to SheetFile.Cells, and their Chunk to
-SheetFile.Chunks. Besides, this function also set SheetFile.LastAvailableChunk to the
-first Chunk whose isAvailable() is true.
+SheetFile.Chunks, as well as their overflow Chunks. Besides, this function also set
+SheetFile.LastAvailableChunks of every size to the fullest Chunk whose isAvailable() is
+true, see resetLastAvailableChunks.
 
-This method should only be used to load checkpoints in sqlite. (See GetSheetCellsAll)
+This method should only be used to load checkpoints in db. (See GetSheetCellsAll)
 
 @para
-	db: a gorm connection. It can be a transaction.
-	filename: The validity of filename won't be checked. Caller should guarantee that
-	a valid filename is passed in.
+	db: a Store. It can be a transaction.
+	refs: ChunkRefs shared by all SheetFiles, can be nil
+	id: The validity of id won't be checked. Caller should guarantee that
+	a valid id is passed in.
 
 @return
-	*SheetFile: pointer of loaded SheetFile.
+	*SheetFile: pointer of loaded SheetFile, or nil if some Chunk can't be loaded.
+	error:
+		*errors.ChunkNotFoundError if a Chunk referenced by some Cell doesn't exist.
+		errors raised by db while loading Chunks.
 */
-func LoadSheetFile(db *gorm.DB, alloc *datanode_alloc.DataNodeAllocator, filename string) *SheetFile {
-	cells := GetSheetCellsAll(db, filename)
+func LoadSheetFile(db Store, alloc *datanode_alloc.DataNodeAllocator, refs *ChunkRefs, id uint64) (*SheetFile, error) {
+	cells := GetSheetCellsAll(db, id)
 	file := &SheetFile{
-		Chunks:   map[uint64]*Chunk{},
-		Cells:    map[int64]*Cell{},
-		filename: filename,
-		alloc:    alloc,
+		Chunks:              map[uint64]*Chunk{},
+		Cells:               map[int64]*Cell{},
+		LastAvailableChunks: map[uint64]*Chunk{},
+		id:                  id,
+		alloc:               alloc,
+		refs:                refs,
+	}
+	// Up to config.SlotsPerChunk cells are stored in the same Chunk, so
+	// distinct Chunks are collected first, and loaded in batches rather than one
+	// query per Chunk.
+	var ids []uint64
+	seen := map[uint64]bool{}
+	for _, cell := range cells {
+		for _, cid := range append([]uint64{cell.ChunkID}, cell.Overflow...) {
+			if !seen[cid] {
+				seen[cid] = true
+				ids = append(ids, cid)
+			}
+		}
+	}
+	chunks, err := loadChunksForFile(db, ids)
+	if err != nil {
+		return nil, err
 	}
 	for _, cell := range cells {
-		// SheetName is ignored by gorm, not persist to sqlite
-		// However it's necessary to persist cell later
-		cell.SheetName = filename
 		file.Cells[cell.CellID] = cell
-		_, ok := file.Chunks[cell.ChunkID]
-		// config.MaxCellsPerChunk cells will be stored in the same Chunk at most.
-		// So Chunk of a Cell may have been added to file.Chunks.
-		// To avoid expensive database operations, we first check whether cell.ChunkID
-		// has been loaded or not.
-		if !ok {
-			dataChunk := loadChunkForFile(db, filename, cell.ChunkID)
-			file.Chunks[cell.ChunkID] = dataChunk
-			// SheetFile.WriteCellChunk guarantees that it always fulfill currently
-			// available Chunk before allocating a new one. So the first Chunk whose
-			// isAvailable() should be the LastAvailableChunk.
-			if file.LastAvailableChunk == nil && dataChunk.isAvailable(config.MaxBytesPerCell) {
-				file.LastAvailableChunk = dataChunk
+		dataChunk := chunks[cell.ChunkID]
+		file.Chunks[cell.ChunkID] = dataChunk
+		dataChunk.Cells = append(dataChunk.Cells, cell)
+	}
+	for _, cell := range cells {
+		for _, oid := range cell.Overflow {
+			overflow := chunks[oid]
+			overflow.Overflow = true
+			file.Chunks[oid] = overflow
+		}
+	}
+	// Since Cells may be deleted or moved, there can be many available Chunks of a size.
+	file.resetLastAvailableChunks()
+	file.rebuildFreeSlots()
+	return file, nil
+}
+
+/*
+rebuildFreeSlots
+Collect all slots not occupied by any Cell into s.FreeSlots, in a deterministic order.
+*/
+func (s *SheetFile) rebuildFreeSlots() {
+	ids := make([]uint64, 0, len(s.Chunks))
+	for id := range s.Chunks {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] > ids[j]
+	})
+	s.FreeSlots = nil
+	for _, id := range ids {
+		c := s.Chunks[id]
+		size := c.slotSize()
+		if size == 0 || !s.isAvailable(c, size) {
+			continue
+		}
+		for offset := config.BytesPerChunk / size * size; offset >= size; {
+			offset -= size
+			if c.cellAt(offset) == nil && !s.reserved[Slot{ChunkID: id, Offset: offset}] {
+				s.FreeSlots = append(s.FreeSlots, Slot{ChunkID: id, Offset: offset})
 			}
 		}
 	}
-	return file
+}
+
+/*
+Copy
+Create a copy of s with given id, sharing all Chunks of s copy-on-write. Only Cells
+are duplicated, so copying is cheap no matter how much data s contains. Cells and Chunks
+of the copy are dirty, so the copy is persisted by next checkpoint even if s is deleted
+before it. The copy may be created again when replaying journal after it has been
+flushed, so its Cells replace stale ones in the Store when flushed.
+
+s must be created or loaded with a non-nil ChunkRefs.
+
+@para
+	db: a Store. It can be a transaction.
+	id: ID of the copy, allocated by caller
+
+@return
+	*SheetFile: the copy if success, or nil.
+	error: errors while preparing the storage of the copy.
+*/
+func (s *SheetFile) Copy(db Store, id uint64) (*SheetFile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f := &SheetFile{
+		Chunks:              map[uint64]*Chunk{},
+		Cells:               map[int64]*Cell{},
+		LastAvailableChunks: map[uint64]*Chunk{},
+		id:                  id,
+		alloc:               s.alloc,
+		refs:                s.refs,
+	}
+	err := f.persistentStructure(db)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]*Chunk, 0, len(s.Chunks))
+	for _, c := range s.Chunks {
+		var nc Chunk
+		nc = *c
+		nc.Cells = make([]*Cell, len(c.Cells))
+		for i, cell := range c.Cells {
+			ncell := NewCell(cell.CellID, cell.Offset, cell.Size, cell.ChunkID, id)
+			ncell.Overflow = append(ChunkIDs(nil), cell.Overflow...)
+			ncell.Length = cell.Length
+			ncell.TabName = cell.TabName
+			ncell.TabPosition = cell.TabPosition
+			nc.Cells[i] = ncell
+			f.Cells[ncell.CellID] = ncell
+		}
+		f.Chunks[nc.ID] = &nc
+		chunks = append(chunks, c)
+	}
+	for size, c := range s.LastAvailableChunks {
+		f.LastAvailableChunks[size] = f.Chunks[c.ID]
+	}
+	f.FreeSlots = append([]Slot(nil), s.FreeSlots...)
+	// Shared Chunks are never mutated, so the copy and s flush the same data of them.
+	for _, c := range f.Chunks {
+		f.markChunk(c)
+	}
+	f.replacing = true
+	f.refs.Share(chunks)
+	return f, nil
+}
+
+/*
+DropSheetFile
+Delete all metadata of a SheetFile from database permanently, including its Chunks
+and all its Cells. Data of Chunks on DataNodes is not touched, caller
+should delete them separately.
+
+@para
+	db: a Store. It can be a transaction.
+	id: ID of the SheetFile to be dropped
+	chunks: all Chunks of the SheetFile
+
+@return
+	error: errors while deleting Chunks or Cells.
+*/
+func DropSheetFile(db Store, id uint64, chunks []*Chunk) error {
+	ids := make([]uint64, len(chunks))
+	for i, c := range chunks {
+		ids[i] = c.ID
+	}
+	err := db.DeleteChunks(ids)
+	if err != nil {
+		return err
+	}
+	return db.DropCells(id)
+}
+
+/*
+ID
+Returns the immutable ID of s.
+*/
+func (s *SheetFile) ID() uint64 {
+	return s.id
 }
 
 /*
@@ -158,47 +368,230 @@ func (s *SheetFile) GetAllChunks() []*Chunk {
 	return chunks
 }
 
+/*
+SheetStat
+Statistics of a SheetFile, see SheetFile.Stat.
+*/
+type SheetStat struct {
+	Cells  uint64
+	Chunks uint64
+	// Bytes of all Chunks, including free slots of them.
+	AllocatedBytes uint64
+	// Bytes of data written to all Cells, including those in overflow Chunks.
+	UsedBytes uint64
+	// Sorted names of distinct DataNode groups storing Chunks.
+	DataNodes []string
+}
+
+/*
+Stat
+Collect statistics of s by scanning over s.Cells and s.Chunks.
+
+@return
+	*SheetStat
+*/
+func (s *SheetFile) Stat() *SheetStat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stat := &SheetStat{
+		Cells:          uint64(len(s.Cells)),
+		Chunks:         uint64(len(s.Chunks)),
+		AllocatedBytes: uint64(len(s.Chunks)) * config.BytesPerChunk,
+		DataNodes:      []string{},
+	}
+	for _, cell := range s.Cells {
+		stat.UsedBytes += cell.Length
+	}
+	dataNodes := map[string]bool{}
+	for _, c := range s.Chunks {
+		if !dataNodes[c.DataNode] {
+			dataNodes[c.DataNode] = true
+			stat.DataNodes = append(stat.DataNodes, c.DataNode)
+		}
+	}
+	sort.Strings(stat.DataNodes)
+	return stat
+}
+
 /*
 GetCellChunk
-Lookup Cell located at (row, col) and its Chunk.
+Lookup Cell located at (row, col) in tab and its Chunk.
 
 @para
+	tab: tab number of Cell
 	row: row number of Cell
 	col: column number of Cell
 
 @return
 	*Cell, *Chunk: corresponding snapshots if no error
+	[]*Chunk: snapshots of overflow Chunks of the Cell, in order.
 	error:
 		*errors.CellNotFoundError if row, col passed in is invalid.
 */
-func (s *SheetFile) GetCellChunk(row, col uint32) (*Cell, *Chunk, error) {
+func (s *SheetFile) GetCellChunk(tab, row, col uint32) (*Cell, *Chunk, []*Chunk, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	// Compute CellID by row, col and lookup cell by CellID.
-	cell := s.Cells[GetCellID(row, col)]
+	if !isValidCell(tab, row, col) {
+		return nil, nil, nil, file_errors.NewCellNotFoundError(row, col)
+	}
+	// Compute CellID by tab, row, col and lookup cell by CellID.
+	cell := s.Cells[GetCellID(tab, row, col)]
 	if cell == nil {
-		return nil, nil, file_errors.NewCellNotFoundError(row, col)
+		return nil, nil, nil, file_errors.NewCellNotFoundError(row, col)
+	}
+	overflow := make([]*Chunk, len(cell.Overflow))
+	for i, id := range cell.Overflow {
+		overflow[i] = s.Chunks[id].Snapshot()
+	}
+	return cell.Snapshot(), s.Chunks[cell.ChunkID].Snapshot(), overflow, nil
+}
+
+/*
+GetRangeChunks
+Lookup Cells in rows [rowStart, rowEnd) and columns [colStart, colEnd) of tab, grouped
+by their Chunks. Cells are looked up one by one if the range is smaller than the sheet,
+otherwise all Cells are scanned, so a huge range is as cheap as ReadSheet.
+
+@para
+	tab: tab number of Cells
+	rowStart, rowEnd, colStart, colEnd: bounds of the range, empty if a start is not
+	less than its end. Rows beyond config.MaxRows are ignored.
+
+@return
+	[]*Chunk: snapshots of Chunks holding Cells in the range, sorted by ID, and Cells of
+	each Chunk are snapshots of those in the range, sorted by Offset. Snapshots of overflow
+	Chunks of those Cells follow them.
+*/
+func (s *SheetFile) GetRangeChunks(tab, rowStart, rowEnd, colStart, colEnd uint32) []*Chunk {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if rowEnd > config.MaxRows {
+		rowEnd = config.MaxRows
+	}
+	var cells []*Cell
+	if tab < config.MaxTabs && rowStart < rowEnd && colStart < colEnd {
+		if uint64(rowEnd-rowStart)*uint64(colEnd-colStart) < uint64(len(s.Cells)) {
+			for row := rowStart; row < rowEnd; row++ {
+				for col := colStart; col < colEnd; col++ {
+					if cell, ok := s.Cells[GetCellID(tab, row, col)]; ok {
+						cells = append(cells, cell)
+					}
+				}
+			}
+		} else {
+			for _, cell := range s.Cells {
+				ctab, row, col := SplitCellID(cell.CellID)
+				if ctab == tab && !cell.IsMeta() && row >= rowStart && row < rowEnd && col >= colStart && col < colEnd {
+					cells = append(cells, cell)
+				}
+			}
+		}
+	}
+	return s.groupCells(cells)
+}
+
+/*
+GetTabChunks
+Lookup all Cells of tab including its MetaCell, grouped by their Chunks like
+GetRangeChunks.
+
+@para
+	tab: tab number of Cells
+
+@return
+	[]*Chunk: snapshots of Chunks holding Cells of tab, see GetRangeChunks.
+	error:
+		*errors.TabNotFoundError if there is no such tab.
+*/
+func (s *SheetFile) GetTabChunks(tab uint32) ([]*Chunk, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.hasTab(tab) {
+		return nil, file_errors.NewTabNotFoundError(tab)
+	}
+	var cells []*Cell
+	for _, cell := range s.Cells {
+		if cell.Tab() == tab {
+			cells = append(cells, cell)
+		}
+	}
+	return s.groupCells(cells), nil
+}
+
+/*
+groupCells
+Group snapshots of cells by their Chunks, see GetRangeChunks. Caller should hold s.mu.
+*/
+func (s *SheetFile) groupCells(cells []*Cell) []*Chunk {
+	grouped := map[uint64]*Chunk{}
+	var chunks, overflow []*Chunk
+	for _, cell := range cells {
+		c, ok := grouped[cell.ChunkID]
+		if !ok {
+			var nc Chunk
+			nc = *s.Chunks[cell.ChunkID]
+			nc.Cells = nil
+			c = &nc
+			grouped[c.ID] = c
+			chunks = append(chunks, c)
+		}
+		c.Cells = append(c.Cells, cell.Snapshot())
+		for _, id := range cell.Overflow {
+			overflow = append(overflow, s.Chunks[id].Snapshot())
+		}
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].ID < chunks[j].ID
+	})
+	for _, c := range chunks {
+		sort.Slice(c.Cells, func(i, j int) bool {
+			return c.Cells[i].Offset < c.Cells[j].Offset
+		})
 	}
-	return cell.Snapshot(), s.Chunks[cell.ChunkID].Snapshot(), nil
+	return append(chunks, overflow...)
 }
 
 /*
 getCellOffset
-Compute the offset of a cell which will be added to an available Chunk.
-Every Chunk has config.MaxCellsPerChunk slots, each slot occupies
-config.MaxBytesPerCell bytes, for storing a single Cell.
-So the offset is the 0-indexed index of the new Cell in the Chunk times
-config.MaxBytesPerCell.
+Compute the offset of a cell of given size which will be added to an available Chunk.
+Every Chunk has config.BytesPerChunk / size slots, each slot occupies size bytes, for
+storing a single Cell. So the offset is the 0-indexed index of the first unoccupied
+and unreserved slot in the Chunk times size.
 */
-func (s *SheetFile) getCellOffset(chunk *Chunk) uint64 {
-	return (uint64(len(chunk.Cells))) * config.MaxBytesPerCell
+func (s *SheetFile) getCellOffset(chunk *Chunk, size uint64) uint64 {
+	offset := uint64(0)
+	for chunk.cellAt(offset) != nil || s.reserved[Slot{ChunkID: chunk.ID, Offset: offset}] {
+		offset += size
+	}
+	return offset
 }
 
 /*
-addCellToLastAvailable
-Add a new cell with given maximum size located at (row,col) to s.LastAvailableChunk.
+isAvailable
+Returns true if c is available to store a new Cell with given size, taking slots
+reserved by the ongoing compaction as occupied, see Chunk.isAvailable.
+*/
+func (s *SheetFile) isAvailable(c *Chunk, size uint64) bool {
+	if !c.isAvailable(size) {
+		return false
+	}
+	used := uint64(len(c.Cells))
+	for slot := range s.reserved {
+		if slot.ChunkID == c.ID {
+			used++
+		}
+	}
+	return (used+1)*size <= config.BytesPerChunk
+}
+
+/*
+addCell
+Add a new cell with given maximum size located at (row,col) in tab to chunk at offset.
 
 @para
+	chunk: Chunk to store the new Cell
+	offset: offset of the new Cell in chunk
+	tab: tab number
 	row: row number
 	col: column number
 	size: maximum size of new Cell
@@ -206,140 +599,1186 @@ Add a new cell with given maximum size located at (row,col) to s.LastAvailableCh
 @return
 	*Cell: pointer of new Cell
 */
-func (s *SheetFile) addCellToLastAvailable(row, col uint32, size uint64) *Cell {
-	cell := NewCell(GetCellID(row, col), s.getCellOffset(s.LastAvailableChunk),
-		size, s.LastAvailableChunk.ID, s.filename)
+func (s *SheetFile) addCell(chunk *Chunk, offset uint64, tab, row, col uint32, size uint64) *Cell {
+	cell := NewCell(GetCellID(tab, row, col), offset, size, chunk.ID, s.id)
 	s.Cells[cell.CellID] = cell
+	s.markCell(cell)
 	// Add new cell to cells of chunk
-	s.LastAvailableChunk.Cells = append(s.LastAvailableChunk.Cells, cell)
-	// Increase Version of LastAvailableChunk because new Cell is added.
-	s.LastAvailableChunk.Version += 1
+	chunk.Cells = append(chunk.Cells, cell)
+	// Increase version of the slot because new Cell is added.
+	s.bumpVersion(chunk, offset)
 	return cell
 }
 
 /*
-WriteCellChunk
-Performs necessary metadata mutations to handle an operation of writing data to a Cell.
+addCellToLastAvailable
+Add a new cell with given maximum size located at (row,col) in tab to the
+LastAvailableChunk of size. See addCell.
+*/
+func (s *SheetFile) addCellToLastAvailable(tab, row, col uint32, size uint64) *Cell {
+	chunk := s.LastAvailableChunks[size]
+	return s.addCell(chunk, s.getCellOffset(chunk, size), tab, row, col, size)
+}
+
+/*
+popFreeSlot
+Take a slot of given size from s.FreeSlots. Stale slots, whose Chunk has been dropped
+or which has been occupied or reserved again, are discarded.
+
+@return
+	Slot: the free slot
+	bool: false if there is no free slot of size
+*/
+func (s *SheetFile) popFreeSlot(size uint64) (Slot, bool) {
+	for i := len(s.FreeSlots) - 1; i >= 0; i-- {
+		slot := s.FreeSlots[i]
+		c, ok := s.Chunks[slot.ChunkID]
+		if ok && c.cellAt(slot.Offset) == nil && c.slotSize() != size {
+			continue
+		}
+		s.FreeSlots = append(s.FreeSlots[:i], s.FreeSlots[i+1:]...)
+		if ok && c.cellAt(slot.Offset) == nil && !s.reserved[slot] {
+			return slot, true
+		}
+	}
+	return Slot{}, false
+}
+
+/*
+replaceChunk
+Replace Chunk old with nc, moving all Cells in old to nc. Offsets of Cells are kept,
+so nc must contain the same data as old.
+*/
+func (s *SheetFile) replaceChunk(old *Chunk, nc *Chunk) {
+	if old.Overflow {
+		nc.Overflow = true
+		s.replaceOverflow(old.ID, nc.ID)
+	}
+	nc.Cells = make([]*Cell, len(old.Cells))
+	for i, cell := range old.Cells {
+		cell.ChunkID = nc.ID
+		nc.Cells[i] = cell
+		s.markCell(cell)
+	}
+	delete(s.Chunks, old.ID)
+	s.Chunks[nc.ID] = nc
+	s.markChunk(nc)
+	for size, c := range s.LastAvailableChunks {
+		if c == old {
+			s.LastAvailableChunks[size] = nc
+		}
+	}
+	for i := range s.FreeSlots {
+		if s.FreeSlots[i].ChunkID == old.ID {
+			s.FreeSlots[i].ChunkID = nc.ID
+		}
+	}
+}
+
+/*
+replaceOverflow
+Replace overflow Chunk old with nc in the Cell owning it.
+*/
+func (s *SheetFile) replaceOverflow(old uint64, nc uint64) {
+	for _, cell := range s.Cells {
+		for i, id := range cell.Overflow {
+			if id == old {
+				cell.Overflow[i] = nc
+				s.markCell(cell)
+				return
+			}
+		}
+	}
+}
+
+/*
+lockWrite
+Lock s.mu for a mutation which may write shared Chunks, after copying all of them, see
+copyOnWrite. Copying data on DataNodes may be slow, so it's done the way Compaction does:
+shared Chunks to copy are planned under s.mu by plan, they are copied without holding
+any lock, and the copies are committed by the mutation under s.mu. Chunks of s may change
+during copying, so plan is run again until every shared Chunk it returns has an
+up-to-date copy.
+
+Copies replace shared Chunks only when the mutation writes them, so every replacement is
+journaled along with the write. Caller should unlock s.mu by unlockWrite, which discards
+copies left unused.
 
 @para
-	row, col: row number, column number of Cell to write
-	tx: a gorm connection, can be a transaction
+	plan: returns shared Chunks which may be written by the mutation, it's called
+	with s.mu held.
+	tx: a Store, can be a transaction
 
 @return
-	*Cell, *Chunk: snapshots of the Cell and its Chunk to be written.
-	error:
-		*errors.NoDataNodeError if there is no DataNode registered.
+	error: errors raised by copier of s.refs or while persisting copies, s.mu is not
+	held in such a case.
 */
-func (s *SheetFile) WriteCellChunk(row, col uint32, tx *gorm.DB) (*Cell, *Chunk, error) {
+func (s *SheetFile) lockWrite(plan func() []*Chunk, tx Store) error {
+	copies := map[uint64]*Chunk{}
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	cell := s.Cells[GetCellID(row, col)]
-	// Lookup an existing Cell by CellID first
-	if cell != nil {
-		// For existing Cell, just increase its Chunk version
-		dataChunk := s.Chunks[cell.ChunkID]
-		dataChunk.Version += 1
-		return cell.Snapshot(), dataChunk.Snapshot(), nil
-	} else {
-		// Generally, the size of the new Cell is config.MaxBytesPerCell. However, for
-		// the MetaCell defined by (config.SheetMetaCellRow,config.SheetMetaCellCol),
-		// a whole chunk should be granted to store metadata of a sheet.
-		newCellSize := config.MaxBytesPerCell
-		if row == config.SheetMetaCellRow && col == config.SheetMetaCellCol {
-			newCellSize = config.BytesPerChunk
-		}
-		// For a new Cell, tries to add it to s.LastAvailableChunk
-		if s.LastAvailableChunk != nil && s.LastAvailableChunk.isAvailable(newCellSize) {
-			// There is a empty slot for the new Cell, add it to s.LastAvailableChunk
-			cell = s.addCellToLastAvailable(row, col, newCellSize)
-			return cell.Snapshot(), s.LastAvailableChunk.Snapshot(), nil
-		} else {
-			// s.LastAvailableChunk has been fulfilled, allocate a new Chunk, and
-			// makes it s.LastAvailableChunk.
-			datanode, err := s.alloc.AllocateNode()
+	for {
+		var missing, stale []*Chunk
+		for _, c := range plan() {
+			nc, ok := copies[c.ID]
+			// Shared Chunks are never modified in place, but c may have been written
+			// while it was owned by s exclusively, and shared again afterwards.
+			if ok && nc.Version == c.Version {
+				continue
+			}
+			if ok {
+				stale = append(stale, nc)
+				delete(copies, c.ID)
+			}
+			missing = append(missing, c.Snapshot())
+		}
+		if len(missing) == 0 {
+			break
+		}
+		s.mu.Unlock()
+		s.discardCopies(stale, tx)
+		for _, c := range missing {
+			nc, err := s.copyChunk(c, tx)
 			if err != nil {
-				// If there is no DataNode, *errors.NoDataNodeError will be returned.
-				return nil, nil, err
+				unused := make([]*Chunk, 0, len(copies))
+				for _, nc := range copies {
+					unused = append(unused, nc)
+				}
+				s.discardCopies(unused, tx)
+				return err
 			}
-			newChunk := &Chunk{
-				DataNode: datanode,
-				Version:  0,
-				Cells:    []*Cell{},
+			if nc != nil {
+				copies[c.ID] = nc
 			}
-			// newChunk.ID is allocated by sqlite(autoIncrement). So it's required to persist
-			// newChunk here to get newChunk.ID.
-			newChunk.Persistent(tx)
-			// Add the newChunk to Chunks collection of s.
-			s.Chunks[newChunk.ID] = newChunk
-			// Let newChunk to be s.LastAvailableChunk
-			s.LastAvailableChunk = newChunk
-			// Re-use logic of addCellToLastAvailable
-			cell = s.addCellToLastAvailable(row, col, newCellSize)
-			return cell.Snapshot(), newChunk.Snapshot(), nil
 		}
+		s.mu.Lock()
 	}
+	s.copies = copies
+	return nil
 }
 
 /*
-Persistent
-Flush the Cell and Chunk data stored in a SheetFile to sqlite.
+unlockWrite
+Unlock s.mu locked by lockWrite. Copies not used by the mutation, because their Chunks
+are not written or not shared any more, are discarded after s.mu is unlocked.
+*/
+func (s *SheetFile) unlockWrite(tx Store) {
+	unused := make([]*Chunk, 0, len(s.copies))
+	for _, nc := range s.copies {
+		unused = append(unused, nc)
+	}
+	s.copies = nil
+	s.mu.Unlock()
+	s.discardCopies(unused, tx)
+}
 
-@para
-	db: a gorm connection. It's supposed to be a transaction.
+/*
+discardCopies
+Delete copies of shared Chunks never used by s from tx and DataNodes. s.mu should not be
+held.
+*/
+func (s *SheetFile) discardCopies(copies []*Chunk, tx Store) {
+	if len(copies) == 0 {
+		return
+	}
+	ids := make([]uint64, len(copies))
+	for i, nc := range copies {
+		ids[i] = nc.ID
+	}
+	_ = tx.DeleteChunks(ids)
+	if s.refs.deleter != nil {
+		s.refs.deleter(copies)
+	}
+}
+
+/*
+sharedChunks
+Returns Chunks in chunks which are shared, without duplicates. Caller should hold s.mu.
+*/
+func (s *SheetFile) sharedChunks(chunks []*Chunk) []*Chunk {
+	if s.refs == nil {
+		return nil
+	}
+	s.refs.mu.Lock()
+	defer s.refs.mu.Unlock()
+	seen := map[uint64]bool{}
+	var shared []*Chunk
+	for _, c := range chunks {
+		if c == nil || seen[c.ID] || !s.refs.isShared(c.ID) {
+			continue
+		}
+		seen[c.ID] = true
+		shared = append(shared, c)
+	}
+	return shared
+}
+
+/*
+planWrites
+Returns shared Chunks which may be written by writes, see WriteCellsChunks. Slots of new
+Cells are predicted like allocSlot, taking every free slot which may be popped, and slots
+freed by moved Cells are in Chunks of those Cells. So it may return more Chunks than
+actually written, but never less. Caller should hold s.mu.
+*/
+func (s *SheetFile) planWrites(writes []CellWrite) []*Chunk {
+	var chunks []*Chunk
+	allocs := map[uint64]int{}
+	for _, w := range writes {
+		slotSize, err := s.slotSizeFor(w.Tab, w.Row, w.Col, w.Size)
+		if err != nil {
+			// The write fails before touching any Chunk.
+			continue
+		}
+		cell := s.Cells[GetCellID(w.Tab, w.Row, w.Col)]
+		if cell == nil || slotSize != cell.Size {
+			allocs[slotSize]++
+		}
+		if cell == nil {
+			continue
+		}
+		chunks = append(chunks, s.Chunks[cell.ChunkID])
+		if slotSize == cell.Size {
+			for _, id := range cell.Overflow {
+				chunks = append(chunks, s.Chunks[id])
+			}
+		}
+	}
+	for size, n := range allocs {
+		chunks = append(chunks, s.peekFreeSlots(size, n)...)
+		if last := s.LastAvailableChunks[size]; last != nil && s.isAvailable(last, size) {
+			chunks = append(chunks, last)
+		}
+	}
+	return s.sharedChunks(chunks)
+}
+
+/*
+peekFreeSlots
+Returns Chunks of at most n slots of size to be popped by popFreeSlot, without popping
+them. Caller should hold s.mu.
+*/
+func (s *SheetFile) peekFreeSlots(size uint64, n int) []*Chunk {
+	if size == config.BytesPerChunk {
+		return nil
+	}
+	var chunks []*Chunk
+	for i := len(s.FreeSlots) - 1; i >= 0 && len(chunks) < n; i-- {
+		slot := s.FreeSlots[i]
+		c, ok := s.Chunks[slot.ChunkID]
+		if ok && c.cellAt(slot.Offset) == nil && c.slotSize() == size && !s.reserved[slot] {
+			chunks = append(chunks, c)
+		}
+	}
+	return chunks
+}
+
+/*
+copyOnWrite
+Returns a Chunk which can be written by s in place instead of c. If c is shared, replace
+it with its copy prepared by lockWrite, otherwise c itself is returned. Caller should
+hold s.mu locked by lockWrite.
+
+c may be released by other SheetFiles since it was copied, so whether it's still shared
+is checked here. If it's owned by s exclusively now, c is returned, and the copy is
+discarded by unlockWrite.
 
 @return
-	error: always nil currently. But potentially errors may be introduced
-	in the future.
+	*Chunk: c or its copy
+	error: errCopyNotPrepared if c is shared but not copied by lockWrite, c is unchanged
+	in such a case.
 */
-func (s *SheetFile) Persistent(tx *gorm.DB) error {
-	err := tx.Transaction(s.persistentData)
-	if err != nil {
-		return err
+func (s *SheetFile) copyOnWrite(c *Chunk) (*Chunk, error) {
+	if s.refs == nil {
+		return c, nil
 	}
-	return nil
+	s.refs.mu.Lock()
+	defer s.refs.mu.Unlock()
+	if !s.refs.isShared(c.ID) {
+		return c, nil
+	}
+	nc, ok := s.copies[c.ID]
+	if !ok {
+		return nil, errCopyNotPrepared
+	}
+	delete(s.copies, c.ID)
+	s.refs.release(c.ID)
+	s.replaceChunk(c, nc)
+	return nc, nil
 }
 
 /*
-persistentStructure
-Creates the table required to store Cells of a SheetFile.
-This method should only be called once when a SheetFile is created.
+copyChunk
+Allocate a new Chunk on the DataNode storing c, and copy data of c to it by copier of
+s.refs. The new Chunk is persisted to obtain its ID, and it's deleted again if copying
+fails. Neither s.mu nor s.refs.mu should be held.
+
+Only one SheetFile copies c at a time, see ChunkRefs.beginCopy. Others wait for it, since
+c may be owned exclusively afterwards.
 
 @para
-	db: a gorm connection. Creating a table in a sqlite transaction is
-	not allowed, so it can't be a transaction.
+	c: a snapshot of the shared Chunk
 
 @return
-	error: errors during creation of the Cell table.
+	*Chunk: the copy, or nil if c is not shared any more.
+	error: errors raised by copier of s.refs or while persisting the copy.
 */
-func (s *SheetFile) persistentStructure(db *gorm.DB) error {
-	err := CreateCellTableIfNotExists(db, s.filename)
+func (s *SheetFile) copyChunk(c *Chunk, tx Store) (*Chunk, error) {
+	done := s.refs.beginCopy(c.ID)
+	if done == nil {
+		return nil, nil
+	}
+	defer func() {
+		s.refs.mu.Lock()
+		s.refs.endCopy(c.ID, done)
+		s.refs.mu.Unlock()
+	}()
+	nc := &Chunk{DataNode: c.DataNode, Version: c.Version, CopyOf: c.ID}
+	// The copy on DataNode keeps versions of slots too.
+	nc.Versions = append(SlotVersions(nil), c.Versions...)
+	err := nc.Persistent(tx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	// Nothing has been written to a Chunk whose Version is 0.
+	if s.refs.copier != nil && c.Version > 0 {
+		err = s.refs.copier(c, nc.Snapshot())
+		if err != nil {
+			_ = tx.DeleteChunks([]uint64{nc.ID})
+			return nil, err
+		}
+	}
+	return nc, nil
 }
 
 /*
-persistentData
-Flush the Cell and Chunk data stored in a SheetFile to sqlite.
+ReplaceSharedChunk
+Replay a copy-on-write of a shared Chunk, which has been performed by copyOnWrite on
+the primary node. Do nothing if nc.CopyOf is not a Chunk of s, or nc has been added.
+This method should only be used to replay journal entries.
 
 @para
-	db: a gorm connection. It is supposed to be a transaction.
+	nc: the new Chunk copied from nc.CopyOf
+*/
+func (s *SheetFile) ReplaceSharedChunk(nc *Chunk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.Chunks[nc.CopyOf]
+	if !ok {
+		return
+	}
+	if _, ok := s.Chunks[nc.ID]; ok {
+		return
+	}
+	if s.refs != nil {
+		s.refs.mu.Lock()
+		s.refs.release(old.ID)
+		s.refs.mu.Unlock()
+	}
+	s.replaceChunk(old, nc)
+}
 
-@return
-	error: always nil currently. But potentially errors may be introduced
-	in the future.
+/*
+overflowCount
+Returns the number of overflow Chunks required to store size bytes in a Cell whose
+slot has slotSize bytes.
 */
-func (s *SheetFile) persistentData(tx *gorm.DB) error {
-	if len(s.Cells) == 0 {
-		return nil
+func overflowCount(slotSize uint64, size uint64) uint64 {
+	if size <= slotSize {
+		return 0
 	}
+	return (size - slotSize + config.BytesPerChunk - 1) / config.BytesPerChunk
+}
 
-	for _, cell := range s.Cells {
-		cell.Persistent(tx)
+/*
+sizeClass
+Returns the size of slots to allocate a new Cell with size bytes of data from, which is
+the smallest one in config.CellSizeClasses not less than size, or the biggest one if
+size exceeds all of them. Empty data(0), which may come from clients not reporting the
+size, takes config.DefaultBytesPerCell.
+*/
+func sizeClass(size uint64) uint64 {
+	if size == 0 {
+		return config.DefaultBytesPerCell
 	}
-	for _, dataChunk := range s.Chunks {
-		dataChunk.Persistent(tx)
+	for _, class := range config.CellSizeClasses {
+		if size <= class {
+			return class
+		}
+	}
+	return config.MaxBytesPerCell
+}
+
+/*
+CellMove
+Describes the old slot of a Cell moved to a bigger size class by WriteCellChunk. The
+old slot is freed like a deleted Cell, see DeleteCell.
+*/
+type CellMove struct {
+	// Snapshot of the Cell before moving.
+	Cell *Cell
+	// Snapshot of the Chunk containing the old slot, the slot should be overwritten at
+	// its version in the Chunk. Chunk is nil if it has been dropped.
+	Chunk *Chunk
+	// Dropped Chunks to be deleted from DataNodes, see removeCell.
+	Dropped []*Chunk
+}
+
+/*
+WriteCellChunk
+Performs necessary metadata mutations to handle an operation of writing data to a Cell.
+A new Cell is allocated from the size class of the data. If the data outgrows the slot of
+an existing Cell, and there is a bigger size class, the Cell is moved to a new slot of the
+size class of the data first. If the data doesn't fit in the slot of the biggest size class,
+overflow Chunks are allocated for it. Overflow Chunks are kept if the Cell shrinks later,
+so writers should always overwrite all of them. If any Chunk to be written is shared with
+other SheetFiles, it's copied first, see lockWrite.
+
+@para
+	tab, row, col: tab number, row number, column number of Cell to write
+	size: bytes of data to write, which is recorded as Length of the Cell
+	tx: a Store, can be a transaction
+
+@return
+	*Cell, *Chunk: snapshots of the Cell and its Chunk to be written.
+	[]*Chunk: snapshots of overflow Chunks of the Cell to be written, in order.
+	*CellMove: the old slot of the Cell if it's moved, or nil.
+	error:
+		*errors.InvalidCellError if the Cell can't be addressed, see GetCellID.
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.CellTooLargeError if size exceeds the limit of a Cell.
+		*errors.NoDataNodeError if there is no DataNode registered.
+		errors raised while copying a shared Chunk or by the Store.
+*/
+func (s *SheetFile) WriteCellChunk(tab, row, col uint32, size uint64, tx Store) (*Cell, *Chunk, []*Chunk, *CellMove, error) {
+	err := s.lockWrite(func() []*Chunk {
+		return s.planWrites([]CellWrite{{Tab: tab, Row: row, Col: col, Size: size}})
+	}, tx)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	defer s.unlockWrite(tx)
+	return s.writeCell(tab, row, col, size, tx)
+}
+
+/*
+slotSizeFor
+Returns the size of the slot to store size bytes of data in the Cell located at (row, col)
+in tab, see WriteCellChunk. Caller should hold s.mu.
+
+@return
+	uint64: size of the slot.
+	error:
+		*errors.InvalidCellError if the Cell can't be addressed, see GetCellID.
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.CellTooLargeError if size exceeds the limit of a Cell.
+*/
+func (s *SheetFile) slotSizeFor(tab, row, col uint32, size uint64) (uint64, error) {
+	if !isValidCell(tab, row, col) {
+		return 0, file_errors.NewInvalidCellError(row, col)
+	}
+	if !s.hasTab(tab) {
+		return 0, file_errors.NewTabNotFoundError(tab)
+	}
+	// The MetaCell of every existing tab has been created along with the tab.
+	cell := s.Cells[GetCellID(tab, row, col)]
+	slotSize := sizeClass(size)
+	if cell != nil && (cell.IsMeta() || slotSize <= cell.Size) {
+		slotSize = cell.Size
+	}
+	if overflowCount(slotSize, size) > config.MaxOverflowChunks {
+		return 0, file_errors.NewCellTooLargeError(row, col, size)
+	}
+	return slotSize, nil
+}
+
+/*
+writeCell
+Performs metadata mutations of WriteCellChunk. Caller should hold s.mu.
+*/
+func (s *SheetFile) writeCell(tab, row, col uint32, size uint64, tx Store) (*Cell, *Chunk, []*Chunk, *CellMove, error) {
+	slotSize, err := s.slotSizeFor(tab, row, col, size)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	cell := s.Cells[GetCellID(tab, row, col)]
+	var moved *CellMove
+	var dataChunk *Chunk
+	if cell != nil && slotSize != cell.Size {
+		moved, dataChunk, err = s.moveCell(cell, slotSize, tx)
+	} else {
+		cell, dataChunk, err = s.writeSlot(tab, row, col, slotSize, tx)
+	}
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	overflow, err := s.writeOverflow(cell, size, tx)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	cell.Length = size
+	s.markCell(cell)
+	return cell.Snapshot(), dataChunk.Snapshot(), overflow, moved, nil
+}
+
+/*
+CellWrite
+A write to the Cell located at (Row, Col) in Tab in a batch, see WriteCellsChunks.
+*/
+type CellWrite struct {
+	Tab  uint32
+	Row  uint32
+	Col  uint32
+	Size uint64
+}
+
+/*
+CellWriteResult
+Metadata mutated by a CellWrite, which are the same as those returned by WriteCellChunk.
+*/
+type CellWriteResult struct {
+	Cell     *Cell
+	Chunk    *Chunk
+	Overflow []*Chunk
+	Moved    *CellMove
+}
+
+/*
+WriteCellsChunks
+Performs metadata mutations of a batch of writes atomically, each of them is handled like
+WriteCellChunk. However, the version of every slot involved in the batch is increased only
+once, so writers should write every slot belonging to the batch once, including the old
+slots of moved Cells unless they are taken by other Cells in the batch.
+
+All writes are validated before any of them is performed, so nothing is changed if some
+write is invalid.
+
+@para
+	writes: writes to different Cells
+	tx: a Store, can be a transaction
+
+@return
+	[]*CellWriteResult: results of writes in the order of writes. If an error is raised
+	while performing some write, results of writes performed before are returned along
+	with the error.
+	error:
+		*errors.DuplicateCellError if a Cell is written more than once.
+		*errors.InvalidCellError if some Cell can't be addressed, see GetCellID.
+		*errors.TabNotFoundError if the tab of some write doesn't exist.
+		*errors.CellTooLargeError if the size of some write exceeds the limit of a Cell.
+		*errors.NoDataNodeError if there is no DataNode registered.
+		errors raised while copying a shared Chunk or by the Store.
+*/
+func (s *SheetFile) WriteCellsChunks(writes []CellWrite, tx Store) ([]*CellWriteResult, error) {
+	err := s.lockWrite(func() []*Chunk {
+		return s.planWrites(writes)
+	}, tx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlockWrite(tx)
+	written := map[int64]bool{}
+	for _, w := range writes {
+		if _, err := s.slotSizeFor(w.Tab, w.Row, w.Col, w.Size); err != nil {
+			return nil, err
+		}
+		id := GetCellID(w.Tab, w.Row, w.Col)
+		if written[id] {
+			return nil, file_errors.NewDuplicateCellError(w.Row, w.Col)
+		}
+		written[id] = true
+	}
+	s.batch = map[Slot]bool{}
+	defer func() {
+		s.batch = nil
+	}()
+	results := make([]*CellWriteResult, 0, len(writes))
+	for _, w := range writes {
+		cell, dataChunk, overflow, moved, err := s.writeCell(w.Tab, w.Row, w.Col, w.Size, tx)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, &CellWriteResult{Cell: cell, Chunk: dataChunk, Overflow: overflow, Moved: moved})
+	}
+	return results, nil
+}
+
+/*
+bumpVersion
+Increase the version of the slot at offset of c to be written. In a batch of writes, a
+slot is written only once, so its version is increased only once too, see
+WriteCellsChunks. Caller should hold s.mu.
+*/
+func (s *SheetFile) bumpVersion(c *Chunk, offset uint64) {
+	if s.batch != nil {
+		slot := Slot{ChunkID: c.ID, Offset: offset}
+		if s.batch[slot] {
+			return
+		}
+		s.batch[slot] = true
+	}
+	c.bumpSlot(offset)
+	s.markChunk(c)
+}
+
+/*
+writeOverflow
+Allocate overflow Chunks for cell until size bytes fit in it, and increase Versions of
+all of its overflow Chunks, since all of them will be written. Caller should hold s.mu.
+
+@return
+	[]*Chunk: snapshots of overflow Chunks of cell, in order.
+	error:
+		*errors.NoDataNodeError if there is no DataNode registered.
+		errors raised while copying a shared Chunk or by the Store.
+*/
+func (s *SheetFile) writeOverflow(cell *Cell, size uint64, tx Store) ([]*Chunk, error) {
+	for uint64(len(cell.Overflow)) < overflowCount(cell.Size, size) {
+		datanode, err := s.alloc.AllocateNode()
+		if err != nil {
+			return nil, err
+		}
+		c := &Chunk{DataNode: datanode, Version: 0, Overflow: true, Cells: []*Cell{}}
+		err = c.Persistent(tx)
+		if err != nil {
+			return nil, err
+		}
+		s.Chunks[c.ID] = c
+		cell.Overflow = append(cell.Overflow, c.ID)
+	}
+	chunks := make([]*Chunk, len(cell.Overflow))
+	for i, id := range cell.Overflow {
+		c, err := s.copyOnWrite(s.Chunks[id])
+		if err != nil {
+			return nil, err
+		}
+		s.bumpVersion(c, 0)
+		chunks[i] = c.Snapshot()
+	}
+	return chunks, nil
+}
+
+/*
+writeSlot
+Performs metadata mutations to write the slot of the Cell located at (row, col) in tab,
+creating the Cell with a slot of newCellSize if not existed. Caller should hold s.mu.
+
+@return
+	*Cell, *Chunk: the Cell and its Chunk to be written.
+	error:
+		*errors.NoDataNodeError if there is no DataNode registered.
+		errors raised while copying a shared Chunk or by the Store.
+*/
+func (s *SheetFile) writeSlot(tab, row, col uint32, newCellSize uint64, tx Store) (*Cell, *Chunk, error) {
+	cell := s.Cells[GetCellID(tab, row, col)]
+	// Lookup an existing Cell by CellID first
+	if cell != nil {
+		// For existing Cell, just increase the version of its slot
+		dataChunk, err := s.copyOnWrite(s.Chunks[cell.ChunkID])
+		if err != nil {
+			return nil, nil, err
+		}
+		s.bumpVersion(dataChunk, cell.Offset)
+		return cell, dataChunk, nil
+	}
+	dataChunk, offset, err := s.allocSlot(newCellSize, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	cell = s.addCell(dataChunk, offset, tab, row, col, newCellSize)
+	return cell, dataChunk, nil
+}
+
+/*
+allocSlot
+Find a slot of given size for a new Cell. Caller should hold s.mu.
+
+@return
+	*Chunk, uint64: the Chunk and offset of the slot.
+	error:
+		*errors.NoDataNodeError if there is no DataNode registered.
+		errors raised while copying a shared Chunk or by the Store.
+*/
+func (s *SheetFile) allocSlot(size uint64, tx Store) (*Chunk, uint64, error) {
+	// Generally, the size of a new Cell is one of config.CellSizeClasses. However, for
+	// the MetaCell defined by (config.SheetMetaCellRow,config.SheetMetaCellCol),
+	// a whole chunk should be granted to store metadata of a sheet.
+	// Tries to reuse a slot freed by a deleted Cell first.
+	if size != config.BytesPerChunk {
+		if slot, ok := s.popFreeSlot(size); ok {
+			dataChunk, err := s.copyOnWrite(s.Chunks[slot.ChunkID])
+			if err != nil {
+				s.FreeSlots = append(s.FreeSlots, slot)
+				return nil, 0, err
+			}
+			return dataChunk, slot.Offset, nil
+		}
+	}
+	// Then tries to add it to the LastAvailableChunk of size
+	if last := s.LastAvailableChunks[size]; last != nil && s.isAvailable(last, size) {
+		// There is a empty slot for the new Cell.
+		// copyOnWrite replaces the LastAvailableChunk if it's shared.
+		dataChunk, err := s.copyOnWrite(last)
+		if err != nil {
+			return nil, 0, err
+		}
+		return dataChunk, s.getCellOffset(dataChunk, size), nil
+	}
+	// The LastAvailableChunk has been fulfilled, allocate a new Chunk, and
+	// makes it the LastAvailableChunk of size.
+	datanode, err := s.alloc.AllocateNode()
+	if err != nil {
+		// If there is no DataNode, *errors.NoDataNodeError will be returned.
+		return nil, 0, err
+	}
+	newChunk := &Chunk{
+		DataNode: datanode,
+		Version:  0,
+		Cells:    []*Cell{},
+	}
+	// newChunk.ID is allocated by the Store. So it's required to persist
+	// newChunk here to get newChunk.ID.
+	err = newChunk.Persistent(tx)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Add the newChunk to Chunks collection of s.
+	s.Chunks[newChunk.ID] = newChunk
+	s.LastAvailableChunks[size] = newChunk
+	return newChunk, 0, nil
+}
+
+/*
+moveCell
+Move cell to a new slot of given size. The new slot is allocated like one for a new Cell,
+and the old slot is freed as removeCell does, but cell is kept in s.Cells. Overflow Chunks
+of cell are dropped. Caller should hold s.mu.
+
+@return
+	*CellMove: the old slot of cell.
+	*Chunk: the Chunk of the new slot to be written.
+	error:
+		*errors.NoDataNodeError if there is no DataNode registered.
+		errors raised while copying a shared Chunk or by the Store.
+*/
+func (s *SheetFile) moveCell(cell *Cell, size uint64, tx Store) (*CellMove, *Chunk, error) {
+	oldChunk := s.Chunks[cell.ChunkID]
+	if len(oldChunk.Cells) > 1 {
+		// The old slot will be overwritten.
+		var err error
+		oldChunk, err = s.copyOnWrite(oldChunk)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	dataChunk, offset, err := s.allocSlot(size, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	moved := &CellMove{Cell: cell.Snapshot()}
+	moved.Dropped = s.detachCell(cell)
+	if _, ok := s.Chunks[oldChunk.ID]; ok {
+		s.bumpVersion(oldChunk, moved.Cell.Offset)
+		moved.Chunk = oldChunk.Snapshot()
+	}
+	cell.ChunkID = dataChunk.ID
+	cell.Offset = offset
+	cell.Size = size
+	cell.Overflow = nil
+	dataChunk.Cells = append(dataChunk.Cells, cell)
+	s.bumpVersion(dataChunk, offset)
+	return moved, dataChunk, nil
+}
+
+/*
+removeCell
+Remove cell from s and its Chunk, it will be deleted from the Store by next Flush. Its
+slot is put into s.FreeSlots, or if it's the last Cell of the Chunk, the Chunk is dropped
+from s. Overflow Chunks of cell are always dropped. References of s to dropped Chunks
+are released.
+
+@para
+	cell: Cell to be removed, must be in s
+
+@return
+	[]*Chunk: dropped Chunks not referenced by any other SheetFile, which should be
+	deleted from DataNodes by caller.
+*/
+func (s *SheetFile) removeCell(cell *Cell) []*Chunk {
+	delete(s.Cells, cell.CellID)
+	s.markDeleted(cell, nil)
+	return s.detachCell(cell)
+}
+
+/*
+detachCell
+Remove cell from its Chunk and drop its overflow Chunks, see removeCell.
+*/
+func (s *SheetFile) detachCell(cell *Cell) []*Chunk {
+	var dropped []*Chunk
+	for _, id := range cell.Overflow {
+		if c, ok := s.Chunks[id]; ok {
+			delete(s.Chunks, id)
+			dropped = append(dropped, c)
+		}
+	}
+	if c := s.releaseSlot(cell); c != nil {
+		dropped = append(dropped, c)
+	}
+	return s.dropChunks(dropped)
+}
+
+/*
+releaseSlot
+Remove cell from its Chunk, and put its slot into s.FreeSlots. If it's the last Cell of
+the Chunk, the Chunk is removed from s instead.
+
+@return
+	*Chunk: the Chunk removed from s, which should be dropped by dropChunks, or nil.
+*/
+func (s *SheetFile) releaseSlot(cell *Cell) *Chunk {
+	c, ok := s.Chunks[cell.ChunkID]
+	if !ok {
+		return nil
+	}
+	for i, ccell := range c.Cells {
+		if ccell.CellID == cell.CellID {
+			c.Cells = append(c.Cells[:i], c.Cells[i+1:]...)
+			break
+		}
+	}
+	if len(c.Cells) > 0 {
+		s.FreeSlots = append(s.FreeSlots, Slot{ChunkID: c.ID, Offset: cell.Offset})
+		return nil
+	}
+	delete(s.Chunks, c.ID)
+	for size, last := range s.LastAvailableChunks {
+		if last == c {
+			delete(s.LastAvailableChunks, size)
+		}
+	}
+	return c
+}
+
+/*
+dropChunks
+Release references of s to Chunks removed from s, and those not referenced by any other
+SheetFile will be deleted from the Store by next Flush.
+
+@return
+	[]*Chunk: Chunks not referenced any more, which should be deleted from DataNodes by
+	caller.
+*/
+func (s *SheetFile) dropChunks(dropped []*Chunk) []*Chunk {
+	if s.refs != nil {
+		dropped = s.refs.ReleaseChunks(dropped)
+	}
+	for _, c := range dropped {
+		s.markDeleted(nil, c)
+	}
+	return dropped
+}
+
+/*
+DeleteCell
+Performs necessary metadata mutations to handle an operation of deleting a Cell.
+The Cell is removed from s.Cells, and its slot will be reused by a new
+Cell later. Data of the slot is not touched on DataNodes, so the version of the slot
+is increased for caller to overwrite it. If the Chunk is shared, it's copied
+first, see lockWrite.
+
+If the deleted Cell is the last one in its Chunk, the Chunk is dropped and nothing
+has to be overwritten. Overflow Chunks of the Cell are always dropped.
+
+@para
+	tab, row, col: tab number, row number, column number of Cell to delete. The MetaCell
+	can't be deleted, see DeleteTab.
+	tx: a Store, can be a transaction
+
+@return
+	*Cell, *Chunk: snapshots of the deleted Cell and its Chunk. Chunk is nil if it has
+	been dropped.
+	[]*Chunk: dropped Chunks to be deleted from DataNodes, see removeCell.
+	error:
+		*errors.CellNotFoundError if row, col passed in is invalid.
+		errors raised while copying a shared Chunk.
+*/
+func (s *SheetFile) DeleteCell(tab, row, col uint32, tx Store) (*Cell, *Chunk, []*Chunk, error) {
+	err := s.lockWrite(func() []*Chunk {
+		if !isValidCell(tab, row, col) {
+			return nil
+		}
+		cell := s.Cells[GetCellID(tab, row, col)]
+		if cell == nil || cell.IsMeta() {
+			return nil
+		}
+		// The slot is overwritten unless the Chunk is dropped along with cell.
+		if c := s.Chunks[cell.ChunkID]; len(c.Cells) > 1 {
+			return s.sharedChunks([]*Chunk{c})
+		}
+		return nil
+	}, tx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer s.unlockWrite(tx)
+	if !isValidCell(tab, row, col) {
+		return nil, nil, nil, file_errors.NewCellNotFoundError(row, col)
+	}
+	cell := s.Cells[GetCellID(tab, row, col)]
+	if cell == nil || cell.IsMeta() {
+		return nil, nil, nil, file_errors.NewCellNotFoundError(row, col)
+	}
+	dataChunk := s.Chunks[cell.ChunkID]
+	if len(dataChunk.Cells) > 1 {
+		dataChunk, err = s.copyOnWrite(dataChunk)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	dropped := s.removeCell(cell)
+	if _, ok := s.Chunks[dataChunk.ID]; !ok {
+		return cell.Snapshot(), nil, dropped, nil
+	}
+	dataChunk.bumpSlot(cell.Offset)
+	s.markChunk(dataChunk)
+	return cell.Snapshot(), dataChunk.Snapshot(), dropped, nil
+}
+
+/*
+RemoveCell
+Replay a deletion of Cell, which has been performed by DeleteCell on the primary node.
+Do nothing if there is no such Cell.
+This method should only be used to replay journal entries.
+
+@para
+	cellID: CellID of the deleted Cell
+*/
+func (s *SheetFile) RemoveCell(cellID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cell, ok := s.Cells[cellID]; ok {
+		s.removeCell(cell)
+	}
+}
+
+/*
+markCell
+Mark cell as mutated since last Flush. Caller should hold s.mu.
+*/
+func (s *SheetFile) markCell(cell *Cell) {
+	if s.dirtyCells == nil {
+		s.dirtyCells = map[*Cell]uint64{}
+	}
+	s.dirtyCells[cell] = s.flushes
+}
+
+/*
+markChunk
+Mark c as mutated since last Flush. Caller should hold s.mu.
+*/
+func (s *SheetFile) markChunk(c *Chunk) {
+	if s.dirtyChunks == nil {
+		s.dirtyChunks = map[*Chunk]uint64{}
+	}
+	s.dirtyChunks[c] = s.flushes
+}
+
+/*
+markDeleted
+Mark cell and c removed from s since last Flush, either can be nil. A Cell which has
+never been saved has nothing to delete. Caller should hold s.mu.
+*/
+func (s *SheetFile) markDeleted(cell *Cell, c *Chunk) {
+	if cell != nil && cell.ID != 0 {
+		if s.deletedCells == nil {
+			s.deletedCells = map[*Cell]uint64{}
+		}
+		s.deletedCells[cell] = s.flushes
+	}
+	if c != nil {
+		if s.deletedChunks == nil {
+			s.deletedChunks = map[*Chunk]uint64{}
+		}
+		s.deletedChunks[c] = s.flushes
+	}
+}
+
+/*
+MarkCellDirty
+Mark cell of s as mutated, so it will be flushed by next checkpoint.
+This method should only be used to replay journal entries, which mutate Cells directly.
+*/
+func (s *SheetFile) MarkCellDirty(cell *Cell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markCell(cell)
+}
+
+/*
+MarkChunkDirty
+Same as MarkCellDirty, but for a Chunk of s.
+*/
+func (s *SheetFile) MarkChunkDirty(c *Chunk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markChunk(c)
+}
+
+/*
+Persistent
+Flush dirty Cells and Chunks of a SheetFile to tx, and mark them clean. See Flush if tx
+is a transaction which may be rolled back afterwards.
+
+@para
+	tx: a Store. It's supposed to be a transaction.
+
+@return
+	error: errors while flushing.
+*/
+func (s *SheetFile) Persistent(tx Store) error {
+	flush, err := s.Flush(tx)
+	if err != nil {
+		return err
+	}
+	s.MarkFlushed(flush)
+	return nil
+}
+
+/*
+Flush
+Delete Cells and Chunks removed from s, and flush those mutated since they were flushed
+last time to tx. They are kept dirty until MarkFlushed is called with the returned number,
+so they will be flushed again if tx is rolled back.
+
+@para
+	tx: a Store. It's supposed to be a transaction.
+
+@return
+	uint64: number of this Flush, which should be passed to MarkFlushed once tx is committed.
+	error: errors while flushing.
+*/
+func (s *SheetFile) Flush(tx Store) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	flush := s.flushes
+	s.flushes++
+	for cell := range s.deletedCells {
+		err := cell.Delete(tx)
+		if err != nil {
+			return flush, err
+		}
+	}
+	ids := make([]uint64, 0, len(s.deletedChunks))
+	for c := range s.deletedChunks {
+		ids = append(ids, c.ID)
+	}
+	err := tx.DeleteChunks(ids)
+	if err != nil {
+		return flush, err
+	}
+	if s.replacing {
+		cells := make([]*Cell, 0, len(s.Cells))
+		for _, cell := range s.Cells {
+			cells = append(cells, cell)
+		}
+		err = tx.ReplaceCells(s.id, cells)
+		if err != nil {
+			return flush, err
+		}
+	}
+	for cell := range s.dirtyCells {
+		if s.replacing || s.Cells[cell.CellID] != cell {
+			continue
+		}
+		err := cell.Persistent(tx)
+		if err != nil {
+			return flush, err
+		}
+	}
+	for c := range s.dirtyChunks {
+		if s.Chunks[c.ID] != c {
+			continue
+		}
+		err := c.Persistent(tx)
+		if err != nil {
+			return flush, err
+		}
+	}
+	return flush, nil
+}
+
+/*
+MarkFlushed
+Mark Cells and Chunks flushed by Flush numbered flush clean, unless they have been mutated
+again after that Flush.
+*/
+func (s *SheetFile) MarkFlushed(flush uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replacing = false
+	for cell, n := range s.deletedCells {
+		if n <= flush {
+			delete(s.deletedCells, cell)
+		}
+	}
+	for c, n := range s.deletedChunks {
+		if n <= flush {
+			delete(s.deletedChunks, c)
+		}
+	}
+	for cell, n := range s.dirtyCells {
+		if n <= flush {
+			delete(s.dirtyCells, cell)
+		}
+	}
+	for c, n := range s.dirtyChunks {
+		if n <= flush {
+			delete(s.dirtyChunks, c)
+		}
+	}
+}
+
+/*
+IsDirty
+Returns true if any Cell or Chunk of s has been mutated or removed since it was flushed
+last time. A dirty SheetFile must be kept in memory until next checkpoint flushes it.
+*/
+func (s *SheetFile) IsDirty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.dirtyCells) > 0 || len(s.dirtyChunks) > 0 ||
+		len(s.deletedCells) > 0 || len(s.deletedChunks) > 0 || s.replacing
+}
+
+/*
+DroppedChunks
+Returns Chunks dropped from s which have not been deleted from the Store, which should be
+deleted along with s if s is deleted before next Flush.
+*/
+func (s *SheetFile) DroppedChunks() []*Chunk {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	chunks := make([]*Chunk, 0, len(s.deletedChunks))
+	for c := range s.deletedChunks {
+		chunks = append(chunks, c)
+	}
+	return chunks
+}
+
+/*
+persistentStructure
+Prepares the storage of Cells of a SheetFile, see Store.CreateCells.
+This method should only be called once when a SheetFile is created.
+
+@para
+	db: a Store. It can be a transaction.
+
+@return
+	error: errors during preparing the storage.
+*/
+func (s *SheetFile) persistentStructure(db Store) error {
+	err := db.CreateCells(s.id)
+	if err != nil {
+		return err
 	}
 	return nil
 }
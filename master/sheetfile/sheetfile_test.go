@@ -2,20 +2,23 @@ package sheetfile
 
 import (
 	ctx "context"
+	"fmt"
 	"github.com/fourstring/sheetfs/master/config"
 	"github.com/fourstring/sheetfs/master/datanode_alloc"
 	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
 	"github.com/fourstring/sheetfs/tests"
 	. "github.com/smartystreets/goconvey/convey"
+	bolt "go.etcd.io/bbolt"
+	"math"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
-	"text/template"
 )
 
 func TestSheetFile_DynamicPersistent(t *testing.T) {
 	Convey("Create test database", t, func() {
-		db, err := tests.GetTestDB(&Chunk{})
+		db, gdb, err := getTestStore()
 		So(err, ShouldBeNil)
 		Convey("Create test chunks", func() {
 			chunk0 := &Chunk{
@@ -26,69 +29,134 @@ func TestSheetFile_DynamicPersistent(t *testing.T) {
 				DataNode: "1",
 				Version:  0,
 			}
-			db.Create(chunk0)
-			db.Create(chunk1)
+			gdb.Create(chunk0)
+			gdb.Create(chunk1)
 			Convey("Test dynamic table name", func() {
 				sheet0 := &SheetFile{
 					Chunks: map[uint64]*Chunk{chunk0.ID: chunk0},
 					Cells: map[int64]*Cell{
-						GetCellID(0, 0): {
-							CellID:    GetCellID(0, 0),
-							Offset:    0,
-							Size:      0,
-							ChunkID:   chunk0.ID,
-							SheetName: "sheet0",
+						GetCellID(0, 0, 0): {
+							CellID:  GetCellID(0, 0, 0),
+							Offset:  0,
+							Size:    0,
+							ChunkID: chunk0.ID,
+							SheetID: 1,
 						},
-						GetCellID(0, 1): {
-							CellID:    GetCellID(0, 1),
-							Offset:    config.MaxBytesPerCell,
-							Size:      0,
-							ChunkID:   chunk0.ID,
-							SheetName: "sheet0",
+						GetCellID(0, 0, 1): {
+							CellID:  GetCellID(0, 0, 1),
+							Offset:  config.DefaultBytesPerCell,
+							Size:    0,
+							ChunkID: chunk0.ID,
+							SheetID: 1,
 						},
 					},
-					filename: "sheet0",
+					id: 1,
 				}
 				sheet1 := &SheetFile{
 					Chunks: map[uint64]*Chunk{chunk1.ID: chunk1},
 					Cells: map[int64]*Cell{
-						GetCellID(0, 0): {
-							CellID:    GetCellID(0, 0),
-							Offset:    0,
-							Size:      0,
-							ChunkID:   chunk1.ID,
-							SheetName: "sheet1",
+						GetCellID(0, 0, 0): {
+							CellID:  GetCellID(0, 0, 0),
+							Offset:  0,
+							Size:    0,
+							ChunkID: chunk1.ID,
+							SheetID: 2,
 						},
-						GetCellID(0, 1): {
-							CellID:    GetCellID(0, 1),
-							Offset:    config.MaxBytesPerCell,
-							Size:      0,
-							ChunkID:   chunk1.ID,
-							SheetName: "sheet1",
+						GetCellID(0, 0, 1): {
+							CellID:  GetCellID(0, 0, 1),
+							Offset:  config.DefaultBytesPerCell,
+							Size:    0,
+							ChunkID: chunk1.ID,
+							SheetID: 2,
 						},
 					},
-					filename: "sheet1",
+					id: 2,
 				}
 				err = sheet0.persistentStructure(db)
 				So(err, ShouldBeNil)
 				err = sheet1.persistentStructure(db)
 				So(err, ShouldBeNil)
+				// Cells are added without mutations, mark them dirty manually.
+				for _, sheet := range []*SheetFile{sheet0, sheet1} {
+					for _, cell := range sheet.Cells {
+						sheet.markCell(cell)
+					}
+				}
 				err = sheet0.Persistent(db)
 				So(err, ShouldBeNil)
 				err = sheet1.Persistent(db)
 				So(err, ShouldBeNil)
-				sheet0Cells := GetSheetCellsAll(db, "sheet0")
+				sheet0Cells := GetSheetCellsAll(db, 1)
 				So(len(sheet0Cells), ShouldEqual, 2)
-				sheet1Cells := GetSheetCellsAll(db, "sheet1")
+				sheet1Cells := GetSheetCellsAll(db, 2)
 				So(len(sheet1Cells), ShouldEqual, 2)
 			})
 		})
 	})
 }
 
+func TestSheetFile_Flush(t *testing.T) {
+	Convey("Create and persist test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
+		So(err, ShouldBeNil)
+		for i := uint32(0); i < 10; i++ {
+			_, _, _, _, err := file.WriteCellChunk(0, i, i, 0, db)
+			So(err, ShouldBeNil)
+		}
+		So(file.Persistent(db), ShouldBeNil)
+		So(len(file.dirtyCells), ShouldEqual, 0)
+		So(len(file.dirtyChunks), ShouldEqual, 0)
+		Convey("Flush mutated Cells and Chunks only", func() {
+			cell, chunk, _, _, err := file.WriteCellChunk(0, 1, 1, 10, db)
+			So(err, ShouldBeNil)
+			So(len(file.dirtyCells), ShouldEqual, 1)
+			So(len(file.dirtyChunks), ShouldEqual, 1)
+			flush, err := file.Flush(db)
+			So(err, ShouldBeNil)
+			// Mutated after Flush, it's kept dirty.
+			_, _, _, _, err = file.WriteCellChunk(0, 2, 2, 10, db)
+			So(err, ShouldBeNil)
+			file.MarkFlushed(flush)
+			So(len(file.dirtyCells), ShouldEqual, 1)
+			So(file.dirtyCells[file.Cells[GetCellID(0, 2, 2)]], ShouldEqual, flush+1)
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			So(loaded.Cells[cell.CellID].Length, ShouldEqual, 10)
+			So(loaded.Chunks[chunk.ID].Version, ShouldEqual, chunk.Version)
+			So(loaded.Cells[GetCellID(0, 2, 2)].Length, ShouldEqual, 0)
+		})
+		Convey("Flush shifted Cells", func() {
+			_, err := file.InsertLines(0, RowAxis, 5, 2)
+			So(err, ShouldBeNil)
+			So(len(file.dirtyCells), ShouldEqual, 5)
+			So(len(file.dirtyChunks), ShouldEqual, 0)
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			So(loaded.Cells[GetCellID(0, 7, 5)], ShouldNotBeNil)
+			So(loaded.Cells[GetCellID(0, 5, 5)], ShouldBeNil)
+		})
+		Convey("Skip deleted Cells", func() {
+			_, _, _, _, err := file.WriteCellChunk(0, 3, 3, 10, db)
+			So(err, ShouldBeNil)
+			_, _, _, err = file.DeleteCell(0, 3, 3, db)
+			So(err, ShouldBeNil)
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			So(len(loaded.Cells), ShouldEqual, 10)
+			So(loaded.Cells[GetCellID(0, 3, 3)], ShouldBeNil)
+		})
+	})
+}
+
 func TestSheetFile_addCellToLastAvailable(t *testing.T) {
 	Convey("Construct test chunk and file", t, func() {
-		db, err := tests.GetTestDB(&Chunk{})
+		db, _, err := getTestStore()
 		So(err, ShouldBeNil)
 		chunk := &Chunk{DataNode: "1", Version: 0, Cells: []*Cell{}}
 		chunk.Persistent(db)
@@ -96,46 +164,38 @@ func TestSheetFile_addCellToLastAvailable(t *testing.T) {
 			Chunks: map[uint64]*Chunk{
 				chunk.ID: chunk,
 			},
-			Cells:              map[int64]*Cell{},
-			LastAvailableChunk: chunk,
+			Cells:               map[int64]*Cell{},
+			LastAvailableChunks: map[uint64]*Chunk{config.DefaultBytesPerCell: chunk},
 		}
 		Convey("Add cells to chunk", func() {
-			sheet.addCellToLastAvailable(0, 0, config.MaxBytesPerCell)
-			sheet.addCellToLastAvailable(1, 1, config.MaxBytesPerCell)
-			sheet.addCellToLastAvailable(2, 2, config.MaxBytesPerCell)
-			So(sheet.LastAvailableChunk.isAvailable(config.MaxBytesPerCell), ShouldEqual, true)
-			sheet.addCellToLastAvailable(3, 3, config.MaxBytesPerCell)
-			So(sheet.LastAvailableChunk.isAvailable(config.MaxBytesPerCell), ShouldEqual, false)
-			So(sheet.LastAvailableChunk.Version, ShouldEqual, 4)
+			sheet.addCellToLastAvailable(0, 0, 0, config.DefaultBytesPerCell)
+			sheet.addCellToLastAvailable(0, 1, 1, config.DefaultBytesPerCell)
+			sheet.addCellToLastAvailable(0, 2, 2, config.DefaultBytesPerCell)
+			So(sheet.LastAvailableChunks[config.DefaultBytesPerCell].isAvailable(config.DefaultBytesPerCell), ShouldEqual, true)
+			sheet.addCellToLastAvailable(0, 3, 3, config.DefaultBytesPerCell)
+			So(sheet.LastAvailableChunks[config.DefaultBytesPerCell].isAvailable(config.DefaultBytesPerCell), ShouldEqual, false)
+			So(sheet.LastAvailableChunks[config.DefaultBytesPerCell].Version, ShouldEqual, 4)
 		})
 	})
 }
 
 func TestCreateSheetFile(t *testing.T) {
 	Convey("Get test db", t, func() {
-		db, err := tests.GetTestDB(&Chunk{})
+		db, _, err := getTestStore()
 		So(err, ShouldBeNil)
 		alloc := datanode_alloc.NewDataNodeAllocator()
 		Convey("Create sheetfile when no datanode registered", func() {
-			_, _, _, err := CreateSheetFile(db, alloc, "errfile")
+			_, _, _, err := CreateSheetFile(db, alloc, nil, 1)
 			So(err, ShouldBeError, &datanode_alloc.NoDataNodeError{})
 		})
 		Convey("Add a datanode", func() {
 			alloc.AddDataNode("node1")
-			backup_create_tmpl := create_tmpl
-			Convey("Create SheetFile using ill-formed SQL", func() {
-				create_tmpl, err = template.New("ill-formed SQL").Parse("ill-formed SQL {{ .Name}}")
-				So(err, ShouldBeNil)
-				_, _, _, err = CreateSheetFile(db, alloc, "ill-file")
-				So(err, ShouldBeError)
-			})
-			create_tmpl = backup_create_tmpl
 			Convey("Create sheetfile and verify invariants", func() {
-				file, _, _, err := CreateSheetFile(db, alloc, "sheet0")
+				file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
 				So(err, ShouldBeNil)
 				So(len(file.Cells), ShouldEqual, 1)
 				So(len(file.Chunks), ShouldEqual, 1)
-				So(file.LastAvailableChunk, ShouldBeNil)
+				So(file.LastAvailableChunks[config.DefaultBytesPerCell], ShouldBeNil)
 				metaCell := file.Cells[config.SheetMetaCellID]
 				// metaChunk is the first Chunk in testing DB, so its ID is 1
 				metaChunk := file.Chunks[1]
@@ -145,7 +205,7 @@ func TestCreateSheetFile(t *testing.T) {
 				So(metaCell.Offset, ShouldEqual, 0)
 				// check metaChunk
 				So(metaChunk.Version, ShouldEqual, 0)
-				So(metaChunk.isAvailable(config.MaxBytesPerCell), ShouldEqual, false)
+				So(metaChunk.isAvailable(config.DefaultBytesPerCell), ShouldEqual, false)
 				// check relationship between metaCell and metaChunk
 				So(metaCell.ChunkID, ShouldEqual, metaChunk.ID)
 				So(len(metaChunk.Cells), ShouldEqual, 1)
@@ -157,20 +217,20 @@ func TestCreateSheetFile(t *testing.T) {
 
 func TestSheetFile_GetCellChunk(t *testing.T) {
 	Convey("Create test file and datanode", t, func() {
-		db, err := tests.GetTestDB(&Chunk{})
+		db, _, err := getTestStore()
 		So(err, ShouldBeNil)
 		alloc := datanode_alloc.NewDataNodeAllocator()
 		alloc.AddDataNode("node1")
-		file, _, _, err := CreateSheetFile(db, alloc, "sheet0")
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
 		So(err, ShouldBeNil)
 		Convey("Get non-exist cell", func() {
-			cell, chunk, err := file.GetCellChunk(0, 0)
+			cell, chunk, _, err := file.GetCellChunk(0, 0, 0)
 			So(cell, ShouldBeNil)
 			So(chunk, ShouldBeNil)
 			So(err, ShouldBeError, file_errors.NewCellNotFoundError(0, 0))
 		})
 		Convey("Get MetaCell", func() {
-			cell, chunk, err := file.GetCellChunk(config.SheetMetaCellRow, config.SheetMetaCellCol)
+			cell, chunk, _, err := file.GetCellChunk(0, config.SheetMetaCellRow, config.SheetMetaCellCol)
 			So(err, ShouldBeNil)
 			So(cell.IsMeta(), ShouldBeTrue)
 			So(cell.Size, ShouldEqual, config.BytesPerChunk)
@@ -179,16 +239,74 @@ func TestSheetFile_GetCellChunk(t *testing.T) {
 	})
 }
 
+func TestSheetFile_GetRangeChunks(t *testing.T) {
+	Convey("Create test file and write cells", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
+		So(err, ShouldBeNil)
+		for i := uint32(0); i < 3; i++ {
+			for j := uint32(0); j < 3; j++ {
+				_, _, _, _, err := file.WriteCellChunk(0, i, j, 0, db)
+				So(err, ShouldBeNil)
+			}
+		}
+		maxSlot := config.MaxBytesPerCell
+		_, _, overflow, _, err := file.WriteCellChunk(0, 5, 5, maxSlot+config.BytesPerChunk, db)
+		So(err, ShouldBeNil)
+		So(len(overflow), ShouldEqual, 1)
+		Convey("Get a small range", func() {
+			chunks := file.GetRangeChunks(0, 0, 2, 1, 3)
+			n := 0
+			for i, c := range chunks {
+				if i > 0 {
+					So(c.ID, ShouldBeGreaterThan, chunks[i-1].ID)
+				}
+				for j, cell := range c.Cells {
+					if j > 0 {
+						So(cell.Offset, ShouldBeGreaterThan, c.Cells[j-1].Offset)
+					}
+					_, row, col := SplitCellID(cell.CellID)
+					So(row, ShouldBeLessThan, 2)
+					So(col, ShouldBeBetweenOrEqual, 1, 2)
+					n++
+				}
+			}
+			So(n, ShouldEqual, 4)
+		})
+		Convey("Get a range larger than the sheet", func() {
+			chunks := file.GetRangeChunks(0, 0, math.MaxUint32, 0, math.MaxUint32)
+			last := chunks[len(chunks)-1]
+			So(last.Overflow, ShouldBeTrue)
+			So(last.ID, ShouldEqual, overflow[0].ID)
+			n := 0
+			for _, c := range chunks {
+				for _, cell := range c.Cells {
+					So(cell.IsMeta(), ShouldBeFalse)
+					n++
+				}
+			}
+			So(n, ShouldEqual, 10)
+		})
+		Convey("Get an empty range", func() {
+			So(file.GetRangeChunks(0, 2, 2, 0, 3), ShouldBeEmpty)
+			So(file.GetRangeChunks(0, 3, 5, 0, 3), ShouldBeEmpty)
+		})
+	})
+}
+
 func TestSheetFile_WriteCellChunk_GetAllChunks(t *testing.T) {
 	Convey("Create test file and datanode", t, func() {
-		db, err := tests.GetTestDB(&Chunk{})
+		db, _, err := getTestStore()
 		So(err, ShouldBeNil)
 		alloc := datanode_alloc.NewDataNodeAllocator()
 		alloc.AddDataNode("node1")
-		file, _, _, err := CreateSheetFile(db, alloc, "sheet0")
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
 		So(err, ShouldBeNil)
 		Convey("Write to MetaCell", func() {
-			cell, chunk, err := file.WriteCellChunk(config.SheetMetaCellRow, config.SheetMetaCellCol, db)
+			cell, chunk, _, _, err := file.WriteCellChunk(0, config.SheetMetaCellRow, config.SheetMetaCellCol, 0, db)
 			So(err, ShouldBeNil)
 			So(chunk.Version, ShouldEqual, 1)
 			So(cell.IsMeta(), ShouldBeTrue)
@@ -196,41 +314,41 @@ func TestSheetFile_WriteCellChunk_GetAllChunks(t *testing.T) {
 		})
 		Convey("Write to non-exist cell", func() {
 			// First write will create a chunk due to no LastAvailable
-			cell, chunk, err := file.WriteCellChunk(0, 0, db)
+			cell, chunk, _, _, err := file.WriteCellChunk(0, 0, 0, 0, db)
 			So(err, ShouldBeNil)
 			So(*cell, shouldBeSameCell, Cell{
 				CellID:  0,
 				Offset:  0,
-				Size:    config.MaxBytesPerCell,
+				Size:    config.DefaultBytesPerCell,
 				ChunkID: chunk.ID,
 			})
-			So(file.LastAvailableChunk.ID, ShouldEqual, chunk.ID)
+			So(file.LastAvailableChunks[config.DefaultBytesPerCell].ID, ShouldEqual, chunk.ID)
 			So(chunk.Version, ShouldEqual, 1)
 			// fulfill newly allocated chunk
 			for i := uint32(1); i < 4; i++ {
-				cell, chunk, err = file.WriteCellChunk(i, i, db)
+				cell, chunk, _, _, err = file.WriteCellChunk(0, i, i, 0, db)
 				So(err, ShouldBeNil)
 				So(*cell, shouldBeSameCell, Cell{
-					CellID:  GetCellID(i, i),
-					Offset:  uint64(i) * config.MaxBytesPerCell,
-					Size:    config.MaxBytesPerCell,
+					CellID:  GetCellID(0, i, i),
+					Offset:  uint64(i) * config.DefaultBytesPerCell,
+					Size:    config.DefaultBytesPerCell,
 					ChunkID: chunk.ID,
 				})
 				So(chunk.Version, ShouldEqual, uint64(i)+1)
 			}
-			So(file.LastAvailableChunk.ID, ShouldEqual, chunk.ID)
+			So(file.LastAvailableChunks[config.DefaultBytesPerCell].ID, ShouldEqual, chunk.ID)
 			// This write should make file to allocate a new Chunk again
 			last_chunk := chunk
-			cell, chunk, err = file.WriteCellChunk(4, 4, db)
+			cell, chunk, _, _, err = file.WriteCellChunk(0, 4, 4, 0, db)
 			So(err, ShouldBeNil)
 			So(*cell, shouldBeSameCell, Cell{
-				CellID:  GetCellID(4, 4),
+				CellID:  GetCellID(0, 4, 4),
 				Offset:  0,
-				Size:    config.MaxBytesPerCell,
+				Size:    config.DefaultBytesPerCell,
 				ChunkID: chunk.ID,
 			})
-			So(file.LastAvailableChunk.ID, ShouldNotEqual, last_chunk.ID)
-			So(file.LastAvailableChunk.ID, ShouldEqual, chunk.ID)
+			So(file.LastAvailableChunks[config.DefaultBytesPerCell].ID, ShouldNotEqual, last_chunk.ID)
+			So(file.LastAvailableChunks[config.DefaultBytesPerCell].ID, ShouldEqual, chunk.ID)
 			Convey("Test GetAllChunks", func() {
 				chunks := file.GetAllChunks()
 				So(len(chunks), ShouldEqual, 3)
@@ -239,22 +357,23 @@ func TestSheetFile_WriteCellChunk_GetAllChunks(t *testing.T) {
 	})
 }
 
-// TODO: change assertions here to config.MaxCellsPerChunk-agnostic
+// TODO: change assertions here to config.DefaultCellsPerChunk-agnostic
 func TestLoadSheetFile(t *testing.T) {
 	Convey("Create and persist test file", t, func() {
-		db, err := tests.GetTestDB(&Chunk{})
+		db, _, err := getTestStore()
 		So(err, ShouldBeNil)
 		alloc := datanode_alloc.NewDataNodeAllocator()
 		alloc.AddDataNode("node1")
-		file, _, _, err := CreateSheetFile(db, alloc, "sheet0")
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
 		So(err, ShouldBeNil)
 		for i := uint32(0); i < 10; i++ {
-			_, _, err := file.WriteCellChunk(i, i, db)
+			_, _, _, _, err := file.WriteCellChunk(0, i, i, 0, db)
 			So(err, ShouldBeNil)
 		}
 		err = file.Persistent(db)
 		So(err, ShouldBeNil)
-		file = LoadSheetFile(db, alloc, "sheet0")
+		file, err = LoadSheetFile(db, alloc, nil, 1)
+		So(err, ShouldBeNil)
 		// 10 normal cell and 1 MetaCell
 		So(len(file.Cells), ShouldEqual, 11)
 		So(len(file.Chunks), ShouldEqual, 4)
@@ -264,30 +383,633 @@ func TestLoadSheetFile(t *testing.T) {
 			case 1:
 				So(len(chunk.Cells), ShouldEqual, 1)
 				So(chunk.Cells[0].IsMeta(), ShouldEqual, true)
-				So(chunk.isAvailable(config.MaxBytesPerCell), ShouldEqual, false)
+				So(chunk.isAvailable(config.DefaultBytesPerCell), ShouldEqual, false)
 				continue
 			case 4:
 				So(len(chunk.Cells), ShouldEqual, 2)
-				So(chunk.isAvailable(config.MaxBytesPerCell), ShouldEqual, true)
+				So(chunk.isAvailable(config.DefaultBytesPerCell), ShouldEqual, true)
 			default:
 				So(len(chunk.Cells), ShouldEqual, 4)
-				So(chunk.isAvailable(config.MaxBytesPerCell), ShouldEqual, false)
+				So(chunk.isAvailable(config.DefaultBytesPerCell), ShouldEqual, false)
 			}
 			for _, cell := range chunk.Cells {
 				So(cell.ChunkID, ShouldEqual, chunk.ID)
+				// Chunks share Cells with the file rather than holding copies.
+				So(file.Cells[cell.CellID], ShouldEqual, cell)
+			}
+		}
+		So(file.LastAvailableChunks[config.DefaultBytesPerCell].ID, ShouldEqual, 4)
+		Convey("Fail to load a file whose Chunk is missing", func() {
+			So(db.DeleteChunks([]uint64{3}), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeError, file_errors.NewChunkNotFoundError(3))
+			So(loaded, ShouldBeNil)
+		})
+		Convey("Load Chunks written before slots were versioned separately", func() {
+			// Such Chunks were versioned as a whole, DataNodes take Version for every slot.
+			for _, c := range file.GetAllChunks() {
+				c.Version = 5
+				c.Versions = nil
+				So(db.SaveChunk(c), ShouldBeNil)
+			}
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			So(loaded.Chunks[2].Versions, ShouldHaveLength, config.SlotsPerChunk)
+			_, chunk, _, _, err := loaded.WriteCellChunk(0, config.SheetMetaCellRow, config.SheetMetaCellCol, 0, db)
+			So(err, ShouldBeNil)
+			So(chunk.ID, ShouldEqual, 1)
+			So(chunk.SlotVersion(0), ShouldEqual, 6)
+			cell, chunk, _, _, err := loaded.WriteCellChunk(0, 1, 0, 0, db)
+			So(err, ShouldBeNil)
+			So(chunk.SlotVersion(cell.Offset), ShouldEqual, 6)
+			So(chunk.Version, ShouldEqual, 6)
+			for _, other := range chunk.Cells {
+				if other.Offset != cell.Offset {
+					So(chunk.SlotVersion(other.Offset), ShouldEqual, 5)
+				}
+			}
+		})
+	})
+}
+
+func TestSheetFile_Length(t *testing.T) {
+	Convey("Create test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
+		So(err, ShouldBeNil)
+		Convey("Record and load lengths of cells", func() {
+			cell, _, _, _, err := file.WriteCellChunk(0, 0, 0, 100, db)
+			So(err, ShouldBeNil)
+			So(cell.Length, ShouldEqual, 100)
+			cell, _, _, _, err = file.WriteCellChunk(0, 0, 0, 10, db)
+			So(err, ShouldBeNil)
+			So(cell.Length, ShouldEqual, 10)
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			cell, _, _, err = loaded.GetCellChunk(0, 0, 0)
+			So(err, ShouldBeNil)
+			So(cell.Length, ShouldEqual, 10)
+		})
+	})
+}
+
+func TestSheetFile_BoltStore(t *testing.T) {
+	Convey("Create test file in bolt", t, func() {
+		bdb, err := bolt.Open(filepath.Join(t.TempDir(), "test.db"), 0600, nil)
+		So(err, ShouldBeNil)
+		defer bdb.Close()
+		db := NewBoltStore(bdb)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		file, _, _, err := CreateSheetFile(db, alloc, NewChunkRefs(nil, nil), 1)
+		So(err, ShouldBeNil)
+		for i := uint32(0); i < 10; i++ {
+			_, _, _, _, err := file.WriteCellChunk(0, i, i, 100, db)
+			So(err, ShouldBeNil)
+		}
+		So(file.Persistent(db), ShouldBeNil)
+		Convey("Load persisted file", func() {
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			So(len(loaded.Cells), ShouldEqual, 11)
+			So(len(loaded.Chunks), ShouldEqual, len(file.Chunks))
+			for id, chunk := range file.Chunks {
+				So(loaded.Chunks[id].Version, ShouldEqual, chunk.Version)
+				So(len(loaded.Chunks[id].Cells), ShouldEqual, len(chunk.Cells))
+			}
+			for id, cell := range file.Cells {
+				So(*loaded.Cells[id], shouldBeSameCell, *cell)
+			}
+			cell, _, _, err := loaded.GetCellChunk(0, 9, 9)
+			So(err, ShouldBeNil)
+			So(cell.Length, ShouldEqual, 100)
+		})
+		Convey("Delete a cell", func() {
+			_, _, _, err := file.DeleteCell(0, 1, 1, db)
+			So(err, ShouldBeNil)
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			So(len(loaded.Cells), ShouldEqual, 10)
+			_, _, _, err = loaded.GetCellChunk(0, 1, 1)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Copy and drop the copy", func() {
+			copyFile, err := file.Copy(db, 2)
+			So(err, ShouldBeNil)
+			// The copy is flushed by checkpointing like any other mutations.
+			So(GetSheetCellsAll(db, 2), ShouldBeEmpty)
+			So(copyFile.IsDirty(), ShouldBeTrue)
+			So(copyFile.Persistent(db), ShouldBeNil)
+			So(copyFile.IsDirty(), ShouldBeFalse)
+			So(len(GetSheetCellsAll(db, 2)), ShouldEqual, 11)
+			So(DropSheetFile(db, 2, nil), ShouldBeNil)
+			So(GetSheetCellsAll(db, 2), ShouldBeEmpty)
+			So(len(GetSheetCellsAll(db, 1)), ShouldEqual, 11)
+		})
+	})
+}
+
+func TestSheetFile_Copy(t *testing.T) {
+	Convey("Create test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		var copied [][2]uint64
+		var deleted []uint64
+		var refs *ChunkRefs
+		var onCopy func()
+		refs = NewChunkRefs(func(src *Chunk, dst *Chunk) error {
+			// refs must not be locked while copying.
+			So(refs.IsShared(src.ID), ShouldBeTrue)
+			copied = append(copied, [2]uint64{src.ID, dst.ID})
+			if onCopy != nil {
+				onCopy()
+			}
+			return nil
+		}, func(chunks []*Chunk) {
+			for _, c := range chunks {
+				deleted = append(deleted, c.ID)
+			}
+		})
+		file, _, _, err := CreateSheetFile(db, alloc, refs, 1)
+		So(err, ShouldBeNil)
+		for i := uint32(0); i < 10; i++ {
+			_, _, _, _, err := file.WriteCellChunk(0, i, i, 0, db)
+			So(err, ShouldBeNil)
+		}
+		Convey("Copy test file", func() {
+			copyFile, err := file.Copy(db, 2)
+			So(err, ShouldBeNil)
+			So(len(copyFile.Cells), ShouldEqual, 11)
+			So(len(copyFile.Chunks), ShouldEqual, 4)
+			So(copyFile.LastAvailableChunks[config.DefaultBytesPerCell].ID, ShouldEqual, file.LastAvailableChunks[config.DefaultBytesPerCell].ID)
+			So(copyFile.Persistent(db), ShouldBeNil)
+			So(len(GetSheetCellsAll(db, 2)), ShouldEqual, 11)
+			for id := range file.Chunks {
+				So(refs.IsShared(id), ShouldBeTrue)
+			}
+			Convey("Write to copy", func() {
+				cell, chunk, _, _, err := copyFile.WriteCellChunk(0, 1, 1, 0, db)
+				So(err, ShouldBeNil)
+				So(chunk.CopyOf, ShouldEqual, 2)
+				So(cell.ChunkID, ShouldEqual, chunk.ID)
+				So(copied, ShouldResemble, [][2]uint64{{2, chunk.ID}})
+				_, ok := copyFile.Chunks[2]
+				So(ok, ShouldBeFalse)
+				So(refs.IsShared(2), ShouldBeFalse)
+				cell, chunk, _, _, err = file.WriteCellChunk(0, 1, 1, 0, db)
+				So(err, ShouldBeNil)
+				So(chunk.ID, ShouldEqual, 2)
+				So(len(copied), ShouldEqual, 1)
+			})
+			Convey("Release the shared chunk while copying", func() {
+				onCopy = func() {
+					refs.ReleaseChunks(file.GetAllChunks())
+				}
+				_, chunk, _, _, err := copyFile.WriteCellChunk(0, 1, 1, 0, db)
+				So(err, ShouldBeNil)
+				So(len(copied), ShouldEqual, 1)
+				// The copy is discarded, because the chunk is owned by copyFile now.
+				So(chunk.ID, ShouldEqual, 2)
+				_, ok := copyFile.Chunks[copied[0][1]]
+				So(ok, ShouldBeFalse)
+				So(refs.IsShared(2), ShouldBeFalse)
+				// Data of the copy is deleted too.
+				So(deleted, ShouldResemble, []uint64{copied[0][1]})
+				chunks, err := db.LoadChunks([]uint64{copied[0][1]})
+				So(err, ShouldBeNil)
+				So(chunks, ShouldBeEmpty)
+			})
+			Convey("Access the copy while copying", func() {
+				onCopy = func() {
+					// The copy must not be locked while copying.
+					So(len(copyFile.GetAllChunks()), ShouldEqual, 4)
+				}
+				_, chunk, _, _, err := copyFile.WriteCellChunk(0, 1, 1, 0, db)
+				So(err, ShouldBeNil)
+				So(chunk.ID, ShouldEqual, copied[0][1])
+				So(deleted, ShouldBeEmpty)
+			})
+			Convey("Copy the copy again", func() {
+				_, err := copyFile.Copy(db, 3)
+				So(err, ShouldBeNil)
+				So(refs.ReleaseChunks(file.GetAllChunks()), ShouldBeEmpty)
+				So(len(refs.ReleaseChunks(copyFile.GetAllChunks())), ShouldEqual, 0)
+				for id := range file.Chunks {
+					So(refs.IsShared(id), ShouldBeFalse)
+				}
+			})
+		})
+	})
+}
+
+func TestSheetFile_DeleteCell(t *testing.T) {
+	Convey("Create test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		refs := NewChunkRefs(nil, nil)
+		file, _, _, err := CreateSheetFile(db, alloc, refs, 1)
+		So(err, ShouldBeNil)
+		for i := uint32(0); i < 10; i++ {
+			_, _, _, _, err := file.WriteCellChunk(0, i, i, 0, db)
+			So(err, ShouldBeNil)
+		}
+		So(file.Persistent(db), ShouldBeNil)
+		Convey("Delete and reuse a slot", func() {
+			cell, chunk, dropped, err := file.DeleteCell(0, 1, 1, db)
+			So(err, ShouldBeNil)
+			So(dropped, ShouldBeEmpty)
+			So(chunk.ID, ShouldEqual, 2)
+			So(chunk.Version, ShouldEqual, 5)
+			So(cell.Offset, ShouldEqual, config.DefaultBytesPerCell)
+			So(len(file.Cells), ShouldEqual, 10)
+			So(len(file.Chunks[2].Cells), ShouldEqual, 3)
+			So(file.FreeSlots, ShouldResemble, []Slot{{ChunkID: 2, Offset: config.DefaultBytesPerCell}})
+			// The Cell is deleted from the Store by next checkpoint.
+			So(len(GetSheetCellsAll(db, 1)), ShouldEqual, 11)
+			So(file.IsDirty(), ShouldBeTrue)
+			So(file.Persistent(db), ShouldBeNil)
+			So(len(GetSheetCellsAll(db, 1)), ShouldEqual, 10)
+			cell, chunk, _, _, err = file.WriteCellChunk(0, 20, 20, 0, db)
+			So(err, ShouldBeNil)
+			So(chunk.ID, ShouldEqual, 2)
+			So(cell.Offset, ShouldEqual, config.DefaultBytesPerCell)
+			So(file.FreeSlots, ShouldBeEmpty)
+			So(len(file.Chunks), ShouldEqual, 4)
+		})
+		Convey("Delete all cells of a chunk", func() {
+			_, chunk, dropped, err := file.DeleteCell(0, 8, 8, db)
+			So(err, ShouldBeNil)
+			So(chunk.ID, ShouldEqual, 4)
+			So(dropped, ShouldBeEmpty)
+			_, chunk, dropped, err = file.DeleteCell(0, 9, 9, db)
+			So(err, ShouldBeNil)
+			So(chunk, ShouldBeNil)
+			So(len(dropped), ShouldEqual, 1)
+			So(dropped[0].ID, ShouldEqual, 4)
+			So(len(file.Chunks), ShouldEqual, 3)
+			So(file.LastAvailableChunks[config.DefaultBytesPerCell], ShouldBeNil)
+			_, _, _, _, err = file.WriteCellChunk(0, 20, 20, 0, db)
+			So(err, ShouldBeNil)
+			So(len(file.Chunks), ShouldEqual, 4)
+		})
+		Convey("Delete invalid cells", func() {
+			_, _, _, err := file.DeleteCell(0, 100, 100, db)
+			So(err, ShouldBeError, file_errors.NewCellNotFoundError(100, 100))
+			_, _, _, err = file.DeleteCell(0, config.SheetMetaCellRow, config.SheetMetaCellCol, db)
+			So(err, ShouldBeError, file_errors.NewCellNotFoundError(config.SheetMetaCellRow, config.SheetMetaCellCol))
+		})
+		Convey("Rebuild free slots when loading", func() {
+			_, _, _, err := file.DeleteCell(0, 5, 5, db)
+			So(err, ShouldBeNil)
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, refs, 1)
+			So(err, ShouldBeNil)
+			So(len(loaded.Cells), ShouldEqual, 10)
+			So(loaded.FreeSlots, ShouldContain, Slot{ChunkID: 3, Offset: config.DefaultBytesPerCell})
+			cell, _, _, _, err := loaded.WriteCellChunk(0, 20, 20, 0, db)
+			So(err, ShouldBeNil)
+			So(cell.ChunkID, ShouldEqual, 3)
+			So(cell.Offset, ShouldEqual, config.DefaultBytesPerCell)
+		})
+		Convey("Delete from a copy", func() {
+			copyFile, err := file.Copy(db, 2)
+			So(err, ShouldBeNil)
+			// The slot is overwritten after deletion, so the shared Chunk is copied.
+			_, chunk, _, err := copyFile.DeleteCell(0, 8, 8, db)
+			So(err, ShouldBeNil)
+			So(chunk.CopyOf, ShouldEqual, 4)
+			So(refs.IsShared(4), ShouldBeFalse)
+			_, _, dropped, err := copyFile.DeleteCell(0, 9, 9, db)
+			So(err, ShouldBeNil)
+			So(len(dropped), ShouldEqual, 1)
+			So(dropped[0].ID, ShouldEqual, chunk.ID)
+			So(len(file.Chunks[4].Cells), ShouldEqual, 2)
+			_, _, _, err = copyFile.DeleteCell(0, 7, 7, db)
+			So(err, ShouldBeNil)
+			So(refs.IsShared(3), ShouldBeFalse)
+		})
+	})
+}
+
+func TestSheetFile_Overflow(t *testing.T) {
+	Convey("Create test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		refs := NewChunkRefs(nil, nil)
+		file, _, _, err := CreateSheetFile(db, alloc, refs, 1)
+		So(err, ShouldBeNil)
+		maxSlot := config.MaxBytesPerCell
+		size := maxSlot + config.BytesPerChunk + 1
+		cell, chunk, overflow, _, err := file.WriteCellChunk(0, 0, 0, size, db)
+		So(err, ShouldBeNil)
+		So(cell.Size, ShouldEqual, maxSlot)
+		So(chunk.Version, ShouldEqual, 1)
+		So(len(overflow), ShouldEqual, 2)
+		So(cell.Overflow, ShouldResemble, ChunkIDs{overflow[0].ID, overflow[1].ID})
+		for _, c := range overflow {
+			So(c.Version, ShouldEqual, 1)
+			So(c.Overflow, ShouldBeTrue)
+		}
+		Convey("Write a large cell again", func() {
+			_, _, overflow, _, err := file.WriteCellChunk(0, 0, 0, 0, db)
+			So(err, ShouldBeNil)
+			So(len(overflow), ShouldEqual, 2)
+			for _, c := range overflow {
+				So(c.Version, ShouldEqual, 2)
+			}
+			_, _, overflow, _, err = file.WriteCellChunk(0, 0, 0, size+config.BytesPerChunk, db)
+			So(err, ShouldBeNil)
+			So(len(overflow), ShouldEqual, 3)
+			So(overflow[2].Version, ShouldEqual, 1)
+		})
+		Convey("Overflow chunks are not used by other cells", func() {
+			for i := uint32(1); i < 10; i++ {
+				cell, _, _, _, err := file.WriteCellChunk(0, i, i, 0, db)
+				So(err, ShouldBeNil)
+				So(cell.ChunkID, ShouldNotBeIn, overflow[0].ID, overflow[1].ID)
+			}
+		})
+		Convey("Load overflow chunks", func() {
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, refs, 1)
+			So(err, ShouldBeNil)
+			_, _, loadedOverflow, err := loaded.GetCellChunk(0, 0, 0)
+			So(err, ShouldBeNil)
+			So(len(loadedOverflow), ShouldEqual, 2)
+			for i, c := range loadedOverflow {
+				So(c.ID, ShouldEqual, overflow[i].ID)
+				So(c.Version, ShouldEqual, 1)
+				So(c.Overflow, ShouldBeTrue)
 			}
+			So(loaded.LastAvailableChunks[maxSlot].ID, ShouldEqual, chunk.ID)
+		})
+		Convey("Delete a large cell", func() {
+			_, _, dropped, err := file.DeleteCell(0, 0, 0, db)
+			So(err, ShouldBeNil)
+			So(len(dropped), ShouldEqual, 3)
+			So(len(file.Chunks), ShouldEqual, 1)
+		})
+		Convey("Write a too large cell", func() {
+			size := maxSlot + (config.MaxOverflowChunks+1)*config.BytesPerChunk
+			_, _, _, _, err := file.WriteCellChunk(0, 1, 1, size, db)
+			So(err, ShouldBeError, file_errors.NewCellTooLargeError(1, 1, size))
+			So(len(file.Chunks), ShouldEqual, 4)
+		})
+	})
+}
+
+func TestSheetFile_SizeClasses(t *testing.T) {
+	Convey("Create test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		file, _, _, err := CreateSheetFile(db, alloc, NewChunkRefs(nil, nil), 1)
+		So(err, ShouldBeNil)
+		small, smallChunk, _, moved, err := file.WriteCellChunk(0, 0, 0, 100, db)
+		So(err, ShouldBeNil)
+		So(moved, ShouldBeNil)
+		So(small.Size, ShouldEqual, 256)
+		So(small.Offset, ShouldEqual, 0)
+		cell, chunk, _, _, err := file.WriteCellChunk(0, 1, 1, 256, db)
+		So(err, ShouldBeNil)
+		So(chunk.ID, ShouldEqual, smallChunk.ID)
+		So(cell.Offset, ShouldEqual, 256)
+		cell, chunk, _, _, err = file.WriteCellChunk(0, 2, 2, 1000, db)
+		So(err, ShouldBeNil)
+		So(cell.Size, ShouldEqual, 1024)
+		So(chunk.ID, ShouldNotEqual, smallChunk.ID)
+		So(len(file.LastAvailableChunks), ShouldEqual, 2)
+		Convey("Move a cell to a bigger slot", func() {
+			cell, chunk, _, moved, err := file.WriteCellChunk(0, 0, 0, 3000, db)
+			So(err, ShouldBeNil)
+			So(cell.Size, ShouldEqual, 4096)
+			So(cell.Offset, ShouldEqual, 0)
+			So(moved.Cell.Size, ShouldEqual, 256)
+			So(moved.Chunk.ID, ShouldEqual, smallChunk.ID)
+			So(moved.Chunk.Version, ShouldEqual, 3)
+			So(moved.Dropped, ShouldBeEmpty)
+			So(len(file.Chunks[chunk.ID].Cells), ShouldEqual, 1)
+			So(len(file.Chunks[smallChunk.ID].Cells), ShouldEqual, 1)
+			// Shrinking cells are not moved.
+			cell, _, _, moved, err = file.WriteCellChunk(0, 0, 0, 10, db)
+			So(err, ShouldBeNil)
+			So(moved, ShouldBeNil)
+			So(cell.Size, ShouldEqual, 4096)
+			// The old slot is reused by a new Cell of the same size.
+			cell, chunk, _, _, err = file.WriteCellChunk(0, 3, 3, 1, db)
+			So(err, ShouldBeNil)
+			So(chunk.ID, ShouldEqual, smallChunk.ID)
+			So(cell.Offset, ShouldEqual, 0)
+		})
+		Convey("Move the last cell of a chunk", func() {
+			_, _, _, moved, err := file.WriteCellChunk(0, 2, 2, 2000, db)
+			So(err, ShouldBeNil)
+			So(moved.Chunk, ShouldBeNil)
+			So(len(moved.Dropped), ShouldEqual, 1)
+			So(moved.Dropped[0].ID, ShouldEqual, moved.Cell.ChunkID)
+			So(file.LastAvailableChunks, ShouldNotContainKey, uint64(1024))
+		})
+		Convey("Rebuild free slots of different sizes when loading", func() {
+			_, _, _, _, err := file.WriteCellChunk(0, 0, 0, 3000, db)
+			So(err, ShouldBeNil)
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, NewChunkRefs(nil, nil), 1)
+			So(err, ShouldBeNil)
+			So(len(loaded.FreeSlots), ShouldEqual, 31+7+1)
+			So(loaded.FreeSlots, ShouldContain, Slot{ChunkID: smallChunk.ID, Offset: 0})
+			cell, chunk, _, _, err := loaded.WriteCellChunk(0, 3, 3, 4000, db)
+			So(err, ShouldBeNil)
+			So(cell.Size, ShouldEqual, 4096)
+			So(cell.Offset, ShouldEqual, 4096)
+			So(chunk.ID, ShouldEqual, loaded.Cells[GetCellID(0, 0, 0)].ChunkID)
+		})
+	})
+}
+
+func TestSheetFile_Lines(t *testing.T) {
+	Convey("Create test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
+		So(err, ShouldBeNil)
+		for i := uint32(0); i < 5; i++ {
+			_, _, _, _, err := file.WriteCellChunk(0, i, i, 0, db)
+			So(err, ShouldBeNil)
+		}
+		So(file.Persistent(db), ShouldBeNil)
+		slot := *file.Cells[GetCellID(0, 2, 2)]
+		Convey("Insert rows and load them", func() {
+			_, err := file.InsertLines(0, RowAxis, 2, 3)
+			So(err, ShouldBeNil)
+			So(len(file.Cells), ShouldEqual, 6)
+			for _, id := range []int64{GetCellID(0, 0, 0), GetCellID(0, 1, 1), GetCellID(0, 5, 2),
+				GetCellID(0, 6, 3), GetCellID(0, 7, 4), config.SheetMetaCellID} {
+				So(file.Cells, ShouldContainKey, id)
+			}
+			cell := file.Cells[GetCellID(0, 5, 2)]
+			So(cell.ChunkID, ShouldEqual, slot.ChunkID)
+			So(cell.Offset, ShouldEqual, slot.Offset)
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			So(len(loaded.Cells), ShouldEqual, 6)
+			So(loaded.Cells, ShouldContainKey, GetCellID(0, 5, 2))
+			So(loaded.Cells, ShouldNotContainKey, GetCellID(0, 2, 2))
+		})
+		Convey("Delete rows and load them", func() {
+			_, dropped, err := file.DeleteLines(0, RowAxis, 1, 2)
+			So(err, ShouldBeNil)
+			So(dropped, ShouldBeEmpty)
+			So(len(file.Cells), ShouldEqual, 4)
+			for _, id := range []int64{GetCellID(0, 0, 0), GetCellID(0, 1, 3), GetCellID(0, 2, 4)} {
+				So(file.Cells, ShouldContainKey, id)
+			}
+			So(len(GetSheetCellsAll(db, 1)), ShouldEqual, 6)
+			// The shifted Cell is deleted by its ID before its new CellID is flushed.
+			_, _, _, err = file.DeleteCell(0, 1, 3, db)
+			So(err, ShouldBeNil)
+			So(file.Persistent(db), ShouldBeNil)
+			So(len(GetSheetCellsAll(db, 1)), ShouldEqual, 3)
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			So(len(loaded.Cells), ShouldEqual, 3)
+			So(loaded.Cells, ShouldContainKey, GetCellID(0, 2, 4))
+			So(loaded.Cells, ShouldNotContainKey, GetCellID(0, 3, 3))
+		})
+		Convey("Insert and delete columns", func() {
+			_, err := file.InsertLines(0, ColumnAxis, 0, 1)
+			So(err, ShouldBeNil)
+			So(file.Cells, ShouldContainKey, GetCellID(0, 0, 1))
+			So(file.Cells, ShouldContainKey, GetCellID(0, 4, 5))
+			So(file.Cells, ShouldNotContainKey, GetCellID(0, 0, 0))
+			_, _, err = file.DeleteLines(0, ColumnAxis, 0, 2)
+			So(err, ShouldBeNil)
+			So(len(file.Cells), ShouldEqual, 5)
+			So(file.Cells, ShouldContainKey, GetCellID(0, 1, 0))
+			So(file.Cells, ShouldContainKey, GetCellID(0, 4, 3))
+			So(file.Cells, ShouldContainKey, config.SheetMetaCellID)
+		})
+		Convey("Replay lines by their remaps", func() {
+			replayed, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			inserted, err := file.InsertLines(0, RowAxis, 2, 3)
+			So(err, ShouldBeNil)
+			deleted, _, err := file.DeleteLines(0, ColumnAxis, 0, 2)
+			So(err, ShouldBeNil)
+			// Applying remaps again has no effect.
+			for i := 0; i < 2; i++ {
+				replayed.ApplyLinesRemap(inserted)
+				replayed.ApplyLinesRemap(deleted)
+			}
+			So(len(replayed.Cells), ShouldEqual, len(file.Cells))
+			for id, cell := range file.Cells {
+				So(replayed.Cells, ShouldContainKey, id)
+				So(replayed.Cells[id].ChunkID, ShouldEqual, cell.ChunkID)
+				So(replayed.Cells[id].Offset, ShouldEqual, cell.Offset)
+			}
+		})
+		Convey("Drop chunks of deleted cells", func() {
+			_, dropped, err := file.DeleteLines(0, RowAxis, 0, 5)
+			So(err, ShouldBeNil)
+			So(len(dropped), ShouldEqual, 2)
+			So(len(file.Cells), ShouldEqual, 1)
+			So(len(file.Chunks), ShouldEqual, 1)
+		})
+		Convey("Reject invalid lines", func() {
+			_, err := file.InsertLines(0, RowAxis, 0, 0)
+			So(err, ShouldHaveSameTypeAs, &file_errors.InvalidLinesError{})
+			_, err = file.InsertLines(0, RowAxis, 3, math.MaxUint32-3)
+			So(err, ShouldHaveSameTypeAs, &file_errors.InvalidLinesError{})
+			_, _, err = file.DeleteLines(0, ColumnAxis, math.MaxUint32, 2)
+			So(err, ShouldHaveSameTypeAs, &file_errors.InvalidLinesError{})
+			So(len(file.Cells), ShouldEqual, 6)
+			So(file.Cells, ShouldContainKey, GetCellID(0, 4, 4))
+		})
+	})
+}
+
+func TestSheetFile_WriteCellsChunks(t *testing.T) {
+	Convey("Create test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocator()
+		alloc.AddDataNode("node1")
+		file, _, _, err := CreateSheetFile(db, alloc, NewChunkRefs(nil, nil), 1)
+		So(err, ShouldBeNil)
+		results, err := file.WriteCellsChunks([]CellWrite{{0, 0, 0, 0}, {0, 0, 1, 0}, {0, 0, 2, 0}}, db)
+		So(err, ShouldBeNil)
+		So(len(results), ShouldEqual, 3)
+		chunkID := results[0].Chunk.ID
+		for _, r := range results {
+			So(r.Chunk.ID, ShouldEqual, chunkID)
+			So(r.Chunk.SlotVersion(r.Cell.Offset), ShouldEqual, 1)
+			So(r.Moved, ShouldBeNil)
 		}
-		So(file.LastAvailableChunk.ID, ShouldEqual, 4)
+		So(file.Chunks[chunkID].Version, ShouldEqual, 3)
+		Convey("Increase versions of slots once per batch", func() {
+			cell, chunk, _, _, err := file.WriteCellChunk(0, 0, 0, 0, db)
+			So(err, ShouldBeNil)
+			So(chunk.SlotVersion(cell.Offset), ShouldEqual, 2)
+			results, err := file.WriteCellsChunks([]CellWrite{{0, 0, 0, 0}, {0, 0, 1, 0}, {0, 5, 5, 0}}, db)
+			So(err, ShouldBeNil)
+			for i, version := range []uint64{3, 2, 1} {
+				So(results[i].Chunk.ID, ShouldEqual, chunkID)
+				So(results[i].Chunk.SlotVersion(results[i].Cell.Offset), ShouldEqual, version)
+			}
+			So(file.Chunks[chunkID].SlotVersion(results[2].Cell.Offset+config.DefaultBytesPerCell), ShouldEqual, 0)
+			So(len(file.Chunks[chunkID].Cells), ShouldEqual, 4)
+		})
+		Convey("Move cells in a batch", func() {
+			results, err := file.WriteCellsChunks([]CellWrite{{0, 0, 1, 3000}, {0, 7, 7, 0}}, db)
+			So(err, ShouldBeNil)
+			moved := results[0].Moved
+			So(moved, ShouldNotBeNil)
+			So(moved.Chunk.ID, ShouldEqual, chunkID)
+			So(moved.Chunk.SlotVersion(moved.Cell.Offset), ShouldEqual, 2)
+			So(results[0].Chunk.ID, ShouldNotEqual, chunkID)
+			So(results[0].Chunk.SlotVersion(results[0].Cell.Offset), ShouldEqual, 1)
+			// The vacated slot is taken by a new Cell, and written only once.
+			So(results[1].Chunk.ID, ShouldEqual, chunkID)
+			So(results[1].Cell.Offset, ShouldEqual, moved.Cell.Offset)
+			So(results[1].Chunk.SlotVersion(results[1].Cell.Offset), ShouldEqual, 2)
+			// Writes of single Cells are not affected by the batch.
+			cell, chunk, _, _, err := file.WriteCellChunk(0, 7, 7, 0, db)
+			So(err, ShouldBeNil)
+			So(chunk.SlotVersion(cell.Offset), ShouldEqual, 3)
+		})
+		Convey("Reject invalid batches without changing anything", func() {
+			_, err := file.WriteCellsChunks([]CellWrite{{0, 1, 1, 0}, {0, 1, 1, 0}}, db)
+			So(err, ShouldBeError, file_errors.NewDuplicateCellError(1, 1))
+			size := config.DefaultBytesPerCell + (config.MaxOverflowChunks+1)*config.BytesPerChunk
+			_, err = file.WriteCellsChunks([]CellWrite{{0, 1, 1, 0}, {0, 2, 2, size}}, db)
+			So(err, ShouldBeError, file_errors.NewCellTooLargeError(2, 2, size))
+			So(len(file.Cells), ShouldEqual, 4)
+			So(file.Chunks[chunkID].Version, ShouldEqual, 3)
+		})
 	})
 }
 
 func TestSheetFile_Concurrency1(t *testing.T) {
 	Convey("Create test file", t, func() {
-		db, err := tests.GetTestDB(&Chunk{})
+		db, _, err := getTestStore()
 		So(err, ShouldBeNil)
 		alloc := datanode_alloc.NewDataNodeAllocator()
 		alloc.AddDataNode("node1")
-		file, _, _, err := CreateSheetFile(db, alloc, "sheet0")
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
 		Convey("Write to cells concurrently", func(c C) {
 			// record expected Version after operation
 			expectedVersions := map[uint64]*uint64{}
@@ -295,7 +1017,7 @@ func TestSheetFile_Concurrency1(t *testing.T) {
 			startRow, endRow := 0, 20
 			startCol, endCol := 0, 20
 			// +1 for MetaCell
-			maxChunks := uint64(tests.DivRoundUp((endRow-startRow)*(endCol-startCol), config.MaxCellsPerChunk)) + 1
+			maxChunks := uint64(tests.DivRoundUp((endRow-startRow)*(endCol-startCol), config.DefaultCellsPerChunk)) + 1
 			for i := uint64(1); i < maxChunks+1; i++ {
 				expectedVersions[i] = new(uint64)
 			}
@@ -304,7 +1026,7 @@ func TestSheetFile_Concurrency1(t *testing.T) {
 				for i := 0; i < 100; i++ {
 					row := uint32(tests.RandInt(startRow, endRow))
 					col := uint32(tests.RandInt(startCol, endCol))
-					_, chunk, err := file.WriteCellChunk(row, col, db)
+					_, chunk, _, _, err := file.WriteCellChunk(0, row, col, 0, db)
 					c.So(err, ShouldBeNil)
 					atomic.AddUint64(expectedVersions[chunk.ID], 1)
 				}
@@ -323,11 +1045,11 @@ func TestSheetFile_Concurrency1(t *testing.T) {
 
 func TestSheetFile_Concurrency2(t *testing.T) {
 	Convey("Create test file", t, func() {
-		db, err := tests.GetTestDB(&Chunk{})
+		db, _, err := getTestStore()
 		So(err, ShouldBeNil)
 		alloc := datanode_alloc.NewDataNodeAllocator()
 		alloc.AddDataNode("node1")
-		file, _, _, err := CreateSheetFile(db, alloc, "sheet0")
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
 		Convey("Read and write concurrently", func(c C) {
 			// record expected Version after operation
 			expectedVersions := map[uint64]*uint64{}
@@ -338,7 +1060,7 @@ func TestSheetFile_Concurrency2(t *testing.T) {
 			startRow, endRow := 0, 20
 			startCol, endCol := 0, 20
 			// +1 for MetaCell
-			maxChunks := uint64(tests.DivRoundUp((endRow-startRow)*(endCol-startCol), config.MaxCellsPerChunk)) + 1
+			maxChunks := uint64(tests.DivRoundUp((endRow-startRow)*(endCol-startCol), config.DefaultCellsPerChunk)) + 1
 
 			for i := uint64(1); i < maxChunks+1; i++ {
 				expectedVersions[i] = new(uint64)
@@ -355,7 +1077,7 @@ func TestSheetFile_Concurrency2(t *testing.T) {
 					default:
 						row := uint32(tests.RandInt(startRow, endRow))
 						col := uint32(tests.RandInt(startCol, endCol))
-						_, chunk, err := file.GetCellChunk(row, col)
+						_, chunk, _, err := file.GetCellChunk(0, row, col)
 						_, ok := err.(*file_errors.CellNotFoundError)
 						if ok {
 							continue
@@ -372,7 +1094,7 @@ func TestSheetFile_Concurrency2(t *testing.T) {
 						mu.RLock()
 						// <= same as above
 						// +1 for MetaCell
-						c.So(len(chunks), ShouldBeLessThanOrEqualTo, tests.DivRoundUp(totalCells, config.MaxCellsPerChunk)+1)
+						c.So(len(chunks), ShouldBeLessThanOrEqualTo, tests.DivRoundUp(totalCells, config.DefaultCellsPerChunk)+1)
 						mu.RUnlock()
 					}
 				}
@@ -382,7 +1104,7 @@ func TestSheetFile_Concurrency2(t *testing.T) {
 				defer wwg.Done()
 				for i := 0; i < endCol-startCol; i++ {
 					mu.Lock()
-					_, chunk, err := file.WriteCellChunk(row, uint32(i), db)
+					_, chunk, _, _, err := file.WriteCellChunk(0, row, uint32(i), 0, db)
 					c.So(err, ShouldBeNil)
 					totalCells += 1
 					*expectedVersions[chunk.ID] += 1
@@ -403,3 +1125,267 @@ func TestSheetFile_Concurrency2(t *testing.T) {
 		})
 	})
 }
+
+func TestSheetFile_Compact(t *testing.T) {
+	Convey("Create test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		// Only Chunks on the same DataNode are packed together.
+		alloc := datanode_alloc.NewDataNodeAllocatorWithGroups([]string{"node1"})
+		refs := NewChunkRefs(nil, nil)
+		file, _, _, err := CreateSheetFile(db, alloc, refs, 1)
+		So(err, ShouldBeNil)
+		for i := uint32(0); i < 12; i++ {
+			_, _, _, _, err := file.WriteCellChunk(0, i, i, 0, db)
+			So(err, ShouldBeNil)
+		}
+		// Leave 1, 2 and 1 Cells in Chunk 2, 3 and 4.
+		for _, i := range []uint32{0, 1, 2, 4, 5, 8, 9, 10} {
+			_, _, _, err := file.DeleteCell(0, i, i, db)
+			So(err, ShouldBeNil)
+		}
+		So(file.Persistent(db), ShouldBeNil)
+		type slotCopy struct {
+			src, srcOffset, srcVersion, dst, dstOffset, dstVersion uint64
+		}
+		var copies []slotCopy
+		copier := func(src *Chunk, srcOffset uint64, dst *Chunk, dstOffset uint64, size uint64) error {
+			So(size, ShouldEqual, config.DefaultBytesPerCell)
+			copies = append(copies, slotCopy{src.ID, srcOffset, src.SlotVersion(srcOffset),
+				dst.ID, dstOffset, dst.SlotVersion(dstOffset)})
+			return nil
+		}
+		Convey("Pack cells into the fullest chunk", func() {
+			moves, dropped, err := file.Compact(copier)
+			So(err, ShouldBeNil)
+			So(copies, ShouldResemble, []slotCopy{
+				{2, 3 * config.DefaultBytesPerCell, 1, 3, 0, 3},
+				{4, 3 * config.DefaultBytesPerCell, 1, 3, config.DefaultBytesPerCell, 3},
+			})
+			So(len(moves), ShouldEqual, 2)
+			So(moves[0].Cell.ChunkID, ShouldEqual, 2)
+			So(moves[0].From, ShouldBeNil)
+			So(moves[0].Moved.CellID, ShouldEqual, GetCellID(0, 3, 3))
+			So(moves[0].Moved.ChunkID, ShouldEqual, 3)
+			So(moves[0].Moved.Offset, ShouldEqual, 0)
+			So(moves[1].To.SlotVersion(config.DefaultBytesPerCell), ShouldEqual, 3)
+			So(len(moves[1].To.Cells), ShouldEqual, 4)
+			So(len(dropped), ShouldEqual, 2)
+			So(dropped[0].ID, ShouldEqual, 2)
+			So(dropped[1].ID, ShouldEqual, 4)
+			So(len(file.Chunks), ShouldEqual, 2)
+			So(len(file.Cells), ShouldEqual, 5)
+			So(file.FreeSlots, ShouldBeEmpty)
+			So(file.LastAvailableChunks[config.DefaultBytesPerCell], ShouldBeNil)
+			cell, chunk, _, err := file.GetCellChunk(0, 11, 11)
+			So(err, ShouldBeNil)
+			So(chunk.ID, ShouldEqual, 3)
+			So(cell.Offset, ShouldEqual, config.DefaultBytesPerCell)
+
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, refs, 1)
+			So(err, ShouldBeNil)
+			So(len(loaded.Chunks), ShouldEqual, 2)
+			So(loaded.Cells[GetCellID(0, 3, 3)].ChunkID, ShouldEqual, 3)
+			So(loaded.Chunks[3].SlotVersion(0), ShouldEqual, 3)
+			Convey("Do nothing if chunks are packed", func() {
+				moves, dropped, err := file.Compact(copier)
+				So(err, ShouldBeNil)
+				So(moves, ShouldBeEmpty)
+				So(dropped, ShouldBeEmpty)
+				So(len(copies), ShouldEqual, 2)
+			})
+		})
+		Convey("Stop compacting once copying fails", func() {
+			copyErr := fmt.Errorf("copy failed")
+			moves, dropped, err := file.Compact(func(src *Chunk, srcOffset uint64, dst *Chunk, dstOffset uint64, size uint64) error {
+				if src.ID == 4 {
+					return copyErr
+				}
+				return nil
+			})
+			So(err, ShouldEqual, copyErr)
+			So(len(moves), ShouldEqual, 1)
+			So(len(dropped), ShouldEqual, 1)
+			So(len(file.Chunks), ShouldEqual, 3)
+			So(file.Cells[GetCellID(0, 11, 11)].ChunkID, ShouldEqual, 4)
+			So(file.Chunks[3].SlotVersion(config.DefaultBytesPerCell), ShouldEqual, 2)
+			So(file.LastAvailableChunks[config.DefaultBytesPerCell].ID, ShouldEqual, 3)
+		})
+		Convey("Abandon moves of cells written while copying", func() {
+			plan := file.PlanCompaction()
+			So(len(plan.moves), ShouldEqual, 2)
+			So(file.PlanCompaction().moves, ShouldBeEmpty)
+			var written *Cell
+			err := plan.Copy(func(src *Chunk, srcOffset uint64, dst *Chunk, dstOffset uint64, size uint64) error {
+				if written != nil {
+					return nil
+				}
+				// The file is not locked while copying.
+				_, _, _, _, err := file.WriteCellChunk(0, 11, 11, 0, db)
+				So(err, ShouldBeNil)
+				written, _, _, _, err = file.WriteCellChunk(0, 12, 12, 0, db)
+				So(err, ShouldBeNil)
+				// Slots reserved for moves are not allocated.
+				So(written.ChunkID == 3 && written.Offset < 2*config.DefaultBytesPerCell, ShouldBeFalse)
+				return nil
+			})
+			So(err, ShouldBeNil)
+			moves, dropped := file.CommitCompaction(plan)
+			So(len(moves), ShouldEqual, 2)
+			So(moves[0].Moved.CellID, ShouldEqual, GetCellID(0, 3, 3))
+			// Data copied for the abandoned move is kept up with.
+			So(moves[1].Cell, ShouldBeNil)
+			So(moves[1].To.ID, ShouldEqual, 3)
+			So(moves[1].To.SlotVersion(config.DefaultBytesPerCell), ShouldEqual, 3)
+			So(len(dropped), ShouldEqual, 1)
+			So(dropped[0].ID, ShouldEqual, 2)
+			So(file.Cells[GetCellID(0, 11, 11)].ChunkID, ShouldEqual, 4)
+			So(file.Chunks[3].SlotVersion(config.DefaultBytesPerCell), ShouldEqual, 3)
+			So(file.reserved, ShouldBeEmpty)
+			// The abandoned slot can be allocated again.
+			cell, _, _, _, err := file.WriteCellChunk(0, 13, 13, 0, db)
+			So(err, ShouldBeNil)
+			So(cell.ChunkID, ShouldEqual, 3)
+			So(cell.Offset, ShouldEqual, config.DefaultBytesPerCell)
+		})
+		Convey("Skip shared chunks", func() {
+			_, err := file.Copy(db, 2)
+			So(err, ShouldBeNil)
+			moves, _, err := file.Compact(copier)
+			So(err, ShouldBeNil)
+			So(moves, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestSheetFile_Tabs(t *testing.T) {
+	Convey("Create test file", t, func() {
+		db, _, err := getTestStore()
+		So(err, ShouldBeNil)
+		alloc := datanode_alloc.NewDataNodeAllocatorWithGroups([]string{"node1"})
+		file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
+		So(err, ShouldBeNil)
+		So(file.Tabs(), ShouldResemble, []*Tab{{ID: 0, Name: "Sheet1", Position: 0}})
+		r, err := file.CreateTab("", db)
+		So(err, ShouldBeNil)
+		So(r.Cell.Tab(), ShouldEqual, 1)
+		So(r.Cell.TabName, ShouldEqual, "Sheet2")
+		r, err = file.CreateTab("data", db)
+		So(err, ShouldBeNil)
+		So(r.Cell.Tab(), ShouldEqual, 2)
+		for tab := uint32(0); tab < 3; tab++ {
+			for i := uint32(0); i < 3; i++ {
+				_, _, _, _, err := file.WriteCellChunk(tab, i, i, 0, db)
+				So(err, ShouldBeNil)
+			}
+		}
+		Convey("Reject invalid tabs and cells", func() {
+			_, err := file.CreateTab("data", db)
+			So(err, ShouldHaveSameTypeAs, &file_errors.TabExistsError{})
+			_, _, _, _, err = file.WriteCellChunk(3, 0, 0, 0, db)
+			So(err, ShouldHaveSameTypeAs, &file_errors.TabNotFoundError{})
+			_, _, _, _, err = file.WriteCellChunk(0, config.MaxRows, 0, 0, db)
+			So(err, ShouldHaveSameTypeAs, &file_errors.InvalidCellError{})
+			_, err = file.RenameTab(0, "")
+			So(err, ShouldHaveSameTypeAs, &file_errors.InvalidTabError{})
+			_, err = file.RenameTab(1, "data")
+			So(err, ShouldHaveSameTypeAs, &file_errors.TabExistsError{})
+			_, err = file.MoveTab(1, 3)
+			So(err, ShouldHaveSameTypeAs, &file_errors.InvalidTabError{})
+		})
+		Convey("Read and edit cells of a tab", func() {
+			chunks, err := file.GetTabChunks(1)
+			So(err, ShouldBeNil)
+			// Cells of tab 1 are in 2 Chunks shared with other tabs, besides its MetaCell.
+			So(len(chunks), ShouldEqual, 3)
+			_, err = file.InsertLines(1, RowAxis, 0, 1)
+			So(err, ShouldBeNil)
+			So(file.Cells, ShouldContainKey, GetCellID(1, 1, 0))
+			So(file.Cells, ShouldContainKey, GetCellID(0, 0, 0))
+			So(file.Cells, ShouldContainKey, GetCellID(2, 0, 0))
+			So(file.Cells, ShouldContainKey, metaCellID(1))
+			_, err = file.GetTabChunks(3)
+			So(err, ShouldHaveSameTypeAs, &file_errors.TabNotFoundError{})
+		})
+		Convey("Rename and move tabs", func() {
+			_, err := file.RenameTab(0, "first")
+			So(err, ShouldBeNil)
+			moved, err := file.MoveTab(2, 0)
+			So(err, ShouldBeNil)
+			So(len(moved), ShouldEqual, 3)
+			So(file.Tabs(), ShouldResemble, []*Tab{
+				{ID: 2, Name: "data", Position: 0},
+				{ID: 0, Name: "first", Position: 1},
+				{ID: 1, Name: "Sheet2", Position: 2},
+			})
+			So(file.Persistent(db), ShouldBeNil)
+			loaded, err := LoadSheetFile(db, alloc, nil, 1)
+			So(err, ShouldBeNil)
+			So(loaded.Tabs(), ShouldResemble, file.Tabs())
+		})
+		Convey("Delete tabs", func() {
+			removed, written, dropped, err := file.DeleteTab(1)
+			So(err, ShouldBeNil)
+			So(len(removed), ShouldEqual, 4)
+			So(len(written), ShouldEqual, 1)
+			So(written[0].Cell.Tab(), ShouldEqual, 2)
+			So(written[0].Cell.TabPosition, ShouldEqual, 1)
+			So(len(dropped), ShouldEqual, 1)
+			for id := range file.Cells {
+				So(file.Cells[id].Tab(), ShouldNotEqual, 1)
+			}
+			So(len(file.Tabs()), ShouldEqual, 2)
+			r, err := file.CreateTab("", db)
+			So(err, ShouldBeNil)
+			So(r.Cell.Tab(), ShouldEqual, 1)
+			So(r.Cell.TabPosition, ShouldEqual, 2)
+			_, _, _, err = file.DeleteTab(1)
+			So(err, ShouldBeNil)
+			_, _, _, err = file.DeleteTab(2)
+			So(err, ShouldBeNil)
+			_, _, _, err = file.DeleteTab(0)
+			So(err, ShouldHaveSameTypeAs, &file_errors.InvalidTabError{})
+			_, _, _, err = file.DeleteTab(1)
+			So(err, ShouldHaveSameTypeAs, &file_errors.TabNotFoundError{})
+		})
+	})
+}
+
+func BenchmarkLoadSheetFile(b *testing.B) {
+	gdb, err := tests.GetPersistTestDB(filepath.Join(b.TempDir(), "bench"), &Chunk{}, &Cell{}, &ChunkRef{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	db := NewSQLiteStore(gdb)
+	alloc := datanode_alloc.NewDataNodeAllocator()
+	alloc.AddDataNode("node1")
+	file, _, _, err := CreateSheetFile(db, alloc, nil, 1)
+	if err != nil {
+		b.Fatal(err)
+	}
+	// 10000 Cells stored in 2500 Chunks.
+	for i := uint32(0); i < 100; i++ {
+		for j := uint32(0); j < 100; j++ {
+			_, _, _, _, err := file.WriteCellChunk(0, i, j, 0, db)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	err = file.Persistent(db)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		loaded, err := LoadSheetFile(db, alloc, nil, 1)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(loaded.Cells) != len(file.Cells) || len(loaded.Chunks) != len(file.Chunks) {
+			b.Fatalf("loaded %d cells in %d chunks, expected %d cells in %d chunks",
+				len(loaded.Cells), len(loaded.Chunks), len(file.Cells), len(file.Chunks))
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package sheetfile
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+/*
+SQLiteStore
+A Store backed by sqlite through gorm. Cells, Chunks and ChunkRefs are stored in tables
+migrated by gorm, and Cells of all SheetFiles share the table 'cells', see Cell.
+
+A SQLiteStore over a transaction runs all its methods in the transaction.
+*/
+type SQLiteStore struct {
+	db *gorm.DB
+}
+
+/*
+NewSQLiteStore
+Create a SQLiteStore over db, which can be a transaction. Tables of Cell, Chunk and
+ChunkRef should have been migrated.
+*/
+func NewSQLiteStore(db *gorm.DB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+/*
+CreateCells
+Cells of all SheetFiles are stored in the same table, so there is nothing to prepare.
+*/
+func (s *SQLiteStore) CreateCells(sheetID uint64) error {
+	return nil
+}
+
+func (s *SQLiteStore) LoadCells(sheetID uint64) ([]*Cell, error) {
+	cells := []*Cell{}
+	err := s.db.Where("sheet_id = ?", sheetID).Find(&cells).Error
+	return cells, err
+}
+
+func (s *SQLiteStore) SaveCell(cell *Cell) error {
+	return s.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(cell).Error
+}
+
+func (s *SQLiteStore) DeleteCell(cell *Cell) error {
+	return s.db.Unscoped().Delete(&Cell{}, cell.ID).Error
+}
+
+func (s *SQLiteStore) ReplaceCells(sheetID uint64, cells []*Cell) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := NewSQLiteStore(tx).DropCells(sheetID)
+		if err != nil {
+			return err
+		}
+		for _, cell := range cells {
+			err = NewSQLiteStore(tx).SaveCell(cell)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) DropCells(sheetID uint64) error {
+	return s.db.Unscoped().Where("sheet_id = ?", sheetID).Delete(&Cell{}).Error
+}
+
+func (s *SQLiteStore) SaveChunk(chunk *Chunk) error {
+	return s.db.Omit(clause.Associations).Clauses(clause.OnConflict{UpdateAll: true}).Create(chunk).Error
+}
+
+func (s *SQLiteStore) LoadChunks(ids []uint64) ([]*Chunk, error) {
+	var chunks []*Chunk
+	if len(ids) == 0 {
+		return chunks, nil
+	}
+	err := s.db.Find(&chunks, ids).Error
+	return chunks, err
+}
+
+/*
+DeleteChunks
+Chunks are soft-deleted by gorm by default, but a deleted Chunk will never be used again,
+so its row is removed.
+*/
+func (s *SQLiteStore) DeleteChunks(ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.db.Unscoped().Delete(&Chunk{}, ids).Error
+}
+
+func (s *SQLiteStore) LoadChunkRefs() ([]*ChunkRef, error) {
+	var refs []*ChunkRef
+	err := s.db.Find(&refs).Error
+	return refs, err
+}
+
+func (s *SQLiteStore) SaveChunkRefs(refs []*ChunkRef) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	return s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(refs).Error
+}
+
+func (s *SQLiteStore) DeleteChunkRefs(ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.db.Delete(&ChunkRef{}, ids).Error
+}
@@ -0,0 +1,47 @@
+package sheetfile
+
+/*
+Store
+Persists Cells, Chunks and reference counts of shared Chunks of SheetFiles. SheetFiles
+are maintained in memory, with the aid of journaling to tolerate fault, so a Store is
+written during checkpointing only, except for a few operations which must take effect at
+once, e.g. allocating IDs of new Chunks.
+
+Cells of different SheetFiles are stored separately, addressed by Cell.SheetID. A Cell or
+a Chunk is identified by its primary key(ID) in a Store, which is assigned by the Store
+when it's saved for the first time.
+
+A single method of a Store is atomic, but a sequence of them is not unless it's called on
+a transaction, see metastore.MetadataStore. Implementations are SQLiteStore and BoltStore.
+*/
+type Store interface {
+	// CreateCells prepares the storage of Cells of sheetID if it doesn't exist.
+	CreateCells(sheetID uint64) error
+	// LoadCells returns all Cells of sheetID, with SheetID set. Cells which can be
+	// decoded are returned even if errors are raised.
+	LoadCells(sheetID uint64) ([]*Cell, error)
+	// SaveCell inserts or updates cell, assigning cell.ID if it's a new Cell.
+	SaveCell(cell *Cell) error
+	// DeleteCell deletes the Cell of cell.SheetID with cell.ID permanently.
+	DeleteCell(cell *Cell) error
+	// ReplaceCells replaces all Cells of sheetID with cells, assigning IDs to them.
+	ReplaceCells(sheetID uint64, cells []*Cell) error
+	// DropCells deletes all Cells of sheetID and their storage permanently.
+	DropCells(sheetID uint64) error
+
+	// SaveChunk inserts or updates chunk, assigning chunk.ID if it's a new Chunk.
+	// chunk.Cells are not saved, see SaveCell.
+	SaveChunk(chunk *Chunk) error
+	// LoadChunks returns Chunks with given ids which exist, without their Cells. The
+	// number of ids is at most config.ChunksPerLoad.
+	LoadChunks(ids []uint64) ([]*Chunk, error)
+	// DeleteChunks deletes Chunks with given ids permanently.
+	DeleteChunks(ids []uint64) error
+
+	// LoadChunkRefs returns reference counts of all shared Chunks.
+	LoadChunkRefs() ([]*ChunkRef, error)
+	// SaveChunkRefs inserts or updates refs.
+	SaveChunkRefs(refs []*ChunkRef) error
+	// DeleteChunkRefs deletes reference counts of Chunks with given ids.
+	DeleteChunkRefs(ids []uint64) error
+}
@@ -0,0 +1,326 @@
+package sheetfile
+
+import (
+	"fmt"
+	"github.com/fourstring/sheetfs/master/config"
+	"github.com/fourstring/sheetfs/master/filemgr/file_errors"
+	"sort"
+)
+
+/*
+Tab
+A tab of a workbook, see SheetFile.
+*/
+type Tab struct {
+	ID   uint32
+	Name string
+	// Tabs are displayed in ascending order of Position, which starts from 0 and has no
+	// gaps.
+	Position uint32
+}
+
+/*
+DefaultTabName
+Returns the name of tab if it's not named, which is the name a tab created before tabs
+were introduced has.
+*/
+func DefaultTabName(tab uint32) string {
+	return fmt.Sprintf("Sheet%d", tab+1)
+}
+
+/*
+metaCellID
+Returns the CellID of the MetaCell of tab.
+*/
+func metaCellID(tab uint32) int64 {
+	return GetCellID(tab, config.SheetMetaCellRow, config.SheetMetaCellCol)
+}
+
+/*
+hasTab
+Returns true if tab exists in s. Caller should hold s.mu.
+*/
+func (s *SheetFile) hasTab(tab uint32) bool {
+	if tab >= config.MaxTabs {
+		return false
+	}
+	_, ok := s.Cells[metaCellID(tab)]
+	return ok
+}
+
+/*
+tabName
+Returns the name of the tab whose MetaCell is meta.
+*/
+func tabName(meta *Cell) string {
+	if meta.TabName == "" {
+		return DefaultTabName(meta.Tab())
+	}
+	return meta.TabName
+}
+
+/*
+tabMetaCells
+Returns MetaCells of all tabs in the order of their positions. Caller should hold s.mu.
+*/
+func (s *SheetFile) tabMetaCells() []*Cell {
+	var metas []*Cell
+	for tab := uint32(0); tab < config.MaxTabs; tab++ {
+		if meta, ok := s.Cells[metaCellID(tab)]; ok {
+			metas = append(metas, meta)
+		}
+	}
+	// Ties, which are only possible for tabs created before tabs were introduced, are
+	// broken by tab numbers.
+	sort.SliceStable(metas, func(i, j int) bool {
+		return metas[i].TabPosition < metas[j].TabPosition
+	})
+	return metas
+}
+
+/*
+isTabNameTaken
+Returns true if some tab other than except is named name. Caller should hold s.mu.
+*/
+func (s *SheetFile) isTabNameTaken(name string, except uint32) bool {
+	for _, meta := range s.tabMetaCells() {
+		if meta.Tab() != except && tabName(meta) == name {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Tabs
+Returns all tabs of s in the order of their positions.
+
+@return
+	[]*Tab: tabs of s, there is at least one tab.
+*/
+func (s *SheetFile) Tabs() []*Tab {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	metas := s.tabMetaCells()
+	tabs := make([]*Tab, len(metas))
+	for i, meta := range metas {
+		tabs[i] = &Tab{ID: meta.Tab(), Name: tabName(meta), Position: uint32(i)}
+	}
+	return tabs
+}
+
+/*
+addMetaCell
+Create the MetaCell of tab with given name and position, and a dedicated Chunk to store
+it. The Chunk is persisted to get its ID, and the version of the MetaCell is not increased
+since nothing has been written to it. Caller should hold s.mu if s has been shared.
+
+@return
+	*Cell, *Chunk: the MetaCell and its Chunk.
+	error:
+		*errors.NoDataNodeError if there is no DataNode registered.
+		errors raised by the Store.
+*/
+func (s *SheetFile) addMetaCell(tab uint32, name string, position uint32, tx Store) (*Cell, *Chunk, error) {
+	dataNode, err := s.alloc.AllocateNode()
+	if err != nil {
+		return nil, nil, err
+	}
+	chunk := &Chunk{DataNode: dataNode, Version: 0}
+	err = chunk.Persistent(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	metaCell := NewCell(metaCellID(tab), 0, config.BytesPerChunk, chunk.ID, s.id)
+	metaCell.TabName = name
+	metaCell.TabPosition = position
+	chunk.Cells = []*Cell{metaCell}
+	s.Chunks[chunk.ID] = chunk
+	s.Cells[metaCell.CellID] = metaCell
+	s.markCell(metaCell)
+	return metaCell, chunk, nil
+}
+
+/*
+metaCellResult
+Returns snapshots of a MetaCell changed by a tab operation, which should be journaled like
+a write of it. Caller should hold s.mu.
+*/
+func (s *SheetFile) metaCellResult(meta *Cell) *CellWriteResult {
+	overflow := make([]*Chunk, len(meta.Overflow))
+	for i, id := range meta.Overflow {
+		overflow[i] = s.Chunks[id].Snapshot()
+	}
+	return &CellWriteResult{Cell: meta.Snapshot(), Chunk: s.Chunks[meta.ChunkID].Snapshot(), Overflow: overflow}
+}
+
+/*
+placeTabs
+Set positions of tabs to the order of metas. Caller should hold s.mu.
+
+@return
+	[]*CellWriteResult: MetaCells whose positions are changed, see metaCellResult.
+*/
+func (s *SheetFile) placeTabs(metas []*Cell) []*CellWriteResult {
+	var results []*CellWriteResult
+	for i, meta := range metas {
+		if meta.TabPosition != uint32(i) {
+			meta.TabPosition = uint32(i)
+			s.markCell(meta)
+			results = append(results, s.metaCellResult(meta))
+		}
+	}
+	return results
+}
+
+/*
+CreateTab
+Create a new tab after all existing tabs, which is numbered by the smallest unused tab
+number. The MetaCell of the new tab is allocated a dedicated Chunk like CreateSheetFile.
+
+@para
+	name: name of the new tab, if it's empty, the first unused name of DefaultTabName
+	from the new tab number on is used.
+	tx: a Store, can be a transaction
+
+@return
+	*CellWriteResult: the new MetaCell and its Chunk, which should be journaled like a write.
+	error:
+		*errors.TabExistsError if there has been a tab named name.
+		*errors.InvalidTabError if there have been config.MaxTabs tabs.
+		*errors.NoDataNodeError if there is no DataNode registered.
+*/
+func (s *SheetFile) CreateTab(name string, tx Store) (*CellWriteResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tab := uint32(0)
+	for tab < config.MaxTabs && s.hasTab(tab) {
+		tab++
+	}
+	if tab == config.MaxTabs {
+		return nil, file_errors.NewInvalidTabError(tab)
+	}
+	if name == "" {
+		for i := tab; name == "" || s.isTabNameTaken(name, tab); i++ {
+			name = DefaultTabName(i)
+		}
+	}
+	if s.isTabNameTaken(name, tab) {
+		return nil, file_errors.NewTabExistsError(name)
+	}
+	meta, _, err := s.addMetaCell(tab, name, uint32(len(s.tabMetaCells())), tx)
+	if err != nil {
+		return nil, err
+	}
+	return s.metaCellResult(meta), nil
+}
+
+/*
+RenameTab
+Rename tab to name.
+
+@return
+	*CellWriteResult: the MetaCell of tab, which should be journaled like a write.
+	error:
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidTabError if name is empty.
+		*errors.TabExistsError if there has been another tab named name.
+*/
+func (s *SheetFile) RenameTab(tab uint32, name string) (*CellWriteResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.hasTab(tab) {
+		return nil, file_errors.NewTabNotFoundError(tab)
+	}
+	if name == "" {
+		return nil, file_errors.NewInvalidTabError(tab)
+	}
+	if s.isTabNameTaken(name, tab) {
+		return nil, file_errors.NewTabExistsError(name)
+	}
+	meta := s.Cells[metaCellID(tab)]
+	meta.TabName = name
+	s.markCell(meta)
+	return s.metaCellResult(meta), nil
+}
+
+/*
+MoveTab
+Move tab to position, tabs between its old and new positions are shifted by one.
+
+@return
+	[]*CellWriteResult: MetaCells of tabs whose positions are changed, which should be
+	journaled like writes.
+	error:
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidTabError if position is not less than the number of tabs.
+*/
+func (s *SheetFile) MoveTab(tab uint32, position uint32) ([]*CellWriteResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.hasTab(tab) {
+		return nil, file_errors.NewTabNotFoundError(tab)
+	}
+	metas := s.tabMetaCells()
+	if position >= uint32(len(metas)) {
+		return nil, file_errors.NewInvalidTabError(tab)
+	}
+	meta := s.Cells[metaCellID(tab)]
+	ordered := make([]*Cell, 0, len(metas))
+	for _, m := range metas {
+		if m != meta {
+			ordered = append(ordered, m)
+		}
+	}
+	ordered = append(ordered[:position], append([]*Cell{meta}, ordered[position:]...)...)
+	return s.placeTabs(ordered), nil
+}
+
+/*
+DeleteTab
+Delete tab and all of its Cells including its MetaCell, tabs after it are shifted
+forward. Cells are removed like DeleteLines, so data on DataNodes is not touched. The
+last tab of s can't be deleted.
+
+@para
+	tab: the tab to delete
+
+@return
+	[]*Cell: snapshots of removed Cells, which should be journaled like deleted Cells.
+	[]*CellWriteResult: MetaCells of tabs whose positions are changed, which should be
+	journaled like writes.
+	[]*Chunk: dropped Chunks to be deleted from DataNodes, see removeCell.
+	error:
+		*errors.TabNotFoundError if there is no such tab.
+		*errors.InvalidTabError if tab is the only tab of s.
+*/
+func (s *SheetFile) DeleteTab(tab uint32) ([]*Cell, []*CellWriteResult, []*Chunk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.hasTab(tab) {
+		return nil, nil, nil, file_errors.NewTabNotFoundError(tab)
+	}
+	metas := s.tabMetaCells()
+	if len(metas) == 1 {
+		return nil, nil, nil, file_errors.NewInvalidTabError(tab)
+	}
+	var cells []*Cell
+	for _, cell := range s.Cells {
+		if cell.Tab() == tab {
+			cells = append(cells, cell)
+		}
+	}
+	var removed []*Cell
+	var dropped []*Chunk
+	for _, cell := range cells {
+		removed = append(removed, cell.Snapshot())
+		dropped = append(dropped, s.removeCell(cell)...)
+	}
+	kept := make([]*Cell, 0, len(metas)-1)
+	for _, meta := range metas {
+		if meta.Tab() != tab {
+			kept = append(kept, meta)
+		}
+	}
+	return removed, s.placeTabs(kept), dropped, nil
+}
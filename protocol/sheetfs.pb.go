@@ -29,6 +29,8 @@ const (
 	Status_WrongVersion Status = 3
 	Status_Invalid      Status = 4
 	Status_Unavailable  Status = 5
+	Status_Expired      Status = 6
+	Status_NotEmpty     Status = 7
 )
 
 // Enum value maps for Status.
@@ -40,6 +42,8 @@ var (
 		3: "WrongVersion",
 		4: "Invalid",
 		5: "Unavailable",
+		6: "Expired",
+		7: "NotEmpty",
 	}
 	Status_value = map[string]int32{
 		"OK":           0,
@@ -48,6 +52,8 @@ var (
 		"WrongVersion": 3,
 		"Invalid":      4,
 		"Unavailable":  5,
+		"Expired":      6,
+		"NotEmpty":     7,
 	}
 )
 
@@ -78,6 +84,103 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{0}
 }
 
+type RecycledFilter int32
+
+const (
+	RecycledFilter_ALL           RecycledFilter = 0
+	RecycledFilter_NOT_RECYCLED  RecycledFilter = 1
+	RecycledFilter_RECYCLED_ONLY RecycledFilter = 2
+)
+
+// Enum value maps for RecycledFilter.
+var (
+	RecycledFilter_name = map[int32]string{
+		0: "ALL",
+		1: "NOT_RECYCLED",
+		2: "RECYCLED_ONLY",
+	}
+	RecycledFilter_value = map[string]int32{
+		"ALL":           0,
+		"NOT_RECYCLED":  1,
+		"RECYCLED_ONLY": 2,
+	}
+)
+
+func (x RecycledFilter) Enum() *RecycledFilter {
+	p := new(RecycledFilter)
+	*p = x
+	return p
+}
+
+func (x RecycledFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecycledFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocol_sheetfs_proto_enumTypes[1].Descriptor()
+}
+
+func (RecycledFilter) Type() protoreflect.EnumType {
+	return &file_protocol_sheetfs_proto_enumTypes[1]
+}
+
+func (x RecycledFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecycledFilter.Descriptor instead.
+func (RecycledFilter) EnumDescriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{1}
+}
+
+type SheetOrder int32
+
+const (
+	SheetOrder_BY_NAME SheetOrder = 0
+	// Ordered by the last modification time, sheets modified at the same time are
+	// ordered by name.
+	SheetOrder_BY_MODIFIED SheetOrder = 1
+)
+
+// Enum value maps for SheetOrder.
+var (
+	SheetOrder_name = map[int32]string{
+		0: "BY_NAME",
+		1: "BY_MODIFIED",
+	}
+	SheetOrder_value = map[string]int32{
+		"BY_NAME":     0,
+		"BY_MODIFIED": 1,
+	}
+)
+
+func (x SheetOrder) Enum() *SheetOrder {
+	p := new(SheetOrder)
+	*p = x
+	return p
+}
+
+func (x SheetOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SheetOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocol_sheetfs_proto_enumTypes[2].Descriptor()
+}
+
+func (SheetOrder) Type() protoreflect.EnumType {
+	return &file_protocol_sheetfs_proto_enumTypes[2]
+}
+
+func (x SheetOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SheetOrder.Descriptor instead.
+func (SheetOrder) EnumDescriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{2}
+}
+
 type Empty struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -210,16 +313,19 @@ func (x *RegisterDataNodeReply) GetStatus() Status {
 	return Status_OK
 }
 
-type CreateSheetRequest struct {
+type OpenSessionReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Status  Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Session uint64 `protobuf:"varint,2,opt,name=session,proto3" json:"session,omitempty"`
+	// Duration of the lease in milliseconds.
+	Lease uint64 `protobuf:"varint,3,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
-func (x *CreateSheetRequest) Reset() {
-	*x = CreateSheetRequest{}
+func (x *OpenSessionReply) Reset() {
+	*x = OpenSessionReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -227,13 +333,13 @@ func (x *CreateSheetRequest) Reset() {
 	}
 }
 
-func (x *CreateSheetRequest) String() string {
+func (x *OpenSessionReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSheetRequest) ProtoMessage() {}
+func (*OpenSessionReply) ProtoMessage() {}
 
-func (x *CreateSheetRequest) ProtoReflect() protoreflect.Message {
+func (x *OpenSessionReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -245,29 +351,42 @@ func (x *CreateSheetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSheetRequest.ProtoReflect.Descriptor instead.
-func (*CreateSheetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OpenSessionReply.ProtoReflect.Descriptor instead.
+func (*OpenSessionReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CreateSheetRequest) GetFilename() string {
+func (x *OpenSessionReply) GetStatus() Status {
 	if x != nil {
-		return x.Filename
+		return x.Status
 	}
-	return ""
+	return Status_OK
 }
 
-type CreateSheetReply struct {
+func (x *OpenSessionReply) GetSession() uint64 {
+	if x != nil {
+		return x.Session
+	}
+	return 0
+}
+
+func (x *OpenSessionReply) GetLease() uint64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
+type KeepAliveRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
-	Fd     uint64 `protobuf:"varint,2,opt,name=fd,proto3" json:"fd,omitempty"`
+	Session uint64 `protobuf:"varint,1,opt,name=session,proto3" json:"session,omitempty"`
 }
 
-func (x *CreateSheetReply) Reset() {
-	*x = CreateSheetReply{}
+func (x *KeepAliveRequest) Reset() {
+	*x = KeepAliveRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -275,13 +394,13 @@ func (x *CreateSheetReply) Reset() {
 	}
 }
 
-func (x *CreateSheetReply) String() string {
+func (x *KeepAliveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSheetReply) ProtoMessage() {}
+func (*KeepAliveRequest) ProtoMessage() {}
 
-func (x *CreateSheetReply) ProtoReflect() protoreflect.Message {
+func (x *KeepAliveRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -293,35 +412,28 @@ func (x *CreateSheetReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSheetReply.ProtoReflect.Descriptor instead.
-func (*CreateSheetReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeepAliveRequest.ProtoReflect.Descriptor instead.
+func (*KeepAliveRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CreateSheetReply) GetStatus() Status {
-	if x != nil {
-		return x.Status
-	}
-	return Status_OK
-}
-
-func (x *CreateSheetReply) GetFd() uint64 {
+func (x *KeepAliveRequest) GetSession() uint64 {
 	if x != nil {
-		return x.Fd
+		return x.Session
 	}
 	return 0
 }
 
-type DeleteSheetRequest struct {
+type KeepAliveReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
 }
 
-func (x *DeleteSheetRequest) Reset() {
-	*x = DeleteSheetRequest{}
+func (x *KeepAliveReply) Reset() {
+	*x = KeepAliveReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -329,13 +441,13 @@ func (x *DeleteSheetRequest) Reset() {
 	}
 }
 
-func (x *DeleteSheetRequest) String() string {
+func (x *KeepAliveReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSheetRequest) ProtoMessage() {}
+func (*KeepAliveReply) ProtoMessage() {}
 
-func (x *DeleteSheetRequest) ProtoReflect() protoreflect.Message {
+func (x *KeepAliveReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -347,28 +459,28 @@ func (x *DeleteSheetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSheetRequest.ProtoReflect.Descriptor instead.
-func (*DeleteSheetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeepAliveReply.ProtoReflect.Descriptor instead.
+func (*KeepAliveReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DeleteSheetRequest) GetFilename() string {
+func (x *KeepAliveReply) GetStatus() Status {
 	if x != nil {
-		return x.Filename
+		return x.Status
 	}
-	return ""
+	return Status_OK
 }
 
-type DeleteSheetReply struct {
+type CloseSessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Session uint64 `protobuf:"varint,1,opt,name=session,proto3" json:"session,omitempty"`
 }
 
-func (x *DeleteSheetReply) Reset() {
-	*x = DeleteSheetReply{}
+func (x *CloseSessionRequest) Reset() {
+	*x = CloseSessionRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -376,13 +488,13 @@ func (x *DeleteSheetReply) Reset() {
 	}
 }
 
-func (x *DeleteSheetReply) String() string {
+func (x *CloseSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSheetReply) ProtoMessage() {}
+func (*CloseSessionRequest) ProtoMessage() {}
 
-func (x *DeleteSheetReply) ProtoReflect() protoreflect.Message {
+func (x *CloseSessionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -394,28 +506,28 @@ func (x *DeleteSheetReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSheetReply.ProtoReflect.Descriptor instead.
-func (*DeleteSheetReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use CloseSessionRequest.ProtoReflect.Descriptor instead.
+func (*CloseSessionRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *DeleteSheetReply) GetStatus() Status {
+func (x *CloseSessionRequest) GetSession() uint64 {
 	if x != nil {
-		return x.Status
+		return x.Session
 	}
-	return Status_OK
+	return 0
 }
 
-type OpenSheetRequest struct {
+type CloseSessionReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
 }
 
-func (x *OpenSheetRequest) Reset() {
-	*x = OpenSheetRequest{}
+func (x *CloseSessionReply) Reset() {
+	*x = CloseSessionReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -423,13 +535,13 @@ func (x *OpenSheetRequest) Reset() {
 	}
 }
 
-func (x *OpenSheetRequest) String() string {
+func (x *CloseSessionReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenSheetRequest) ProtoMessage() {}
+func (*CloseSessionReply) ProtoMessage() {}
 
-func (x *OpenSheetRequest) ProtoReflect() protoreflect.Message {
+func (x *CloseSessionReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -441,31 +553,29 @@ func (x *OpenSheetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OpenSheetRequest.ProtoReflect.Descriptor instead.
-func (*OpenSheetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CloseSessionReply.ProtoReflect.Descriptor instead.
+func (*CloseSessionReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *OpenSheetRequest) GetFilename() string {
+func (x *CloseSessionReply) GetStatus() Status {
 	if x != nil {
-		return x.Filename
+		return x.Status
 	}
-	return ""
+	return Status_OK
 }
 
-type Chunk struct {
+type CreateSheetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Datanode  string `protobuf:"bytes,2,opt,name=datanode,proto3" json:"datanode,omitempty"`
-	Version   uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	HoldsMeta bool   `protobuf:"varint,4,opt,name=holds_meta,json=holdsMeta,proto3" json:"holds_meta,omitempty"`
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Session  uint64 `protobuf:"varint,2,opt,name=session,proto3" json:"session,omitempty"`
 }
 
-func (x *Chunk) Reset() {
-	*x = Chunk{}
+func (x *CreateSheetRequest) Reset() {
+	*x = CreateSheetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -473,13 +583,13 @@ func (x *Chunk) Reset() {
 	}
 }
 
-func (x *Chunk) String() string {
+func (x *CreateSheetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Chunk) ProtoMessage() {}
+func (*CreateSheetRequest) ProtoMessage() {}
 
-func (x *Chunk) ProtoReflect() protoreflect.Message {
+func (x *CreateSheetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -491,40 +601,26 @@ func (x *Chunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Chunk.ProtoReflect.Descriptor instead.
-func (*Chunk) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateSheetRequest.ProtoReflect.Descriptor instead.
+func (*CreateSheetRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Chunk) GetId() uint64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *Chunk) GetDatanode() string {
+func (x *CreateSheetRequest) GetFilename() string {
 	if x != nil {
-		return x.Datanode
+		return x.Filename
 	}
 	return ""
 }
 
-func (x *Chunk) GetVersion() uint64 {
+func (x *CreateSheetRequest) GetSession() uint64 {
 	if x != nil {
-		return x.Version
+		return x.Session
 	}
 	return 0
 }
 
-func (x *Chunk) GetHoldsMeta() bool {
-	if x != nil {
-		return x.HoldsMeta
-	}
-	return false
-}
-
-type OpenSheetReply struct {
+type CreateSheetReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -533,8 +629,8 @@ type OpenSheetReply struct {
 	Fd     uint64 `protobuf:"varint,2,opt,name=fd,proto3" json:"fd,omitempty"`
 }
 
-func (x *OpenSheetReply) Reset() {
-	*x = OpenSheetReply{}
+func (x *CreateSheetReply) Reset() {
+	*x = CreateSheetReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -542,13 +638,13 @@ func (x *OpenSheetReply) Reset() {
 	}
 }
 
-func (x *OpenSheetReply) String() string {
+func (x *CreateSheetReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenSheetReply) ProtoMessage() {}
+func (*CreateSheetReply) ProtoMessage() {}
 
-func (x *OpenSheetReply) ProtoReflect() protoreflect.Message {
+func (x *CreateSheetReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -560,35 +656,35 @@ func (x *OpenSheetReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OpenSheetReply.ProtoReflect.Descriptor instead.
-func (*OpenSheetReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateSheetReply.ProtoReflect.Descriptor instead.
+func (*CreateSheetReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *OpenSheetReply) GetStatus() Status {
+func (x *CreateSheetReply) GetStatus() Status {
 	if x != nil {
 		return x.Status
 	}
 	return Status_OK
 }
 
-func (x *OpenSheetReply) GetFd() uint64 {
+func (x *CreateSheetReply) GetFd() uint64 {
 	if x != nil {
 		return x.Fd
 	}
 	return 0
 }
 
-type ReadSheetRequest struct {
+type DeleteSheetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Fd uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
 }
 
-func (x *ReadSheetRequest) Reset() {
-	*x = ReadSheetRequest{}
+func (x *DeleteSheetRequest) Reset() {
+	*x = DeleteSheetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -596,13 +692,13 @@ func (x *ReadSheetRequest) Reset() {
 	}
 }
 
-func (x *ReadSheetRequest) String() string {
+func (x *DeleteSheetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadSheetRequest) ProtoMessage() {}
+func (*DeleteSheetRequest) ProtoMessage() {}
 
-func (x *ReadSheetRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteSheetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -614,29 +710,28 @@ func (x *ReadSheetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadSheetRequest.ProtoReflect.Descriptor instead.
-func (*ReadSheetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteSheetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSheetRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ReadSheetRequest) GetFd() uint64 {
+func (x *DeleteSheetRequest) GetFilename() string {
 	if x != nil {
-		return x.Fd
+		return x.Filename
 	}
-	return 0
+	return ""
 }
 
-type ReadSheetReply struct {
+type DeleteSheetReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status   `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
-	Chunks []*Chunk `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
 }
 
-func (x *ReadSheetReply) Reset() {
-	*x = ReadSheetReply{}
+func (x *DeleteSheetReply) Reset() {
+	*x = DeleteSheetReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -644,13 +739,13 @@ func (x *ReadSheetReply) Reset() {
 	}
 }
 
-func (x *ReadSheetReply) String() string {
+func (x *DeleteSheetReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadSheetReply) ProtoMessage() {}
+func (*DeleteSheetReply) ProtoMessage() {}
 
-func (x *ReadSheetReply) ProtoReflect() protoreflect.Message {
+func (x *DeleteSheetReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -662,35 +757,29 @@ func (x *ReadSheetReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadSheetReply.ProtoReflect.Descriptor instead.
-func (*ReadSheetReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteSheetReply.ProtoReflect.Descriptor instead.
+func (*DeleteSheetReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ReadSheetReply) GetStatus() Status {
+func (x *DeleteSheetReply) GetStatus() Status {
 	if x != nil {
 		return x.Status
 	}
 	return Status_OK
 }
 
-func (x *ReadSheetReply) GetChunks() []*Chunk {
-	if x != nil {
-		return x.Chunks
-	}
-	return nil
-}
-
-type RecycleSheetRequest struct {
+type RenameSheetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Filename    string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	NewFilename string `protobuf:"bytes,2,opt,name=new_filename,json=newFilename,proto3" json:"new_filename,omitempty"`
 }
 
-func (x *RecycleSheetRequest) Reset() {
-	*x = RecycleSheetRequest{}
+func (x *RenameSheetRequest) Reset() {
+	*x = RenameSheetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -698,13 +787,13 @@ func (x *RecycleSheetRequest) Reset() {
 	}
 }
 
-func (x *RecycleSheetRequest) String() string {
+func (x *RenameSheetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecycleSheetRequest) ProtoMessage() {}
+func (*RenameSheetRequest) ProtoMessage() {}
 
-func (x *RecycleSheetRequest) ProtoReflect() protoreflect.Message {
+func (x *RenameSheetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -716,19 +805,26 @@ func (x *RecycleSheetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecycleSheetRequest.ProtoReflect.Descriptor instead.
-func (*RecycleSheetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RenameSheetRequest.ProtoReflect.Descriptor instead.
+func (*RenameSheetRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *RecycleSheetRequest) GetFilename() string {
+func (x *RenameSheetRequest) GetFilename() string {
 	if x != nil {
 		return x.Filename
 	}
 	return ""
 }
 
-type RecycleSheetReply struct {
+func (x *RenameSheetRequest) GetNewFilename() string {
+	if x != nil {
+		return x.NewFilename
+	}
+	return ""
+}
+
+type RenameSheetReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -736,8 +832,8 @@ type RecycleSheetReply struct {
 	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
 }
 
-func (x *RecycleSheetReply) Reset() {
-	*x = RecycleSheetReply{}
+func (x *RenameSheetReply) Reset() {
+	*x = RenameSheetReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -745,13 +841,13 @@ func (x *RecycleSheetReply) Reset() {
 	}
 }
 
-func (x *RecycleSheetReply) String() string {
+func (x *RenameSheetReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecycleSheetReply) ProtoMessage() {}
+func (*RenameSheetReply) ProtoMessage() {}
 
-func (x *RecycleSheetReply) ProtoReflect() protoreflect.Message {
+func (x *RenameSheetReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -763,28 +859,29 @@ func (x *RecycleSheetReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecycleSheetReply.ProtoReflect.Descriptor instead.
-func (*RecycleSheetReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use RenameSheetReply.ProtoReflect.Descriptor instead.
+func (*RenameSheetReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *RecycleSheetReply) GetStatus() Status {
+func (x *RenameSheetReply) GetStatus() Status {
 	if x != nil {
 		return x.Status
 	}
 	return Status_OK
 }
 
-type ResumeSheetRequest struct {
+type CopySheetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Filename    string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	NewFilename string `protobuf:"bytes,2,opt,name=new_filename,json=newFilename,proto3" json:"new_filename,omitempty"`
 }
 
-func (x *ResumeSheetRequest) Reset() {
-	*x = ResumeSheetRequest{}
+func (x *CopySheetRequest) Reset() {
+	*x = CopySheetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -792,13 +889,13 @@ func (x *ResumeSheetRequest) Reset() {
 	}
 }
 
-func (x *ResumeSheetRequest) String() string {
+func (x *CopySheetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResumeSheetRequest) ProtoMessage() {}
+func (*CopySheetRequest) ProtoMessage() {}
 
-func (x *ResumeSheetRequest) ProtoReflect() protoreflect.Message {
+func (x *CopySheetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -810,19 +907,26 @@ func (x *ResumeSheetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResumeSheetRequest.ProtoReflect.Descriptor instead.
-func (*ResumeSheetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CopySheetRequest.ProtoReflect.Descriptor instead.
+func (*CopySheetRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ResumeSheetRequest) GetFilename() string {
+func (x *CopySheetRequest) GetFilename() string {
 	if x != nil {
 		return x.Filename
 	}
 	return ""
 }
 
-type ResumeSheetReply struct {
+func (x *CopySheetRequest) GetNewFilename() string {
+	if x != nil {
+		return x.NewFilename
+	}
+	return ""
+}
+
+type CopySheetReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -830,8 +934,8 @@ type ResumeSheetReply struct {
 	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
 }
 
-func (x *ResumeSheetReply) Reset() {
-	*x = ResumeSheetReply{}
+func (x *CopySheetReply) Reset() {
+	*x = CopySheetReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -839,13 +943,13 @@ func (x *ResumeSheetReply) Reset() {
 	}
 }
 
-func (x *ResumeSheetReply) String() string {
+func (x *CopySheetReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResumeSheetReply) ProtoMessage() {}
+func (*CopySheetReply) ProtoMessage() {}
 
-func (x *ResumeSheetReply) ProtoReflect() protoreflect.Message {
+func (x *CopySheetReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -857,29 +961,29 @@ func (x *ResumeSheetReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResumeSheetReply.ProtoReflect.Descriptor instead.
-func (*ResumeSheetReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use CopySheetReply.ProtoReflect.Descriptor instead.
+func (*CopySheetReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ResumeSheetReply) GetStatus() Status {
+func (x *CopySheetReply) GetStatus() Status {
 	if x != nil {
 		return x.Status
 	}
 	return Status_OK
 }
 
-type Sheet struct {
+type OpenSheetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	Recycled bool   `protobuf:"varint,2,opt,name=recycled,proto3" json:"recycled,omitempty"`
+	Session  uint64 `protobuf:"varint,2,opt,name=session,proto3" json:"session,omitempty"`
 }
 
-func (x *Sheet) Reset() {
-	*x = Sheet{}
+func (x *OpenSheetRequest) Reset() {
+	*x = OpenSheetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -887,13 +991,13 @@ func (x *Sheet) Reset() {
 	}
 }
 
-func (x *Sheet) String() string {
+func (x *OpenSheetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Sheet) ProtoMessage() {}
+func (*OpenSheetRequest) ProtoMessage() {}
 
-func (x *Sheet) ProtoReflect() protoreflect.Message {
+func (x *OpenSheetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -905,36 +1009,41 @@ func (x *Sheet) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Sheet.ProtoReflect.Descriptor instead.
-func (*Sheet) Descriptor() ([]byte, []int) {
+// Deprecated: Use OpenSheetRequest.ProtoReflect.Descriptor instead.
+func (*OpenSheetRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *Sheet) GetFilename() string {
+func (x *OpenSheetRequest) GetFilename() string {
 	if x != nil {
 		return x.Filename
 	}
 	return ""
 }
 
-func (x *Sheet) GetRecycled() bool {
+func (x *OpenSheetRequest) GetSession() uint64 {
 	if x != nil {
-		return x.Recycled
+		return x.Session
 	}
-	return false
+	return 0
 }
 
-type ListSheetsReply struct {
+type Chunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status   `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
-	Sheets []*Sheet `protobuf:"bytes,2,rep,name=sheets,proto3" json:"sheets,omitempty"`
+	Id       uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Datanode string `protobuf:"bytes,2,opt,name=datanode,proto3" json:"datanode,omitempty"`
+	Version  uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// True if the chunk holds the metadata cell of a tab, which is the tab of its extent.
+	HoldsMeta bool `protobuf:"varint,4,opt,name=holds_meta,json=holdsMeta,proto3" json:"holds_meta,omitempty"`
+	// Cells in this chunk, only set in ReadSheetReply and ReadCellsReply.
+	Extents []*CellExtent `protobuf:"bytes,5,rep,name=extents,proto3" json:"extents,omitempty"`
 }
 
-func (x *ListSheetsReply) Reset() {
-	*x = ListSheetsReply{}
+func (x *Chunk) Reset() {
+	*x = Chunk{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -942,13 +1051,13 @@ func (x *ListSheetsReply) Reset() {
 	}
 }
 
-func (x *ListSheetsReply) String() string {
+func (x *Chunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSheetsReply) ProtoMessage() {}
+func (*Chunk) ProtoMessage() {}
 
-func (x *ListSheetsReply) ProtoReflect() protoreflect.Message {
+func (x *Chunk) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -960,37 +1069,68 @@ func (x *ListSheetsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSheetsReply.ProtoReflect.Descriptor instead.
-func (*ListSheetsReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use Chunk.ProtoReflect.Descriptor instead.
+func (*Chunk) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ListSheetsReply) GetStatus() Status {
+func (x *Chunk) GetId() uint64 {
 	if x != nil {
-		return x.Status
+		return x.Id
 	}
-	return Status_OK
+	return 0
 }
 
-func (x *ListSheetsReply) GetSheets() []*Sheet {
+func (x *Chunk) GetDatanode() string {
 	if x != nil {
-		return x.Sheets
+		return x.Datanode
+	}
+	return ""
+}
+
+func (x *Chunk) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Chunk) GetHoldsMeta() bool {
+	if x != nil {
+		return x.HoldsMeta
+	}
+	return false
+}
+
+func (x *Chunk) GetExtents() []*CellExtent {
+	if x != nil {
+		return x.Extents
 	}
 	return nil
 }
 
-type Cell struct {
+// Position of a cell in its chunk. Only the first length bytes of the cell are
+// data, the rest of its slot is padding. Data of a large cell continues in overflow
+// chunks, which should be spliced right after the slot of the cell at offset + size
+// of its chunk.
+type CellExtent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Chunk  *Chunk `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
-	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Size   uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Offset uint64   `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size   uint64   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Chunks []*Chunk `protobuf:"bytes,3,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	Length uint64   `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	Row    uint32   `protobuf:"varint,5,opt,name=row,proto3" json:"row,omitempty"`
+	Column uint32   `protobuf:"varint,6,opt,name=column,proto3" json:"column,omitempty"`
+	// Version of the slot of the cell, see Cell.
+	Version uint64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	Tab     uint32 `protobuf:"varint,8,opt,name=tab,proto3" json:"tab,omitempty"`
 }
 
-func (x *Cell) Reset() {
-	*x = Cell{}
+func (x *CellExtent) Reset() {
+	*x = CellExtent{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -998,13 +1138,13 @@ func (x *Cell) Reset() {
 	}
 }
 
-func (x *Cell) String() string {
+func (x *CellExtent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Cell) ProtoMessage() {}
+func (*CellExtent) ProtoMessage() {}
 
-func (x *Cell) ProtoReflect() protoreflect.Message {
+func (x *CellExtent) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1016,44 +1156,78 @@ func (x *Cell) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Cell.ProtoReflect.Descriptor instead.
-func (*Cell) Descriptor() ([]byte, []int) {
+// Deprecated: Use CellExtent.ProtoReflect.Descriptor instead.
+func (*CellExtent) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *Cell) GetChunk() *Chunk {
+func (x *CellExtent) GetOffset() uint64 {
 	if x != nil {
-		return x.Chunk
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *CellExtent) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CellExtent) GetChunks() []*Chunk {
+	if x != nil {
+		return x.Chunks
 	}
 	return nil
 }
 
-func (x *Cell) GetOffset() uint64 {
+func (x *CellExtent) GetLength() uint64 {
 	if x != nil {
-		return x.Offset
+		return x.Length
 	}
 	return 0
 }
 
-func (x *Cell) GetSize() uint64 {
+func (x *CellExtent) GetRow() uint32 {
 	if x != nil {
-		return x.Size
+		return x.Row
 	}
 	return 0
 }
 
-type ReadCellRequest struct {
+func (x *CellExtent) GetColumn() uint32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *CellExtent) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CellExtent) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type OpenSheetReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Fd     uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
-	Row    uint32 `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
-	Column uint32 `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Fd     uint64 `protobuf:"varint,2,opt,name=fd,proto3" json:"fd,omitempty"`
 }
 
-func (x *ReadCellRequest) Reset() {
-	*x = ReadCellRequest{}
+func (x *OpenSheetReply) Reset() {
+	*x = OpenSheetReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1061,13 +1235,13 @@ func (x *ReadCellRequest) Reset() {
 	}
 }
 
-func (x *ReadCellRequest) String() string {
+func (x *OpenSheetReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadCellRequest) ProtoMessage() {}
+func (*OpenSheetReply) ProtoMessage() {}
 
-func (x *ReadCellRequest) ProtoReflect() protoreflect.Message {
+func (x *OpenSheetReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1079,43 +1253,35 @@ func (x *ReadCellRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadCellRequest.ProtoReflect.Descriptor instead.
-func (*ReadCellRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OpenSheetReply.ProtoReflect.Descriptor instead.
+func (*OpenSheetReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ReadCellRequest) GetFd() uint64 {
-	if x != nil {
-		return x.Fd
-	}
-	return 0
-}
-
-func (x *ReadCellRequest) GetRow() uint32 {
+func (x *OpenSheetReply) GetStatus() Status {
 	if x != nil {
-		return x.Row
+		return x.Status
 	}
-	return 0
+	return Status_OK
 }
 
-func (x *ReadCellRequest) GetColumn() uint32 {
+func (x *OpenSheetReply) GetFd() uint64 {
 	if x != nil {
-		return x.Column
+		return x.Fd
 	}
 	return 0
 }
 
-type ReadCellReply struct {
+type CloseSheetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
-	Cell   *Cell  `protobuf:"bytes,2,opt,name=cell,proto3" json:"cell,omitempty"`
+	Fd uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
 }
 
-func (x *ReadCellReply) Reset() {
-	*x = ReadCellReply{}
+func (x *CloseSheetRequest) Reset() {
+	*x = CloseSheetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1123,13 +1289,13 @@ func (x *ReadCellReply) Reset() {
 	}
 }
 
-func (x *ReadCellReply) String() string {
+func (x *CloseSheetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadCellReply) ProtoMessage() {}
+func (*CloseSheetRequest) ProtoMessage() {}
 
-func (x *ReadCellReply) ProtoReflect() protoreflect.Message {
+func (x *CloseSheetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1141,37 +1307,28 @@ func (x *ReadCellReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadCellReply.ProtoReflect.Descriptor instead.
-func (*ReadCellReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use CloseSheetRequest.ProtoReflect.Descriptor instead.
+func (*CloseSheetRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ReadCellReply) GetStatus() Status {
-	if x != nil {
-		return x.Status
-	}
-	return Status_OK
-}
-
-func (x *ReadCellReply) GetCell() *Cell {
+func (x *CloseSheetRequest) GetFd() uint64 {
 	if x != nil {
-		return x.Cell
+		return x.Fd
 	}
-	return nil
+	return 0
 }
 
-type WriteCellRequest struct {
+type CloseSheetReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Fd     uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
-	Row    uint32 `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
-	Column uint32 `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
 }
 
-func (x *WriteCellRequest) Reset() {
-	*x = WriteCellRequest{}
+func (x *CloseSheetReply) Reset() {
+	*x = CloseSheetReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1179,13 +1336,13 @@ func (x *WriteCellRequest) Reset() {
 	}
 }
 
-func (x *WriteCellRequest) String() string {
+func (x *CloseSheetReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteCellRequest) ProtoMessage() {}
+func (*CloseSheetReply) ProtoMessage() {}
 
-func (x *WriteCellRequest) ProtoReflect() protoreflect.Message {
+func (x *CloseSheetReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1197,43 +1354,31 @@ func (x *WriteCellRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteCellRequest.ProtoReflect.Descriptor instead.
-func (*WriteCellRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CloseSheetReply.ProtoReflect.Descriptor instead.
+func (*CloseSheetReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *WriteCellRequest) GetFd() uint64 {
-	if x != nil {
-		return x.Fd
-	}
-	return 0
-}
-
-func (x *WriteCellRequest) GetRow() uint32 {
-	if x != nil {
-		return x.Row
-	}
-	return 0
-}
-
-func (x *WriteCellRequest) GetColumn() uint32 {
+func (x *CloseSheetReply) GetStatus() Status {
 	if x != nil {
-		return x.Column
+		return x.Status
 	}
-	return 0
+	return Status_OK
 }
 
-type WriteCellReply struct {
+type StatSheetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
-	Cell   *Cell  `protobuf:"bytes,2,opt,name=cell,proto3" json:"cell,omitempty"`
+	// Types that are assignable to Target:
+	//	*StatSheetRequest_Filename
+	//	*StatSheetRequest_Fd
+	Target isStatSheetRequest_Target `protobuf_oneof:"target"`
 }
 
-func (x *WriteCellReply) Reset() {
-	*x = WriteCellReply{}
+func (x *StatSheetRequest) Reset() {
+	*x = StatSheetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1241,13 +1386,13 @@ func (x *WriteCellReply) Reset() {
 	}
 }
 
-func (x *WriteCellReply) String() string {
+func (x *StatSheetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteCellReply) ProtoMessage() {}
+func (*StatSheetRequest) ProtoMessage() {}
 
-func (x *WriteCellReply) ProtoReflect() protoreflect.Message {
+func (x *StatSheetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1259,38 +1404,72 @@ func (x *WriteCellReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteCellReply.ProtoReflect.Descriptor instead.
-func (*WriteCellReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use StatSheetRequest.ProtoReflect.Descriptor instead.
+func (*StatSheetRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *WriteCellReply) GetStatus() Status {
-	if x != nil {
-		return x.Status
+func (m *StatSheetRequest) GetTarget() isStatSheetRequest_Target {
+	if m != nil {
+		return m.Target
 	}
-	return Status_OK
+	return nil
 }
 
-func (x *WriteCellReply) GetCell() *Cell {
-	if x != nil {
-		return x.Cell
+func (x *StatSheetRequest) GetFilename() string {
+	if x, ok := x.GetTarget().(*StatSheetRequest_Filename); ok {
+		return x.Filename
 	}
-	return nil
+	return ""
 }
 
-type ReadChunkRequest struct {
+func (x *StatSheetRequest) GetFd() uint64 {
+	if x, ok := x.GetTarget().(*StatSheetRequest_Fd); ok {
+		return x.Fd
+	}
+	return 0
+}
+
+type isStatSheetRequest_Target interface {
+	isStatSheetRequest_Target()
+}
+
+type StatSheetRequest_Filename struct {
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3,oneof"`
+}
+
+type StatSheetRequest_Fd struct {
+	Fd uint64 `protobuf:"varint,2,opt,name=fd,proto3,oneof"`
+}
+
+func (*StatSheetRequest_Filename) isStatSheetRequest_Target() {}
+
+func (*StatSheetRequest_Fd) isStatSheetRequest_Target() {}
+
+type SheetStat struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Offset  uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Size    uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
-	Version uint64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
-}
-
-func (x *ReadChunkRequest) Reset() {
-	*x = ReadChunkRequest{}
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Cells    uint64 `protobuf:"varint,2,opt,name=cells,proto3" json:"cells,omitempty"`
+	Chunks   uint64 `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	// Bytes of all chunks allocated to the sheet.
+	AllocatedBytes uint64 `protobuf:"varint,4,opt,name=allocated_bytes,json=allocatedBytes,proto3" json:"allocated_bytes,omitempty"`
+	// Bytes of data written to all cells in the sheet.
+	UsedBytes uint64 `protobuf:"varint,5,opt,name=used_bytes,json=usedBytes,proto3" json:"used_bytes,omitempty"`
+	// Distinct datanode groups storing chunks of the sheet.
+	Datanodes []string `protobuf:"bytes,6,rep,name=datanodes,proto3" json:"datanodes,omitempty"`
+	// Timestamps are unix time in nanoseconds.
+	CreatedAt  int64  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ModifiedAt int64  `protobuf:"varint,8,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	OpenFds    uint64 `protobuf:"varint,9,opt,name=open_fds,json=openFds,proto3" json:"open_fds,omitempty"`
+	Recycled   bool   `protobuf:"varint,10,opt,name=recycled,proto3" json:"recycled,omitempty"`
+	RecycledAt int64  `protobuf:"varint,11,opt,name=recycled_at,json=recycledAt,proto3" json:"recycled_at,omitempty"`
+}
+
+func (x *SheetStat) Reset() {
+	*x = SheetStat{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1298,13 +1477,13 @@ func (x *ReadChunkRequest) Reset() {
 	}
 }
 
-func (x *ReadChunkRequest) String() string {
+func (x *SheetStat) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadChunkRequest) ProtoMessage() {}
+func (*SheetStat) ProtoMessage() {}
 
-func (x *ReadChunkRequest) ProtoReflect() protoreflect.Message {
+func (x *SheetStat) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1316,51 +1495,99 @@ func (x *ReadChunkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadChunkRequest.ProtoReflect.Descriptor instead.
-func (*ReadChunkRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SheetStat.ProtoReflect.Descriptor instead.
+func (*SheetStat) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *ReadChunkRequest) GetId() uint64 {
+func (x *SheetStat) GetFilename() string {
 	if x != nil {
-		return x.Id
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *SheetStat) GetCells() uint64 {
+	if x != nil {
+		return x.Cells
 	}
 	return 0
 }
 
-func (x *ReadChunkRequest) GetOffset() uint64 {
+func (x *SheetStat) GetChunks() uint64 {
 	if x != nil {
-		return x.Offset
+		return x.Chunks
 	}
 	return 0
 }
 
-func (x *ReadChunkRequest) GetSize() uint64 {
+func (x *SheetStat) GetAllocatedBytes() uint64 {
 	if x != nil {
-		return x.Size
+		return x.AllocatedBytes
 	}
 	return 0
 }
 
-func (x *ReadChunkRequest) GetVersion() uint64 {
+func (x *SheetStat) GetUsedBytes() uint64 {
 	if x != nil {
-		return x.Version
+		return x.UsedBytes
 	}
 	return 0
 }
 
-type ReadChunkReply struct {
+func (x *SheetStat) GetDatanodes() []string {
+	if x != nil {
+		return x.Datanodes
+	}
+	return nil
+}
+
+func (x *SheetStat) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *SheetStat) GetModifiedAt() int64 {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return 0
+}
+
+func (x *SheetStat) GetOpenFds() uint64 {
+	if x != nil {
+		return x.OpenFds
+	}
+	return 0
+}
+
+func (x *SheetStat) GetRecycled() bool {
+	if x != nil {
+		return x.Recycled
+	}
+	return false
+}
+
+func (x *SheetStat) GetRecycledAt() int64 {
+	if x != nil {
+		return x.RecycledAt
+	}
+	return 0
+}
+
+type StatSheetReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status  Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
-	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
-	Data    []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Status Status     `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Stat   *SheetStat `protobuf:"bytes,2,opt,name=stat,proto3" json:"stat,omitempty"`
 }
 
-func (x *ReadChunkReply) Reset() {
-	*x = ReadChunkReply{}
+func (x *StatSheetReply) Reset() {
+	*x = StatSheetReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1368,13 +1595,13 @@ func (x *ReadChunkReply) Reset() {
 	}
 }
 
-func (x *ReadChunkReply) String() string {
+func (x *StatSheetReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadChunkReply) ProtoMessage() {}
+func (*StatSheetReply) ProtoMessage() {}
 
-func (x *ReadChunkReply) ProtoReflect() protoreflect.Message {
+func (x *StatSheetReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1386,48 +1613,38 @@ func (x *ReadChunkReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadChunkReply.ProtoReflect.Descriptor instead.
-func (*ReadChunkReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use StatSheetReply.ProtoReflect.Descriptor instead.
+func (*StatSheetReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *ReadChunkReply) GetStatus() Status {
+func (x *StatSheetReply) GetStatus() Status {
 	if x != nil {
 		return x.Status
 	}
 	return Status_OK
 }
 
-func (x *ReadChunkReply) GetVersion() uint64 {
-	if x != nil {
-		return x.Version
-	}
-	return 0
-}
-
-func (x *ReadChunkReply) GetData() []byte {
+func (x *StatSheetReply) GetStat() *SheetStat {
 	if x != nil {
-		return x.Data
+		return x.Stat
 	}
 	return nil
 }
 
-type WriteChunkRequest struct {
+// Cells of tab are read, or cells of all tabs if all_tabs is set.
+type ReadSheetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Offset     uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Size       uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
-	TargetSize uint64 `protobuf:"varint,4,opt,name=target_size,json=targetSize,proto3" json:"target_size,omitempty"`
-	Version    uint64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
-	Padding    string `protobuf:"bytes,6,opt,name=padding,proto3" json:"padding,omitempty"`
-	Data       []byte `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+	Fd      uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Tab     uint32 `protobuf:"varint,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	AllTabs bool   `protobuf:"varint,3,opt,name=all_tabs,json=allTabs,proto3" json:"all_tabs,omitempty"`
 }
 
-func (x *WriteChunkRequest) Reset() {
-	*x = WriteChunkRequest{}
+func (x *ReadSheetRequest) Reset() {
+	*x = ReadSheetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1435,13 +1652,13 @@ func (x *WriteChunkRequest) Reset() {
 	}
 }
 
-func (x *WriteChunkRequest) String() string {
+func (x *ReadSheetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteChunkRequest) ProtoMessage() {}
+func (*ReadSheetRequest) ProtoMessage() {}
 
-func (x *WriteChunkRequest) ProtoReflect() protoreflect.Message {
+func (x *ReadSheetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1453,70 +1670,45 @@ func (x *WriteChunkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteChunkRequest.ProtoReflect.Descriptor instead.
-func (*WriteChunkRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadSheetRequest.ProtoReflect.Descriptor instead.
+func (*ReadSheetRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *WriteChunkRequest) GetId() uint64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *WriteChunkRequest) GetOffset() uint64 {
-	if x != nil {
-		return x.Offset
-	}
-	return 0
-}
-
-func (x *WriteChunkRequest) GetSize() uint64 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
-}
-
-func (x *WriteChunkRequest) GetTargetSize() uint64 {
+func (x *ReadSheetRequest) GetFd() uint64 {
 	if x != nil {
-		return x.TargetSize
+		return x.Fd
 	}
 	return 0
 }
 
-func (x *WriteChunkRequest) GetVersion() uint64 {
+func (x *ReadSheetRequest) GetTab() uint32 {
 	if x != nil {
-		return x.Version
+		return x.Tab
 	}
 	return 0
 }
 
-func (x *WriteChunkRequest) GetPadding() string {
-	if x != nil {
-		return x.Padding
-	}
-	return ""
-}
-
-func (x *WriteChunkRequest) GetData() []byte {
+func (x *ReadSheetRequest) GetAllTabs() bool {
 	if x != nil {
-		return x.Data
+		return x.AllTabs
 	}
-	return nil
+	return false
 }
 
-type WriteChunkReply struct {
+type ReadSheetReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Status Status   `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Chunks []*Chunk `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	// All tabs in order, only set if all_tabs is set in the request.
+	Tabs []*Tab `protobuf:"bytes,3,rep,name=tabs,proto3" json:"tabs,omitempty"`
 }
 
-func (x *WriteChunkReply) Reset() {
-	*x = WriteChunkReply{}
+func (x *ReadSheetReply) Reset() {
+	*x = ReadSheetReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1524,13 +1716,13 @@ func (x *WriteChunkReply) Reset() {
 	}
 }
 
-func (x *WriteChunkReply) String() string {
+func (x *ReadSheetReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteChunkReply) ProtoMessage() {}
+func (*ReadSheetReply) ProtoMessage() {}
 
-func (x *WriteChunkReply) ProtoReflect() protoreflect.Message {
+func (x *ReadSheetReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1542,28 +1734,42 @@ func (x *WriteChunkReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteChunkReply.ProtoReflect.Descriptor instead.
-func (*WriteChunkReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadSheetReply.ProtoReflect.Descriptor instead.
+func (*ReadSheetReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *WriteChunkReply) GetStatus() Status {
+func (x *ReadSheetReply) GetStatus() Status {
 	if x != nil {
 		return x.Status
 	}
 	return Status_OK
 }
 
-type DeleteChunkRequest struct {
+func (x *ReadSheetReply) GetChunks() []*Chunk {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *ReadSheetReply) GetTabs() []*Tab {
+	if x != nil {
+		return x.Tabs
+	}
+	return nil
+}
+
+type RecycleSheetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
 }
 
-func (x *DeleteChunkRequest) Reset() {
-	*x = DeleteChunkRequest{}
+func (x *RecycleSheetRequest) Reset() {
+	*x = RecycleSheetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1571,13 +1777,13 @@ func (x *DeleteChunkRequest) Reset() {
 	}
 }
 
-func (x *DeleteChunkRequest) String() string {
+func (x *RecycleSheetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteChunkRequest) ProtoMessage() {}
+func (*RecycleSheetRequest) ProtoMessage() {}
 
-func (x *DeleteChunkRequest) ProtoReflect() protoreflect.Message {
+func (x *RecycleSheetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1589,19 +1795,19 @@ func (x *DeleteChunkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteChunkRequest.ProtoReflect.Descriptor instead.
-func (*DeleteChunkRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RecycleSheetRequest.ProtoReflect.Descriptor instead.
+func (*RecycleSheetRequest) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *DeleteChunkRequest) GetId() uint64 {
+func (x *RecycleSheetRequest) GetFilename() string {
 	if x != nil {
-		return x.Id
+		return x.Filename
 	}
-	return 0
+	return ""
 }
 
-type DeleteChunkReply struct {
+type RecycleSheetReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1609,8 +1815,8 @@ type DeleteChunkReply struct {
 	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
 }
 
-func (x *DeleteChunkReply) Reset() {
-	*x = DeleteChunkReply{}
+func (x *RecycleSheetReply) Reset() {
+	*x = RecycleSheetReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protocol_sheetfs_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1618,13 +1824,13 @@ func (x *DeleteChunkReply) Reset() {
 	}
 }
 
-func (x *DeleteChunkReply) String() string {
+func (x *RecycleSheetReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteChunkReply) ProtoMessage() {}
+func (*RecycleSheetReply) ProtoMessage() {}
 
-func (x *DeleteChunkReply) ProtoReflect() protoreflect.Message {
+func (x *RecycleSheetReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protocol_sheetfs_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1636,331 +1842,4451 @@ func (x *DeleteChunkReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteChunkReply.ProtoReflect.Descriptor instead.
-func (*DeleteChunkReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use RecycleSheetReply.ProtoReflect.Descriptor instead.
+func (*RecycleSheetReply) Descriptor() ([]byte, []int) {
 	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *DeleteChunkReply) GetStatus() Status {
+func (x *RecycleSheetReply) GetStatus() Status {
 	if x != nil {
 		return x.Status
 	}
 	return Status_OK
 }
 
-var File_protocol_sheetfs_proto protoreflect.FileDescriptor
+type ResumeSheetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_protocol_sheetfs_proto_rawDesc = []byte{
-	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x68, 0x65, 0x65, 0x74,
-	0x66, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
-	0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2d, 0x0a, 0x17, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x22, 0x40, 0x0a, 0x15, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x30, 0x0a, 0x12, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x4b, 0x0a,
-	0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x22, 0x30, 0x0a, 0x12, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3b, 0x0a, 0x10,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x2e, 0x0a, 0x10, 0x4f, 0x70, 0x65,
-	0x6e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x6c, 0x0a, 0x05, 0x43, 0x68, 0x75,
-	0x6e, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x6f, 0x6c, 0x64,
-	0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x68, 0x6f,
-	0x6c, 0x64, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x22, 0x49, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x6e, 0x53,
-	0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65,
-	0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
-	0x66, 0x64, 0x22, 0x22, 0x0a, 0x10, 0x52, 0x65, 0x61, 0x64, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x22, 0x61, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x68,
-	0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
-	0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x68, 0x75, 0x6e,
-	0x6b, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x22, 0x31, 0x0a, 0x13, 0x52, 0x65, 0x63,
-	0x79, 0x63, 0x6c, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3c, 0x0a, 0x11,
-	0x52, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x30, 0x0a, 0x12, 0x52, 0x65,
-	0x73, 0x75, 0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3b, 0x0a, 0x10,
-	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x3f, 0x0a, 0x05, 0x53, 0x68, 0x65,
-	0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x22, 0x62, 0x0a, 0x0f, 0x4c, 0x69,
-	0x73, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e,
-	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x73, 0x68, 0x65, 0x65, 0x74, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73,
-	0x2e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x06, 0x73, 0x68, 0x65, 0x65, 0x74, 0x73, 0x22, 0x58,
-	0x0a, 0x04, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x24, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
-	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06,
-	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66,
-	0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x4b, 0x0a, 0x0f, 0x52, 0x65, 0x61, 0x64,
-	0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x72,
-	0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x16, 0x0a,
-	0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63,
-	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x22, 0x5b, 0x0a, 0x0d, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c,
-	0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x21, 0x0a, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x04, 0x63, 0x65,
-	0x6c, 0x6c, 0x22, 0x4c, 0x0a, 0x10, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75,
-	0x6d, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
-	0x22, 0x5c, 0x0a, 0x0e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x04, 0x63,
-	0x65, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x68, 0x65, 0x65,
-	0x74, 0x66, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x22, 0x68,
-	0x0a, 0x10, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
-	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x67, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64,
-	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65,
-	0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x22, 0xb8, 0x01, 0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
-	0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x69,
-	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x53, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18,
-	0x0a, 0x07, 0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3a, 0x0a, 0x0f,
-	0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x24, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3b,
-	0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2a, 0x59, 0x0a, 0x06, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12, 0x09, 0x0a,
-	0x05, 0x45, 0x78, 0x69, 0x73, 0x74, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x6f, 0x74, 0x46,
-	0x6f, 0x75, 0x6e, 0x64, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x72, 0x6f, 0x6e, 0x67, 0x56,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x61,
-	0x6c, 0x69, 0x64, 0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c,
-	0x61, 0x62, 0x6c, 0x65, 0x10, 0x05, 0x32, 0xce, 0x05, 0x0a, 0x0a, 0x4d, 0x61, 0x73, 0x74, 0x65,
-	0x72, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x56, 0x0a, 0x10, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x2e, 0x73, 0x68, 0x65, 0x65,
-	0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
-	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x68,
-	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x61,
-	0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a,
-	0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x12, 0x1b, 0x2e, 0x73,
-	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65,
-	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65,
-	0x74, 0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x53, 0x68, 0x65, 0x65, 0x74, 0x12, 0x1b, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x41, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x73,
-	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x68, 0x65, 0x65, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
-	0x73, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x53, 0x68, 0x65, 0x65, 0x74, 0x12,
-	0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x68,
-	0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65,
-	0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65,
-	0x53, 0x68, 0x65, 0x65, 0x74, 0x12, 0x1c, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
-	0x52, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65,
-	0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x47, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74,
-	0x12, 0x1b, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d,
-	0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x53, 0x68,
-	0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x4c, 0x69,
-	0x73, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x73, 0x12, 0x0e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
-	0x66, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
-	0x66, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x73, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c,
-	0x12, 0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43,
-	0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x73, 0x68, 0x65,
-	0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65, 0x6c,
-	0x6c, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73,
-	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0xdc, 0x01, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61,
-	0x4e, 0x6f, 0x64, 0x65, 0x12, 0x41, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e,
-	0x6b, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64,
-	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73,
-	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65,
-	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x1a, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
-	0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a,
-	0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x1b, 0x2e, 0x73,
-	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x75,
-	0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65,
-	0x74, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x6f, 0x75, 0x72, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x2f,
-	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
-	0x3b, 0x66, 0x73, 0x5f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
 }
 
-var (
-	file_protocol_sheetfs_proto_rawDescOnce sync.Once
-	file_protocol_sheetfs_proto_rawDescData = file_protocol_sheetfs_proto_rawDesc
-)
+func (x *ResumeSheetRequest) Reset() {
+	*x = ResumeSheetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_protocol_sheetfs_proto_rawDescGZIP() []byte {
-	file_protocol_sheetfs_proto_rawDescOnce.Do(func() {
-		file_protocol_sheetfs_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocol_sheetfs_proto_rawDescData)
-	})
-	return file_protocol_sheetfs_proto_rawDescData
+func (x *ResumeSheetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_protocol_sheetfs_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_protocol_sheetfs_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
-var file_protocol_sheetfs_proto_goTypes = []interface{}{
-	(Status)(0),                     // 0: sheetfs.Status
-	(*Empty)(nil),                   // 1: sheetfs.Empty
-	(*RegisterDataNodeRequest)(nil), // 2: sheetfs.RegisterDataNodeRequest
-	(*RegisterDataNodeReply)(nil),   // 3: sheetfs.RegisterDataNodeReply
-	(*CreateSheetRequest)(nil),      // 4: sheetfs.CreateSheetRequest
-	(*CreateSheetReply)(nil),        // 5: sheetfs.CreateSheetReply
-	(*DeleteSheetRequest)(nil),      // 6: sheetfs.DeleteSheetRequest
-	(*DeleteSheetReply)(nil),        // 7: sheetfs.DeleteSheetReply
-	(*OpenSheetRequest)(nil),        // 8: sheetfs.OpenSheetRequest
-	(*Chunk)(nil),                   // 9: sheetfs.Chunk
-	(*OpenSheetReply)(nil),          // 10: sheetfs.OpenSheetReply
-	(*ReadSheetRequest)(nil),        // 11: sheetfs.ReadSheetRequest
-	(*ReadSheetReply)(nil),          // 12: sheetfs.ReadSheetReply
-	(*RecycleSheetRequest)(nil),     // 13: sheetfs.RecycleSheetRequest
-	(*RecycleSheetReply)(nil),       // 14: sheetfs.RecycleSheetReply
-	(*ResumeSheetRequest)(nil),      // 15: sheetfs.ResumeSheetRequest
-	(*ResumeSheetReply)(nil),        // 16: sheetfs.ResumeSheetReply
-	(*Sheet)(nil),                   // 17: sheetfs.Sheet
-	(*ListSheetsReply)(nil),         // 18: sheetfs.ListSheetsReply
-	(*Cell)(nil),                    // 19: sheetfs.Cell
-	(*ReadCellRequest)(nil),         // 20: sheetfs.ReadCellRequest
-	(*ReadCellReply)(nil),           // 21: sheetfs.ReadCellReply
-	(*WriteCellRequest)(nil),        // 22: sheetfs.WriteCellRequest
-	(*WriteCellReply)(nil),          // 23: sheetfs.WriteCellReply
-	(*ReadChunkRequest)(nil),        // 24: sheetfs.ReadChunkRequest
-	(*ReadChunkReply)(nil),          // 25: sheetfs.ReadChunkReply
-	(*WriteChunkRequest)(nil),       // 26: sheetfs.WriteChunkRequest
-	(*WriteChunkReply)(nil),         // 27: sheetfs.WriteChunkReply
-	(*DeleteChunkRequest)(nil),      // 28: sheetfs.DeleteChunkRequest
-	(*DeleteChunkReply)(nil),        // 29: sheetfs.DeleteChunkReply
+func (*ResumeSheetRequest) ProtoMessage() {}
+
+func (x *ResumeSheetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_protocol_sheetfs_proto_depIdxs = []int32{
-	0,  // 0: sheetfs.RegisterDataNodeReply.status:type_name -> sheetfs.Status
-	0,  // 1: sheetfs.CreateSheetReply.status:type_name -> sheetfs.Status
-	0,  // 2: sheetfs.DeleteSheetReply.status:type_name -> sheetfs.Status
-	0,  // 3: sheetfs.OpenSheetReply.status:type_name -> sheetfs.Status
-	0,  // 4: sheetfs.ReadSheetReply.status:type_name -> sheetfs.Status
-	9,  // 5: sheetfs.ReadSheetReply.chunks:type_name -> sheetfs.Chunk
-	0,  // 6: sheetfs.RecycleSheetReply.status:type_name -> sheetfs.Status
-	0,  // 7: sheetfs.ResumeSheetReply.status:type_name -> sheetfs.Status
-	0,  // 8: sheetfs.ListSheetsReply.status:type_name -> sheetfs.Status
-	17, // 9: sheetfs.ListSheetsReply.sheets:type_name -> sheetfs.Sheet
-	9,  // 10: sheetfs.Cell.chunk:type_name -> sheetfs.Chunk
-	0,  // 11: sheetfs.ReadCellReply.status:type_name -> sheetfs.Status
-	19, // 12: sheetfs.ReadCellReply.cell:type_name -> sheetfs.Cell
-	0,  // 13: sheetfs.WriteCellReply.status:type_name -> sheetfs.Status
-	19, // 14: sheetfs.WriteCellReply.cell:type_name -> sheetfs.Cell
-	0,  // 15: sheetfs.ReadChunkReply.status:type_name -> sheetfs.Status
-	0,  // 16: sheetfs.WriteChunkReply.status:type_name -> sheetfs.Status
-	0,  // 17: sheetfs.DeleteChunkReply.status:type_name -> sheetfs.Status
-	2,  // 18: sheetfs.MasterNode.RegisterDataNode:input_type -> sheetfs.RegisterDataNodeRequest
-	4,  // 19: sheetfs.MasterNode.CreateSheet:input_type -> sheetfs.CreateSheetRequest
-	6,  // 20: sheetfs.MasterNode.DeleteSheet:input_type -> sheetfs.DeleteSheetRequest
-	8,  // 21: sheetfs.MasterNode.OpenSheet:input_type -> sheetfs.OpenSheetRequest
-	11, // 22: sheetfs.MasterNode.ReadSheet:input_type -> sheetfs.ReadSheetRequest
-	13, // 23: sheetfs.MasterNode.RecycleSheet:input_type -> sheetfs.RecycleSheetRequest
-	15, // 24: sheetfs.MasterNode.ResumeSheet:input_type -> sheetfs.ResumeSheetRequest
-	1,  // 25: sheetfs.MasterNode.ListSheets:input_type -> sheetfs.Empty
-	20, // 26: sheetfs.MasterNode.ReadCell:input_type -> sheetfs.ReadCellRequest
-	22, // 27: sheetfs.MasterNode.WriteCell:input_type -> sheetfs.WriteCellRequest
-	24, // 28: sheetfs.DataNode.ReadChunk:input_type -> sheetfs.ReadChunkRequest
-	26, // 29: sheetfs.DataNode.WriteChunk:input_type -> sheetfs.WriteChunkRequest
-	28, // 30: sheetfs.DataNode.DeleteChunk:input_type -> sheetfs.DeleteChunkRequest
-	3,  // 31: sheetfs.MasterNode.RegisterDataNode:output_type -> sheetfs.RegisterDataNodeReply
-	5,  // 32: sheetfs.MasterNode.CreateSheet:output_type -> sheetfs.CreateSheetReply
-	7,  // 33: sheetfs.MasterNode.DeleteSheet:output_type -> sheetfs.DeleteSheetReply
-	10, // 34: sheetfs.MasterNode.OpenSheet:output_type -> sheetfs.OpenSheetReply
-	12, // 35: sheetfs.MasterNode.ReadSheet:output_type -> sheetfs.ReadSheetReply
-	14, // 36: sheetfs.MasterNode.RecycleSheet:output_type -> sheetfs.RecycleSheetReply
-	16, // 37: sheetfs.MasterNode.ResumeSheet:output_type -> sheetfs.ResumeSheetReply
-	18, // 38: sheetfs.MasterNode.ListSheets:output_type -> sheetfs.ListSheetsReply
-	21, // 39: sheetfs.MasterNode.ReadCell:output_type -> sheetfs.ReadCellReply
-	23, // 40: sheetfs.MasterNode.WriteCell:output_type -> sheetfs.WriteCellReply
-	25, // 41: sheetfs.DataNode.ReadChunk:output_type -> sheetfs.ReadChunkReply
-	27, // 42: sheetfs.DataNode.WriteChunk:output_type -> sheetfs.WriteChunkReply
-	29, // 43: sheetfs.DataNode.DeleteChunk:output_type -> sheetfs.DeleteChunkReply
-	31, // [31:44] is the sub-list for method output_type
-	18, // [18:31] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+
+// Deprecated: Use ResumeSheetRequest.ProtoReflect.Descriptor instead.
+func (*ResumeSheetRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{29}
 }
 
-func init() { file_protocol_sheetfs_proto_init() }
-func file_protocol_sheetfs_proto_init() {
-	if File_protocol_sheetfs_proto != nil {
-		return
+func (x *ResumeSheetRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_protocol_sheetfs_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
+	return ""
+}
+
+type ResumeSheetReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *ResumeSheetReply) Reset() {
+	*x = ResumeSheetReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeSheetReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeSheetReply) ProtoMessage() {}
+
+func (x *ResumeSheetReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeSheetReply.ProtoReflect.Descriptor instead.
+func (*ResumeSheetReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ResumeSheetReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+type Sheet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Recycled bool   `protobuf:"varint,2,opt,name=recycled,proto3" json:"recycled,omitempty"`
+	// Unix timestamp in nanoseconds of the last modification.
+	ModifiedAt int64 `protobuf:"varint,3,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+}
+
+func (x *Sheet) Reset() {
+	*x = Sheet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sheet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sheet) ProtoMessage() {}
+
+func (x *Sheet) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sheet.ProtoReflect.Descriptor instead.
+func (*Sheet) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Sheet) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Sheet) GetRecycled() bool {
+	if x != nil {
+		return x.Recycled
+	}
+	return false
+}
+
+func (x *Sheet) GetModifiedAt() int64 {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return 0
+}
+
+type ListSheetsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the directory, sheets in all of its subdirectories are listed too.
+	// An empty path means the root directory.
+	Dir string `protobuf:"bytes,1,opt,name=dir,proto3" json:"dir,omitempty"`
+	// Only sheets whose filename starts with prefix are listed.
+	Prefix   string         `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Recycled RecycledFilter `protobuf:"varint,3,opt,name=recycled,proto3,enum=sheetfs.RecycledFilter" json:"recycled,omitempty"`
+	Order    SheetOrder     `protobuf:"varint,4,opt,name=order,proto3,enum=sheetfs.SheetOrder" json:"order,omitempty"`
+	// Max number of sheets in a reply, 0 for the default page size.
+	PageSize uint32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous reply, empty for the first page.
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListSheetsRequest) Reset() {
+	*x = ListSheetsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSheetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSheetsRequest) ProtoMessage() {}
+
+func (x *ListSheetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSheetsRequest.ProtoReflect.Descriptor instead.
+func (*ListSheetsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListSheetsRequest) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+func (x *ListSheetsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListSheetsRequest) GetRecycled() RecycledFilter {
+	if x != nil {
+		return x.Recycled
+	}
+	return RecycledFilter_ALL
+}
+
+func (x *ListSheetsRequest) GetOrder() SheetOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SheetOrder_BY_NAME
+}
+
+func (x *ListSheetsRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSheetsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSheetsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status   `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Sheets []*Sheet `protobuf:"bytes,2,rep,name=sheets,proto3" json:"sheets,omitempty"`
+	// Empty if there are no more sheets.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListSheetsReply) Reset() {
+	*x = ListSheetsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSheetsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSheetsReply) ProtoMessage() {}
+
+func (x *ListSheetsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSheetsReply.ProtoReflect.Descriptor instead.
+func (*ListSheetsReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListSheetsReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *ListSheetsReply) GetSheets() []*Sheet {
+	if x != nil {
+		return x.Sheets
+	}
+	return nil
+}
+
+func (x *ListSheetsReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type MkDirRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *MkDirRequest) Reset() {
+	*x = MkDirRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MkDirRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MkDirRequest) ProtoMessage() {}
+
+func (x *MkDirRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MkDirRequest.ProtoReflect.Descriptor instead.
+func (*MkDirRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MkDirRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type MkDirReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *MkDirReply) Reset() {
+	*x = MkDirReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MkDirReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MkDirReply) ProtoMessage() {}
+
+func (x *MkDirReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MkDirReply.ProtoReflect.Descriptor instead.
+func (*MkDirReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MkDirReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+type RmDirRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Remove all sheets and subdirectories in the directory too, otherwise the
+	// directory must be empty.
+	Recursive bool `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
+}
+
+func (x *RmDirRequest) Reset() {
+	*x = RmDirRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RmDirRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RmDirRequest) ProtoMessage() {}
+
+func (x *RmDirRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RmDirRequest.ProtoReflect.Descriptor instead.
+func (*RmDirRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RmDirRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RmDirRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+type RmDirReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *RmDirReply) Reset() {
+	*x = RmDirReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RmDirReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RmDirReply) ProtoMessage() {}
+
+func (x *RmDirReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RmDirReply.ProtoReflect.Descriptor instead.
+func (*RmDirReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RmDirReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+type ListDirRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *ListDirRequest) Reset() {
+	*x = ListDirRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDirRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDirRequest) ProtoMessage() {}
+
+func (x *ListDirRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDirRequest.ProtoReflect.Descriptor instead.
+func (*ListDirRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListDirRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListDirReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	// Full paths of subdirectories directly in the directory.
+	Dirs   []string `protobuf:"bytes,2,rep,name=dirs,proto3" json:"dirs,omitempty"`
+	Sheets []*Sheet `protobuf:"bytes,3,rep,name=sheets,proto3" json:"sheets,omitempty"`
+}
+
+func (x *ListDirReply) Reset() {
+	*x = ListDirReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDirReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDirReply) ProtoMessage() {}
+
+func (x *ListDirReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDirReply.ProtoReflect.Descriptor instead.
+func (*ListDirReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListDirReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *ListDirReply) GetDirs() []string {
+	if x != nil {
+		return x.Dirs
+	}
+	return nil
+}
+
+func (x *ListDirReply) GetSheets() []*Sheet {
+	if x != nil {
+		return x.Sheets
+	}
+	return nil
+}
+
+type Cell struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chunk  *Chunk `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size   uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Data beyond size is stored in overflow chunks in order, a whole chunk per each.
+	Overflow []*Chunk `protobuf:"bytes,4,rep,name=overflow,proto3" json:"overflow,omitempty"`
+	// Length of data written to the cell, the rest of the cell is padding.
+	Length uint64 `protobuf:"varint,5,opt,name=length,proto3" json:"length,omitempty"`
+	// Slots of a chunk are versioned separately, the slot of the cell should be read
+	// or written at this version rather than the version of its chunk. Versions of
+	// overflow chunks are those of their only slots.
+	Version uint64 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *Cell) Reset() {
+	*x = Cell{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cell) ProtoMessage() {}
+
+func (x *Cell) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cell.ProtoReflect.Descriptor instead.
+func (*Cell) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Cell) GetChunk() *Chunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *Cell) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Cell) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Cell) GetOverflow() []*Chunk {
+	if x != nil {
+		return x.Overflow
+	}
+	return nil
+}
+
+func (x *Cell) GetLength() uint64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *Cell) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ReadCellRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd     uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Row    uint32 `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	Column uint32 `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	Tab    uint32 `protobuf:"varint,4,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *ReadCellRequest) Reset() {
+	*x = ReadCellRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadCellRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadCellRequest) ProtoMessage() {}
+
+func (x *ReadCellRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadCellRequest.ProtoReflect.Descriptor instead.
+func (*ReadCellRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ReadCellRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *ReadCellRequest) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ReadCellRequest) GetColumn() uint32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *ReadCellRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type ReadCellReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Cell   *Cell  `protobuf:"bytes,2,opt,name=cell,proto3" json:"cell,omitempty"`
+}
+
+func (x *ReadCellReply) Reset() {
+	*x = ReadCellReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadCellReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadCellReply) ProtoMessage() {}
+
+func (x *ReadCellReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadCellReply.ProtoReflect.Descriptor instead.
+func (*ReadCellReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ReadCellReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *ReadCellReply) GetCell() *Cell {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+// Cells in rows [row_start, row_end) and columns [column_start, column_end) are read.
+type ReadCellsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd          uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	RowStart    uint32 `protobuf:"varint,2,opt,name=row_start,json=rowStart,proto3" json:"row_start,omitempty"`
+	RowEnd      uint32 `protobuf:"varint,3,opt,name=row_end,json=rowEnd,proto3" json:"row_end,omitempty"`
+	ColumnStart uint32 `protobuf:"varint,4,opt,name=column_start,json=columnStart,proto3" json:"column_start,omitempty"`
+	ColumnEnd   uint32 `protobuf:"varint,5,opt,name=column_end,json=columnEnd,proto3" json:"column_end,omitempty"`
+	Tab         uint32 `protobuf:"varint,6,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *ReadCellsRequest) Reset() {
+	*x = ReadCellsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadCellsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadCellsRequest) ProtoMessage() {}
+
+func (x *ReadCellsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadCellsRequest.ProtoReflect.Descriptor instead.
+func (*ReadCellsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReadCellsRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *ReadCellsRequest) GetRowStart() uint32 {
+	if x != nil {
+		return x.RowStart
+	}
+	return 0
+}
+
+func (x *ReadCellsRequest) GetRowEnd() uint32 {
+	if x != nil {
+		return x.RowEnd
+	}
+	return 0
+}
+
+func (x *ReadCellsRequest) GetColumnStart() uint32 {
+	if x != nil {
+		return x.ColumnStart
+	}
+	return 0
+}
+
+func (x *ReadCellsRequest) GetColumnEnd() uint32 {
+	if x != nil {
+		return x.ColumnEnd
+	}
+	return 0
+}
+
+func (x *ReadCellsRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type ReadCellsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	// Chunks holding cells in the range, with extents of those cells only.
+	Chunks []*Chunk `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks,omitempty"`
+}
+
+func (x *ReadCellsReply) Reset() {
+	*x = ReadCellsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadCellsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadCellsReply) ProtoMessage() {}
+
+func (x *ReadCellsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadCellsReply.ProtoReflect.Descriptor instead.
+func (*ReadCellsReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ReadCellsReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *ReadCellsReply) GetChunks() []*Chunk {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+type WriteCellRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd     uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Row    uint32 `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	Column uint32 `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	// Bytes of data to write, 0 if it fits in a slot anyway.
+	Size uint64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Tab  uint32 `protobuf:"varint,5,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *WriteCellRequest) Reset() {
+	*x = WriteCellRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteCellRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteCellRequest) ProtoMessage() {}
+
+func (x *WriteCellRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteCellRequest.ProtoReflect.Descriptor instead.
+func (*WriteCellRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WriteCellRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *WriteCellRequest) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *WriteCellRequest) GetColumn() uint32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *WriteCellRequest) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *WriteCellRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type WriteCellReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Cell   *Cell  `protobuf:"bytes,2,opt,name=cell,proto3" json:"cell,omitempty"`
+	// The old slot of the cell if it has been moved to a bigger slot, which should be
+	// overwritten with padding like a deleted cell. Absent if the cell is not moved,
+	// or the chunk of the old slot has been freed.
+	Vacated *Cell `protobuf:"bytes,3,opt,name=vacated,proto3" json:"vacated,omitempty"`
+}
+
+func (x *WriteCellReply) Reset() {
+	*x = WriteCellReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteCellReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteCellReply) ProtoMessage() {}
+
+func (x *WriteCellReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteCellReply.ProtoReflect.Descriptor instead.
+func (*WriteCellReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *WriteCellReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *WriteCellReply) GetCell() *Cell {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *WriteCellReply) GetVacated() *Cell {
+	if x != nil {
+		return x.Vacated
+	}
+	return nil
+}
+
+type CellWrite struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Row    uint32 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Column uint32 `protobuf:"varint,2,opt,name=column,proto3" json:"column,omitempty"`
+	// Bytes of data to write, 0 if it fits in a slot anyway.
+	Size uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Tab  uint32 `protobuf:"varint,4,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *CellWrite) Reset() {
+	*x = CellWrite{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellWrite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellWrite) ProtoMessage() {}
+
+func (x *CellWrite) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellWrite.ProtoReflect.Descriptor instead.
+func (*CellWrite) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CellWrite) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *CellWrite) GetColumn() uint32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *CellWrite) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CellWrite) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type WriteCellsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	// Writes to different cells.
+	Cells []*CellWrite `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+}
+
+func (x *WriteCellsRequest) Reset() {
+	*x = WriteCellsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteCellsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteCellsRequest) ProtoMessage() {}
+
+func (x *WriteCellsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteCellsRequest.ProtoReflect.Descriptor instead.
+func (*WriteCellsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *WriteCellsRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *WriteCellsRequest) GetCells() []*CellWrite {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+// The version of every chunk involved is increased once for the whole batch, so all
+// slots of cells and vacated slots in a chunk should be written at once.
+type WriteCellsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	// Cells in the order of the request. If status is not OK, cells written before the
+	// failure are returned, which should still be written by the client.
+	Cells []*Cell `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+	// Old slots of moved cells, see WriteCellReply.vacated.
+	Vacated []*Cell `protobuf:"bytes,3,rep,name=vacated,proto3" json:"vacated,omitempty"`
+}
+
+func (x *WriteCellsReply) Reset() {
+	*x = WriteCellsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteCellsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteCellsReply) ProtoMessage() {}
+
+func (x *WriteCellsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteCellsReply.ProtoReflect.Descriptor instead.
+func (*WriteCellsReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *WriteCellsReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *WriteCellsReply) GetCells() []*Cell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *WriteCellsReply) GetVacated() []*Cell {
+	if x != nil {
+		return x.Vacated
+	}
+	return nil
+}
+
+type DeleteCellRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd     uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Row    uint32 `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	Column uint32 `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	Tab    uint32 `protobuf:"varint,4,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *DeleteCellRequest) Reset() {
+	*x = DeleteCellRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCellRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCellRequest) ProtoMessage() {}
+
+func (x *DeleteCellRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCellRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCellRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DeleteCellRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *DeleteCellRequest) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *DeleteCellRequest) GetColumn() uint32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *DeleteCellRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type DeleteCellReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	// The freed slot, which should be overwritten with padding at the version of
+	// its chunk. Absent if the whole chunk has been freed.
+	Cell *Cell `protobuf:"bytes,2,opt,name=cell,proto3" json:"cell,omitempty"`
+}
+
+func (x *DeleteCellReply) Reset() {
+	*x = DeleteCellReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCellReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCellReply) ProtoMessage() {}
+
+func (x *DeleteCellReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCellReply.ProtoReflect.Descriptor instead.
+func (*DeleteCellReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DeleteCellReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *DeleteCellReply) GetCell() *Cell {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+// Rows from row on are shifted down by count, leaving count empty rows.
+type InsertRowsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd    uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Row   uint32 `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	Count uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Tab   uint32 `protobuf:"varint,4,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *InsertRowsRequest) Reset() {
+	*x = InsertRowsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InsertRowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsertRowsRequest) ProtoMessage() {}
+
+func (x *InsertRowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsertRowsRequest.ProtoReflect.Descriptor instead.
+func (*InsertRowsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *InsertRowsRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *InsertRowsRequest) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *InsertRowsRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *InsertRowsRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type InsertRowsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *InsertRowsReply) Reset() {
+	*x = InsertRowsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InsertRowsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsertRowsReply) ProtoMessage() {}
+
+func (x *InsertRowsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsertRowsReply.ProtoReflect.Descriptor instead.
+func (*InsertRowsReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *InsertRowsReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+// Cells in rows [row, row+count) are deleted, and rows below are shifted up by count.
+type DeleteRowsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd    uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Row   uint32 `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	Count uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Tab   uint32 `protobuf:"varint,4,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *DeleteRowsRequest) Reset() {
+	*x = DeleteRowsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRowsRequest) ProtoMessage() {}
+
+func (x *DeleteRowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRowsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRowsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeleteRowsRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *DeleteRowsRequest) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *DeleteRowsRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DeleteRowsRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type DeleteRowsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *DeleteRowsReply) Reset() {
+	*x = DeleteRowsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRowsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRowsReply) ProtoMessage() {}
+
+func (x *DeleteRowsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRowsReply.ProtoReflect.Descriptor instead.
+func (*DeleteRowsReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteRowsReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+// Columns from column on are shifted right by count, leaving count empty columns.
+type InsertColumnsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd     uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Column uint32 `protobuf:"varint,2,opt,name=column,proto3" json:"column,omitempty"`
+	Count  uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Tab    uint32 `protobuf:"varint,4,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *InsertColumnsRequest) Reset() {
+	*x = InsertColumnsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InsertColumnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsertColumnsRequest) ProtoMessage() {}
+
+func (x *InsertColumnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsertColumnsRequest.ProtoReflect.Descriptor instead.
+func (*InsertColumnsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *InsertColumnsRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *InsertColumnsRequest) GetColumn() uint32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *InsertColumnsRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *InsertColumnsRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type InsertColumnsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *InsertColumnsReply) Reset() {
+	*x = InsertColumnsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InsertColumnsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsertColumnsReply) ProtoMessage() {}
+
+func (x *InsertColumnsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsertColumnsReply.ProtoReflect.Descriptor instead.
+func (*InsertColumnsReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *InsertColumnsReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+// Cells in columns [column, column+count) are deleted, and columns on the right are
+// shifted left by count.
+type DeleteColumnsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd     uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Column uint32 `protobuf:"varint,2,opt,name=column,proto3" json:"column,omitempty"`
+	Count  uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Tab    uint32 `protobuf:"varint,4,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *DeleteColumnsRequest) Reset() {
+	*x = DeleteColumnsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteColumnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteColumnsRequest) ProtoMessage() {}
+
+func (x *DeleteColumnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteColumnsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteColumnsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *DeleteColumnsRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *DeleteColumnsRequest) GetColumn() uint32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *DeleteColumnsRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DeleteColumnsRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type DeleteColumnsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *DeleteColumnsReply) Reset() {
+	*x = DeleteColumnsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteColumnsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteColumnsReply) ProtoMessage() {}
+
+func (x *DeleteColumnsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteColumnsReply.ProtoReflect.Descriptor instead.
+func (*DeleteColumnsReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DeleteColumnsReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+type ReadChunkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset  uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size    uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Version uint64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *ReadChunkRequest) Reset() {
+	*x = ReadChunkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadChunkRequest) ProtoMessage() {}
+
+func (x *ReadChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadChunkRequest.ProtoReflect.Descriptor instead.
+func (*ReadChunkRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ReadChunkRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReadChunkRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadChunkRequest) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ReadChunkRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ReadChunkReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Data    []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Versions of all slots overlapping the range read, in order. A chunk is versioned
+	// per every SLOT_SIZE bytes, and only the slot at offset is checked against version
+	// of the request, so readers of multiple slots should check the others themselves.
+	Versions []uint64 `protobuf:"varint,4,rep,packed,name=versions,proto3" json:"versions,omitempty"`
+}
+
+func (x *ReadChunkReply) Reset() {
+	*x = ReadChunkReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadChunkReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadChunkReply) ProtoMessage() {}
+
+func (x *ReadChunkReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadChunkReply.ProtoReflect.Descriptor instead.
+func (*ReadChunkReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ReadChunkReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *ReadChunkReply) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ReadChunkReply) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReadChunkReply) GetVersions() []uint64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type WriteChunkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset     uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size       uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	TargetSize uint64 `protobuf:"varint,4,opt,name=target_size,json=targetSize,proto3" json:"target_size,omitempty"`
+	Version    uint64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Padding    string `protobuf:"bytes,6,opt,name=padding,proto3" json:"padding,omitempty"`
+	Data       []byte `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *WriteChunkRequest) Reset() {
+	*x = WriteChunkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteChunkRequest) ProtoMessage() {}
+
+func (x *WriteChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteChunkRequest.ProtoReflect.Descriptor instead.
+func (*WriteChunkRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *WriteChunkRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WriteChunkRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *WriteChunkRequest) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *WriteChunkRequest) GetTargetSize() uint64 {
+	if x != nil {
+		return x.TargetSize
+	}
+	return 0
+}
+
+func (x *WriteChunkRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *WriteChunkRequest) GetPadding() string {
+	if x != nil {
+		return x.Padding
+	}
+	return ""
+}
+
+func (x *WriteChunkRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WriteChunkReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *WriteChunkReply) Reset() {
+	*x = WriteChunkReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteChunkReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteChunkReply) ProtoMessage() {}
+
+func (x *WriteChunkReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteChunkReply.ProtoReflect.Descriptor instead.
+func (*WriteChunkReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *WriteChunkReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+type DeleteChunkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteChunkRequest) Reset() {
+	*x = DeleteChunkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChunkRequest) ProtoMessage() {}
+
+func (x *DeleteChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChunkRequest.ProtoReflect.Descriptor instead.
+func (*DeleteChunkRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *DeleteChunkRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteChunkReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *DeleteChunkReply) Reset() {
+	*x = DeleteChunkReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteChunkReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChunkReply) ProtoMessage() {}
+
+func (x *DeleteChunkReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChunkReply.ProtoReflect.Descriptor instead.
+func (*DeleteChunkReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DeleteChunkReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+type CopyChunkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NewId uint64 `protobuf:"varint,2,opt,name=new_id,json=newId,proto3" json:"new_id,omitempty"`
+}
+
+func (x *CopyChunkRequest) Reset() {
+	*x = CopyChunkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyChunkRequest) ProtoMessage() {}
+
+func (x *CopyChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyChunkRequest.ProtoReflect.Descriptor instead.
+func (*CopyChunkRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CopyChunkRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CopyChunkRequest) GetNewId() uint64 {
+	if x != nil {
+		return x.NewId
+	}
+	return 0
+}
+
+type CopyChunkReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *CopyChunkReply) Reset() {
+	*x = CopyChunkReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyChunkReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyChunkReply) ProtoMessage() {}
+
+func (x *CopyChunkReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyChunkReply.ProtoReflect.Descriptor instead.
+func (*CopyChunkReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CopyChunkReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+type CopySlotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset     uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size       uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Version    uint64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	NewId      uint64 `protobuf:"varint,5,opt,name=new_id,json=newId,proto3" json:"new_id,omitempty"`
+	NewOffset  uint64 `protobuf:"varint,6,opt,name=new_offset,json=newOffset,proto3" json:"new_offset,omitempty"`
+	NewVersion uint64 `protobuf:"varint,7,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
+}
+
+func (x *CopySlotRequest) Reset() {
+	*x = CopySlotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopySlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopySlotRequest) ProtoMessage() {}
+
+func (x *CopySlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopySlotRequest.ProtoReflect.Descriptor instead.
+func (*CopySlotRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CopySlotRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CopySlotRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *CopySlotRequest) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CopySlotRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CopySlotRequest) GetNewId() uint64 {
+	if x != nil {
+		return x.NewId
+	}
+	return 0
+}
+
+func (x *CopySlotRequest) GetNewOffset() uint64 {
+	if x != nil {
+		return x.NewOffset
+	}
+	return 0
+}
+
+func (x *CopySlotRequest) GetNewVersion() uint64 {
+	if x != nil {
+		return x.NewVersion
+	}
+	return 0
+}
+
+type CopySlotReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *CopySlotReply) Reset() {
+	*x = CopySlotReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopySlotReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopySlotReply) ProtoMessage() {}
+
+func (x *CopySlotReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopySlotReply.ProtoReflect.Descriptor instead.
+func (*CopySlotReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CopySlotReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+// A tab of a sheet. Every cell belongs to a tab, which is addressed by the tab field of
+// requests, and a new sheet has a single tab 0. Tabs are ordered by position from 0.
+type Tab struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Position uint32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *Tab) Reset() {
+	*x = Tab{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tab) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tab) ProtoMessage() {}
+
+func (x *Tab) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tab.ProtoReflect.Descriptor instead.
+func (*Tab) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *Tab) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tab) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tab) GetPosition() uint32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type ListTabsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+}
+
+func (x *ListTabsRequest) Reset() {
+	*x = ListTabsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTabsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTabsRequest) ProtoMessage() {}
+
+func (x *ListTabsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTabsRequest.ProtoReflect.Descriptor instead.
+func (*ListTabsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListTabsRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+type ListTabsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	// All tabs in order.
+	Tabs []*Tab `protobuf:"bytes,2,rep,name=tabs,proto3" json:"tabs,omitempty"`
+}
+
+func (x *ListTabsReply) Reset() {
+	*x = ListTabsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTabsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTabsReply) ProtoMessage() {}
+
+func (x *ListTabsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTabsReply.ProtoReflect.Descriptor instead.
+func (*ListTabsReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListTabsReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *ListTabsReply) GetTabs() []*Tab {
+	if x != nil {
+		return x.Tabs
+	}
+	return nil
+}
+
+// A tab is created after all tabs, a default name is given if name is empty.
+type CreateTabRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd   uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *CreateTabRequest) Reset() {
+	*x = CreateTabRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTabRequest) ProtoMessage() {}
+
+func (x *CreateTabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTabRequest.ProtoReflect.Descriptor instead.
+func (*CreateTabRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CreateTabRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *CreateTabRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateTabReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+	Tab    uint32 `protobuf:"varint,2,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *CreateTabReply) Reset() {
+	*x = CreateTabReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTabReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTabReply) ProtoMessage() {}
+
+func (x *CreateTabReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTabReply.ProtoReflect.Descriptor instead.
+func (*CreateTabReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CreateTabReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *CreateTabReply) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type RenameTabRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd   uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Tab  uint32 `protobuf:"varint,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RenameTabRequest) Reset() {
+	*x = RenameTabRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenameTabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTabRequest) ProtoMessage() {}
+
+func (x *RenameTabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTabRequest.ProtoReflect.Descriptor instead.
+func (*RenameTabRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RenameTabRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *RenameTabRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+func (x *RenameTabRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RenameTabReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *RenameTabReply) Reset() {
+	*x = RenameTabReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenameTabReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTabReply) ProtoMessage() {}
+
+func (x *RenameTabReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTabReply.ProtoReflect.Descriptor instead.
+func (*RenameTabReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RenameTabReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+// The tab is moved to position, tabs between its old and new positions are shifted.
+type MoveTabRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd       uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Tab      uint32 `protobuf:"varint,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	Position uint32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *MoveTabRequest) Reset() {
+	*x = MoveTabRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveTabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTabRequest) ProtoMessage() {}
+
+func (x *MoveTabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTabRequest.ProtoReflect.Descriptor instead.
+func (*MoveTabRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *MoveTabRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *MoveTabRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+func (x *MoveTabRequest) GetPosition() uint32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type MoveTabReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *MoveTabReply) Reset() {
+	*x = MoveTabReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveTabReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTabReply) ProtoMessage() {}
+
+func (x *MoveTabReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTabReply.ProtoReflect.Descriptor instead.
+func (*MoveTabReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *MoveTabReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+// The tab and all of its cells are deleted, the last tab of a sheet can not be deleted.
+type DeleteTabRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fd  uint64 `protobuf:"varint,1,opt,name=fd,proto3" json:"fd,omitempty"`
+	Tab uint32 `protobuf:"varint,2,opt,name=tab,proto3" json:"tab,omitempty"`
+}
+
+func (x *DeleteTabRequest) Reset() {
+	*x = DeleteTabRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTabRequest) ProtoMessage() {}
+
+func (x *DeleteTabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTabRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTabRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DeleteTabRequest) GetFd() uint64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *DeleteTabRequest) GetTab() uint32 {
+	if x != nil {
+		return x.Tab
+	}
+	return 0
+}
+
+type DeleteTabReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=sheetfs.Status" json:"status,omitempty"`
+}
+
+func (x *DeleteTabReply) Reset() {
+	*x = DeleteTabReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_sheetfs_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTabReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTabReply) ProtoMessage() {}
+
+func (x *DeleteTabReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_sheetfs_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTabReply.ProtoReflect.Descriptor instead.
+func (*DeleteTabReply) Descriptor() ([]byte, []int) {
+	return file_protocol_sheetfs_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *DeleteTabReply) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+var File_protocol_sheetfs_proto protoreflect.FileDescriptor
+
+var file_protocol_sheetfs_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x68, 0x65, 0x65, 0x74,
+	0x66, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
+	0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2d, 0x0a, 0x17, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x22, 0x40, 0x0a, 0x15, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x6b, 0x0a, 0x10, 0x4f,
+	0x70, 0x65, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x2c, 0x0a, 0x10, 0x4b, 0x65, 0x65, 0x70,
+	0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x39, 0x0a, 0x0e, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c,
+	0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
+	0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x22, 0x2f, 0x0a, 0x13, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x3c, 0x0a, 0x11, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
+	0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x22, 0x4a, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x10,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x22, 0x30, 0x0a, 0x12, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3b, 0x0a, 0x10, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x53, 0x0a, 0x12, 0x52, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65,
+	0x77, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6e, 0x65, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3b, 0x0a,
+	0x10, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x51, 0x0a, 0x10, 0x43, 0x6f,
+	0x70, 0x79, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65,
+	0x77, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6e, 0x65, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x39, 0x0a,
+	0x0e, 0x43, 0x6f, 0x70, 0x79, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x48, 0x0a, 0x10, 0x4f, 0x70, 0x65, 0x6e,
+	0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x9b, 0x01, 0x0a, 0x05, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x4d, 0x65, 0x74,
+	0x61, 0x12, 0x2d, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x65, 0x6c,
+	0x6c, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x73,
+	0x22, 0xce, 0x01, 0x0a, 0x0a, 0x43, 0x65, 0x6c, 0x6c, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x06, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x72,
+	0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x10, 0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61,
+	0x62, 0x22, 0x49, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02,
+	0x66, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x22, 0x23, 0x0a, 0x11,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66,
+	0x64, 0x22, 0x3a, 0x0a, 0x0f, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x4c, 0x0a,
+	0x10, 0x53, 0x74, 0x61, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x10, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x02, 0x66,
+	0x64, 0x42, 0x08, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0xd3, 0x02, 0x0a, 0x09,
+	0x53, 0x68, 0x65, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x61, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x75, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x75, 0x73, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64,
+	0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x64, 0x61, 0x74, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d,
+	0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x70, 0x65,
+	0x6e, 0x5f, 0x66, 0x64, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6f, 0x70, 0x65,
+	0x6e, 0x46, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x41,
+	0x74, 0x22, 0x61, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x04,
+	0x73, 0x74, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x68, 0x65,
+	0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x52, 0x04,
+	0x73, 0x74, 0x61, 0x74, 0x22, 0x4f, 0x0a, 0x10, 0x52, 0x65, 0x61, 0x64, 0x53, 0x68, 0x65, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x62, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6c,
+	0x6c, 0x5f, 0x74, 0x61, 0x62, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x6c,
+	0x6c, 0x54, 0x61, 0x62, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x68,
+	0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
+	0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x04, 0x74, 0x61, 0x62,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
+	0x73, 0x2e, 0x54, 0x61, 0x62, 0x52, 0x04, 0x74, 0x61, 0x62, 0x73, 0x22, 0x31, 0x0a, 0x13, 0x52,
+	0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3c,
+	0x0a, 0x11, 0x52, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x30, 0x0a, 0x12,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3b,
+	0x0a, 0x10, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x60, 0x0a, 0x05, 0x53,
+	0x68, 0x65, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x74, 0x22, 0xd9, 0x01,
+	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x64, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x33, 0x0a,
+	0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x08, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x68, 0x65, 0x65,
+	0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x8a, 0x01, 0x0a, 0x0f, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x73, 0x68, 0x65, 0x65, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73,
+	0x2e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x06, 0x73, 0x68, 0x65, 0x65, 0x74, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x22, 0x0a, 0x0c, 0x4d, 0x6b, 0x44, 0x69, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x35, 0x0a, 0x0a, 0x4d, 0x6b,
+	0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
+	0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x22, 0x40, 0x0a, 0x0c, 0x52, 0x6d, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69,
+	0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73,
+	0x69, 0x76, 0x65, 0x22, 0x35, 0x0a, 0x0a, 0x52, 0x6d, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x24, 0x0a, 0x0e, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x22, 0x73, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x69, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x72, 0x73, 0x12, 0x26, 0x0a,
+	0x06, 0x73, 0x68, 0x65, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x06, 0x73,
+	0x68, 0x65, 0x65, 0x74, 0x73, 0x22, 0xb6, 0x01, 0x0a, 0x04, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x24,
+	0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x05, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x12, 0x2a, 0x0a, 0x08, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x52, 0x08, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5d,
+	0x0a, 0x0f, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x72, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x74,
+	0x61, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62, 0x22, 0x5b, 0x0a,
+	0x0d, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f,
+	0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
+	0x43, 0x65, 0x6c, 0x6c, 0x52, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x22, 0xac, 0x01, 0x0a, 0x10, 0x52,
+	0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x77, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x72, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x72, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x72,
+	0x6f, 0x77, 0x45, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x45, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62, 0x22, 0x61, 0x0a, 0x0e, 0x52, 0x65, 0x61,
+	0x64, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x22, 0x72, 0x0a, 0x10,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x72,
+	0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62,
+	0x22, 0x85, 0x01, 0x0a, 0x0e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x04,
+	0x63, 0x65, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x68, 0x65,
+	0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x12,
+	0x27, 0x0a, 0x07, 0x76, 0x61, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52,
+	0x07, 0x76, 0x61, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0x5b, 0x0a, 0x09, 0x43, 0x65, 0x6c, 0x6c,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x74, 0x61, 0x62, 0x22, 0x4d, 0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65,
+	0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x63, 0x65,
+	0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x68, 0x65, 0x65,
+	0x74, 0x66, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x05, 0x63,
+	0x65, 0x6c, 0x6c, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x0f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65,
+	0x6c, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
+	0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x23, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52,
+	0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x27, 0x0a, 0x07, 0x76, 0x61, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
+	0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x07, 0x76, 0x61, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22,
+	0x5f, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x02, 0x66, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62,
+	0x22, 0x5d, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x04,
+	0x63, 0x65, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x68, 0x65,
+	0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x22,
+	0x5d, 0x0a, 0x11, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x02, 0x66, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x61, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62, 0x22, 0x3a,
+	0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x5d, 0x0a, 0x11, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x72, 0x6f,
+	0x77, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62, 0x22, 0x3a, 0x0a, 0x0f, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73,
+	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x66, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x43,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74,
+	0x61, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62, 0x22, 0x3d, 0x0a,
+	0x12, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x66, 0x0a, 0x14,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x02, 0x66, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x03, 0x74, 0x61, 0x62, 0x22, 0x3d, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65,
+	0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x68, 0x0a, 0x10, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x83, 0x01,
+	0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3a,
+	0x0a, 0x0f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x24, 0x0a, 0x12, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x3b, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x39, 0x0a,
+	0x10, 0x43, 0x6f, 0x70, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x6e, 0x65, 0x77, 0x49, 0x64, 0x22, 0x39, 0x0a, 0x0e, 0x43, 0x6f, 0x70, 0x79,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65,
+	0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x70, 0x79, 0x53, 0x6c, 0x6f, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a,
+	0x06, 0x6e, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e,
+	0x65, 0x77, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x38, 0x0a, 0x0d, 0x43, 0x6f, 0x70, 0x79, 0x53, 0x6c, 0x6f, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x45,
+	0x0a, 0x03, 0x54, 0x61, 0x62, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x21, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x22, 0x5a, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x61, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65,
+	0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x20, 0x0a, 0x04, 0x74, 0x61, 0x62, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x54, 0x61, 0x62, 0x52, 0x04,
+	0x74, 0x61, 0x62, 0x73, 0x22, 0x36, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61,
+	0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x4b, 0x0a, 0x0e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f,
+	0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62, 0x22, 0x48, 0x0a, 0x10, 0x52, 0x65, 0x6e,
+	0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x74, 0x61, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x39, 0x0a, 0x0e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x4e,
+	0x0a, 0x0e, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74,
+	0x61, 0x62, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x37,
+	0x0a, 0x0c, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f,
+	0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x34, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x66,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x66, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74,
+	0x61, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x61, 0x62, 0x22, 0x39, 0x0a,
+	0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0f, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2a, 0x74, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x78,
+	0x69, 0x73, 0x74, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e,
+	0x64, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x72, 0x6f, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x10, 0x06,
+	0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x6f, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x10, 0x07, 0x2a, 0x3e,
+	0x0a, 0x0e, 0x52, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x12, 0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x4f, 0x54,
+	0x5f, 0x52, 0x45, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x52,
+	0x45, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x44, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x02, 0x2a, 0x2a,
+	0x0a, 0x0a, 0x53, 0x68, 0x65, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x0b, 0x0a, 0x07,
+	0x42, 0x59, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x59, 0x5f,
+	0x4d, 0x4f, 0x44, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x01, 0x32, 0xa4, 0x11, 0x0a, 0x0a, 0x4d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x56, 0x0a, 0x10, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x0e, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a,
+	0x09, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x65,
+	0x65, 0x74, 0x66, 0x73, 0x2e, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
+	0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x4a, 0x0a, 0x0c, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0b,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x12, 0x1b, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
+	0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x68, 0x65, 0x65, 0x74, 0x12, 0x1b, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47,
+	0x0a, 0x0b, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x12, 0x1b, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x68,
+	0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x65,
+	0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x43, 0x6f, 0x70, 0x79, 0x53,
+	0x68, 0x65, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43,
+	0x6f, 0x70, 0x79, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x53, 0x68,
+	0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x4f, 0x70,
+	0x65, 0x6e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
+	0x73, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x4f, 0x70, 0x65,
+	0x6e, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a,
+	0x0a, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x12, 0x1a, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
+	0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74,
+	0x12, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53,
+	0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x53, 0x68,
+	0x65, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65,
+	0x61, 0x64, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x68, 0x65,
+	0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0c, 0x52, 0x65, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x12, 0x1c, 0x2e, 0x73, 0x68, 0x65, 0x65,
+	0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
+	0x73, 0x2e, 0x52, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x53,
+	0x68, 0x65, 0x65, 0x74, 0x12, 0x1b, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x53, 0x68, 0x65, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44,
+	0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x73,
+	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
+	0x66, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x65, 0x65, 0x74, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x05, 0x4d, 0x6b, 0x44, 0x69, 0x72, 0x12, 0x15, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x4d, 0x6b, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x4d,
+	0x6b, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x05, 0x52,
+	0x6d, 0x44, 0x69, 0x72, 0x12, 0x15, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52,
+	0x6d, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x6d, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x3b, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x12, 0x17, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x3e, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x18, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x41, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x19, 0x2e, 0x73,
+	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
+	0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x12,
+	0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43,
+	0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65,
+	0x65, 0x74, 0x66, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x65,
+	0x6c, 0x6c, 0x73, 0x12, 0x1a, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43,
+	0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x1a, 0x2e, 0x73, 0x68, 0x65, 0x65,
+	0x74, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x44, 0x0a, 0x0a, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x12,
+	0x1a, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74,
+	0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x6f, 0x77, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x1a, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4d, 0x0a,
+	0x0d, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x1d,
+	0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x43,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x43, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0d,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x1d, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73,
+	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x08, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x73, 0x12, 0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66,
+	0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x61, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
+	0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41,
+	0x0a, 0x09, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x12, 0x19, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73,
+	0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x3b, 0x0a, 0x07, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x62, 0x12, 0x17, 0x2e, 0x73,
+	0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e,
+	0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41,
+	0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x12, 0x19, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x32, 0xdf, 0x02, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x41,
+	0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x19, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x44, 0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12,
+	0x1a, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x73, 0x68,
+	0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x1b, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x41, 0x0a, 0x09, 0x43, 0x6f, 0x70, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x19, 0x2e,
+	0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74,
+	0x66, 0x73, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x08, 0x43, 0x6f, 0x70, 0x79, 0x53, 0x6c, 0x6f, 0x74, 0x12,
+	0x18, 0x2e, 0x73, 0x68, 0x65, 0x65, 0x74, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x53, 0x6c,
+	0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x73, 0x68, 0x65, 0x65,
+	0x74, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x66, 0x6f, 0x75, 0x72, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x68, 0x65,
+	0x65, 0x74, 0x66, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x3b, 0x66, 0x73,
+	0x5f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protocol_sheetfs_proto_rawDescOnce sync.Once
+	file_protocol_sheetfs_proto_rawDescData = file_protocol_sheetfs_proto_rawDesc
+)
+
+func file_protocol_sheetfs_proto_rawDescGZIP() []byte {
+	file_protocol_sheetfs_proto_rawDescOnce.Do(func() {
+		file_protocol_sheetfs_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocol_sheetfs_proto_rawDescData)
+	})
+	return file_protocol_sheetfs_proto_rawDescData
+}
+
+var file_protocol_sheetfs_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_protocol_sheetfs_proto_msgTypes = make([]protoimpl.MessageInfo, 81)
+var file_protocol_sheetfs_proto_goTypes = []interface{}{
+	(Status)(0),                     // 0: sheetfs.Status
+	(RecycledFilter)(0),             // 1: sheetfs.RecycledFilter
+	(SheetOrder)(0),                 // 2: sheetfs.SheetOrder
+	(*Empty)(nil),                   // 3: sheetfs.Empty
+	(*RegisterDataNodeRequest)(nil), // 4: sheetfs.RegisterDataNodeRequest
+	(*RegisterDataNodeReply)(nil),   // 5: sheetfs.RegisterDataNodeReply
+	(*OpenSessionReply)(nil),        // 6: sheetfs.OpenSessionReply
+	(*KeepAliveRequest)(nil),        // 7: sheetfs.KeepAliveRequest
+	(*KeepAliveReply)(nil),          // 8: sheetfs.KeepAliveReply
+	(*CloseSessionRequest)(nil),     // 9: sheetfs.CloseSessionRequest
+	(*CloseSessionReply)(nil),       // 10: sheetfs.CloseSessionReply
+	(*CreateSheetRequest)(nil),      // 11: sheetfs.CreateSheetRequest
+	(*CreateSheetReply)(nil),        // 12: sheetfs.CreateSheetReply
+	(*DeleteSheetRequest)(nil),      // 13: sheetfs.DeleteSheetRequest
+	(*DeleteSheetReply)(nil),        // 14: sheetfs.DeleteSheetReply
+	(*RenameSheetRequest)(nil),      // 15: sheetfs.RenameSheetRequest
+	(*RenameSheetReply)(nil),        // 16: sheetfs.RenameSheetReply
+	(*CopySheetRequest)(nil),        // 17: sheetfs.CopySheetRequest
+	(*CopySheetReply)(nil),          // 18: sheetfs.CopySheetReply
+	(*OpenSheetRequest)(nil),        // 19: sheetfs.OpenSheetRequest
+	(*Chunk)(nil),                   // 20: sheetfs.Chunk
+	(*CellExtent)(nil),              // 21: sheetfs.CellExtent
+	(*OpenSheetReply)(nil),          // 22: sheetfs.OpenSheetReply
+	(*CloseSheetRequest)(nil),       // 23: sheetfs.CloseSheetRequest
+	(*CloseSheetReply)(nil),         // 24: sheetfs.CloseSheetReply
+	(*StatSheetRequest)(nil),        // 25: sheetfs.StatSheetRequest
+	(*SheetStat)(nil),               // 26: sheetfs.SheetStat
+	(*StatSheetReply)(nil),          // 27: sheetfs.StatSheetReply
+	(*ReadSheetRequest)(nil),        // 28: sheetfs.ReadSheetRequest
+	(*ReadSheetReply)(nil),          // 29: sheetfs.ReadSheetReply
+	(*RecycleSheetRequest)(nil),     // 30: sheetfs.RecycleSheetRequest
+	(*RecycleSheetReply)(nil),       // 31: sheetfs.RecycleSheetReply
+	(*ResumeSheetRequest)(nil),      // 32: sheetfs.ResumeSheetRequest
+	(*ResumeSheetReply)(nil),        // 33: sheetfs.ResumeSheetReply
+	(*Sheet)(nil),                   // 34: sheetfs.Sheet
+	(*ListSheetsRequest)(nil),       // 35: sheetfs.ListSheetsRequest
+	(*ListSheetsReply)(nil),         // 36: sheetfs.ListSheetsReply
+	(*MkDirRequest)(nil),            // 37: sheetfs.MkDirRequest
+	(*MkDirReply)(nil),              // 38: sheetfs.MkDirReply
+	(*RmDirRequest)(nil),            // 39: sheetfs.RmDirRequest
+	(*RmDirReply)(nil),              // 40: sheetfs.RmDirReply
+	(*ListDirRequest)(nil),          // 41: sheetfs.ListDirRequest
+	(*ListDirReply)(nil),            // 42: sheetfs.ListDirReply
+	(*Cell)(nil),                    // 43: sheetfs.Cell
+	(*ReadCellRequest)(nil),         // 44: sheetfs.ReadCellRequest
+	(*ReadCellReply)(nil),           // 45: sheetfs.ReadCellReply
+	(*ReadCellsRequest)(nil),        // 46: sheetfs.ReadCellsRequest
+	(*ReadCellsReply)(nil),          // 47: sheetfs.ReadCellsReply
+	(*WriteCellRequest)(nil),        // 48: sheetfs.WriteCellRequest
+	(*WriteCellReply)(nil),          // 49: sheetfs.WriteCellReply
+	(*CellWrite)(nil),               // 50: sheetfs.CellWrite
+	(*WriteCellsRequest)(nil),       // 51: sheetfs.WriteCellsRequest
+	(*WriteCellsReply)(nil),         // 52: sheetfs.WriteCellsReply
+	(*DeleteCellRequest)(nil),       // 53: sheetfs.DeleteCellRequest
+	(*DeleteCellReply)(nil),         // 54: sheetfs.DeleteCellReply
+	(*InsertRowsRequest)(nil),       // 55: sheetfs.InsertRowsRequest
+	(*InsertRowsReply)(nil),         // 56: sheetfs.InsertRowsReply
+	(*DeleteRowsRequest)(nil),       // 57: sheetfs.DeleteRowsRequest
+	(*DeleteRowsReply)(nil),         // 58: sheetfs.DeleteRowsReply
+	(*InsertColumnsRequest)(nil),    // 59: sheetfs.InsertColumnsRequest
+	(*InsertColumnsReply)(nil),      // 60: sheetfs.InsertColumnsReply
+	(*DeleteColumnsRequest)(nil),    // 61: sheetfs.DeleteColumnsRequest
+	(*DeleteColumnsReply)(nil),      // 62: sheetfs.DeleteColumnsReply
+	(*ReadChunkRequest)(nil),        // 63: sheetfs.ReadChunkRequest
+	(*ReadChunkReply)(nil),          // 64: sheetfs.ReadChunkReply
+	(*WriteChunkRequest)(nil),       // 65: sheetfs.WriteChunkRequest
+	(*WriteChunkReply)(nil),         // 66: sheetfs.WriteChunkReply
+	(*DeleteChunkRequest)(nil),      // 67: sheetfs.DeleteChunkRequest
+	(*DeleteChunkReply)(nil),        // 68: sheetfs.DeleteChunkReply
+	(*CopyChunkRequest)(nil),        // 69: sheetfs.CopyChunkRequest
+	(*CopyChunkReply)(nil),          // 70: sheetfs.CopyChunkReply
+	(*CopySlotRequest)(nil),         // 71: sheetfs.CopySlotRequest
+	(*CopySlotReply)(nil),           // 72: sheetfs.CopySlotReply
+	(*Tab)(nil),                     // 73: sheetfs.Tab
+	(*ListTabsRequest)(nil),         // 74: sheetfs.ListTabsRequest
+	(*ListTabsReply)(nil),           // 75: sheetfs.ListTabsReply
+	(*CreateTabRequest)(nil),        // 76: sheetfs.CreateTabRequest
+	(*CreateTabReply)(nil),          // 77: sheetfs.CreateTabReply
+	(*RenameTabRequest)(nil),        // 78: sheetfs.RenameTabRequest
+	(*RenameTabReply)(nil),          // 79: sheetfs.RenameTabReply
+	(*MoveTabRequest)(nil),          // 80: sheetfs.MoveTabRequest
+	(*MoveTabReply)(nil),            // 81: sheetfs.MoveTabReply
+	(*DeleteTabRequest)(nil),        // 82: sheetfs.DeleteTabRequest
+	(*DeleteTabReply)(nil),          // 83: sheetfs.DeleteTabReply
+}
+var file_protocol_sheetfs_proto_depIdxs = []int32{
+	0,  // 0: sheetfs.RegisterDataNodeReply.status:type_name -> sheetfs.Status
+	0,  // 1: sheetfs.OpenSessionReply.status:type_name -> sheetfs.Status
+	0,  // 2: sheetfs.KeepAliveReply.status:type_name -> sheetfs.Status
+	0,  // 3: sheetfs.CloseSessionReply.status:type_name -> sheetfs.Status
+	0,  // 4: sheetfs.CreateSheetReply.status:type_name -> sheetfs.Status
+	0,  // 5: sheetfs.DeleteSheetReply.status:type_name -> sheetfs.Status
+	0,  // 6: sheetfs.RenameSheetReply.status:type_name -> sheetfs.Status
+	0,  // 7: sheetfs.CopySheetReply.status:type_name -> sheetfs.Status
+	21, // 8: sheetfs.Chunk.extents:type_name -> sheetfs.CellExtent
+	20, // 9: sheetfs.CellExtent.chunks:type_name -> sheetfs.Chunk
+	0,  // 10: sheetfs.OpenSheetReply.status:type_name -> sheetfs.Status
+	0,  // 11: sheetfs.CloseSheetReply.status:type_name -> sheetfs.Status
+	0,  // 12: sheetfs.StatSheetReply.status:type_name -> sheetfs.Status
+	26, // 13: sheetfs.StatSheetReply.stat:type_name -> sheetfs.SheetStat
+	0,  // 14: sheetfs.ReadSheetReply.status:type_name -> sheetfs.Status
+	20, // 15: sheetfs.ReadSheetReply.chunks:type_name -> sheetfs.Chunk
+	73, // 16: sheetfs.ReadSheetReply.tabs:type_name -> sheetfs.Tab
+	0,  // 17: sheetfs.RecycleSheetReply.status:type_name -> sheetfs.Status
+	0,  // 18: sheetfs.ResumeSheetReply.status:type_name -> sheetfs.Status
+	1,  // 19: sheetfs.ListSheetsRequest.recycled:type_name -> sheetfs.RecycledFilter
+	2,  // 20: sheetfs.ListSheetsRequest.order:type_name -> sheetfs.SheetOrder
+	0,  // 21: sheetfs.ListSheetsReply.status:type_name -> sheetfs.Status
+	34, // 22: sheetfs.ListSheetsReply.sheets:type_name -> sheetfs.Sheet
+	0,  // 23: sheetfs.MkDirReply.status:type_name -> sheetfs.Status
+	0,  // 24: sheetfs.RmDirReply.status:type_name -> sheetfs.Status
+	0,  // 25: sheetfs.ListDirReply.status:type_name -> sheetfs.Status
+	34, // 26: sheetfs.ListDirReply.sheets:type_name -> sheetfs.Sheet
+	20, // 27: sheetfs.Cell.chunk:type_name -> sheetfs.Chunk
+	20, // 28: sheetfs.Cell.overflow:type_name -> sheetfs.Chunk
+	0,  // 29: sheetfs.ReadCellReply.status:type_name -> sheetfs.Status
+	43, // 30: sheetfs.ReadCellReply.cell:type_name -> sheetfs.Cell
+	0,  // 31: sheetfs.ReadCellsReply.status:type_name -> sheetfs.Status
+	20, // 32: sheetfs.ReadCellsReply.chunks:type_name -> sheetfs.Chunk
+	0,  // 33: sheetfs.WriteCellReply.status:type_name -> sheetfs.Status
+	43, // 34: sheetfs.WriteCellReply.cell:type_name -> sheetfs.Cell
+	43, // 35: sheetfs.WriteCellReply.vacated:type_name -> sheetfs.Cell
+	50, // 36: sheetfs.WriteCellsRequest.cells:type_name -> sheetfs.CellWrite
+	0,  // 37: sheetfs.WriteCellsReply.status:type_name -> sheetfs.Status
+	43, // 38: sheetfs.WriteCellsReply.cells:type_name -> sheetfs.Cell
+	43, // 39: sheetfs.WriteCellsReply.vacated:type_name -> sheetfs.Cell
+	0,  // 40: sheetfs.DeleteCellReply.status:type_name -> sheetfs.Status
+	43, // 41: sheetfs.DeleteCellReply.cell:type_name -> sheetfs.Cell
+	0,  // 42: sheetfs.InsertRowsReply.status:type_name -> sheetfs.Status
+	0,  // 43: sheetfs.DeleteRowsReply.status:type_name -> sheetfs.Status
+	0,  // 44: sheetfs.InsertColumnsReply.status:type_name -> sheetfs.Status
+	0,  // 45: sheetfs.DeleteColumnsReply.status:type_name -> sheetfs.Status
+	0,  // 46: sheetfs.ReadChunkReply.status:type_name -> sheetfs.Status
+	0,  // 47: sheetfs.WriteChunkReply.status:type_name -> sheetfs.Status
+	0,  // 48: sheetfs.DeleteChunkReply.status:type_name -> sheetfs.Status
+	0,  // 49: sheetfs.CopyChunkReply.status:type_name -> sheetfs.Status
+	0,  // 50: sheetfs.CopySlotReply.status:type_name -> sheetfs.Status
+	0,  // 51: sheetfs.ListTabsReply.status:type_name -> sheetfs.Status
+	73, // 52: sheetfs.ListTabsReply.tabs:type_name -> sheetfs.Tab
+	0,  // 53: sheetfs.CreateTabReply.status:type_name -> sheetfs.Status
+	0,  // 54: sheetfs.RenameTabReply.status:type_name -> sheetfs.Status
+	0,  // 55: sheetfs.MoveTabReply.status:type_name -> sheetfs.Status
+	0,  // 56: sheetfs.DeleteTabReply.status:type_name -> sheetfs.Status
+	4,  // 57: sheetfs.MasterNode.RegisterDataNode:input_type -> sheetfs.RegisterDataNodeRequest
+	3,  // 58: sheetfs.MasterNode.OpenSession:input_type -> sheetfs.Empty
+	7,  // 59: sheetfs.MasterNode.KeepAlive:input_type -> sheetfs.KeepAliveRequest
+	9,  // 60: sheetfs.MasterNode.CloseSession:input_type -> sheetfs.CloseSessionRequest
+	11, // 61: sheetfs.MasterNode.CreateSheet:input_type -> sheetfs.CreateSheetRequest
+	13, // 62: sheetfs.MasterNode.DeleteSheet:input_type -> sheetfs.DeleteSheetRequest
+	15, // 63: sheetfs.MasterNode.RenameSheet:input_type -> sheetfs.RenameSheetRequest
+	17, // 64: sheetfs.MasterNode.CopySheet:input_type -> sheetfs.CopySheetRequest
+	19, // 65: sheetfs.MasterNode.OpenSheet:input_type -> sheetfs.OpenSheetRequest
+	23, // 66: sheetfs.MasterNode.CloseSheet:input_type -> sheetfs.CloseSheetRequest
+	25, // 67: sheetfs.MasterNode.StatSheet:input_type -> sheetfs.StatSheetRequest
+	28, // 68: sheetfs.MasterNode.ReadSheet:input_type -> sheetfs.ReadSheetRequest
+	30, // 69: sheetfs.MasterNode.RecycleSheet:input_type -> sheetfs.RecycleSheetRequest
+	32, // 70: sheetfs.MasterNode.ResumeSheet:input_type -> sheetfs.ResumeSheetRequest
+	35, // 71: sheetfs.MasterNode.ListSheets:input_type -> sheetfs.ListSheetsRequest
+	37, // 72: sheetfs.MasterNode.MkDir:input_type -> sheetfs.MkDirRequest
+	39, // 73: sheetfs.MasterNode.RmDir:input_type -> sheetfs.RmDirRequest
+	41, // 74: sheetfs.MasterNode.ListDir:input_type -> sheetfs.ListDirRequest
+	44, // 75: sheetfs.MasterNode.ReadCell:input_type -> sheetfs.ReadCellRequest
+	46, // 76: sheetfs.MasterNode.ReadCells:input_type -> sheetfs.ReadCellsRequest
+	48, // 77: sheetfs.MasterNode.WriteCell:input_type -> sheetfs.WriteCellRequest
+	51, // 78: sheetfs.MasterNode.WriteCells:input_type -> sheetfs.WriteCellsRequest
+	53, // 79: sheetfs.MasterNode.DeleteCell:input_type -> sheetfs.DeleteCellRequest
+	55, // 80: sheetfs.MasterNode.InsertRows:input_type -> sheetfs.InsertRowsRequest
+	57, // 81: sheetfs.MasterNode.DeleteRows:input_type -> sheetfs.DeleteRowsRequest
+	59, // 82: sheetfs.MasterNode.InsertColumns:input_type -> sheetfs.InsertColumnsRequest
+	61, // 83: sheetfs.MasterNode.DeleteColumns:input_type -> sheetfs.DeleteColumnsRequest
+	74, // 84: sheetfs.MasterNode.ListTabs:input_type -> sheetfs.ListTabsRequest
+	76, // 85: sheetfs.MasterNode.CreateTab:input_type -> sheetfs.CreateTabRequest
+	78, // 86: sheetfs.MasterNode.RenameTab:input_type -> sheetfs.RenameTabRequest
+	80, // 87: sheetfs.MasterNode.MoveTab:input_type -> sheetfs.MoveTabRequest
+	82, // 88: sheetfs.MasterNode.DeleteTab:input_type -> sheetfs.DeleteTabRequest
+	63, // 89: sheetfs.DataNode.ReadChunk:input_type -> sheetfs.ReadChunkRequest
+	65, // 90: sheetfs.DataNode.WriteChunk:input_type -> sheetfs.WriteChunkRequest
+	67, // 91: sheetfs.DataNode.DeleteChunk:input_type -> sheetfs.DeleteChunkRequest
+	69, // 92: sheetfs.DataNode.CopyChunk:input_type -> sheetfs.CopyChunkRequest
+	71, // 93: sheetfs.DataNode.CopySlot:input_type -> sheetfs.CopySlotRequest
+	5,  // 94: sheetfs.MasterNode.RegisterDataNode:output_type -> sheetfs.RegisterDataNodeReply
+	6,  // 95: sheetfs.MasterNode.OpenSession:output_type -> sheetfs.OpenSessionReply
+	8,  // 96: sheetfs.MasterNode.KeepAlive:output_type -> sheetfs.KeepAliveReply
+	10, // 97: sheetfs.MasterNode.CloseSession:output_type -> sheetfs.CloseSessionReply
+	12, // 98: sheetfs.MasterNode.CreateSheet:output_type -> sheetfs.CreateSheetReply
+	14, // 99: sheetfs.MasterNode.DeleteSheet:output_type -> sheetfs.DeleteSheetReply
+	16, // 100: sheetfs.MasterNode.RenameSheet:output_type -> sheetfs.RenameSheetReply
+	18, // 101: sheetfs.MasterNode.CopySheet:output_type -> sheetfs.CopySheetReply
+	22, // 102: sheetfs.MasterNode.OpenSheet:output_type -> sheetfs.OpenSheetReply
+	24, // 103: sheetfs.MasterNode.CloseSheet:output_type -> sheetfs.CloseSheetReply
+	27, // 104: sheetfs.MasterNode.StatSheet:output_type -> sheetfs.StatSheetReply
+	29, // 105: sheetfs.MasterNode.ReadSheet:output_type -> sheetfs.ReadSheetReply
+	31, // 106: sheetfs.MasterNode.RecycleSheet:output_type -> sheetfs.RecycleSheetReply
+	33, // 107: sheetfs.MasterNode.ResumeSheet:output_type -> sheetfs.ResumeSheetReply
+	36, // 108: sheetfs.MasterNode.ListSheets:output_type -> sheetfs.ListSheetsReply
+	38, // 109: sheetfs.MasterNode.MkDir:output_type -> sheetfs.MkDirReply
+	40, // 110: sheetfs.MasterNode.RmDir:output_type -> sheetfs.RmDirReply
+	42, // 111: sheetfs.MasterNode.ListDir:output_type -> sheetfs.ListDirReply
+	45, // 112: sheetfs.MasterNode.ReadCell:output_type -> sheetfs.ReadCellReply
+	47, // 113: sheetfs.MasterNode.ReadCells:output_type -> sheetfs.ReadCellsReply
+	49, // 114: sheetfs.MasterNode.WriteCell:output_type -> sheetfs.WriteCellReply
+	52, // 115: sheetfs.MasterNode.WriteCells:output_type -> sheetfs.WriteCellsReply
+	54, // 116: sheetfs.MasterNode.DeleteCell:output_type -> sheetfs.DeleteCellReply
+	56, // 117: sheetfs.MasterNode.InsertRows:output_type -> sheetfs.InsertRowsReply
+	58, // 118: sheetfs.MasterNode.DeleteRows:output_type -> sheetfs.DeleteRowsReply
+	60, // 119: sheetfs.MasterNode.InsertColumns:output_type -> sheetfs.InsertColumnsReply
+	62, // 120: sheetfs.MasterNode.DeleteColumns:output_type -> sheetfs.DeleteColumnsReply
+	75, // 121: sheetfs.MasterNode.ListTabs:output_type -> sheetfs.ListTabsReply
+	77, // 122: sheetfs.MasterNode.CreateTab:output_type -> sheetfs.CreateTabReply
+	79, // 123: sheetfs.MasterNode.RenameTab:output_type -> sheetfs.RenameTabReply
+	81, // 124: sheetfs.MasterNode.MoveTab:output_type -> sheetfs.MoveTabReply
+	83, // 125: sheetfs.MasterNode.DeleteTab:output_type -> sheetfs.DeleteTabReply
+	64, // 126: sheetfs.DataNode.ReadChunk:output_type -> sheetfs.ReadChunkReply
+	66, // 127: sheetfs.DataNode.WriteChunk:output_type -> sheetfs.WriteChunkReply
+	68, // 128: sheetfs.DataNode.DeleteChunk:output_type -> sheetfs.DeleteChunkReply
+	70, // 129: sheetfs.DataNode.CopyChunk:output_type -> sheetfs.CopyChunkReply
+	72, // 130: sheetfs.DataNode.CopySlot:output_type -> sheetfs.CopySlotReply
+	94, // [94:131] is the sub-list for method output_type
+	57, // [57:94] is the sub-list for method input_type
+	57, // [57:57] is the sub-list for extension type_name
+	57, // [57:57] is the sub-list for extension extendee
+	0,  // [0:57] is the sub-list for field type_name
+}
+
+func init() { file_protocol_sheetfs_proto_init() }
+func file_protocol_sheetfs_proto_init() {
+	if File_protocol_sheetfs_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protocol_sheetfs_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterDataNodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterDataNodeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenSessionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeepAliveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeepAliveReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseSessionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenameSheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenameSheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopySheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopySheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenSheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Chunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellExtent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenSheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseSheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseSheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatSheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SheetStat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatSheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadSheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadSheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecycleSheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecycleSheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeSheetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeSheetReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sheet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSheetsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSheetsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1971,8 +6297,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterDataNodeRequest); i {
+		file_protocol_sheetfs_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MkDirRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1983,8 +6309,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterDataNodeReply); i {
+		file_protocol_sheetfs_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MkDirReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1995,8 +6321,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSheetRequest); i {
+		file_protocol_sheetfs_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RmDirRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2007,8 +6333,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSheetReply); i {
+		file_protocol_sheetfs_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RmDirReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2019,8 +6345,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteSheetRequest); i {
+		file_protocol_sheetfs_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDirRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2031,8 +6357,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteSheetReply); i {
+		file_protocol_sheetfs_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDirReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2043,8 +6369,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpenSheetRequest); i {
+		file_protocol_sheetfs_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cell); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2055,8 +6381,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Chunk); i {
+		file_protocol_sheetfs_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadCellRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2067,8 +6393,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpenSheetReply); i {
+		file_protocol_sheetfs_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadCellReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2079,8 +6405,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadSheetRequest); i {
+		file_protocol_sheetfs_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadCellsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2091,8 +6417,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadSheetReply); i {
+		file_protocol_sheetfs_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadCellsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2103,8 +6429,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RecycleSheetRequest); i {
+		file_protocol_sheetfs_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteCellRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2115,8 +6441,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RecycleSheetReply); i {
+		file_protocol_sheetfs_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteCellReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2127,8 +6453,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResumeSheetRequest); i {
+		file_protocol_sheetfs_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellWrite); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2139,8 +6465,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResumeSheetReply); i {
+		file_protocol_sheetfs_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteCellsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2151,8 +6477,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Sheet); i {
+		file_protocol_sheetfs_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteCellsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2163,8 +6489,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListSheetsReply); i {
+		file_protocol_sheetfs_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCellRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2175,8 +6501,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Cell); i {
+		file_protocol_sheetfs_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCellReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2187,8 +6513,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadCellRequest); i {
+		file_protocol_sheetfs_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InsertRowsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2199,8 +6525,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadCellReply); i {
+		file_protocol_sheetfs_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InsertRowsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2211,8 +6537,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteCellRequest); i {
+		file_protocol_sheetfs_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRowsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2223,8 +6549,8 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteCellReply); i {
+		file_protocol_sheetfs_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRowsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2235,7 +6561,55 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_protocol_sheetfs_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InsertColumnsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InsertColumnsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteColumnsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteColumnsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadChunkRequest); i {
 			case 0:
 				return &v.state
@@ -2247,7 +6621,7 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_protocol_sheetfs_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadChunkReply); i {
 			case 0:
 				return &v.state
@@ -2259,7 +6633,7 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_protocol_sheetfs_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteChunkRequest); i {
 			case 0:
 				return &v.state
@@ -2271,7 +6645,7 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_protocol_sheetfs_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteChunkReply); i {
 			case 0:
 				return &v.state
@@ -2283,7 +6657,7 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_protocol_sheetfs_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteChunkRequest); i {
 			case 0:
 				return &v.state
@@ -2295,7 +6669,7 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_sheetfs_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_protocol_sheetfs_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteChunkReply); i {
 			case 0:
 				return &v.state
@@ -2307,14 +6681,198 @@ func file_protocol_sheetfs_proto_init() {
 				return nil
 			}
 		}
+		file_protocol_sheetfs_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyChunkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyChunkReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopySlotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopySlotReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tab); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTabsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTabsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTabRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTabReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenameTabRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenameTabReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveTabRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveTabReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTabRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_sheetfs_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTabReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_protocol_sheetfs_proto_msgTypes[22].OneofWrappers = []interface{}{
+		(*StatSheetRequest_Filename)(nil),
+		(*StatSheetRequest_Fd)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protocol_sheetfs_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   29,
+			NumEnums:      3,
+			NumMessages:   81,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -19,15 +19,37 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MasterNodeClient interface {
 	RegisterDataNode(ctx context.Context, in *RegisterDataNodeRequest, opts ...grpc.CallOption) (*RegisterDataNodeReply, error)
+	OpenSession(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OpenSessionReply, error)
+	KeepAlive(ctx context.Context, in *KeepAliveRequest, opts ...grpc.CallOption) (*KeepAliveReply, error)
+	CloseSession(ctx context.Context, in *CloseSessionRequest, opts ...grpc.CallOption) (*CloseSessionReply, error)
 	CreateSheet(ctx context.Context, in *CreateSheetRequest, opts ...grpc.CallOption) (*CreateSheetReply, error)
 	DeleteSheet(ctx context.Context, in *DeleteSheetRequest, opts ...grpc.CallOption) (*DeleteSheetReply, error)
+	RenameSheet(ctx context.Context, in *RenameSheetRequest, opts ...grpc.CallOption) (*RenameSheetReply, error)
+	CopySheet(ctx context.Context, in *CopySheetRequest, opts ...grpc.CallOption) (*CopySheetReply, error)
 	OpenSheet(ctx context.Context, in *OpenSheetRequest, opts ...grpc.CallOption) (*OpenSheetReply, error)
+	CloseSheet(ctx context.Context, in *CloseSheetRequest, opts ...grpc.CallOption) (*CloseSheetReply, error)
+	StatSheet(ctx context.Context, in *StatSheetRequest, opts ...grpc.CallOption) (*StatSheetReply, error)
 	ReadSheet(ctx context.Context, in *ReadSheetRequest, opts ...grpc.CallOption) (*ReadSheetReply, error)
 	RecycleSheet(ctx context.Context, in *RecycleSheetRequest, opts ...grpc.CallOption) (*RecycleSheetReply, error)
 	ResumeSheet(ctx context.Context, in *ResumeSheetRequest, opts ...grpc.CallOption) (*ResumeSheetReply, error)
-	ListSheets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSheetsReply, error)
+	ListSheets(ctx context.Context, in *ListSheetsRequest, opts ...grpc.CallOption) (*ListSheetsReply, error)
+	MkDir(ctx context.Context, in *MkDirRequest, opts ...grpc.CallOption) (*MkDirReply, error)
+	RmDir(ctx context.Context, in *RmDirRequest, opts ...grpc.CallOption) (*RmDirReply, error)
+	ListDir(ctx context.Context, in *ListDirRequest, opts ...grpc.CallOption) (*ListDirReply, error)
 	ReadCell(ctx context.Context, in *ReadCellRequest, opts ...grpc.CallOption) (*ReadCellReply, error)
+	ReadCells(ctx context.Context, in *ReadCellsRequest, opts ...grpc.CallOption) (*ReadCellsReply, error)
 	WriteCell(ctx context.Context, in *WriteCellRequest, opts ...grpc.CallOption) (*WriteCellReply, error)
+	WriteCells(ctx context.Context, in *WriteCellsRequest, opts ...grpc.CallOption) (*WriteCellsReply, error)
+	DeleteCell(ctx context.Context, in *DeleteCellRequest, opts ...grpc.CallOption) (*DeleteCellReply, error)
+	InsertRows(ctx context.Context, in *InsertRowsRequest, opts ...grpc.CallOption) (*InsertRowsReply, error)
+	DeleteRows(ctx context.Context, in *DeleteRowsRequest, opts ...grpc.CallOption) (*DeleteRowsReply, error)
+	InsertColumns(ctx context.Context, in *InsertColumnsRequest, opts ...grpc.CallOption) (*InsertColumnsReply, error)
+	DeleteColumns(ctx context.Context, in *DeleteColumnsRequest, opts ...grpc.CallOption) (*DeleteColumnsReply, error)
+	ListTabs(ctx context.Context, in *ListTabsRequest, opts ...grpc.CallOption) (*ListTabsReply, error)
+	CreateTab(ctx context.Context, in *CreateTabRequest, opts ...grpc.CallOption) (*CreateTabReply, error)
+	RenameTab(ctx context.Context, in *RenameTabRequest, opts ...grpc.CallOption) (*RenameTabReply, error)
+	MoveTab(ctx context.Context, in *MoveTabRequest, opts ...grpc.CallOption) (*MoveTabReply, error)
+	DeleteTab(ctx context.Context, in *DeleteTabRequest, opts ...grpc.CallOption) (*DeleteTabReply, error)
 }
 
 type masterNodeClient struct {
@@ -47,6 +69,33 @@ func (c *masterNodeClient) RegisterDataNode(ctx context.Context, in *RegisterDat
 	return out, nil
 }
 
+func (c *masterNodeClient) OpenSession(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OpenSessionReply, error) {
+	out := new(OpenSessionReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/OpenSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) KeepAlive(ctx context.Context, in *KeepAliveRequest, opts ...grpc.CallOption) (*KeepAliveReply, error) {
+	out := new(KeepAliveReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/KeepAlive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) CloseSession(ctx context.Context, in *CloseSessionRequest, opts ...grpc.CallOption) (*CloseSessionReply, error) {
+	out := new(CloseSessionReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/CloseSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *masterNodeClient) CreateSheet(ctx context.Context, in *CreateSheetRequest, opts ...grpc.CallOption) (*CreateSheetReply, error) {
 	out := new(CreateSheetReply)
 	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/CreateSheet", in, out, opts...)
@@ -65,6 +114,24 @@ func (c *masterNodeClient) DeleteSheet(ctx context.Context, in *DeleteSheetReque
 	return out, nil
 }
 
+func (c *masterNodeClient) RenameSheet(ctx context.Context, in *RenameSheetRequest, opts ...grpc.CallOption) (*RenameSheetReply, error) {
+	out := new(RenameSheetReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/RenameSheet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) CopySheet(ctx context.Context, in *CopySheetRequest, opts ...grpc.CallOption) (*CopySheetReply, error) {
+	out := new(CopySheetReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/CopySheet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *masterNodeClient) OpenSheet(ctx context.Context, in *OpenSheetRequest, opts ...grpc.CallOption) (*OpenSheetReply, error) {
 	out := new(OpenSheetReply)
 	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/OpenSheet", in, out, opts...)
@@ -74,6 +141,24 @@ func (c *masterNodeClient) OpenSheet(ctx context.Context, in *OpenSheetRequest,
 	return out, nil
 }
 
+func (c *masterNodeClient) CloseSheet(ctx context.Context, in *CloseSheetRequest, opts ...grpc.CallOption) (*CloseSheetReply, error) {
+	out := new(CloseSheetReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/CloseSheet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) StatSheet(ctx context.Context, in *StatSheetRequest, opts ...grpc.CallOption) (*StatSheetReply, error) {
+	out := new(StatSheetReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/StatSheet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *masterNodeClient) ReadSheet(ctx context.Context, in *ReadSheetRequest, opts ...grpc.CallOption) (*ReadSheetReply, error) {
 	out := new(ReadSheetReply)
 	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ReadSheet", in, out, opts...)
@@ -89,285 +174,904 @@ func (c *masterNodeClient) RecycleSheet(ctx context.Context, in *RecycleSheetReq
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *masterNodeClient) ResumeSheet(ctx context.Context, in *ResumeSheetRequest, opts ...grpc.CallOption) (*ResumeSheetReply, error) {
+	out := new(ResumeSheetReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ResumeSheet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) ListSheets(ctx context.Context, in *ListSheetsRequest, opts ...grpc.CallOption) (*ListSheetsReply, error) {
+	out := new(ListSheetsReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ListSheets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) MkDir(ctx context.Context, in *MkDirRequest, opts ...grpc.CallOption) (*MkDirReply, error) {
+	out := new(MkDirReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/MkDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) RmDir(ctx context.Context, in *RmDirRequest, opts ...grpc.CallOption) (*RmDirReply, error) {
+	out := new(RmDirReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/RmDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) ListDir(ctx context.Context, in *ListDirRequest, opts ...grpc.CallOption) (*ListDirReply, error) {
+	out := new(ListDirReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ListDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) ReadCell(ctx context.Context, in *ReadCellRequest, opts ...grpc.CallOption) (*ReadCellReply, error) {
+	out := new(ReadCellReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ReadCell", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) ReadCells(ctx context.Context, in *ReadCellsRequest, opts ...grpc.CallOption) (*ReadCellsReply, error) {
+	out := new(ReadCellsReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ReadCells", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) WriteCell(ctx context.Context, in *WriteCellRequest, opts ...grpc.CallOption) (*WriteCellReply, error) {
+	out := new(WriteCellReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/WriteCell", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) WriteCells(ctx context.Context, in *WriteCellsRequest, opts ...grpc.CallOption) (*WriteCellsReply, error) {
+	out := new(WriteCellsReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/WriteCells", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) DeleteCell(ctx context.Context, in *DeleteCellRequest, opts ...grpc.CallOption) (*DeleteCellReply, error) {
+	out := new(DeleteCellReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/DeleteCell", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) InsertRows(ctx context.Context, in *InsertRowsRequest, opts ...grpc.CallOption) (*InsertRowsReply, error) {
+	out := new(InsertRowsReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/InsertRows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) DeleteRows(ctx context.Context, in *DeleteRowsRequest, opts ...grpc.CallOption) (*DeleteRowsReply, error) {
+	out := new(DeleteRowsReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/DeleteRows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) InsertColumns(ctx context.Context, in *InsertColumnsRequest, opts ...grpc.CallOption) (*InsertColumnsReply, error) {
+	out := new(InsertColumnsReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/InsertColumns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) DeleteColumns(ctx context.Context, in *DeleteColumnsRequest, opts ...grpc.CallOption) (*DeleteColumnsReply, error) {
+	out := new(DeleteColumnsReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/DeleteColumns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) ListTabs(ctx context.Context, in *ListTabsRequest, opts ...grpc.CallOption) (*ListTabsReply, error) {
+	out := new(ListTabsReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ListTabs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) CreateTab(ctx context.Context, in *CreateTabRequest, opts ...grpc.CallOption) (*CreateTabReply, error) {
+	out := new(CreateTabReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/CreateTab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) RenameTab(ctx context.Context, in *RenameTabRequest, opts ...grpc.CallOption) (*RenameTabReply, error) {
+	out := new(RenameTabReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/RenameTab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) MoveTab(ctx context.Context, in *MoveTabRequest, opts ...grpc.CallOption) (*MoveTabReply, error) {
+	out := new(MoveTabReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/MoveTab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterNodeClient) DeleteTab(ctx context.Context, in *DeleteTabRequest, opts ...grpc.CallOption) (*DeleteTabReply, error) {
+	out := new(DeleteTabReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/DeleteTab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MasterNodeServer is the server API for MasterNode service.
+// All implementations must embed UnimplementedMasterNodeServer
+// for forward compatibility
+type MasterNodeServer interface {
+	RegisterDataNode(context.Context, *RegisterDataNodeRequest) (*RegisterDataNodeReply, error)
+	OpenSession(context.Context, *Empty) (*OpenSessionReply, error)
+	KeepAlive(context.Context, *KeepAliveRequest) (*KeepAliveReply, error)
+	CloseSession(context.Context, *CloseSessionRequest) (*CloseSessionReply, error)
+	CreateSheet(context.Context, *CreateSheetRequest) (*CreateSheetReply, error)
+	DeleteSheet(context.Context, *DeleteSheetRequest) (*DeleteSheetReply, error)
+	RenameSheet(context.Context, *RenameSheetRequest) (*RenameSheetReply, error)
+	CopySheet(context.Context, *CopySheetRequest) (*CopySheetReply, error)
+	OpenSheet(context.Context, *OpenSheetRequest) (*OpenSheetReply, error)
+	CloseSheet(context.Context, *CloseSheetRequest) (*CloseSheetReply, error)
+	StatSheet(context.Context, *StatSheetRequest) (*StatSheetReply, error)
+	ReadSheet(context.Context, *ReadSheetRequest) (*ReadSheetReply, error)
+	RecycleSheet(context.Context, *RecycleSheetRequest) (*RecycleSheetReply, error)
+	ResumeSheet(context.Context, *ResumeSheetRequest) (*ResumeSheetReply, error)
+	ListSheets(context.Context, *ListSheetsRequest) (*ListSheetsReply, error)
+	MkDir(context.Context, *MkDirRequest) (*MkDirReply, error)
+	RmDir(context.Context, *RmDirRequest) (*RmDirReply, error)
+	ListDir(context.Context, *ListDirRequest) (*ListDirReply, error)
+	ReadCell(context.Context, *ReadCellRequest) (*ReadCellReply, error)
+	ReadCells(context.Context, *ReadCellsRequest) (*ReadCellsReply, error)
+	WriteCell(context.Context, *WriteCellRequest) (*WriteCellReply, error)
+	WriteCells(context.Context, *WriteCellsRequest) (*WriteCellsReply, error)
+	DeleteCell(context.Context, *DeleteCellRequest) (*DeleteCellReply, error)
+	InsertRows(context.Context, *InsertRowsRequest) (*InsertRowsReply, error)
+	DeleteRows(context.Context, *DeleteRowsRequest) (*DeleteRowsReply, error)
+	InsertColumns(context.Context, *InsertColumnsRequest) (*InsertColumnsReply, error)
+	DeleteColumns(context.Context, *DeleteColumnsRequest) (*DeleteColumnsReply, error)
+	ListTabs(context.Context, *ListTabsRequest) (*ListTabsReply, error)
+	CreateTab(context.Context, *CreateTabRequest) (*CreateTabReply, error)
+	RenameTab(context.Context, *RenameTabRequest) (*RenameTabReply, error)
+	MoveTab(context.Context, *MoveTabRequest) (*MoveTabReply, error)
+	DeleteTab(context.Context, *DeleteTabRequest) (*DeleteTabReply, error)
+	mustEmbedUnimplementedMasterNodeServer()
+}
+
+// UnimplementedMasterNodeServer must be embedded to have forward compatible implementations.
+type UnimplementedMasterNodeServer struct {
+}
+
+func (UnimplementedMasterNodeServer) RegisterDataNode(context.Context, *RegisterDataNodeRequest) (*RegisterDataNodeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterDataNode not implemented")
+}
+func (UnimplementedMasterNodeServer) OpenSession(context.Context, *Empty) (*OpenSessionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenSession not implemented")
+}
+func (UnimplementedMasterNodeServer) KeepAlive(context.Context, *KeepAliveRequest) (*KeepAliveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeepAlive not implemented")
+}
+func (UnimplementedMasterNodeServer) CloseSession(context.Context, *CloseSessionRequest) (*CloseSessionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseSession not implemented")
+}
+func (UnimplementedMasterNodeServer) CreateSheet(context.Context, *CreateSheetRequest) (*CreateSheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSheet not implemented")
+}
+func (UnimplementedMasterNodeServer) DeleteSheet(context.Context, *DeleteSheetRequest) (*DeleteSheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSheet not implemented")
+}
+func (UnimplementedMasterNodeServer) RenameSheet(context.Context, *RenameSheetRequest) (*RenameSheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameSheet not implemented")
+}
+func (UnimplementedMasterNodeServer) CopySheet(context.Context, *CopySheetRequest) (*CopySheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopySheet not implemented")
+}
+func (UnimplementedMasterNodeServer) OpenSheet(context.Context, *OpenSheetRequest) (*OpenSheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenSheet not implemented")
+}
+func (UnimplementedMasterNodeServer) CloseSheet(context.Context, *CloseSheetRequest) (*CloseSheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseSheet not implemented")
+}
+func (UnimplementedMasterNodeServer) StatSheet(context.Context, *StatSheetRequest) (*StatSheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatSheet not implemented")
+}
+func (UnimplementedMasterNodeServer) ReadSheet(context.Context, *ReadSheetRequest) (*ReadSheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadSheet not implemented")
+}
+func (UnimplementedMasterNodeServer) RecycleSheet(context.Context, *RecycleSheetRequest) (*RecycleSheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecycleSheet not implemented")
+}
+func (UnimplementedMasterNodeServer) ResumeSheet(context.Context, *ResumeSheetRequest) (*ResumeSheetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSheet not implemented")
+}
+func (UnimplementedMasterNodeServer) ListSheets(context.Context, *ListSheetsRequest) (*ListSheetsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSheets not implemented")
+}
+func (UnimplementedMasterNodeServer) MkDir(context.Context, *MkDirRequest) (*MkDirReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MkDir not implemented")
+}
+func (UnimplementedMasterNodeServer) RmDir(context.Context, *RmDirRequest) (*RmDirReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RmDir not implemented")
+}
+func (UnimplementedMasterNodeServer) ListDir(context.Context, *ListDirRequest) (*ListDirReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDir not implemented")
+}
+func (UnimplementedMasterNodeServer) ReadCell(context.Context, *ReadCellRequest) (*ReadCellReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadCell not implemented")
+}
+func (UnimplementedMasterNodeServer) ReadCells(context.Context, *ReadCellsRequest) (*ReadCellsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadCells not implemented")
+}
+func (UnimplementedMasterNodeServer) WriteCell(context.Context, *WriteCellRequest) (*WriteCellReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteCell not implemented")
+}
+func (UnimplementedMasterNodeServer) WriteCells(context.Context, *WriteCellsRequest) (*WriteCellsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteCells not implemented")
+}
+func (UnimplementedMasterNodeServer) DeleteCell(context.Context, *DeleteCellRequest) (*DeleteCellReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCell not implemented")
+}
+func (UnimplementedMasterNodeServer) InsertRows(context.Context, *InsertRowsRequest) (*InsertRowsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertRows not implemented")
+}
+func (UnimplementedMasterNodeServer) DeleteRows(context.Context, *DeleteRowsRequest) (*DeleteRowsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRows not implemented")
+}
+func (UnimplementedMasterNodeServer) InsertColumns(context.Context, *InsertColumnsRequest) (*InsertColumnsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertColumns not implemented")
+}
+func (UnimplementedMasterNodeServer) DeleteColumns(context.Context, *DeleteColumnsRequest) (*DeleteColumnsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteColumns not implemented")
+}
+func (UnimplementedMasterNodeServer) ListTabs(context.Context, *ListTabsRequest) (*ListTabsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTabs not implemented")
+}
+func (UnimplementedMasterNodeServer) CreateTab(context.Context, *CreateTabRequest) (*CreateTabReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTab not implemented")
+}
+func (UnimplementedMasterNodeServer) RenameTab(context.Context, *RenameTabRequest) (*RenameTabReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTab not implemented")
+}
+func (UnimplementedMasterNodeServer) MoveTab(context.Context, *MoveTabRequest) (*MoveTabReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTab not implemented")
+}
+func (UnimplementedMasterNodeServer) DeleteTab(context.Context, *DeleteTabRequest) (*DeleteTabReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTab not implemented")
+}
+func (UnimplementedMasterNodeServer) mustEmbedUnimplementedMasterNodeServer() {}
+
+// UnsafeMasterNodeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MasterNodeServer will
+// result in compilation errors.
+type UnsafeMasterNodeServer interface {
+	mustEmbedUnimplementedMasterNodeServer()
+}
+
+func RegisterMasterNodeServer(s grpc.ServiceRegistrar, srv MasterNodeServer) {
+	s.RegisterService(&MasterNode_ServiceDesc, srv)
+}
+
+func _MasterNode_RegisterDataNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterDataNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).RegisterDataNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/RegisterDataNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).RegisterDataNode(ctx, req.(*RegisterDataNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_OpenSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).OpenSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/OpenSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).OpenSession(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_KeepAlive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeepAliveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).KeepAlive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/KeepAlive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).KeepAlive(ctx, req.(*KeepAliveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_CloseSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).CloseSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/CloseSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).CloseSession(ctx, req.(*CloseSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_CreateSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).CreateSheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/CreateSheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).CreateSheet(ctx, req.(*CreateSheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_DeleteSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).DeleteSheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/DeleteSheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).DeleteSheet(ctx, req.(*DeleteSheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_RenameSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameSheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).RenameSheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/RenameSheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).RenameSheet(ctx, req.(*RenameSheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_CopySheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopySheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).CopySheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/CopySheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).CopySheet(ctx, req.(*CopySheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_OpenSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenSheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).OpenSheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/OpenSheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).OpenSheet(ctx, req.(*OpenSheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_CloseSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseSheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).CloseSheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/CloseSheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).CloseSheet(ctx, req.(*CloseSheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_StatSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatSheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).StatSheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/StatSheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).StatSheet(ctx, req.(*StatSheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_ReadSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadSheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).ReadSheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/ReadSheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).ReadSheet(ctx, req.(*ReadSheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_RecycleSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecycleSheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).RecycleSheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/RecycleSheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).RecycleSheet(ctx, req.(*RecycleSheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_ResumeSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeSheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).ResumeSheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/ResumeSheet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).ResumeSheet(ctx, req.(*ResumeSheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_ListSheets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSheetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).ListSheets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/ListSheets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).ListSheets(ctx, req.(*ListSheetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_MkDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MkDirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).MkDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/MkDir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).MkDir(ctx, req.(*MkDirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_RmDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RmDirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).RmDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/RmDir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).RmDir(ctx, req.(*RmDirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_ListDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).ListDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/ListDir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).ListDir(ctx, req.(*ListDirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterNode_ReadCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadCellRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterNodeServer).ReadCell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/ReadCell",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).ReadCell(ctx, req.(*ReadCellRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *masterNodeClient) ResumeSheet(ctx context.Context, in *ResumeSheetRequest, opts ...grpc.CallOption) (*ResumeSheetReply, error) {
-	out := new(ResumeSheetReply)
-	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ResumeSheet", in, out, opts...)
-	if err != nil {
+func _MasterNode_ReadCells_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadCellsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *masterNodeClient) ListSheets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSheetsReply, error) {
-	out := new(ListSheetsReply)
-	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ListSheets", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(MasterNodeServer).ReadCells(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/ReadCells",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).ReadCells(ctx, req.(*ReadCellsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *masterNodeClient) ReadCell(ctx context.Context, in *ReadCellRequest, opts ...grpc.CallOption) (*ReadCellReply, error) {
-	out := new(ReadCellReply)
-	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/ReadCell", in, out, opts...)
-	if err != nil {
+func _MasterNode_WriteCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteCellRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(MasterNodeServer).WriteCell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/WriteCell",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).WriteCell(ctx, req.(*WriteCellRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *masterNodeClient) WriteCell(ctx context.Context, in *WriteCellRequest, opts ...grpc.CallOption) (*WriteCellReply, error) {
-	out := new(WriteCellReply)
-	err := c.cc.Invoke(ctx, "/sheetfs.MasterNode/WriteCell", in, out, opts...)
-	if err != nil {
+func _MasterNode_WriteCells_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteCellsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// MasterNodeServer is the server API for MasterNode service.
-// All implementations must embed UnimplementedMasterNodeServer
-// for forward compatibility
-type MasterNodeServer interface {
-	RegisterDataNode(context.Context, *RegisterDataNodeRequest) (*RegisterDataNodeReply, error)
-	CreateSheet(context.Context, *CreateSheetRequest) (*CreateSheetReply, error)
-	DeleteSheet(context.Context, *DeleteSheetRequest) (*DeleteSheetReply, error)
-	OpenSheet(context.Context, *OpenSheetRequest) (*OpenSheetReply, error)
-	ReadSheet(context.Context, *ReadSheetRequest) (*ReadSheetReply, error)
-	RecycleSheet(context.Context, *RecycleSheetRequest) (*RecycleSheetReply, error)
-	ResumeSheet(context.Context, *ResumeSheetRequest) (*ResumeSheetReply, error)
-	ListSheets(context.Context, *Empty) (*ListSheetsReply, error)
-	ReadCell(context.Context, *ReadCellRequest) (*ReadCellReply, error)
-	WriteCell(context.Context, *WriteCellRequest) (*WriteCellReply, error)
-	mustEmbedUnimplementedMasterNodeServer()
-}
-
-// UnimplementedMasterNodeServer must be embedded to have forward compatible implementations.
-type UnimplementedMasterNodeServer struct {
-}
-
-func (UnimplementedMasterNodeServer) RegisterDataNode(context.Context, *RegisterDataNodeRequest) (*RegisterDataNodeReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RegisterDataNode not implemented")
-}
-func (UnimplementedMasterNodeServer) CreateSheet(context.Context, *CreateSheetRequest) (*CreateSheetReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateSheet not implemented")
-}
-func (UnimplementedMasterNodeServer) DeleteSheet(context.Context, *DeleteSheetRequest) (*DeleteSheetReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteSheet not implemented")
-}
-func (UnimplementedMasterNodeServer) OpenSheet(context.Context, *OpenSheetRequest) (*OpenSheetReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method OpenSheet not implemented")
-}
-func (UnimplementedMasterNodeServer) ReadSheet(context.Context, *ReadSheetRequest) (*ReadSheetReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReadSheet not implemented")
-}
-func (UnimplementedMasterNodeServer) RecycleSheet(context.Context, *RecycleSheetRequest) (*RecycleSheetReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RecycleSheet not implemented")
-}
-func (UnimplementedMasterNodeServer) ResumeSheet(context.Context, *ResumeSheetRequest) (*ResumeSheetReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ResumeSheet not implemented")
-}
-func (UnimplementedMasterNodeServer) ListSheets(context.Context, *Empty) (*ListSheetsReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListSheets not implemented")
-}
-func (UnimplementedMasterNodeServer) ReadCell(context.Context, *ReadCellRequest) (*ReadCellReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReadCell not implemented")
-}
-func (UnimplementedMasterNodeServer) WriteCell(context.Context, *WriteCellRequest) (*WriteCellReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method WriteCell not implemented")
-}
-func (UnimplementedMasterNodeServer) mustEmbedUnimplementedMasterNodeServer() {}
-
-// UnsafeMasterNodeServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to MasterNodeServer will
-// result in compilation errors.
-type UnsafeMasterNodeServer interface {
-	mustEmbedUnimplementedMasterNodeServer()
-}
-
-func RegisterMasterNodeServer(s grpc.ServiceRegistrar, srv MasterNodeServer) {
-	s.RegisterService(&MasterNode_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(MasterNodeServer).WriteCells(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.MasterNode/WriteCells",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterNodeServer).WriteCells(ctx, req.(*WriteCellsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_RegisterDataNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterDataNodeRequest)
+func _MasterNode_DeleteCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCellRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).RegisterDataNode(ctx, in)
+		return srv.(MasterNodeServer).DeleteCell(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/RegisterDataNode",
+		FullMethod: "/sheetfs.MasterNode/DeleteCell",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).RegisterDataNode(ctx, req.(*RegisterDataNodeRequest))
+		return srv.(MasterNodeServer).DeleteCell(ctx, req.(*DeleteCellRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_CreateSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateSheetRequest)
+func _MasterNode_InsertRows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertRowsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).CreateSheet(ctx, in)
+		return srv.(MasterNodeServer).InsertRows(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/CreateSheet",
+		FullMethod: "/sheetfs.MasterNode/InsertRows",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).CreateSheet(ctx, req.(*CreateSheetRequest))
+		return srv.(MasterNodeServer).InsertRows(ctx, req.(*InsertRowsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_DeleteSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteSheetRequest)
+func _MasterNode_DeleteRows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRowsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).DeleteSheet(ctx, in)
+		return srv.(MasterNodeServer).DeleteRows(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/DeleteSheet",
+		FullMethod: "/sheetfs.MasterNode/DeleteRows",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).DeleteSheet(ctx, req.(*DeleteSheetRequest))
+		return srv.(MasterNodeServer).DeleteRows(ctx, req.(*DeleteRowsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_OpenSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OpenSheetRequest)
+func _MasterNode_InsertColumns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertColumnsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).OpenSheet(ctx, in)
+		return srv.(MasterNodeServer).InsertColumns(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/OpenSheet",
+		FullMethod: "/sheetfs.MasterNode/InsertColumns",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).OpenSheet(ctx, req.(*OpenSheetRequest))
+		return srv.(MasterNodeServer).InsertColumns(ctx, req.(*InsertColumnsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_ReadSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadSheetRequest)
+func _MasterNode_DeleteColumns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteColumnsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).ReadSheet(ctx, in)
+		return srv.(MasterNodeServer).DeleteColumns(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/ReadSheet",
+		FullMethod: "/sheetfs.MasterNode/DeleteColumns",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).ReadSheet(ctx, req.(*ReadSheetRequest))
+		return srv.(MasterNodeServer).DeleteColumns(ctx, req.(*DeleteColumnsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_RecycleSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RecycleSheetRequest)
+func _MasterNode_ListTabs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTabsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).RecycleSheet(ctx, in)
+		return srv.(MasterNodeServer).ListTabs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/RecycleSheet",
+		FullMethod: "/sheetfs.MasterNode/ListTabs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).RecycleSheet(ctx, req.(*RecycleSheetRequest))
+		return srv.(MasterNodeServer).ListTabs(ctx, req.(*ListTabsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_ResumeSheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ResumeSheetRequest)
+func _MasterNode_CreateTab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTabRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).ResumeSheet(ctx, in)
+		return srv.(MasterNodeServer).CreateTab(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/ResumeSheet",
+		FullMethod: "/sheetfs.MasterNode/CreateTab",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).ResumeSheet(ctx, req.(*ResumeSheetRequest))
+		return srv.(MasterNodeServer).CreateTab(ctx, req.(*CreateTabRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_ListSheets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _MasterNode_RenameTab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTabRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).ListSheets(ctx, in)
+		return srv.(MasterNodeServer).RenameTab(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/ListSheets",
+		FullMethod: "/sheetfs.MasterNode/RenameTab",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).ListSheets(ctx, req.(*Empty))
+		return srv.(MasterNodeServer).RenameTab(ctx, req.(*RenameTabRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_ReadCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadCellRequest)
+func _MasterNode_MoveTab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTabRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).ReadCell(ctx, in)
+		return srv.(MasterNodeServer).MoveTab(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/ReadCell",
+		FullMethod: "/sheetfs.MasterNode/MoveTab",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).ReadCell(ctx, req.(*ReadCellRequest))
+		return srv.(MasterNodeServer).MoveTab(ctx, req.(*MoveTabRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MasterNode_WriteCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WriteCellRequest)
+func _MasterNode_DeleteTab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTabRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MasterNodeServer).WriteCell(ctx, in)
+		return srv.(MasterNodeServer).DeleteTab(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/sheetfs.MasterNode/WriteCell",
+		FullMethod: "/sheetfs.MasterNode/DeleteTab",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterNodeServer).WriteCell(ctx, req.(*WriteCellRequest))
+		return srv.(MasterNodeServer).DeleteTab(ctx, req.(*DeleteTabRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -383,6 +1087,18 @@ var MasterNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterDataNode",
 			Handler:    _MasterNode_RegisterDataNode_Handler,
 		},
+		{
+			MethodName: "OpenSession",
+			Handler:    _MasterNode_OpenSession_Handler,
+		},
+		{
+			MethodName: "KeepAlive",
+			Handler:    _MasterNode_KeepAlive_Handler,
+		},
+		{
+			MethodName: "CloseSession",
+			Handler:    _MasterNode_CloseSession_Handler,
+		},
 		{
 			MethodName: "CreateSheet",
 			Handler:    _MasterNode_CreateSheet_Handler,
@@ -391,10 +1107,26 @@ var MasterNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSheet",
 			Handler:    _MasterNode_DeleteSheet_Handler,
 		},
+		{
+			MethodName: "RenameSheet",
+			Handler:    _MasterNode_RenameSheet_Handler,
+		},
+		{
+			MethodName: "CopySheet",
+			Handler:    _MasterNode_CopySheet_Handler,
+		},
 		{
 			MethodName: "OpenSheet",
 			Handler:    _MasterNode_OpenSheet_Handler,
 		},
+		{
+			MethodName: "CloseSheet",
+			Handler:    _MasterNode_CloseSheet_Handler,
+		},
+		{
+			MethodName: "StatSheet",
+			Handler:    _MasterNode_StatSheet_Handler,
+		},
 		{
 			MethodName: "ReadSheet",
 			Handler:    _MasterNode_ReadSheet_Handler,
@@ -411,14 +1143,74 @@ var MasterNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSheets",
 			Handler:    _MasterNode_ListSheets_Handler,
 		},
+		{
+			MethodName: "MkDir",
+			Handler:    _MasterNode_MkDir_Handler,
+		},
+		{
+			MethodName: "RmDir",
+			Handler:    _MasterNode_RmDir_Handler,
+		},
+		{
+			MethodName: "ListDir",
+			Handler:    _MasterNode_ListDir_Handler,
+		},
 		{
 			MethodName: "ReadCell",
 			Handler:    _MasterNode_ReadCell_Handler,
 		},
+		{
+			MethodName: "ReadCells",
+			Handler:    _MasterNode_ReadCells_Handler,
+		},
 		{
 			MethodName: "WriteCell",
 			Handler:    _MasterNode_WriteCell_Handler,
 		},
+		{
+			MethodName: "WriteCells",
+			Handler:    _MasterNode_WriteCells_Handler,
+		},
+		{
+			MethodName: "DeleteCell",
+			Handler:    _MasterNode_DeleteCell_Handler,
+		},
+		{
+			MethodName: "InsertRows",
+			Handler:    _MasterNode_InsertRows_Handler,
+		},
+		{
+			MethodName: "DeleteRows",
+			Handler:    _MasterNode_DeleteRows_Handler,
+		},
+		{
+			MethodName: "InsertColumns",
+			Handler:    _MasterNode_InsertColumns_Handler,
+		},
+		{
+			MethodName: "DeleteColumns",
+			Handler:    _MasterNode_DeleteColumns_Handler,
+		},
+		{
+			MethodName: "ListTabs",
+			Handler:    _MasterNode_ListTabs_Handler,
+		},
+		{
+			MethodName: "CreateTab",
+			Handler:    _MasterNode_CreateTab_Handler,
+		},
+		{
+			MethodName: "RenameTab",
+			Handler:    _MasterNode_RenameTab_Handler,
+		},
+		{
+			MethodName: "MoveTab",
+			Handler:    _MasterNode_MoveTab_Handler,
+		},
+		{
+			MethodName: "DeleteTab",
+			Handler:    _MasterNode_DeleteTab_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protocol/sheetfs.proto",
@@ -431,6 +1223,8 @@ type DataNodeClient interface {
 	ReadChunk(ctx context.Context, in *ReadChunkRequest, opts ...grpc.CallOption) (*ReadChunkReply, error)
 	WriteChunk(ctx context.Context, in *WriteChunkRequest, opts ...grpc.CallOption) (*WriteChunkReply, error)
 	DeleteChunk(ctx context.Context, in *DeleteChunkRequest, opts ...grpc.CallOption) (*DeleteChunkReply, error)
+	CopyChunk(ctx context.Context, in *CopyChunkRequest, opts ...grpc.CallOption) (*CopyChunkReply, error)
+	CopySlot(ctx context.Context, in *CopySlotRequest, opts ...grpc.CallOption) (*CopySlotReply, error)
 }
 
 type dataNodeClient struct {
@@ -468,6 +1262,24 @@ func (c *dataNodeClient) DeleteChunk(ctx context.Context, in *DeleteChunkRequest
 	return out, nil
 }
 
+func (c *dataNodeClient) CopyChunk(ctx context.Context, in *CopyChunkRequest, opts ...grpc.CallOption) (*CopyChunkReply, error) {
+	out := new(CopyChunkReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.DataNode/CopyChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataNodeClient) CopySlot(ctx context.Context, in *CopySlotRequest, opts ...grpc.CallOption) (*CopySlotReply, error) {
+	out := new(CopySlotReply)
+	err := c.cc.Invoke(ctx, "/sheetfs.DataNode/CopySlot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataNodeServer is the server API for DataNode service.
 // All implementations must embed UnimplementedDataNodeServer
 // for forward compatibility
@@ -475,6 +1287,8 @@ type DataNodeServer interface {
 	ReadChunk(context.Context, *ReadChunkRequest) (*ReadChunkReply, error)
 	WriteChunk(context.Context, *WriteChunkRequest) (*WriteChunkReply, error)
 	DeleteChunk(context.Context, *DeleteChunkRequest) (*DeleteChunkReply, error)
+	CopyChunk(context.Context, *CopyChunkRequest) (*CopyChunkReply, error)
+	CopySlot(context.Context, *CopySlotRequest) (*CopySlotReply, error)
 	mustEmbedUnimplementedDataNodeServer()
 }
 
@@ -491,6 +1305,12 @@ func (UnimplementedDataNodeServer) WriteChunk(context.Context, *WriteChunkReques
 func (UnimplementedDataNodeServer) DeleteChunk(context.Context, *DeleteChunkRequest) (*DeleteChunkReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteChunk not implemented")
 }
+func (UnimplementedDataNodeServer) CopyChunk(context.Context, *CopyChunkRequest) (*CopyChunkReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyChunk not implemented")
+}
+func (UnimplementedDataNodeServer) CopySlot(context.Context, *CopySlotRequest) (*CopySlotReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopySlot not implemented")
+}
 func (UnimplementedDataNodeServer) mustEmbedUnimplementedDataNodeServer() {}
 
 // UnsafeDataNodeServer may be embedded to opt out of forward compatibility for this service.
@@ -558,6 +1378,42 @@ func _DataNode_DeleteChunk_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataNode_CopyChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataNodeServer).CopyChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.DataNode/CopyChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataNodeServer).CopyChunk(ctx, req.(*CopyChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataNode_CopySlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopySlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataNodeServer).CopySlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheetfs.DataNode/CopySlot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataNodeServer).CopySlot(ctx, req.(*CopySlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DataNode_ServiceDesc is the grpc.ServiceDesc for DataNode service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -577,6 +1433,14 @@ var DataNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteChunk",
 			Handler:    _DataNode_DeleteChunk_Handler,
 		},
+		{
+			MethodName: "CopyChunk",
+			Handler:    _DataNode_CopyChunk_Handler,
+		},
+		{
+			MethodName: "CopySlot",
+			Handler:    _DataNode_CopySlot_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protocol/sheetfs.proto",